@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/your-org/gateway-service/internal/auth"
+	"github.com/your-org/gateway-service/internal/backends"
+	"github.com/your-org/gateway-service/internal/profile"
+	"github.com/your-org/gateway-service/internal/ratelimit"
+	"github.com/your-org/gateway-service/internal/transport/graphql"
+	"github.com/your-org/gateway-service/internal/transport/grpc"
+	"github.com/your-org/gateway-service/internal/transport/rest"
+)
+
+const (
+	httpPort = 8080  // REST API и GraphQL для клиентов
+	grpcPort = 50050 // gRPC для клиентов, те же сервисы из proto
+
+	usersServiceAddr = "localhost:50051"       // адрес gRPC users-service
+	tasksServiceAddr = "localhost:50052"       // адрес gRPC tasks-service
+	usersGatewayURL  = "http://localhost:8081" // REST-шлюз users-service
+	tasksGatewayURL  = "http://localhost:8082" // REST-шлюз tasks-service
+
+	tokenTTL       = 24 * time.Hour // сколько действует токен доступа
+	minTokenSecret = 32             // минимальная длина ключа подписи токенов, байт
+
+	globalRate  = 1000 // запросов в секунду через шлюз от всех клиентов
+	globalBurst = 2000
+	clientRate  = 20 // запросов в секунду от одного пользователя или адреса
+	clientBurst = 40
+
+	rateLimitCleanup = time.Minute
+	profileTimeout   = 5 * time.Second // сколько ждать сервисы при сборке профиля
+)
+
+func main() {
+	// Контекст с отменой по Ctrl+C/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Ключ подписи токенов общий для всех реплик шлюза и переживает перезапуск
+	secret := []byte(os.Getenv("GATEWAY_TOKEN_SECRET"))
+	if len(secret) < minTokenSecret {
+		log.Fatalf("GATEWAY_TOKEN_SECRET must be set to at least %d bytes", minTokenSecret)
+	}
+	issuer := auth.NewIssuer(secret, tokenTTL)
+
+	clients, cleanup, err := backends.Dial(usersServiceAddr, tasksServiceAddr)
+	if err != nil {
+		log.Fatalf("backends dial failed: %v", err)
+	}
+	defer cleanup()
+
+	// Лимит общий для HTTP и gRPC
+	limiter := ratelimit.NewLimiter(
+		ratelimit.Config{Rate: globalRate, Burst: globalBurst},
+		ratelimit.Config{Rate: clientRate, Burst: clientBurst},
+	)
+	go ratelimit.RunCleanup(ctx, limiter, rateLimitCleanup)
+
+	profiles := profile.NewService(clients.Users, clients.Tasks, profileTimeout)
+
+	usersURL, err := url.Parse(usersGatewayURL)
+	if err != nil {
+		log.Fatalf("invalid users gateway url: %v", err)
+	}
+	tasksURL, err := url.Parse(tasksGatewayURL)
+	if err != nil {
+		log.Fatalf("invalid tasks gateway url: %v", err)
+	}
+	// GraphQL поверх тех же gRPC-клиентов: /graphql, схема — /graphql/schema.graphql
+	graph, err := graphql.NewHandler(clients.Users, clients.Tasks)
+	if err != nil {
+		log.Fatalf("Failed to create GraphQL schema: %v", err)
+	}
+	httpServer := rest.NewServer(httpPort, rest.NewHandler(issuer, limiter, clients.Users, profiles, graph, usersURL, tasksURL))
+
+	proxy, err := grpc.NewProxy(issuer, limiter, clients)
+	if err != nil {
+		log.Fatalf("Failed to create gRPC proxy: %v", err)
+	}
+	grpcServer := grpc.NewServer(grpcPort, proxy)
+
+	errCh := make(chan error, 2)
+	go func() {
+		if err := grpcServer.Start(); err != nil {
+			errCh <- err
+		}
+	}()
+	go func() {
+		if err := httpServer.Start(); err != nil {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		httpServer.Stop()
+		grpcServer.Stop()
+	case err := <-errCh:
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
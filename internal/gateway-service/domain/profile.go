@@ -0,0 +1,27 @@
+package domain
+
+// Profile — сводка о пользователе из users-service и tasks-service для фронтенда
+type Profile struct {
+	User     User       `json:"user"`
+	Tasks    TaskCounts `json:"tasks"`
+	Projects int        `json:"projects"`
+	Tags     int        `json:"tags"`
+}
+
+// User — пользователь без пароля
+type User struct {
+	ID    uint32 `json:"id"`
+	Email string `json:"email"`
+	Etag  string `json:"etag"`
+}
+
+// TaskCounts — счетчики задач пользователя, кроме удаленных
+type TaskCounts struct {
+	Total int `json:"total"`
+	Done  int `json:"done"`
+	// Overdue — невыполненные задачи со сроком в прошлом
+	Overdue int `json:"overdue"`
+	// ByStatus и ByPriority — по именам значений enum из proto
+	ByStatus   map[string]int `json:"byStatus"`
+	ByPriority map[string]int `json:"byPriority"`
+}
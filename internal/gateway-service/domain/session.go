@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Session — ответ на вход: токен доступа для заголовка Authorization: Bearer
+type Session struct {
+	AccessToken string    `json:"accessToken"`
+	TokenType   string    `json:"tokenType"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	User        User      `json:"user"`
+}
@@ -0,0 +1,21 @@
+module github.com/your-org/gateway-service
+
+go 1.24.3
+
+require (
+	github.com/blastuha/test-service-proto v1.1.0
+	github.com/golang-jwt/jwt/v5 v5.3.0
+	google.golang.org/grpc v1.74.2
+	google.golang.org/protobuf v1.36.7
+)
+
+require (
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.27.1 // indirect
+	golang.org/x/net v0.40.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20250603155806-513f23925822 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250603155806-513f23925822 // indirect
+)
+
+replace github.com/blastuha/test-service-proto => ../test-service-proto
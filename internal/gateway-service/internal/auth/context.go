@@ -0,0 +1,16 @@
+package auth
+
+import "context"
+
+type userKey struct{}
+
+// WithUser кладет в контекст id пользователя, чей токен прошел проверку
+func WithUser(ctx context.Context, userID uint32) context.Context {
+	return context.WithValue(ctx, userKey{}, userID)
+}
+
+// UserFromContext возвращает id пользователя запроса; false — запрос без токена
+func UserFromContext(ctx context.Context) (uint32, bool) {
+	userID, ok := ctx.Value(userKey{}).(uint32)
+	return userID, ok && userID != 0
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = fmt.Errorf("invalid access token")
+var ErrTokenExpired = fmt.Errorf("access token expired")
+
+// Issuer выпускает и проверяет токены доступа: JWT с подписью HS256, sub — id пользователя.
+// Сервисы за шлюзом токен не видят, им передается id пользователя в x-actor-id.
+type Issuer struct {
+	secret []byte
+	ttl    time.Duration
+	parser *jwt.Parser
+}
+
+func NewIssuer(secret []byte, ttl time.Duration) *Issuer {
+	return &Issuer{
+		secret: secret,
+		ttl:    ttl,
+		// алгоритм фиксирован: токен с alg none или другим ключом не принимается
+		parser: jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired()),
+	}
+}
+
+// Issue выпускает токен пользователя userID и возвращает его со временем истечения
+func (i *Issuer) Issue(userID uint32) (string, time.Time, error) {
+	now := time.Now()
+	expires := now.Add(i.ttl)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   strconv.FormatUint(uint64(userID), 10),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expires),
+	}).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("auth.Issue: %w", err)
+	}
+	return token, expires, nil
+}
+
+// Verify проверяет подпись и срок токена и возвращает id пользователя
+func (i *Issuer) Verify(token string) (uint32, error) {
+	var claims jwt.RegisteredClaims
+	_, err := i.parser.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return i.secret, nil
+	})
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return 0, ErrTokenExpired
+	}
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil || userID == 0 {
+		return 0, ErrInvalidToken
+	}
+	return uint32(userID), nil
+}
+
+// BearerToken возвращает токен из значения заголовка Authorization: Bearer <token>
+func BearerToken(header string) (string, bool) {
+	const prefix = "bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
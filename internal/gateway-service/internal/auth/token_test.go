@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestIssuerVerify(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	issuer := NewIssuer(secret, time.Hour)
+
+	valid, _, err := issuer.Issue(42)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	expired, _, _ := NewIssuer(secret, -time.Minute).Issue(42)
+	foreign, _, _ := NewIssuer([]byte("another secret, another gateway!"), time.Hour).Issue(42)
+	none, _ := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+		Subject:   "42",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	noExpiry, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "42"}).SignedString(secret)
+	badSubject, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "admin",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString(secret)
+
+	tests := []struct {
+		name    string
+		token   string
+		want    uint32
+		wantErr error
+	}{
+		{"valid", valid, 42, nil},
+		{"expired", expired, 0, ErrTokenExpired},
+		{"another key", foreign, 0, ErrInvalidToken},
+		{"alg none", none, 0, ErrInvalidToken},
+		{"no expiry", noExpiry, 0, ErrInvalidToken},
+		{"subject not an id", badSubject, 0, ErrInvalidToken},
+		{"garbage", "a.b.c", 0, ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		got, err := issuer.Verify(tt.token)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("%s: Verify = %d, %v; want %d, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
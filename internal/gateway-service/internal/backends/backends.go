@@ -0,0 +1,48 @@
+package backends
+
+import (
+	"fmt"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Backends — gRPC-соединения с сервисами за шлюзом и их клиенты из proto
+type Backends struct {
+	UsersConn *grpc.ClientConn
+	TasksConn *grpc.ClientConn
+	Users     userpb.UserServiceClient
+	Tasks     taskspb.TasksServiceClient
+}
+
+// Dial создает соединения с users-service и tasks-service.
+// Возвращает клиенты, функцию для закрытия соединений и ошибку.
+func Dial(usersAddr, tasksAddr string) (*Backends, func(), error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+
+	usersConn, err := grpc.NewClient(usersAddr, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to users service: %w", err)
+	}
+	tasksConn, err := grpc.NewClient(tasksAddr, opts...)
+	if err != nil {
+		usersConn.Close()
+		return nil, nil, fmt.Errorf("failed to connect to tasks service: %w", err)
+	}
+
+	cleanup := func() {
+		usersConn.Close()
+		tasksConn.Close()
+	}
+
+	return &Backends{
+		UsersConn: usersConn,
+		TasksConn: tasksConn,
+		Users:     userpb.NewUserServiceClient(usersConn),
+		Tasks:     taskspb.NewTasksServiceClient(tasksConn),
+	}, cleanup, nil
+}
@@ -0,0 +1,139 @@
+package dataloader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchFunc загружает значения по ключам. Значения и ошибки — в порядке keys;
+// errs может быть nil, если ошибок нет.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (values []V, errs []error)
+
+// result — значение одного ключа; done закрывается, когда пакет загружен
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// batch — ключи, собранные за окно ожидания
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	results []*result[V]
+	timer   *time.Timer
+	once    sync.Once
+}
+
+// Loader собирает ключи, запрошенные параллельно в течение wait, в один вызов
+// BatchFunc и запоминает результаты. Создается на один запрос клиента: кэш не
+// сбрасывается и не должен переживать запрос.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+// New создает загрузчик: пакет уходит через wait после первого ключа или сразу,
+// когда набралось maxBatch ключей
+func New[K comparable, V any](fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*result[V]),
+	}
+}
+
+// Load возвращает значение ключа. Пакет загружается с контекстом первого
+// запросившего ключа; отмена ctx прерывает только ожидание.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r := l.enqueue(ctx, key)
+	l.mu.Unlock()
+	return r.wait(ctx)
+}
+
+// LoadMany загружает ключи одним пакетом, не заводя горутину на ключ
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	results := make([]*result[V], len(keys))
+	l.mu.Lock()
+	for i, key := range keys {
+		results[i] = l.enqueue(ctx, key)
+	}
+	l.mu.Unlock()
+
+	values := make([]V, len(keys))
+	for i, r := range results {
+		v, err := r.wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// enqueue возвращает результат ключа из кэша или добавляет ключ в текущий пакет.
+// Вызывается под l.mu.
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	if r, ok := l.cache[key]; ok {
+		return r
+	}
+	r := &result[V]{done: make(chan struct{})}
+	l.cache[key] = r
+
+	b := l.batch
+	if b == nil {
+		b = &batch[K, V]{ctx: ctx}
+		b.timer = time.AfterFunc(l.wait, func() { l.dispatch(b) })
+		l.batch = b
+	}
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, r)
+	if len(b.keys) >= l.maxBatch {
+		b.timer.Stop()
+		l.batch = nil
+		go l.dispatch(b)
+	}
+	return r
+}
+
+func (r *result[V]) wait(ctx context.Context) (V, error) {
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	b.once.Do(func() {
+		l.mu.Lock()
+		if l.batch == b {
+			l.batch = nil
+		}
+		l.mu.Unlock()
+
+		values, errs := l.fetch(b.ctx, b.keys)
+		for i, r := range b.results {
+			switch {
+			case len(values) != len(b.keys):
+				r.err = fmt.Errorf("dataloader: batch returned %d values for %d keys", len(values), len(b.keys))
+			case i < len(errs) && errs[i] != nil:
+				r.err = errs[i]
+			default:
+				r.value = values[i]
+			}
+			close(r.done)
+		}
+	})
+}
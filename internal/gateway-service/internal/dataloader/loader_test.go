@@ -0,0 +1,60 @@
+package dataloader
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoadManyBatchesKeys(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]int
+	)
+	l := New(func(_ context.Context, keys []int) ([]string, []error) {
+		mu.Lock()
+		batches = append(batches, slices.Clone(keys))
+		mu.Unlock()
+		values := make([]string, len(keys))
+		for i, k := range keys {
+			values[i] = string(rune('a' + k))
+		}
+		return values, nil
+	}, time.Millisecond, 3)
+
+	got, err := l.LoadMany(context.Background(), []int{0, 1, 2, 1, 3})
+	if err != nil {
+		t.Fatalf("LoadMany: %v", err)
+	}
+	if want := []string{"a", "b", "c", "b", "d"}; !slices.Equal(got, want) {
+		t.Fatalf("LoadMany = %v, want %v", got, want)
+	}
+	// повторный ключ загружается один раз, пакет не больше maxBatch
+	if len(batches) != 2 || !slices.Equal(batches[0], []int{0, 1, 2}) || !slices.Equal(batches[1], []int{3}) {
+		t.Fatalf("batches = %v", batches)
+	}
+
+	// значения из кэша не загружаются снова
+	if v, err := l.Load(context.Background(), 3); err != nil || v != "d" || len(batches) != 2 {
+		t.Fatalf("Load(3) = %q, %v after %d batches", v, err, len(batches))
+	}
+}
+
+func TestLoadManyError(t *testing.T) {
+	fail := context.DeadlineExceeded
+	l := New(func(_ context.Context, keys []int) ([]int, []error) {
+		errs := make([]error, len(keys))
+		for i, k := range keys {
+			if k == 2 {
+				errs[i] = fail
+			}
+		}
+		return keys, errs
+	}, time.Millisecond, 10)
+
+	if _, err := l.LoadMany(context.Background(), []int{1, 2, 3}); err != fail {
+		t.Fatalf("LoadMany: err = %v, want %v", err, fail)
+	}
+}
@@ -0,0 +1,143 @@
+package graphql
+
+// Location — строка и столбец в документе запроса, с 1
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// document — разобранный запрос: операции и фрагменты
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operationType — query, mutation или subscription
+type operationType string
+
+const (
+	opQuery        operationType = "query"
+	opMutation     operationType = "mutation"
+	opSubscription operationType = "subscription"
+)
+
+type operation struct {
+	typ        operationType
+	name       string
+	variables  []*variableDefinition
+	directives []*directive
+	selections []selection
+	pos        Location
+}
+
+type variableDefinition struct {
+	name     string
+	typ      *typeRef
+	defValue *value
+	pos      Location
+}
+
+// typeRef — тип переменной в записи запроса: Name, [T] или T!
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+func (t *typeRef) String() string {
+	s := t.name
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+// selection — поле, фрагмент или встроенный фрагмент
+type selection interface {
+	isSelection()
+}
+
+type field struct {
+	alias      string
+	name       string
+	arguments  []*argument
+	directives []*directive
+	selections []selection
+	pos        Location
+}
+
+// responseKey — имя поля в ответе: псевдоним или имя
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	pos        Location
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selections    []selection
+	pos           Location
+}
+
+func (*field) isSelection()          {}
+func (*fragmentSpread) isSelection() {}
+func (*inlineFragment) isSelection() {}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []selection
+	pos           Location
+}
+
+type argument struct {
+	name  string
+	value *value
+	pos   Location
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+	pos       Location
+}
+
+type valueKind int
+
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+// value — значение в тексте запроса. raw — имя переменной, текст числа, строка
+// или имя значения enum; list и fields — элементы списка и поля объекта.
+type value struct {
+	kind   valueKind
+	raw    string
+	list   []*value
+	fields []*objectField
+	pos    Location
+}
+
+type objectField struct {
+	name  string
+	value *value
+}
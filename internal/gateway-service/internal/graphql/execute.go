@@ -0,0 +1,334 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"runtime/debug"
+	"sync"
+)
+
+// prepared — запрос, прошедший разбор и проверку
+type prepared struct {
+	schema *Schema
+	doc    *document
+	op     *operation
+	root   *Object
+	vars   map[string]any
+}
+
+// prepare разбирает запрос, выбирает операцию, приводит переменные и проверяет запрос по схеме
+func (s *Schema) prepare(req Request) (*prepared, []*Error) {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return nil, []*Error{toError(err, Location{}, nil)}
+	}
+	if err := checkFragmentCycles(doc); err != nil {
+		return nil, []*Error{toError(err, Location{}, nil)}
+	}
+
+	var op *operation
+	for _, o := range doc.operations {
+		if req.OperationName == "" && len(doc.operations) > 1 {
+			return nil, []*Error{{Message: "operationName is required for a document with several operations"}}
+		}
+		if req.OperationName == "" || o.name == req.OperationName {
+			op = o
+			break
+		}
+	}
+	if op == nil {
+		return nil, []*Error{{Message: fmt.Sprintf("unknown operation %q", req.OperationName)}}
+	}
+
+	p := &prepared{schema: s, doc: doc, op: op}
+	switch op.typ {
+	case opQuery:
+		p.root = s.query
+	case opSubscription:
+		if s.subscription == nil {
+			return nil, []*Error{locate(fmt.Errorf("schema does not support subscriptions"), op.pos)}
+		}
+		p.root = s.subscription
+	default:
+		return nil, []*Error{locate(fmt.Errorf("%s operations are not supported", op.typ), op.pos)}
+	}
+
+	varDefs := make(map[string]*variableInfo, len(op.variables))
+	p.vars = make(map[string]any, len(op.variables))
+	for _, def := range op.variables {
+		if _, ok := varDefs[def.name]; ok {
+			return nil, []*Error{locate(fmt.Errorf("variable $%s is defined more than once", def.name), def.pos)}
+		}
+		t, err := s.inputType(def.typ)
+		if err != nil {
+			return nil, []*Error{locate(fmt.Errorf("variable $%s: %w", def.name, err), def.pos)}
+		}
+		varDefs[def.name] = &variableInfo{typ: t, hasDefault: def.defValue != nil}
+
+		raw, provided := req.Variables[def.name]
+		switch {
+		case provided:
+			v, err := coerceVariable(raw, t)
+			if err != nil {
+				return nil, []*Error{locate(fmt.Errorf("variable $%s: %w", def.name, err), def.pos)}
+			}
+			p.vars[def.name] = v
+		case def.defValue != nil:
+			v, err := coerceLiteral(def.defValue, t, nil)
+			if err != nil {
+				return nil, []*Error{locate(fmt.Errorf("variable $%s default: %w", def.name, err), def.pos)}
+			}
+			p.vars[def.name] = v
+		default:
+			if _, ok := t.(*NonNull); ok {
+				return nil, []*Error{locate(fmt.Errorf("variable $%s of type %s is required", def.name, t), def.pos)}
+			}
+		}
+	}
+
+	v := &validator{schema: s, doc: doc, vars: p.vars, varDefs: varDefs}
+	v.selectionSet(p.root, op.selections, scope{depth: 1, weight: 1})
+	if len(v.errs) > 0 {
+		return nil, v.errs
+	}
+
+	if op.typ == opSubscription {
+		groups, _ := collectFields(doc, p.root, op.selections, p.vars)
+		if len(groups) != 1 {
+			return nil, []*Error{locate(fmt.Errorf("subscription must select exactly one top level field"), op.pos)}
+		}
+	}
+	return p, nil
+}
+
+// Execute выполняет запрос query. Поля объекта и элементы списков вычисляются
+// параллельно (до Limits.MaxConcurrency горутин), поэтому загрузчики успевают
+// собрать ключи соседних полей в пакет.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	p, errs := s.prepare(req)
+	if errs != nil {
+		return &Response{Errors: errs}
+	}
+	if p.op.typ != opQuery {
+		return &Response{Errors: []*Error{locate(fmt.Errorf("use Subscribe for %s operations", p.op.typ), p.op.pos)}}
+	}
+	return p.execute(ctx, nil)
+}
+
+// IsSubscription сообщает, что в запросе выбрана операция subscription
+func (s *Schema) IsSubscription(req Request) bool {
+	p, errs := s.prepare(req)
+	return errs == nil && p.op.typ == opSubscription
+}
+
+func (p *prepared) execute(ctx context.Context, source any) *Response {
+	e := &executor{schema: p.schema, doc: p.doc, vars: p.vars}
+	if n := p.schema.limits.MaxConcurrency; n > 0 {
+		e.sem = make(chan struct{}, n)
+	}
+	data, ok := e.selectionSet(ctx, p.root, source, p.op.selections, nil)
+
+	resp := &Response{Errors: e.errs}
+	if ok {
+		resp.Data = data
+	} else {
+		resp.Data = nullData
+	}
+	return resp
+}
+
+// executor выполняет один запрос и собирает ошибки полей
+type executor struct {
+	schema *Schema
+	doc    *document
+	vars   map[string]any
+	// sem — занятые горутины запроса, nil — без предела
+	sem chan struct{}
+
+	mu   sync.Mutex
+	errs []*Error
+}
+
+func (e *executor) addError(err *Error) {
+	e.mu.Lock()
+	e.errs = append(e.errs, err)
+	e.mu.Unlock()
+}
+
+// spawn выполняет fn в новой горутине, если предел параллельности не исчерпан,
+// иначе — в текущей. Ждать свободного места нельзя: горутины полей сами ждут
+// своих подполей, и запрос мог бы остановиться целиком.
+func (e *executor) spawn(wg *sync.WaitGroup, fn func()) {
+	if e.sem != nil {
+		select {
+		case e.sem <- struct{}{}:
+		default:
+			fn()
+			return
+		}
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if e.sem != nil {
+			defer func() { <-e.sem }()
+		}
+		fn()
+	}()
+}
+
+// selectionSet вычисляет поля объекта. false — non-null поле оказалось null,
+// и весь объект становится null.
+func (e *executor) selectionSet(ctx context.Context, obj *Object, source any, sels []selection, path []any) (*orderedMap, bool) {
+	groups, err := collectFields(e.doc, obj, sels, e.vars)
+	if err != nil {
+		e.addError(toError(err, Location{}, path))
+		return nil, false
+	}
+
+	out := &orderedMap{keys: make([]string, len(groups)), values: make([]any, len(groups))}
+	oks := make([]bool, len(groups))
+
+	var wg sync.WaitGroup
+	for i, g := range groups {
+		out.keys[i] = g.key
+		e.spawn(&wg, func() {
+			out.values[i], oks[i] = e.field(ctx, obj, source, g, appendPath(path, g.key))
+		})
+	}
+	wg.Wait()
+
+	for _, ok := range oks {
+		if !ok {
+			return nil, false
+		}
+	}
+	return out, true
+}
+
+func (e *executor) field(ctx context.Context, obj *Object, source any, g *fieldGroup, path []any) (result any, ok bool) {
+	f := g.fields[0]
+	if f.name == "__typename" {
+		return obj.Name, true
+	}
+	def := e.schema.fieldDef(obj, f.name)
+	_, nonNull := def.Type.(*NonNull)
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("graphql: panic in %s.%s: %v\n%s", obj.Name, def.Name, r, debug.Stack())
+			e.addError(&Error{Message: "internal error", Locations: []Location{f.pos}, Path: path})
+			result, ok = nil, !nonNull
+		}
+	}()
+
+	args, err := coerceArguments(def, f.arguments, e.vars)
+	if err != nil {
+		e.addError(toError(err, f.pos, path))
+		return nil, !nonNull
+	}
+
+	var value any = source
+	if def.Resolve != nil {
+		value, err = def.Resolve(ctx, source, args)
+		if err != nil {
+			e.addError(toError(err, f.pos, path))
+			return nil, !nonNull
+		}
+	}
+
+	var sub []selection
+	for _, f := range g.fields {
+		sub = append(sub, f.selections...)
+	}
+	return e.complete(ctx, def.Type, f, sub, value, path)
+}
+
+// complete переводит значение резолвера в значение ответа по типу поля
+func (e *executor) complete(ctx context.Context, t Type, f *field, sub []selection, value any, path []any) (any, bool) {
+	if nn, ok := t.(*NonNull); ok {
+		v, ok := e.completeNullable(ctx, nn.Of, f, sub, value, path)
+		if !ok {
+			return nil, false
+		}
+		if v == nil {
+			e.addError(&Error{Message: fmt.Sprintf("cannot return null for non-nullable field %s", f.name), Locations: []Location{f.pos}, Path: path})
+			return nil, false
+		}
+		return v, true
+	}
+	v, ok := e.completeNullable(ctx, t, f, sub, value, path)
+	if !ok {
+		// ошибка non-null значения внутри останавливается на ближайшем nullable поле
+		return nil, true
+	}
+	return v, true
+}
+
+func (e *executor) completeNullable(ctx context.Context, t Type, f *field, sub []selection, value any, path []any) (any, bool) {
+	if isNil(value) {
+		return nil, true
+	}
+
+	switch t := t.(type) {
+	case *List:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.addError(&Error{Message: fmt.Sprintf("field %s: expected a list, got %T", f.name, value), Locations: []Location{f.pos}, Path: path})
+			return nil, false
+		}
+		items := make([]any, rv.Len())
+		oks := make([]bool, rv.Len())
+		var wg sync.WaitGroup
+		for i := range items {
+			e.spawn(&wg, func() {
+				items[i], oks[i] = e.complete(ctx, t.Of, f, sub, rv.Index(i).Interface(), appendPath(path, i))
+			})
+		}
+		wg.Wait()
+		for _, ok := range oks {
+			if !ok {
+				return nil, false
+			}
+		}
+		return items, true
+	case *Scalar:
+		v, err := t.Serialize(value)
+		if err != nil {
+			e.addError(&Error{Message: fmt.Sprintf("field %s: %v", f.name, err), Locations: []Location{f.pos}, Path: path})
+			return nil, false
+		}
+		return v, true
+	case *Enum:
+		ev, ok := t.byValue(value)
+		if !ok {
+			e.addError(&Error{Message: fmt.Sprintf("field %s: %v is not a value of enum %s", f.name, value, t.Name), Locations: []Location{f.pos}, Path: path})
+			return nil, false
+		}
+		return ev.Name, true
+	case *Object:
+		return e.selectionSet(ctx, t, value, sub, path)
+	}
+	e.addError(&Error{Message: fmt.Sprintf("field %s: unsupported type %s", f.name, t), Locations: []Location{f.pos}, Path: path})
+	return nil, false
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func appendPath(path []any, key any) []any {
+	out := make([]any, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, key)
+}
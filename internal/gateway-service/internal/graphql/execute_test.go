@@ -0,0 +1,228 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testTask — задача тестовой схемы
+type testTask struct {
+	ID       int
+	Title    string
+	Done     bool
+	Status   string
+	Subtasks []*testTask
+	Parent   *testTask
+}
+
+// testTasks — задача 1 с подзадачами 2 (выполнена) и 3 и отдельная задача 4
+func testTasks() []*testTask {
+	root := &testTask{ID: 1, Title: "Ремонт", Status: "todo"}
+	root.Subtasks = []*testTask{
+		{ID: 2, Title: "Обои", Done: true, Status: "done", Parent: root},
+		{ID: 3, Title: "Пол", Status: "todo", Parent: root},
+	}
+	return []*testTask{root, root.Subtasks[0], root.Subtasks[1], {ID: 4, Title: "Отпуск", Status: "todo"}}
+}
+
+// filterDone оставляет задачи с done = args["done"], если аргумент задан
+func filterDone(tasks []*testTask, args map[string]any) []*testTask {
+	done, ok := args["done"].(bool)
+	if !ok {
+		return tasks
+	}
+	var out []*testTask
+	for _, t := range tasks {
+		if t.Done == done {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// newTestSchema строит схему Query { tasks, task } над testTasks. Поля fail,
+// missing и broken возвращают ошибку, ошибку gRPC и панику. onResolve, если задан,
+// вызывается в начале каждого резолвера Task.title.
+func newTestSchema(t *testing.T, limits Limits, onResolve func()) *Schema {
+	t.Helper()
+	tasks := testTasks()
+
+	statusEnum := &Enum{Name: "Status", Values: []EnumValue{
+		{Name: "TODO", Value: "todo"},
+		{Name: "DONE", Value: "done", Description: "выполнена"},
+	}}
+	task := &Object{Name: "Task", Description: "задача"}
+	task.Fields = []*Field{
+		{Name: "id", Type: &NonNull{Of: ID}, Resolve: func(_ context.Context, src any, _ map[string]any) (any, error) {
+			return src.(*testTask).ID, nil
+		}},
+		{Name: "title", Type: &NonNull{Of: String}, Resolve: func(_ context.Context, src any, _ map[string]any) (any, error) {
+			if onResolve != nil {
+				onResolve()
+			}
+			return src.(*testTask).Title, nil
+		}},
+		{Name: "done", Type: &NonNull{Of: Boolean}, Resolve: func(_ context.Context, src any, _ map[string]any) (any, error) {
+			return src.(*testTask).Done, nil
+		}},
+		{Name: "status", Type: statusEnum, Resolve: func(_ context.Context, src any, _ map[string]any) (any, error) {
+			return src.(*testTask).Status, nil
+		}},
+		{
+			Name: "subtasks", Type: &NonNull{Of: &List{Of: &NonNull{Of: task}}},
+			Args: []*Argument{{Name: "done", Type: Boolean}},
+			Resolve: func(_ context.Context, src any, args map[string]any) (any, error) {
+				return filterDone(src.(*testTask).Subtasks, args), nil
+			},
+		},
+		{Name: "parent", Type: task, Resolve: func(_ context.Context, src any, _ map[string]any) (any, error) {
+			return src.(*testTask).Parent, nil
+		}},
+		{Name: "fail", Type: &NonNull{Of: String}, Resolve: func(context.Context, any, map[string]any) (any, error) {
+			return nil, errors.New("failed")
+		}},
+		{Name: "missing", Type: String, Resolve: func(context.Context, any, map[string]any) (any, error) {
+			return nil, status.Error(codes.NotFound, "gone")
+		}},
+		{Name: "broken", Type: String, Resolve: func(context.Context, any, map[string]any) (any, error) {
+			panic("broken resolver")
+		}},
+	}
+	query := &Object{Name: "Query", Fields: []*Field{
+		{
+			Name: "tasks", Type: &NonNull{Of: &List{Of: &NonNull{Of: task}}},
+			Args: []*Argument{{Name: "done", Type: Boolean}},
+			Resolve: func(_ context.Context, _ any, args map[string]any) (any, error) {
+				return filterDone(tasks, args), nil
+			},
+		},
+		{
+			Name: "task", Type: task, Description: "задача по id",
+			Args: []*Argument{{Name: "id", Type: &NonNull{Of: ID}}},
+			Resolve: func(_ context.Context, _ any, args map[string]any) (any, error) {
+				for _, t := range tasks {
+					if id, _ := serializeID(t.ID); id == args["id"] {
+						return t, nil
+					}
+				}
+				return nil, nil
+			},
+		},
+	}}
+
+	s, err := NewSchema(query, nil, limits)
+	if err != nil {
+		t.Fatalf("NewSchema: %v", err)
+	}
+	return s
+}
+
+// execute выполняет запрос и возвращает ответ в JSON
+func execute(t *testing.T, s *Schema, query string, vars map[string]any) string {
+	t.Helper()
+	out, err := json.Marshal(s.Execute(context.Background(), Request{Query: query, Variables: vars}))
+	if err != nil {
+		t.Fatalf("marshal response: %v", err)
+	}
+	return string(out)
+}
+
+func TestExecute(t *testing.T) {
+	s := newTestSchema(t, Limits{}, nil)
+
+	tests := []struct {
+		name  string
+		query string
+		vars  map[string]any
+		want  string
+	}{
+		{
+			"aliases, variables, enums and fragments",
+			`query Q($done: Boolean) { all: tasks(done: $done) { id title status ...Parent } }
+			fragment Parent on Task { parent { title } }`,
+			map[string]any{"done": true},
+			`{"data":{"all":[{"id":"2","title":"Обои","status":"DONE","parent":{"title":"Ремонт"}}]}}`,
+		},
+		{
+			"arguments, directives and merged fields",
+			`{ task(id: 1) {
+				__typename id subtasks(done: false) { id } s: subtasks { id }
+				t: title @skip(if: true) ... @include(if: false) { done }
+				id ... on Task { subtasks(done: false) { title } }
+			} none: task(id: 9) { id } }`,
+			nil,
+			`{"data":{"task":{"__typename":"Task","id":"1","subtasks":[{"id":"3","title":"Пол"}],"s":[{"id":"2"},{"id":"3"}]},"none":null}}`,
+		},
+		{
+			"required variable",
+			`query($id: ID!) { task(id: $id) { title } }`,
+			map[string]any{"id": "4"},
+			`{"data":{"task":{"title":"Отпуск"}}}`,
+		},
+		{
+			"variable default",
+			`query($done: Boolean = false) { tasks(done: $done) { id } }`,
+			nil,
+			`{"data":{"tasks":[{"id":"1"},{"id":"3"},{"id":"4"}]}}`,
+		},
+		{
+			"null in non-null field makes the parent null",
+			`{ task(id: 1) { id fail } }`,
+			nil,
+			`{"data":{"task":null},"errors":[{"message":"failed","locations":[{"line":1,"column":20}],"path":["task","fail"]}]}`,
+		},
+		{
+			"grpc error code",
+			`{ task(id: 1) { missing } }`,
+			nil,
+			`{"data":{"task":{"missing":null}},"errors":[{"message":"gone","locations":[{"line":1,"column":17}],"path":["task","missing"],"extensions":{"code":"NOT_FOUND"}}]}`,
+		},
+		{
+			"panic in resolver",
+			`{ task(id: 1) { broken } }`,
+			nil,
+			`{"data":{"task":{"broken":null}},"errors":[{"message":"internal error","locations":[{"line":1,"column":17}],"path":["task","broken"]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		if got := execute(t, s, tt.query, tt.vars); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExecuteNullReachesRoot(t *testing.T) {
+	s := newTestSchema(t, Limits{}, nil)
+	resp := s.Execute(context.Background(), Request{Query: `{ tasks { fail } }`})
+	if data, _ := json.Marshal(resp.Data); string(data) != "null" {
+		t.Fatalf("data = %s, want null", data)
+	}
+	if len(resp.Errors) != 4 {
+		t.Fatalf("errors = %v, want one per task", resp.Errors)
+	}
+}
+
+func TestExecuteOperationName(t *testing.T) {
+	s := newTestSchema(t, Limits{}, nil)
+	const doc = `query A { task(id: 1) { id } } query B { task(id: 4) { id } }`
+
+	resp := s.Execute(context.Background(), Request{Query: doc, OperationName: "B"})
+	if out, _ := json.Marshal(resp); string(out) != `{"data":{"task":{"id":"4"}}}` {
+		t.Fatalf("operation B = %s", out)
+	}
+	for name, want := range map[string]string{
+		"":  "operationName is required",
+		"C": `unknown operation "C"`,
+	} {
+		resp := s.Execute(context.Background(), Request{Query: doc, OperationName: name})
+		if resp.Data != nil || len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, want) {
+			t.Errorf("operationName %q: %+v, want %q", name, resp, want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+)
+
+// maxIntrospectionLists — сколько списков можно вложить друг в друга внутри
+// __schema и __type. Стандартному запросу GraphiQL хватает types.fields.args,
+// а каждый следующий уровень (fields.type.fields...) умножает ответ на число полей.
+const maxIntrospectionLists = 3
+
+// directiveDef — директива, которую понимает исполнитель
+type directiveDef struct {
+	name        string
+	description string
+	locations   []string
+	args        []*Argument
+}
+
+var builtinDirectives = []*directiveDef{
+	{
+		name:        "skip",
+		description: "пропустить поле или фрагмент, если if = true",
+		locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args:        []*Argument{{Name: "if", Type: &NonNull{Of: Boolean}}},
+	},
+	{
+		name:        "include",
+		description: "включить поле или фрагмент, только если if = true",
+		locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args:        []*Argument{{Name: "if", Type: &NonNull{Of: Boolean}}},
+	},
+}
+
+// introspection — мета-поля __schema и __type корневого типа query и их типы
+type introspection struct {
+	schemaField *Field
+	typeField   *Field
+	types       map[string]namedType
+}
+
+// resolveWith — резолвер, которому нужен только source
+func resolveWith[S any](fn func(src S) any) ResolveFunc {
+	return func(_ context.Context, source any, _ map[string]any) (any, error) {
+		return fn(source.(S)), nil
+	}
+}
+
+// optional — пустая строка в ответе становится null
+func optional(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// includeDeprecated — аргумент списков; устаревших полей в схеме нет, он ни на что не влияет
+var includeDeprecated = []*Argument{{Name: "includeDeprecated", Type: Boolean, Default: false}}
+
+func newIntrospection(s *Schema) *introspection {
+	kinds := []string{"SCALAR", "OBJECT", "INTERFACE", "UNION", "ENUM", "INPUT_OBJECT", "LIST", "NON_NULL"}
+	typeKind := &Enum{Name: "__TypeKind", Description: "вид типа"}
+	for _, k := range kinds {
+		typeKind.Values = append(typeKind.Values, EnumValue{Name: k, Value: k})
+	}
+	locations := []string{
+		"QUERY", "MUTATION", "SUBSCRIPTION", "FIELD", "FRAGMENT_DEFINITION",
+		"FRAGMENT_SPREAD", "INLINE_FRAGMENT", "VARIABLE_DEFINITION",
+	}
+	directiveLocation := &Enum{Name: "__DirectiveLocation", Description: "где в запросе допустима директива"}
+	for _, l := range locations {
+		directiveLocation.Values = append(directiveLocation.Values, EnumValue{Name: l, Value: l})
+	}
+
+	typ := &Object{Name: "__Type", Description: "тип схемы"}
+	field := &Object{Name: "__Field", Description: "поле объектного типа"}
+	inputValue := &Object{Name: "__InputValue", Description: "аргумент поля или директивы"}
+	enumValue := &Object{Name: "__EnumValue", Description: "значение enum"}
+	directive := &Object{Name: "__Directive", Description: "директива"}
+	schema := &Object{Name: "__Schema", Description: "схема: корневые типы, все типы и директивы"}
+
+	deprecation := []*Field{
+		{Name: "isDeprecated", Type: &NonNull{Of: Boolean}, Resolve: resolveWith(func(any) any { return false })},
+		{Name: "deprecationReason", Type: String, Resolve: resolveWith(func(any) any { return nil })},
+	}
+	typeRef := func(of Type) any {
+		if isNil(of) {
+			return nil
+		}
+		return of
+	}
+
+	typ.Fields = []*Field{
+		{Name: "kind", Type: &NonNull{Of: typeKind}, Resolve: resolveWith(func(t Type) any {
+			switch t.(type) {
+			case *Scalar:
+				return "SCALAR"
+			case *Enum:
+				return "ENUM"
+			case *List:
+				return "LIST"
+			case *NonNull:
+				return "NON_NULL"
+			}
+			return "OBJECT"
+		})},
+		{Name: "name", Type: String, Resolve: resolveWith(func(t Type) any {
+			if named, ok := t.(namedType); ok {
+				return named.typeName()
+			}
+			return nil
+		})},
+		{Name: "description", Type: String, Resolve: resolveWith(func(t Type) any {
+			if named, ok := t.(namedType); ok {
+				return optional(named.description())
+			}
+			return nil
+		})},
+		{Name: "specifiedByURL", Type: String, Resolve: resolveWith(func(Type) any { return nil })},
+		{
+			Name: "fields", Type: &List{Of: &NonNull{Of: field}}, Args: includeDeprecated,
+			Resolve: resolveWith(func(t Type) any {
+				if obj, ok := t.(*Object); ok {
+					return obj.Fields
+				}
+				return nil
+			}),
+		},
+		{Name: "interfaces", Type: &List{Of: &NonNull{Of: typ}}, Resolve: resolveWith(func(t Type) any {
+			if _, ok := t.(*Object); ok {
+				return []Type{}
+			}
+			return nil
+		})},
+		{Name: "possibleTypes", Type: &List{Of: &NonNull{Of: typ}}, Resolve: resolveWith(func(Type) any { return nil })},
+		{
+			Name: "enumValues", Type: &List{Of: &NonNull{Of: enumValue}}, Args: includeDeprecated,
+			Resolve: resolveWith(func(t Type) any {
+				if e, ok := t.(*Enum); ok {
+					return e.Values
+				}
+				return nil
+			}),
+		},
+		{
+			Name: "inputFields", Type: &List{Of: &NonNull{Of: inputValue}}, Args: includeDeprecated,
+			Resolve: resolveWith(func(Type) any { return nil }),
+		},
+		{Name: "ofType", Type: typ, Resolve: resolveWith(func(t Type) any {
+			switch t := t.(type) {
+			case *List:
+				return t.Of
+			case *NonNull:
+				return t.Of
+			}
+			return nil
+		})},
+	}
+
+	field.Fields = append([]*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: resolveWith(func(f *Field) any { return f.Name })},
+		{Name: "description", Type: String, Resolve: resolveWith(func(f *Field) any { return optional(f.Description) })},
+		{
+			Name: "args", Type: &NonNull{Of: &List{Of: &NonNull{Of: inputValue}}}, Args: includeDeprecated,
+			Resolve: resolveWith(func(f *Field) any { return f.Args }),
+		},
+		{Name: "type", Type: &NonNull{Of: typ}, Resolve: resolveWith(func(f *Field) any { return typeRef(f.Type) })},
+	}, deprecation...)
+
+	inputValue.Fields = append([]*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: resolveWith(func(a *Argument) any { return a.Name })},
+		{Name: "description", Type: String, Resolve: resolveWith(func(a *Argument) any { return optional(a.Description) })},
+		{Name: "type", Type: &NonNull{Of: typ}, Resolve: resolveWith(func(a *Argument) any { return typeRef(a.Type) })},
+		{Name: "defaultValue", Type: String, Resolve: resolveWith(func(a *Argument) any {
+			if a.Default == nil {
+				return nil
+			}
+			return formatDefault(a.Type, a.Default)
+		})},
+	}, deprecation...)
+
+	enumValue.Fields = append([]*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: resolveWith(func(v EnumValue) any { return v.Name })},
+		{Name: "description", Type: String, Resolve: resolveWith(func(v EnumValue) any { return optional(v.Description) })},
+	}, deprecation...)
+
+	directive.Fields = []*Field{
+		{Name: "name", Type: &NonNull{Of: String}, Resolve: resolveWith(func(d *directiveDef) any { return d.name })},
+		{Name: "description", Type: String, Resolve: resolveWith(func(d *directiveDef) any { return optional(d.description) })},
+		{
+			Name: "locations", Type: &NonNull{Of: &List{Of: &NonNull{Of: directiveLocation}}},
+			Resolve: resolveWith(func(d *directiveDef) any { return d.locations }),
+		},
+		{
+			Name: "args", Type: &NonNull{Of: &List{Of: &NonNull{Of: inputValue}}}, Args: includeDeprecated,
+			Resolve: resolveWith(func(d *directiveDef) any { return d.args }),
+		},
+		{Name: "isRepeatable", Type: &NonNull{Of: Boolean}, Resolve: resolveWith(func(*directiveDef) any { return false })},
+	}
+
+	in := &introspection{types: make(map[string]namedType)}
+	for _, t := range []namedType{typeKind, directiveLocation, typ, field, inputValue, enumValue, directive, schema} {
+		in.types[t.typeName()] = t
+	}
+
+	schema.Fields = []*Field{
+		{Name: "description", Type: String, Resolve: resolveWith(func(*Schema) any { return nil })},
+		{Name: "types", Type: &NonNull{Of: &List{Of: &NonNull{Of: typ}}}, Resolve: resolveWith(func(s *Schema) any {
+			names := make([]string, 0, len(s.types)+len(in.types))
+			for name := range s.types {
+				names = append(names, name)
+			}
+			for name := range in.types {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			types := make([]Type, len(names))
+			for i, name := range names {
+				types[i] = s.lookupType(name)
+			}
+			return types
+		})},
+		{Name: "queryType", Type: &NonNull{Of: typ}, Resolve: resolveWith(func(s *Schema) any { return s.query })},
+		{Name: "mutationType", Type: typ, Resolve: resolveWith(func(*Schema) any { return nil })},
+		{Name: "subscriptionType", Type: typ, Resolve: resolveWith(func(s *Schema) any { return typeRef(s.subscription) })},
+		{
+			Name: "directives", Type: &NonNull{Of: &List{Of: &NonNull{Of: directive}}},
+			Resolve: resolveWith(func(*Schema) any { return builtinDirectives }),
+		},
+	}
+
+	in.schemaField = &Field{
+		Name: "__schema", Type: &NonNull{Of: schema},
+		Resolve: func(context.Context, any, map[string]any) (any, error) { return s, nil },
+	}
+	in.typeField = &Field{
+		Name: "__type", Type: typ,
+		Args: []*Argument{{Name: "name", Type: &NonNull{Of: String}}},
+		Resolve: func(_ context.Context, _ any, args map[string]any) (any, error) {
+			if t := s.lookupType(args["name"].(string)); t != nil {
+				return t, nil
+			}
+			return nil, nil
+		},
+	}
+	return in
+}
+
+// lookupType возвращает именованный тип схемы или интроспекции, nil — такого нет
+func (s *Schema) lookupType(name string) namedType {
+	if t, ok := s.types[name]; ok {
+		return t
+	}
+	if t, ok := s.meta.types[name]; ok {
+		return t
+	}
+	return nil
+}
+
+// fieldDef возвращает поле типа obj; у корневого типа query есть еще __schema и __type
+func (s *Schema) fieldDef(obj *Object, name string) *Field {
+	if obj == s.query {
+		switch name {
+		case "__schema":
+			return s.meta.schemaField
+		case "__type":
+			return s.meta.typeField
+		}
+	}
+	return obj.field(name)
+}
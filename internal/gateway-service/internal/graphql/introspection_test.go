@@ -0,0 +1,126 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// introspectionQuery — запрос, которым GraphiQL и генераторы клиентов читают схему
+const introspectionQuery = `
+query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives { name description locations args { ...InputValue } }
+  }
+}
+fragment FullType on __Type {
+  kind name description
+  fields(includeDeprecated: true) {
+    name description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+  possibleTypes { ...TypeRef }
+}
+fragment InputValue on __InputValue { name description type { ...TypeRef } defaultValue }
+fragment TypeRef on __Type {
+  kind name
+  ofType { kind name ofType { kind name ofType { kind name ofType { kind name
+    ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } } }
+}`
+
+func TestIntrospection(t *testing.T) {
+	s := newTestSchema(t, Limits{}, nil)
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			"typename of the root",
+			`{ __typename }`,
+			`{"data":{"__typename":"Query"}}`,
+		},
+		{
+			"type by name",
+			`{ __type(name: "Task") { kind name description fields { name type { kind name ofType { name } } } } }`,
+			`{"data":{"__type":{"kind":"OBJECT","name":"Task","description":"задача","fields":[` +
+				`{"name":"id","type":{"kind":"NON_NULL","name":null,"ofType":{"name":"ID"}}},` +
+				`{"name":"title","type":{"kind":"NON_NULL","name":null,"ofType":{"name":"String"}}},` +
+				`{"name":"done","type":{"kind":"NON_NULL","name":null,"ofType":{"name":"Boolean"}}},` +
+				`{"name":"status","type":{"kind":"ENUM","name":"Status","ofType":null}},` +
+				`{"name":"subtasks","type":{"kind":"NON_NULL","name":null,"ofType":{"name":null}}},` +
+				`{"name":"parent","type":{"kind":"OBJECT","name":"Task","ofType":null}},` +
+				`{"name":"fail","type":{"kind":"NON_NULL","name":null,"ofType":{"name":"String"}}},` +
+				`{"name":"missing","type":{"kind":"SCALAR","name":"String","ofType":null}},` +
+				`{"name":"broken","type":{"kind":"SCALAR","name":"String","ofType":null}}]}}}`,
+		},
+		{
+			"enum values",
+			`{ __type(name: "Status") { kind enumValues { name description } fields { name } } }`,
+			`{"data":{"__type":{"kind":"ENUM","enumValues":[{"name":"TODO","description":null},` +
+				`{"name":"DONE","description":"выполнена"}],"fields":null}}}`,
+		},
+		{
+			"arguments",
+			`{ __schema { queryType { fields { name args { name type { kind } defaultValue } } } } }`,
+			`{"data":{"__schema":{"queryType":{"fields":[{"name":"tasks","args":[{"name":"done","type":{"kind":"SCALAR"},"defaultValue":null}]},` +
+				`{"name":"task","args":[{"name":"id","type":{"kind":"NON_NULL"},"defaultValue":null}]}]}}}}`,
+		},
+		{
+			"unknown type",
+			`{ __type(name: "Project") { name } }`,
+			`{"data":{"__type":null}}`,
+		},
+		{
+			"directives",
+			`{ __schema { directives { name locations args { name } } } }`,
+			`{"data":{"__schema":{"directives":[` +
+				`{"name":"skip","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if"}]},` +
+				`{"name":"include","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if"}]}]}}}`,
+		},
+	}
+	for _, tt := range tests {
+		if got := execute(t, s, tt.query, nil); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIntrospectionTypes(t *testing.T) {
+	s := newTestSchema(t, Limits{}, nil)
+	got := execute(t, s, `{ __schema { types { name } } }`, nil)
+	for _, name := range []string{"Query", "Task", "Status", "ID", "String", "Boolean", "__Schema", "__Type", "__TypeKind"} {
+		if !strings.Contains(got, `{"name":"`+name+`"}`) {
+			t.Errorf("types do not include %s: %s", name, got)
+		}
+	}
+	if strings.Contains(got, "errors") {
+		t.Fatalf("types: %s", got)
+	}
+}
+
+func TestIntrospectionQuery(t *testing.T) {
+	s := newTestSchema(t, Limits{MaxDepth: 8, MaxComplexity: 5000, ListSize: 10, MaxConcurrency: 64}, nil)
+	resp := s.Execute(context.Background(), Request{Query: introspectionQuery})
+	if len(resp.Errors) != 0 {
+		t.Fatalf("introspection query: %v", resp.Errors)
+	}
+}
+
+func TestIntrospectionNestedLists(t *testing.T) {
+	s := newTestSchema(t, Limits{}, nil)
+	got := execute(t, s, `{ __schema { types { fields { type { fields { type { fields { name } } } } } } } }`, nil)
+	if !strings.Contains(got, "introspection query nests more than 3 lists") {
+		t.Fatalf("nested lists: %s", got)
+	}
+}
@@ -0,0 +1,268 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// bom — метка порядка байтов в начале документа, пропускается как пробел
+const bom = "\uFEFF"
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   Location
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of document"
+	}
+	return strconv.Quote(t.value)
+}
+
+// lexer разбивает документ запроса на лексемы. Запятые, пробелы и комментарии
+// в GraphQL ничего не значат и пропускаются.
+type lexer struct {
+	src  string
+	off  int
+	line int
+	col  int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1, col: 1}
+}
+
+func (l *lexer) errorf(pos Location, format string, args ...any) error {
+	return &Error{Message: "syntax error: " + fmt.Sprintf(format, args...), Locations: []Location{pos}}
+}
+
+func (l *lexer) advance(n int) {
+	for _, r := range l.src[l.off : l.off+n] {
+		if r == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+	}
+	l.off += n
+}
+
+func (l *lexer) skipIgnored() {
+	for l.off < len(l.src) {
+		switch c := l.src[l.off]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			end := strings.IndexByte(l.src[l.off:], '\n')
+			if end < 0 {
+				end = len(l.src) - l.off
+			}
+			l.advance(end)
+		case strings.HasPrefix(l.src[l.off:], bom):
+			l.off += len(bom)
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	pos := Location{Line: l.line, Column: l.col}
+	if l.off >= len(l.src) {
+		return token{kind: tokenEOF, pos: pos}, nil
+	}
+
+	c := l.src[l.off]
+	switch {
+	case strings.HasPrefix(l.src[l.off:], "..."):
+		l.advance(3)
+		return token{kind: tokenPunct, value: "...", pos: pos}, nil
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.advance(1)
+		return token{kind: tokenPunct, value: string(c), pos: pos}, nil
+	case c == '_' || isLetter(c):
+		start := l.off
+		end := start + 1
+		for end < len(l.src) && (l.src[end] == '_' || isLetter(l.src[end]) || isDigit(l.src[end])) {
+			end++
+		}
+		l.advance(end - start)
+		return token{kind: tokenName, value: l.src[start:end], pos: pos}, nil
+	case c == '-' || isDigit(c):
+		return l.number(pos)
+	case c == '"':
+		if strings.HasPrefix(l.src[l.off:], `"""`) {
+			return l.blockString(pos)
+		}
+		return l.string(pos)
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.off:])
+	return token{}, l.errorf(pos, "unexpected character %q", r)
+}
+
+func (l *lexer) number(pos Location) (token, error) {
+	start := l.off
+	end := start
+	if l.src[end] == '-' {
+		end++
+	}
+	digits := func() int {
+		n := 0
+		for end < len(l.src) && isDigit(l.src[end]) {
+			end++
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return token{}, l.errorf(pos, "invalid number")
+	}
+	kind := tokenInt
+	if end < len(l.src) && l.src[end] == '.' {
+		end++
+		kind = tokenFloat
+		if digits() == 0 {
+			return token{}, l.errorf(pos, "invalid number")
+		}
+	}
+	if end < len(l.src) && (l.src[end] == 'e' || l.src[end] == 'E') {
+		end++
+		kind = tokenFloat
+		if end < len(l.src) && (l.src[end] == '+' || l.src[end] == '-') {
+			end++
+		}
+		if digits() == 0 {
+			return token{}, l.errorf(pos, "invalid number")
+		}
+	}
+	if end < len(l.src) && (l.src[end] == '_' || l.src[end] == '.' || isLetter(l.src[end])) {
+		return token{}, l.errorf(pos, "invalid number")
+	}
+	l.advance(end - start)
+	return token{kind: kind, value: l.src[start:end], pos: pos}, nil
+}
+
+func (l *lexer) string(pos Location) (token, error) {
+	var b strings.Builder
+	i := l.off + 1
+	for i < len(l.src) {
+		c := l.src[i]
+		switch {
+		case c == '"':
+			l.advance(i + 1 - l.off)
+			return token{kind: tokenString, value: b.String(), pos: pos}, nil
+		case c == '\n' || c == '\r':
+			return token{}, l.errorf(pos, "unterminated string")
+		case c == '\\':
+			if i+1 >= len(l.src) {
+				return token{}, l.errorf(pos, "unterminated string")
+			}
+			switch esc := l.src[i+1]; esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if i+6 > len(l.src) {
+					return token{}, l.errorf(pos, "invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(l.src[i+2:i+6], 16, 32)
+				if err != nil {
+					return token{}, l.errorf(pos, "invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				i += 4
+			default:
+				return token{}, l.errorf(pos, "invalid escape \\%c", esc)
+			}
+			i += 2
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return token{}, l.errorf(pos, "unterminated string")
+}
+
+// blockString — строка в тройных кавычках, общий отступ строк убирается
+func (l *lexer) blockString(pos Location) (token, error) {
+	i := l.off + 3
+	for {
+		j := strings.Index(l.src[i:], `"""`)
+		if j < 0 {
+			return token{}, l.errorf(pos, "unterminated block string")
+		}
+		j += i
+		if l.src[j-1] == '\\' {
+			i = j + 3
+			continue
+		}
+		raw := strings.ReplaceAll(l.src[l.off+3:j], `\"""`, `"""`)
+		l.advance(j + 3 - l.off)
+		return token{kind: tokenString, value: dedentBlock(raw), pos: pos}, nil
+	}
+}
+
+func dedentBlock(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
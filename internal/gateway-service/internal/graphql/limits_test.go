@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxDepth(t *testing.T) {
+	s := newTestSchema(t, Limits{MaxDepth: 3}, nil)
+	if got := execute(t, s, `{ tasks { subtasks { id } } }`, nil); strings.Contains(got, "errors") {
+		t.Fatalf("query within depth: %s", got)
+	}
+	got := execute(t, s, `{ tasks { subtasks { subtasks { id } } } }`, nil)
+	if !strings.Contains(got, "nested deeper than 3 levels") {
+		t.Fatalf("deep query: %s", got)
+	}
+}
+
+func TestMaxComplexity(t *testing.T) {
+	s := newTestSchema(t, Limits{MaxComplexity: 25, ListSize: 10}, nil)
+
+	// tasks + 2 поля в списке: 1 + 2*10
+	if got := execute(t, s, `{ tasks { id title } }`, nil); strings.Contains(got, "errors") {
+		t.Fatalf("cheap query: %s", got)
+	}
+	// поля внутри вложенного списка стоят по 100
+	got := execute(t, s, `{ tasks { subtasks { id } } }`, nil)
+	if !strings.Contains(got, "query is too complex: cost exceeds 25") {
+		t.Fatalf("nested lists: %s", got)
+	}
+	// псевдонимы не объединяются и стоят каждый отдельно
+	got = execute(t, s, `{ a: task(id: 1) { id } b: task(id: 1) { id } c: task(id: 1) { id }
+		d: task(id: 1) { id } e: task(id: 1) { id } f: task(id: 1) { id } g: task(id: 1) { id }
+		h: task(id: 1) { id } i: task(id: 1) { id } j: task(id: 1) { id } k: task(id: 1) { id }
+		l: task(id: 1) { id } m: task(id: 1) { id } }`, nil)
+	if !strings.Contains(got, "too complex") {
+		t.Fatalf("wide query: %s", got)
+	}
+}
+
+func TestMaxComplexityStopsFragmentExpansion(t *testing.T) {
+	// каждый фрагмент удваивает предыдущий: раскрытый запрос — 2^40 полей
+	var b strings.Builder
+	b.WriteString("{ task(id: 1) { ...F0 } }\n")
+	const levels = 40
+	for i := 0; i < levels; i++ {
+		if i == levels-1 {
+			b.WriteString("fragment F39 on Task { id }\n")
+			break
+		}
+		next := "F" + strconv.Itoa(i+1)
+		b.WriteString("fragment F" + strconv.Itoa(i) + " on Task { a: parent { ..." + next + " } b: parent { ..." + next + " } }\n")
+	}
+
+	s := newTestSchema(t, Limits{MaxComplexity: 1000}, nil)
+	done := make(chan *Response, 1)
+	go func() { done <- s.Execute(context.Background(), Request{Query: b.String()}) }()
+	select {
+	case resp := <-done:
+		if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "too complex") {
+			t.Fatalf("fragment bomb: errors = %v", resp.Errors)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("validation does not stop at the complexity limit")
+	}
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	s := newTestSchema(t, Limits{MaxConcurrency: 2}, func() {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+	})
+
+	got := execute(t, s, `{ a: tasks { title } b: tasks { title } c: tasks { title } }`, nil)
+	if strings.Contains(got, "errors") {
+		t.Fatalf("query failed: %s", got)
+	}
+	if strings.Count(got, `"title"`) != 12 {
+		t.Fatalf("response = %s, want 12 titles", got)
+	}
+	// горутина, исчерпавшая предел, вычисляет поля сама: в работе не больше 2+1
+	if p := peak.Load(); p > 3 {
+		t.Fatalf("%d resolvers ran at once, want at most 3", p)
+	}
+}
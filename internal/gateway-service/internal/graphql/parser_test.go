@@ -0,0 +1,115 @@
+package graphql
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	const src = bom + "# комментарий\n" +
+		`query Tasks($done: Boolean = false, $ids: [ID!]!) @skip(if: false) {
+			list: tasks(done: $done, ids: $ids) { id ...F ... on Task @include(if: true) { title } }
+		}
+		fragment F on Task { status }
+		{ task(id: "1", n: -1.5e3, e: TODO, l: [1, 2], o: {a: null}) { id } }`
+	doc, err := parse(src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(doc.operations) != 2 || len(doc.fragments) != 1 {
+		t.Fatalf("parsed %d operations and %d fragments", len(doc.operations), len(doc.fragments))
+	}
+
+	op := doc.operations[0]
+	if op.typ != opQuery || op.name != "Tasks" || len(op.directives) != 1 {
+		t.Fatalf("operation = %+v", op)
+	}
+	if len(op.variables) != 2 || op.variables[0].typ.String() != "Boolean" || op.variables[0].defValue.raw != "false" ||
+		op.variables[1].typ.String() != "[ID!]!" {
+		t.Fatalf("variables = %+v %+v", op.variables[0], op.variables[1])
+	}
+
+	list := op.selections[0].(*field)
+	if list.alias != "list" || list.name != "tasks" || list.responseKey() != "list" || list.pos != (Location{Line: 3, Column: 4}) {
+		t.Fatalf("field = %+v", list)
+	}
+	if len(list.arguments) != 2 || list.arguments[0].value.kind != valueVariable || list.arguments[0].value.raw != "done" {
+		t.Fatalf("arguments = %+v", list.arguments)
+	}
+	if _, ok := list.selections[1].(*fragmentSpread); !ok {
+		t.Fatalf("selection 1 = %T, want fragment spread", list.selections[1])
+	}
+	inline, ok := list.selections[2].(*inlineFragment)
+	if !ok || inline.typeCondition != "Task" || len(inline.directives) != 1 {
+		t.Fatalf("selection 2 = %+v", list.selections[2])
+	}
+
+	anon := doc.operations[1]
+	args := anon.selections[0].(*field).arguments
+	kinds := []valueKind{valueString, valueFloat, valueEnum, valueList, valueObject}
+	for i, want := range kinds {
+		if args[i].value.kind != want {
+			t.Errorf("argument %s: kind %d, want %d", args[i].name, args[i].value.kind, want)
+		}
+	}
+	if args[1].value.raw != "-1.5e3" || len(args[3].value.list) != 2 || args[4].value.fields[0].value.kind != valueNull {
+		t.Errorf("values = %+v %+v %+v", args[1].value, args[3].value, args[4].value)
+	}
+}
+
+func TestParseStrings(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{`"a\"b\\c\/d\nЖ"`, "a\"b\\c/d\nЖ"},
+		{`"Задача"`, "Задача"},
+		{"\"\"\"\n    первая\n      вторая\n\n    \\\"\"\"\n  \"\"\"", "первая\n  вторая\n\n\"\"\""},
+	}
+	for _, tt := range tests {
+		doc, err := parse(`{ task(id: ` + tt.src + `) { id } }`)
+		if err != nil {
+			t.Errorf("parse(%s): %v", tt.src, err)
+			continue
+		}
+		if got := doc.operations[0].selections[0].(*field).arguments[0].value.raw; got != tt.want {
+			t.Errorf("parse(%s) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src, want string
+		pos       Location
+	}{
+		{"", "document has no operations", Location{}},
+		{"fragment F on Task { id }", "document has no operations", Location{}},
+		{"{ }", "selection set cannot be empty", Location{Line: 1, Column: 3}},
+		{"{ id", "expected name, got end of document", Location{Line: 1, Column: 5}},
+		{"{ task() { id } }", "argument list cannot be empty", Location{Line: 1, Column: 8}},
+		{"{\n  task(id: 01x) { id } }", "invalid number", Location{Line: 2, Column: 12}},
+		{`{ task(id: "a`, "unterminated string", Location{Line: 1, Column: 12}},
+		{`{ task(id: "\q") { id } }`, `invalid escape \q`, Location{Line: 1, Column: 12}},
+		{`{ task(id: """a) { id } }`, "unterminated block string", Location{Line: 1, Column: 12}},
+		{"{ a ? }", `unexpected character '?'`, Location{Line: 1, Column: 5}},
+		{"query($id: ID = $x) { a }", "variables are not allowed here", Location{Line: 1, Column: 17}},
+		{"fragment on on T { a } { a }", `fragment cannot be named "on"`, Location{Line: 1, Column: 1}},
+		{"fragment F on T { a } fragment F on T { b } { a }", "fragment F is defined more than once", Location{Line: 1, Column: 23}},
+		{"mutation { a } extra", `unexpected "extra"`, Location{Line: 1, Column: 16}},
+	}
+	for _, tt := range tests {
+		_, err := parse(tt.src)
+		var gqlErr *Error
+		if !errors.As(err, &gqlErr) {
+			t.Errorf("parse(%q): err = %v, want *Error", tt.src, err)
+			continue
+		}
+		if !strings.Contains(gqlErr.Message, tt.want) {
+			t.Errorf("parse(%q): %q, want %q", tt.src, gqlErr.Message, tt.want)
+		}
+		if tt.pos != (Location{}) && (len(gqlErr.Locations) != 1 || gqlErr.Locations[0] != tt.pos) {
+			t.Errorf("parse(%q): locations %v, want %v", tt.src, gqlErr.Locations, tt.pos)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc/status"
+)
+
+// Request — запрос GraphQL over HTTP
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response — ответ: data нет, если запрос не прошел разбор или проверку,
+// и null, если ошибка дошла до корня ответа
+type Response struct {
+	Data   any      `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// nullData — data: null в ответе
+var nullData = json.RawMessage("null")
+
+// Error — ошибка в ответе GraphQL. Для ошибок сервисов в extensions.code —
+// код gRPC: NOT_FOUND, PERMISSION_DENIED и т.д.
+type Error struct {
+	Message    string         `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// toError переводит ошибку резолвера в ошибку ответа
+func toError(err error, pos Location, path []any) *Error {
+	var gqlErr *Error
+	if errors.As(err, &gqlErr) {
+		out := *gqlErr
+		if out.Locations == nil {
+			out.Locations = []Location{pos}
+		}
+		out.Path = path
+		return &out
+	}
+	out := &Error{Message: err.Error(), Locations: []Location{pos}, Path: path}
+	if st, ok := status.FromError(err); ok {
+		out.Message = st.Message()
+		out.Extensions = map[string]any{"code": codeName(st.Code().String())}
+	}
+	return out
+}
+
+// codeName переводит имя кода gRPC в вид, принятый для extensions.code: NotFound → NOT_FOUND
+func codeName(name string) string {
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 && name[i-1] >= 'a' && name[i-1] <= 'z' {
+				b = append(b, '_')
+			}
+			b = append(b, c)
+			continue
+		}
+		b = append(b, c-'a'+'A')
+	}
+	return string(b)
+}
+
+// orderedMap — объект ответа: поля в порядке запроса, как требует спецификация
+type orderedMap struct {
+	keys   []string
+	values []any
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
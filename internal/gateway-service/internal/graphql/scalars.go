@@ -0,0 +1,147 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Значения аргументов приходят из текста запроса (int64, float64, string, bool)
+// или из JSON переменных (json.Number, string, bool). Внутри Int — int,
+// Float — float64, ID — string.
+
+func serializeInt(v any) (any, error) {
+	var n int64
+	switch v := v.(type) {
+	case int:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case uint32:
+		n = int64(v)
+	case uint64:
+		if v > math.MaxInt32 {
+			return nil, fmt.Errorf("Int cannot represent %d", v)
+		}
+		n = int64(v)
+	default:
+		return nil, fmt.Errorf("Int cannot represent %T", v)
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return nil, fmt.Errorf("Int cannot represent %d", n)
+	}
+	return n, nil
+}
+
+func parseInt(v any) (any, error) {
+	var n int64
+	switch v := v.(type) {
+	case int64:
+		n = v
+	case json.Number:
+		i, err := strconv.ParseInt(v.String(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Int cannot represent %s", v)
+		}
+		n = i
+	default:
+		return nil, fmt.Errorf("Int cannot represent %v", v)
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return nil, fmt.Errorf("Int cannot represent %d", n)
+	}
+	return int(n), nil
+}
+
+func serializeFloat(v any) (any, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int, int32, int64, uint32:
+		return serializeInt(v)
+	}
+	return nil, fmt.Errorf("Float cannot represent %T", v)
+}
+
+func parseFloat(v any) (any, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("Float cannot represent %s", v)
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("Float cannot represent %v", v)
+}
+
+func serializeString(v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return nil, fmt.Errorf("String cannot represent %T", v)
+}
+
+func parseString(v any) (any, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("String cannot represent %v", v)
+}
+
+func serializeBoolean(v any) (any, error) {
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("Boolean cannot represent %T", v)
+}
+
+func parseBoolean(v any) (any, error) {
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("Boolean cannot represent %v", v)
+}
+
+func serializeID(v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	}
+	return nil, fmt.Errorf("ID cannot represent %T", v)
+}
+
+func parseID(v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case json.Number:
+		if _, err := strconv.ParseInt(v.String(), 10, 64); err != nil {
+			return nil, fmt.Errorf("ID cannot represent %s", v)
+		}
+		return v.String(), nil
+	}
+	return nil, fmt.Errorf("ID cannot represent %v", v)
+}
@@ -0,0 +1,390 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Type — тип GraphQL: *Scalar, *Enum, *Object, *List или *NonNull
+type Type interface {
+	String() string
+}
+
+// namedType — тип с именем, описывается в схеме
+type namedType interface {
+	Type
+	typeName() string
+	description() string
+}
+
+// Scalar — скалярный тип. Serialize переводит значение резолвера в значение
+// ответа JSON, Parse — значение аргумента (из текста запроса или JSON
+// переменных) во внутреннее значение.
+type Scalar struct {
+	Name        string
+	Description string
+	Serialize   func(v any) (any, error)
+	Parse       func(v any) (any, error)
+}
+
+func (s *Scalar) String() string      { return s.Name }
+func (s *Scalar) typeName() string    { return s.Name }
+func (s *Scalar) description() string { return s.Description }
+
+// EnumValue — значение enum: имя в запросе и ответе и значение для резолверов
+type EnumValue struct {
+	Name        string
+	Description string
+	Value       any
+}
+
+// Enum — перечисление. Резолвер возвращает Value одного из значений.
+type Enum struct {
+	Name        string
+	Description string
+	Values      []EnumValue
+}
+
+func (e *Enum) String() string      { return e.Name }
+func (e *Enum) typeName() string    { return e.Name }
+func (e *Enum) description() string { return e.Description }
+
+func (e *Enum) byName(name string) (EnumValue, bool) {
+	for _, v := range e.Values {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return EnumValue{}, false
+}
+
+func (e *Enum) byValue(value any) (EnumValue, bool) {
+	for _, v := range e.Values {
+		if v.Value == value {
+			return v, true
+		}
+	}
+	return EnumValue{}, false
+}
+
+// ResolveFunc возвращает значение поля объекта source с аргументами args
+type ResolveFunc func(ctx context.Context, source any, args map[string]any) (any, error)
+
+// Stream — источник событий подписки; io.EOF — события кончились
+type Stream interface {
+	Next() (any, error)
+}
+
+// SubscribeFunc открывает поток событий для поля подписки; поток закрывается отменой ctx
+type SubscribeFunc func(ctx context.Context, args map[string]any) (Stream, error)
+
+// Argument — аргумент поля. Default используется, если аргумент не передан.
+type Argument struct {
+	Name        string
+	Description string
+	Type        Type
+	Default     any
+}
+
+// Field — поле объекта. Subscribe задается только у полей типа Subscription:
+// каждое событие потока становится source для Resolve.
+type Field struct {
+	Name        string
+	Description string
+	Type        Type
+	Args        []*Argument
+	Resolve     ResolveFunc
+	Subscribe   SubscribeFunc
+}
+
+func (f *Field) arg(name string) *Argument {
+	for _, a := range f.Args {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// Object — объектный тип. Fields можно дописывать до NewSchema, поэтому
+// типы могут ссылаться друг на друга.
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*Field
+}
+
+func (o *Object) String() string      { return o.Name }
+func (o *Object) typeName() string    { return o.Name }
+func (o *Object) description() string { return o.Description }
+
+func (o *Object) field(name string) *Field {
+	for _, f := range o.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// List — список значений типа Of
+type List struct {
+	Of Type
+}
+
+func (l *List) String() string { return "[" + l.Of.String() + "]" }
+
+// NonNull — значение типа Of, которое не может быть null
+type NonNull struct {
+	Of Type
+}
+
+func (n *NonNull) String() string { return n.Of.String() + "!" }
+
+// Встроенные скаляры GraphQL
+var (
+	Int = &Scalar{
+		Name:        "Int",
+		Description: "32-битное целое со знаком",
+		Serialize:   serializeInt,
+		Parse:       parseInt,
+	}
+	Float = &Scalar{
+		Name:        "Float",
+		Description: "число с плавающей точкой двойной точности",
+		Serialize:   serializeFloat,
+		Parse:       parseFloat,
+	}
+	String = &Scalar{
+		Name:        "String",
+		Description: "строка UTF-8",
+		Serialize:   serializeString,
+		Parse:       parseString,
+	}
+	Boolean = &Scalar{
+		Name:        "Boolean",
+		Description: "true или false",
+		Serialize:   serializeBoolean,
+		Parse:       parseBoolean,
+	}
+	ID = &Scalar{
+		Name:        "ID",
+		Description: "идентификатор, в ответе — строка",
+		Serialize:   serializeID,
+		Parse:       parseID,
+	}
+)
+
+// Limits ограничивает стоимость одного запроса; 0 — без предела. Типы ссылаются
+// друг на друга, и без пределов один запрос может обойти все данные пользователя
+// много раз.
+type Limits struct {
+	// MaxDepth — предел вложенности полей
+	MaxDepth int
+	// MaxComplexity — предел сложности: каждое поле стоит 1, поля внутри списка
+	// считаются ListSize раз
+	MaxComplexity int
+	// ListSize — ожидаемая длина списка при подсчете сложности, по умолчанию 1
+	ListSize int
+	// MaxConcurrency — сколько полей и элементов списков одного запроса вычисляются
+	// параллельно; остальные вычисляются в той же горутине
+	MaxConcurrency int
+}
+
+// Schema — схема: корневые типы и все именованные типы, достижимые из них
+type Schema struct {
+	query        *Object
+	subscription *Object
+	types        map[string]namedType
+	limits       Limits
+	// meta — поля и типы интроспекции
+	meta *introspection
+}
+
+// NewSchema проверяет схему и собирает ее типы. subscription может быть nil.
+func NewSchema(query, subscription *Object, limits Limits) (*Schema, error) {
+	if query == nil {
+		return nil, fmt.Errorf("graphql: schema must have a query type")
+	}
+	if limits.ListSize < 1 {
+		limits.ListSize = 1
+	}
+	s := &Schema{
+		query:        query,
+		subscription: subscription,
+		types:        make(map[string]namedType),
+		limits:       limits,
+	}
+	s.meta = newIntrospection(s)
+	for _, t := range []Type{Int, Float, String, Boolean, ID, query} {
+		if err := s.collect(t); err != nil {
+			return nil, err
+		}
+	}
+	if subscription != nil {
+		if err := s.collect(subscription); err != nil {
+			return nil, err
+		}
+		for _, f := range subscription.Fields {
+			if f.Subscribe == nil {
+				return nil, fmt.Errorf("graphql: subscription field %s has no Subscribe", f.Name)
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *Schema) collect(t Type) error {
+	switch t := t.(type) {
+	case *List:
+		return s.collect(t.Of)
+	case *NonNull:
+		if _, ok := t.Of.(*NonNull); ok {
+			return fmt.Errorf("graphql: %s is not a valid type", t)
+		}
+		return s.collect(t.Of)
+	case namedType:
+		if prev, ok := s.types[t.typeName()]; ok {
+			if prev != t {
+				return fmt.Errorf("graphql: type %s is defined more than once", t.typeName())
+			}
+			return nil
+		}
+		s.types[t.typeName()] = t
+		obj, ok := t.(*Object)
+		if !ok {
+			return nil
+		}
+		if len(obj.Fields) == 0 {
+			return fmt.Errorf("graphql: type %s has no fields", obj.Name)
+		}
+		for _, f := range obj.Fields {
+			if f.Type == nil {
+				return fmt.Errorf("graphql: field %s.%s has no type", obj.Name, f.Name)
+			}
+			if err := s.collect(f.Type); err != nil {
+				return err
+			}
+			for _, a := range f.Args {
+				if !isInputType(a.Type) {
+					return fmt.Errorf("graphql: argument %s.%s(%s) must be a scalar or enum", obj.Name, f.Name, a.Name)
+				}
+				if err := s.collect(a.Type); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("graphql: unsupported type %T", t)
+	}
+}
+
+func isInputType(t Type) bool {
+	switch t := t.(type) {
+	case *List:
+		return isInputType(t.Of)
+	case *NonNull:
+		return isInputType(t.Of)
+	case *Scalar, *Enum:
+		return true
+	}
+	return false
+}
+
+// SDL возвращает схему на языке описания схем GraphQL
+func (s *Schema) SDL() string {
+	var b strings.Builder
+
+	b.WriteString("schema {\n  query: " + s.query.Name + "\n")
+	if s.subscription != nil {
+		b.WriteString("  subscription: " + s.subscription.Name + "\n")
+	}
+	b.WriteString("}\n")
+
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t := s.types[name]
+		if sc, ok := t.(*Scalar); ok && isBuiltinScalar(sc) {
+			continue
+		}
+		b.WriteString("\n")
+		writeDescription(&b, "", t.description())
+		switch t := t.(type) {
+		case *Scalar:
+			b.WriteString("scalar " + t.Name + "\n")
+		case *Enum:
+			b.WriteString("enum " + t.Name + " {\n")
+			for _, v := range t.Values {
+				writeDescription(&b, "  ", v.Description)
+				b.WriteString("  " + v.Name + "\n")
+			}
+			b.WriteString("}\n")
+		case *Object:
+			b.WriteString("type " + t.Name + " {\n")
+			for _, f := range t.Fields {
+				writeDescription(&b, "  ", f.Description)
+				b.WriteString("  " + f.Name)
+				if len(f.Args) > 0 {
+					args := make([]string, len(f.Args))
+					for i, a := range f.Args {
+						args[i] = a.Name + ": " + a.Type.String()
+						if a.Default != nil {
+							args[i] += " = " + formatDefault(a.Type, a.Default)
+						}
+					}
+					b.WriteString("(" + strings.Join(args, ", ") + ")")
+				}
+				b.WriteString(": " + f.Type.String() + "\n")
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func isBuiltinScalar(s *Scalar) bool {
+	return s == Int || s == Float || s == String || s == Boolean || s == ID
+}
+
+func writeDescription(b *strings.Builder, indent, desc string) {
+	if desc == "" {
+		return
+	}
+	if !strings.Contains(desc, "\n") {
+		b.WriteString(indent + `"` + strings.ReplaceAll(desc, `"`, `\"`) + `"` + "\n")
+		return
+	}
+	b.WriteString(indent + `"""` + "\n")
+	for _, line := range strings.Split(desc, "\n") {
+		b.WriteString(indent + strings.ReplaceAll(line, `"""`, `\"""`) + "\n")
+	}
+	b.WriteString(indent + `"""` + "\n")
+}
+
+// formatDefault записывает значение аргумента по умолчанию как в тексте запроса
+func formatDefault(t Type, v any) string {
+	switch t := t.(type) {
+	case *NonNull:
+		return formatDefault(t.Of, v)
+	case *Enum:
+		if ev, ok := t.byValue(v); ok {
+			return ev.Name
+		}
+	case *Scalar:
+		if out, err := t.Serialize(v); err == nil {
+			if str, ok := out.(string); ok {
+				return fmt.Sprintf("%q", str)
+			}
+			return fmt.Sprint(out)
+		}
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,66 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Subscription — открытая подписка: каждое событие потока выполняется как
+// запрос, в котором событие — source корневого поля
+type Subscription struct {
+	p      *prepared
+	stream Stream
+}
+
+// Subscribe проверяет запрос subscription и открывает поток событий. Поток
+// живет, пока не отменен ctx. Ошибка запроса возвращается готовым ответом.
+func (s *Schema) Subscribe(ctx context.Context, req Request) (*Subscription, *Response) {
+	p, errs := s.prepare(req)
+	if errs != nil {
+		return nil, &Response{Errors: errs}
+	}
+	if p.op.typ != opSubscription {
+		return nil, &Response{Errors: []*Error{locate(fmt.Errorf("use Execute for %s operations", p.op.typ), p.op.pos)}}
+	}
+
+	groups, _ := collectFields(p.doc, p.root, p.op.selections, p.vars)
+	f := groups[0].fields[0]
+	path := []any{groups[0].key}
+	if f.name == "__typename" {
+		return nil, &Response{Errors: []*Error{locate(fmt.Errorf("subscription must select a field of %s", p.root.Name), f.pos)}}
+	}
+	def := p.root.field(f.name)
+
+	args, err := coerceArguments(def, f.arguments, p.vars)
+	if err != nil {
+		return nil, &Response{Errors: []*Error{toError(err, f.pos, path)}}
+	}
+	stream, err := def.Subscribe(ctx, args)
+	if err != nil {
+		return nil, &Response{Errors: []*Error{toError(err, f.pos, path)}}
+	}
+	return &Subscription{p: p, stream: stream}, nil
+}
+
+// Next ждет следующее событие и выполняет для него запрос в контексте ctx.
+// io.EOF — подписка завершена; ошибка потока возвращается ответом с errors
+// и после нее подписка тоже завершена.
+func (sub *Subscription) Next(ctx context.Context) (*Response, error) {
+	event, err := sub.stream.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		f := sub.p.op.selections
+		pos := sub.p.op.pos
+		if len(f) > 0 {
+			if fd, ok := f[0].(*field); ok {
+				pos = fd.pos
+			}
+		}
+		return &Response{Errors: []*Error{toError(err, pos, nil)}}, err
+	}
+	return sub.p.execute(ctx, event), nil
+}
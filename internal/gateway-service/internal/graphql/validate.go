@@ -0,0 +1,437 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+)
+
+// fieldGroup — поля выборки с одним ключом ответа; их подвыборки объединяются
+type fieldGroup struct {
+	key    string
+	fields []*field
+}
+
+// collectFields раскрывает фрагменты и директивы @skip/@include и группирует поля
+// выборки по ключам ответа в порядке запроса
+func collectFields(doc *document, obj *Object, sels []selection, vars map[string]any) ([]*fieldGroup, error) {
+	var groups []*fieldGroup
+	index := make(map[string]*fieldGroup)
+	visited := make(map[string]bool)
+
+	var walk func(sels []selection) error
+	walk = func(sels []selection) error {
+		for _, sel := range sels {
+			switch sel := sel.(type) {
+			case *field:
+				ok, err := includeSelection(sel.directives, vars)
+				if err != nil {
+					return locate(err, sel.pos)
+				}
+				if !ok {
+					continue
+				}
+				key := sel.responseKey()
+				g, ok := index[key]
+				if !ok {
+					g = &fieldGroup{key: key}
+					index[key] = g
+					groups = append(groups, g)
+				}
+				g.fields = append(g.fields, sel)
+			case *fragmentSpread:
+				ok, err := includeSelection(sel.directives, vars)
+				if err != nil {
+					return locate(err, sel.pos)
+				}
+				if !ok || visited[sel.name] {
+					continue
+				}
+				visited[sel.name] = true
+				frag, exists := doc.fragments[sel.name]
+				if !exists {
+					return &Error{Message: fmt.Sprintf("unknown fragment %q", sel.name), Locations: []Location{sel.pos}}
+				}
+				if frag.typeCondition != obj.Name {
+					return &Error{Message: fmt.Sprintf("fragment %q on %s cannot be spread on type %s", sel.name, frag.typeCondition, obj.Name), Locations: []Location{sel.pos}}
+				}
+				if err := walk(frag.selections); err != nil {
+					return err
+				}
+			case *inlineFragment:
+				ok, err := includeSelection(sel.directives, vars)
+				if err != nil {
+					return locate(err, sel.pos)
+				}
+				if !ok {
+					continue
+				}
+				if sel.typeCondition != "" && sel.typeCondition != obj.Name {
+					return &Error{Message: fmt.Sprintf("fragment on %s cannot be spread on type %s", sel.typeCondition, obj.Name), Locations: []Location{sel.pos}}
+				}
+				if err := walk(sel.selections); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := walk(sels); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// includeSelection вычисляет @skip(if:) и @include(if:)
+func includeSelection(dirs []*directive, vars map[string]any) (bool, error) {
+	for _, d := range dirs {
+		if d.name != "skip" && d.name != "include" {
+			return false, fmt.Errorf("unknown directive @%s", d.name)
+		}
+		if len(d.arguments) != 1 || d.arguments[0].name != "if" {
+			return false, fmt.Errorf("directive @%s requires the single argument \"if\"", d.name)
+		}
+		v, err := coerceLiteral(d.arguments[0].value, &NonNull{Of: Boolean}, vars)
+		if err != nil {
+			return false, fmt.Errorf("directive @%s: %w", d.name, err)
+		}
+		if v.(bool) == (d.name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func locate(err error, pos Location) *Error {
+	return &Error{Message: err.Error(), Locations: []Location{pos}}
+}
+
+// unwrap возвращает именованный тип под List и NonNull
+func unwrap(t Type) Type {
+	for {
+		switch w := t.(type) {
+		case *List:
+			t = w.Of
+		case *NonNull:
+			t = w.Of
+		default:
+			return t
+		}
+	}
+}
+
+// validator проверяет запрос по схеме до выполнения: поля, аргументы,
+// переменные, подвыборки, глубину и сложность
+type validator struct {
+	schema  *Schema
+	doc     *document
+	vars    map[string]any
+	varDefs map[string]*variableInfo
+	errs    []*Error
+	// cost — сложность проверенной части запроса
+	cost       int
+	tooComplex bool
+}
+
+type variableInfo struct {
+	typ        Type
+	hasDefault bool
+}
+
+func (v *validator) errorf(pos Location, format string, args ...any) {
+	v.errs = append(v.errs, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{pos}})
+}
+
+// scope — положение выборки в запросе
+type scope struct {
+	depth int
+	// weight — во сколько раз выборка будет вычислена из-за списков выше нее
+	weight int
+	// introspection — выборка внутри __schema или __type; lists — сколько
+	// списков над ней внутри интроспекции
+	introspection bool
+	lists         int
+}
+
+func (v *validator) selectionSet(obj *Object, sels []selection, sc scope) {
+	groups, err := collectFields(v.doc, obj, sels, v.vars)
+	if err != nil {
+		v.errs = append(v.errs, toError(err, Location{}, nil))
+		return
+	}
+
+	for _, g := range groups {
+		first := g.fields[0]
+		if !v.charge(first.pos, sc.weight) {
+			// дальше запрос не обходится: с фрагментами он может раскрываться экспоненциально
+			return
+		}
+		if !v.canMerge(g) {
+			continue
+		}
+
+		if first.name == "__typename" {
+			for _, f := range g.fields {
+				if len(f.arguments) > 0 || f.selections != nil {
+					v.errorf(f.pos, "field __typename takes no arguments and has no subfields")
+				}
+			}
+			continue
+		}
+
+		def := v.schema.fieldDef(obj, first.name)
+		if def == nil {
+			v.errorf(first.pos, "cannot query field %q on type %s", first.name, obj.Name)
+			continue
+		}
+
+		var sub []selection
+		for _, f := range g.fields {
+			v.arguments(def, f)
+			sub = append(sub, f.selections...)
+		}
+
+		named := unwrap(def.Type)
+		if child, ok := named.(*Object); ok {
+			if len(sub) == 0 {
+				v.errorf(first.pos, "field %q of type %s must have a selection of subfields", first.name, def.Type)
+				continue
+			}
+			if next, ok := v.child(sc, def, first.pos); ok {
+				v.selectionSet(child, sub, next)
+			}
+		} else if len(sub) > 0 {
+			v.errorf(first.pos, "field %q of type %s cannot have a selection of subfields", first.name, def.Type)
+		}
+	}
+}
+
+// canMerge проверяет, что поля с одним ключом ответа — одно и то же поле с одними
+// и теми же аргументами: иначе в ответе под ключом было бы два разных значения
+func (v *validator) canMerge(g *fieldGroup) bool {
+	first := g.fields[0]
+	ok := true
+	for _, f := range g.fields[1:] {
+		switch {
+		case f.name != first.name:
+			v.errorf(f.pos, "fields %q conflict: %s and %s are different fields", g.key, first.name, f.name)
+			ok = false
+		case !sameArguments(first.arguments, f.arguments):
+			v.errorf(f.pos, "fields %q conflict: they have differing arguments", g.key)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// sameArguments сообщает, что наборы аргументов совпадают без учета порядка
+func sameArguments(a, b []*argument) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, x := range a {
+		i := slices.IndexFunc(b, func(y *argument) bool { return y.name == x.name })
+		if i < 0 || !sameValue(x.value, b[i].value) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameValue сравнивает значения в тексте запроса; переменные равны только себе
+func sameValue(a, b *value) bool {
+	if a.kind != b.kind || a.raw != b.raw || len(a.list) != len(b.list) || len(a.fields) != len(b.fields) {
+		return false
+	}
+	for i := range a.list {
+		if !sameValue(a.list[i], b.list[i]) {
+			return false
+		}
+	}
+	for _, x := range a.fields {
+		i := slices.IndexFunc(b.fields, func(y *objectField) bool { return y.name == x.name })
+		if i < 0 || !sameValue(x.value, b.fields[i].value) {
+			return false
+		}
+	}
+	return true
+}
+
+// child возвращает положение подвыборки поля def; false — подвыборка превышает
+// предел, ошибка уже записана
+func (v *validator) child(sc scope, def *Field, pos Location) (scope, bool) {
+	next := sc
+	next.depth++
+	if def == v.schema.meta.schemaField || def == v.schema.meta.typeField {
+		next.introspection = true
+	}
+
+	lists := listDepth(def.Type)
+	if next.introspection {
+		// интроспекция отвечает из памяти: и глубина, и размер ответа зависят
+		// только от вложенных списков
+		next.lists += lists
+		if next.lists > maxIntrospectionLists {
+			v.errorf(pos, "introspection query nests more than %d lists", maxIntrospectionLists)
+			return next, false
+		}
+		return next, true
+	}
+
+	if max := v.schema.limits.MaxDepth; max > 0 && next.depth > max {
+		v.errorf(pos, "query is nested deeper than %d levels", max)
+		return next, false
+	}
+	// каждый элемент списка вычисляет подвыборку заново
+	for range lists {
+		next.weight *= v.schema.limits.ListSize
+		if max := v.schema.limits.MaxComplexity; max > 0 && next.weight > max {
+			// больше предела считать незачем, а произведение может переполниться
+			next.weight = max + 1
+		}
+	}
+	return next, true
+}
+
+// charge добавляет к сложности запроса weight; false — предел превышен
+func (v *validator) charge(pos Location, weight int) bool {
+	max := v.schema.limits.MaxComplexity
+	if max == 0 {
+		return true
+	}
+	if v.tooComplex {
+		return false
+	}
+	v.cost += weight
+	if v.cost > max {
+		v.tooComplex = true
+		v.errorf(pos, "query is too complex: cost exceeds %d", max)
+		return false
+	}
+	return true
+}
+
+// listDepth — сколько списков в типе t: у [[T]!] — два
+func listDepth(t Type) int {
+	n := 0
+	for {
+		switch w := t.(type) {
+		case *NonNull:
+			t = w.Of
+		case *List:
+			n++
+			t = w.Of
+		default:
+			return n
+		}
+	}
+}
+
+func (v *validator) arguments(def *Field, f *field) {
+	seen := make(map[string]bool, len(f.arguments))
+	for _, arg := range f.arguments {
+		if seen[arg.name] {
+			v.errorf(arg.pos, "argument %q is given more than once", arg.name)
+			continue
+		}
+		seen[arg.name] = true
+
+		a := def.arg(arg.name)
+		if a == nil {
+			v.errorf(arg.pos, "unknown argument %q on field %s", arg.name, def.Name)
+			continue
+		}
+		v.variables(arg.value, a.Type)
+	}
+	if _, err := coerceArguments(def, f.arguments, v.vars); err != nil {
+		v.errorf(f.pos, "field %s: %v", def.Name, err)
+	}
+}
+
+// variables проверяет, что переменные в значении объявлены и подходят по типу
+func (v *validator) variables(val *value, want Type) {
+	switch val.kind {
+	case valueVariable:
+		info, ok := v.varDefs[val.raw]
+		if !ok {
+			v.errorf(val.pos, "variable $%s is not defined", val.raw)
+			return
+		}
+		if !variableFits(info.typ, want, info.hasDefault) {
+			v.errorf(val.pos, "variable $%s of type %s cannot be used as %s", val.raw, info.typ, want)
+		}
+	case valueList:
+		if l, ok := unwrapNonNull(want).(*List); ok {
+			for _, item := range val.list {
+				v.variables(item, l.Of)
+			}
+		}
+	}
+}
+
+func unwrapNonNull(t Type) Type {
+	if nn, ok := t.(*NonNull); ok {
+		return nn.Of
+	}
+	return t
+}
+
+// checkFragmentCycles запрещает фрагменты, которые прямо или через другие
+// фрагменты включают сами себя: такой запрос раскрывается бесконечно
+func checkFragmentCycles(doc *document) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+
+	var spreads func(sels []selection, fn func(*fragmentSpread) error) error
+	spreads = func(sels []selection, fn func(*fragmentSpread) error) error {
+		for _, sel := range sels {
+			switch sel := sel.(type) {
+			case *field:
+				if err := spreads(sel.selections, fn); err != nil {
+					return err
+				}
+			case *inlineFragment:
+				if err := spreads(sel.selections, fn); err != nil {
+					return err
+				}
+			case *fragmentSpread:
+				if err := fn(sel); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	var visit func(f *fragment) error
+	visit = func(f *fragment) error {
+		state[f.name] = visiting
+		err := spreads(f.selections, func(s *fragmentSpread) error {
+			next, ok := doc.fragments[s.name]
+			if !ok {
+				return nil
+			}
+			switch state[s.name] {
+			case visiting:
+				return &Error{Message: fmt.Sprintf("fragment %q spreads itself", s.name), Locations: []Location{s.pos}}
+			case done:
+				return nil
+			}
+			return visit(next)
+		})
+		state[f.name] = done
+		return err
+	}
+
+	for _, f := range doc.fragments {
+		if state[f.name] == 0 {
+			if err := visit(f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	s := newTestSchema(t, Limits{}, nil)
+
+	tests := []struct {
+		query string
+		vars  map[string]any
+		want  string
+	}{
+		{`{ tasks { owner } }`, nil, `cannot query field "owner" on type Task`},
+		{`{ tasks(limit: 1) { id } }`, nil, `unknown argument "limit" on field tasks`},
+		{`{ tasks(done: true, done: false) { id } }`, nil, `argument "done" is given more than once`},
+		{`{ task { id } }`, nil, "argument id of type ID! is required"},
+		{`{ task(id: 1) }`, nil, `field "task" of type Task must have a selection of subfields`},
+		{`{ tasks { id { x } } }`, nil, `field "id" of type ID! cannot have a selection of subfields`},
+		{`{ tasks { __typename(x: 1) } }`, nil, "field __typename takes no arguments and has no subfields"},
+		{`{ tasks { x: id x: title } }`, nil, `fields "x" conflict: id and title are different fields`},
+		{
+			`{ task(id: 1) { subtasks(done: true) { id } ...F } } fragment F on Task { subtasks(done: false) { title } }`,
+			nil, `fields "subtasks" conflict: they have differing arguments`,
+		},
+		{`{ task(id: 1) { subtasks(done: true) { id } subtasks { id } } }`, nil, "differing arguments"},
+		{`{ tasks(done: $done) { id } }`, nil, "variable $done is not defined"},
+		{`query($id: ID) { task(id: $id) { id } }`, nil, "variable $id of type ID cannot be used as ID!"},
+		{`query($id: ID!) { task(id: $id) { id } }`, nil, "variable $id of type ID! is required"},
+		{`query($d: Boolean) { tasks(done: $d) { id } }`, map[string]any{"d": "yes"}, "variable $d"},
+		{`{ tasks { ...F } }`, nil, `unknown fragment "F"`},
+		{`{ tasks { ...F } } fragment F on Query { tasks { id } }`, nil, `fragment "F" on Query cannot be spread on type Task`},
+		{`{ tasks { ... on Query { tasks { id } } } }`, nil, "fragment on Query cannot be spread on type Task"},
+		{
+			`{ tasks { ...A } } fragment A on Task { parent { ...B } } fragment B on Task { parent { ...A } }`,
+			nil, "spreads itself",
+		},
+		{`{ tasks @defer { id } }`, nil, "unknown directive @defer"},
+		{`{ tasks @skip { id } }`, nil, `directive @skip requires the single argument "if"`},
+		{`mutation { tasks { id } }`, nil, "mutation operations are not supported"},
+		{`subscription { tasks { id } }`, nil, "schema does not support subscriptions"},
+	}
+	for _, tt := range tests {
+		resp := s.Execute(context.Background(), Request{Query: tt.query, Variables: tt.vars})
+		if resp.Data != nil {
+			t.Errorf("%s: query was executed", tt.query)
+			continue
+		}
+		found := false
+		for _, err := range resp.Errors {
+			found = found || strings.Contains(err.Message, tt.want)
+		}
+		if !found {
+			t.Errorf("%s: errors = %v, want %q", tt.query, resp.Errors, tt.want)
+		}
+	}
+}
+
+func TestValidateMergesSameFields(t *testing.T) {
+	s := newTestSchema(t, Limits{}, nil)
+	const query = `query($d: Boolean) { task(id: 1) {
+		subtasks(done: $d) { id } ... on Task { subtasks(done: $d) { title } }
+		parent { id } parent { title }
+	} }`
+	want := `{"data":{"task":{"subtasks":[{"id":"3","title":"Пол"}],"parent":null}}}`
+	if got := execute(t, s, query, map[string]any{"d": false}); got != want {
+		t.Fatalf("got %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,219 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// inputType возвращает тип схемы для типа переменной из запроса
+func (s *Schema) inputType(t *typeRef) (Type, error) {
+	var out Type
+	if t.elem != nil {
+		elem, err := s.inputType(t.elem)
+		if err != nil {
+			return nil, err
+		}
+		out = &List{Of: elem}
+	} else {
+		named, ok := s.types[t.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %s", t.name)
+		}
+		if !isInputType(named) {
+			return nil, fmt.Errorf("type %s cannot be used as a variable type", t.name)
+		}
+		out = named
+	}
+	if t.nonNull {
+		out = &NonNull{Of: out}
+	}
+	return out, nil
+}
+
+// variableFits сообщает, что переменную типа varType можно передать в позицию типа
+// want: типы совпадают, переменная может быть строже по null
+func variableFits(varType, want Type, hasDefault bool) bool {
+	if nn, ok := want.(*NonNull); ok {
+		vnn, ok := varType.(*NonNull)
+		if !ok {
+			// nullable переменная с значением по умолчанию в non-null позиции допустима
+			return hasDefault && variableFits(varType, nn.Of, false)
+		}
+		return variableFits(vnn.Of, nn.Of, false)
+	}
+	if vnn, ok := varType.(*NonNull); ok {
+		return variableFits(vnn.Of, want, false)
+	}
+	if wl, ok := want.(*List); ok {
+		vl, ok := varType.(*List)
+		return ok && variableFits(vl.Of, wl.Of, false)
+	}
+	if _, ok := varType.(*List); ok {
+		return false
+	}
+	return varType.String() == want.String()
+}
+
+// coerceVariable переводит значение переменной из JSON во внутреннее значение типа t
+func coerceVariable(v any, t Type) (any, error) {
+	if nn, ok := t.(*NonNull); ok {
+		if v == nil {
+			return nil, fmt.Errorf("expected non-null %s", nn.Of)
+		}
+		return coerceVariable(v, nn.Of)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	switch t := t.(type) {
+	case *List:
+		items, ok := v.([]any)
+		if !ok {
+			item, err := coerceVariable(v, t.Of)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		out := make([]any, len(items))
+		for i, item := range items {
+			var err error
+			if out[i], err = coerceVariable(item, t.Of); err != nil {
+				return nil, fmt.Errorf("at index %d: %w", i, err)
+			}
+		}
+		return out, nil
+	case *Scalar:
+		return t.Parse(v)
+	case *Enum:
+		name, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("enum %s value must be a string", t.Name)
+		}
+		ev, ok := t.byName(name)
+		if !ok {
+			return nil, fmt.Errorf("value %q does not exist in enum %s", name, t.Name)
+		}
+		return ev.Value, nil
+	}
+	return nil, fmt.Errorf("unsupported input type %s", t)
+}
+
+// coerceLiteral переводит значение из текста запроса во внутреннее значение типа t.
+// Переменные уже приведены к своему типу и подставляются как есть.
+func coerceLiteral(v *value, t Type, vars map[string]any) (any, error) {
+	if v.kind == valueVariable {
+		val := vars[v.raw]
+		if _, ok := t.(*NonNull); ok && val == nil {
+			return nil, fmt.Errorf("variable $%s must not be null", v.raw)
+		}
+		return val, nil
+	}
+	if nn, ok := t.(*NonNull); ok {
+		if v.kind == valueNull {
+			return nil, fmt.Errorf("expected non-null %s", nn.Of)
+		}
+		return coerceLiteral(v, nn.Of, vars)
+	}
+	if v.kind == valueNull {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		if v.kind != valueList {
+			item, err := coerceLiteral(v, t.Of, vars)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		out := make([]any, len(v.list))
+		for i, item := range v.list {
+			var err error
+			if out[i], err = coerceLiteral(item, t.Of, vars); err != nil {
+				return nil, fmt.Errorf("at index %d: %w", i, err)
+			}
+		}
+		return out, nil
+	case *Scalar:
+		raw, err := literalValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return t.Parse(raw)
+	case *Enum:
+		if v.kind != valueEnum {
+			return nil, fmt.Errorf("enum %s value must be a name, not a literal", t.Name)
+		}
+		ev, ok := t.byName(v.raw)
+		if !ok {
+			return nil, fmt.Errorf("value %s does not exist in enum %s", v.raw, t.Name)
+		}
+		return ev.Value, nil
+	}
+	return nil, fmt.Errorf("unsupported input type %s", t)
+}
+
+// literalValue — значение скаляра из текста запроса: int64, float64, string или bool
+func literalValue(v *value) (any, error) {
+	switch v.kind {
+	case valueInt:
+		n, err := strconv.ParseInt(v.raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %s", v.raw)
+		}
+		return n, nil
+	case valueFloat:
+		f, err := strconv.ParseFloat(v.raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float %s", v.raw)
+		}
+		return f, nil
+	case valueString:
+		return v.raw, nil
+	case valueBoolean:
+		return v.raw == "true", nil
+	case valueEnum:
+		return nil, fmt.Errorf("unexpected enum value %s", v.raw)
+	case valueList:
+		return nil, fmt.Errorf("unexpected list")
+	case valueObject:
+		return nil, fmt.Errorf("input objects are not supported")
+	}
+	return nil, fmt.Errorf("unexpected value")
+}
+
+// coerceArguments возвращает аргументы поля: из запроса, иначе значения по умолчанию
+func coerceArguments(def *Field, args []*argument, vars map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(def.Args))
+	for _, a := range def.Args {
+		var lit *argument
+		for _, arg := range args {
+			if arg.name == a.Name {
+				lit = arg
+				break
+			}
+		}
+
+		provided := lit != nil
+		if provided && lit.value.kind == valueVariable {
+			_, provided = vars[lit.value.raw]
+		}
+		if !provided {
+			if a.Default != nil {
+				out[a.Name] = a.Default
+			} else if _, ok := a.Type.(*NonNull); ok {
+				return nil, fmt.Errorf("argument %s of type %s is required", a.Name, a.Type)
+			}
+			continue
+		}
+
+		v, err := coerceLiteral(lit.value, a.Type, vars)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", a.Name, err)
+		}
+		out[a.Name] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,99 @@
+package profile
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/gateway-service/domain"
+	"google.golang.org/grpc/metadata"
+)
+
+// actorHeader — id пользователя для сервисов за шлюзом: сводка собирается от его имени
+const actorHeader = "x-actor-id"
+
+// Service собирает сводку о пользователе из обоих сервисов
+type Service struct {
+	users   userpb.UserServiceClient
+	tasks   taskspb.TasksServiceClient
+	timeout time.Duration
+}
+
+func NewService(users userpb.UserServiceClient, tasks taskspb.TasksServiceClient, timeout time.Duration) *Service {
+	return &Service{users: users, tasks: tasks, timeout: timeout}
+}
+
+// Get запрашивает пользователя, его задачи, проекты и теги параллельно.
+// Ошибка любого запроса возвращается как есть, со статусом gRPC.
+func (s *Service) Get(ctx context.Context, userID uint32) (*domain.Profile, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, actorHeader, strconv.FormatUint(uint64(userID), 10))
+
+	var (
+		user     *userpb.User
+		tasks    *taskspb.TaskListResponse
+		projects *taskspb.ListProjectsResponse
+		tags     *taskspb.ListTagsResponse
+		errs     [4]error
+		wg       sync.WaitGroup
+	)
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		user, errs[0] = s.users.GetUser(ctx, &userpb.GetUserRequest{Id: userID})
+	}()
+	go func() {
+		defer wg.Done()
+		tasks, errs[1] = s.tasks.ListTasksByUser(ctx, &taskspb.ListTasksByUserRequest{UserId: userID})
+	}()
+	go func() {
+		defer wg.Done()
+		projects, errs[2] = s.tasks.ListProjects(ctx, &taskspb.ListProjectsRequest{UserId: userID})
+	}()
+	go func() {
+		defer wg.Done()
+		tags, errs[3] = s.tasks.ListTags(ctx, &taskspb.ListTagsRequest{UserId: userID})
+	}()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.Profile{
+		User: domain.User{
+			ID:    user.GetId(),
+			Email: user.GetEmail(),
+			Etag:  user.GetEtag(),
+		},
+		Tasks:    countTasks(tasks.GetTasks(), time.Now()),
+		Projects: len(projects.GetProjects()),
+		Tags:     len(tags.GetTags()),
+	}, nil
+}
+
+func countTasks(tasks []*taskspb.Task, now time.Time) domain.TaskCounts {
+	counts := domain.TaskCounts{
+		Total:      len(tasks),
+		ByStatus:   make(map[string]int),
+		ByPriority: make(map[string]int),
+	}
+	for _, t := range tasks {
+		counts.ByStatus[t.GetStatus().String()]++
+		counts.ByPriority[t.GetPriority().String()]++
+		if t.GetIsDone() {
+			counts.Done++
+			continue
+		}
+		if t.GetDueAt() != nil && t.GetDueAt().AsTime().Before(now) {
+			counts.Overdue++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// bucket — ведро токенов: пополняется со скоростью rate до burst, запрос забирает один токен
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take забирает токен и возвращает, сколько ждать, если токенов нет (0 — запрос пропущен)
+func (b *bucket) take(now time.Time, rate, burst float64) time.Duration {
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / rate * float64(time.Second))
+}
+
+// Config — скорость в запросах в секунду и допустимый всплеск
+type Config struct {
+	Rate  float64
+	Burst int
+}
+
+// Limiter ограничивает общий поток запросов через шлюз и поток каждого клиента
+type Limiter struct {
+	mu      sync.Mutex
+	global  Config
+	client  Config
+	total   bucket
+	clients map[string]*bucket
+}
+
+func NewLimiter(global, client Config) *Limiter {
+	now := time.Now()
+	return &Limiter{
+		global:  global,
+		client:  client,
+		total:   bucket{tokens: float64(global.Burst), last: now},
+		clients: make(map[string]*bucket),
+	}
+}
+
+// Allow учитывает запрос клиента key. Если лимит исчерпан, возвращает false и
+// через сколько стоит повторить запрос.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.clients[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.client.Burst), last: now}
+		l.clients[key] = b
+	}
+	// токен клиента берется первым: клиент сверх своего лимита не тратит общий
+	if wait := b.take(now, l.client.Rate, float64(l.client.Burst)); wait > 0 {
+		return false, wait
+	}
+	if wait := l.total.take(now, l.global.Rate, float64(l.global.Burst)); wait > 0 {
+		// запрос не прошел, токен клиента возвращается
+		b.tokens++
+		return false, wait
+	}
+
+	return true, 0
+}
+
+// cleanup удаляет ведра клиентов, которые успели наполниться: они не отличаются от новых
+func (l *Limiter) cleanup() {
+	now := time.Now()
+	full := time.Duration(float64(l.client.Burst) / l.client.Rate * float64(time.Second))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, b := range l.clients {
+		if now.Sub(b.last) >= full {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// RunCleanup периодически освобождает память неактивных клиентов до отмены ctx
+func RunCleanup(ctx context.Context, l *Limiter, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.cleanup()
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/gateway-service/internal/graphql"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// maxBodySize — предел тела запроса GraphQL
+	maxBodySize = 1 << 20
+	// keepAliveInterval — как часто в поток подписки пишется комментарий, чтобы
+	// прокси не закрывали простаивающее соединение
+	keepAliveInterval = 15 * time.Second
+
+	// actorHeader и forwardedForHeader — метаданные вызовов сервисов, как у остальных маршрутов шлюза
+	actorHeader        = "x-actor-id"
+	forwardedForHeader = "x-forwarded-for"
+)
+
+// Handler — GraphQL over HTTP: запросы POST и GET с ответом JSON и подписки
+// потоком Server-Sent Events (протокол graphql-sse, отдельное соединение на
+// подписку) при Accept: text/event-stream
+type Handler struct {
+	schema *graphql.Schema
+	users  userpb.UserServiceClient
+	tasks  taskspb.TasksServiceClient
+	sdl    []byte
+}
+
+func NewHandler(users userpb.UserServiceClient, tasks taskspb.TasksServiceClient) (*Handler, error) {
+	schema, err := newSchema(tasks)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{
+		schema: schema,
+		users:  users,
+		tasks:  tasks,
+		sdl:    []byte(schema.SDL()),
+	}, nil
+}
+
+// ServeSchema отдает схему на языке описания схем GraphQL
+func (h *Handler) ServeSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(h.sdl)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := readRequest(r)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, &graphql.Response{Errors: []*graphql.Error{{Message: err.Error()}}})
+		return
+	}
+
+	ctx := outgoingContext(r)
+	if acceptsEventStream(r) {
+		h.serveEvents(ctx, w, req)
+		return
+	}
+	if h.schema.IsSubscription(req) {
+		writeResponse(w, http.StatusBadRequest, &graphql.Response{Errors: []*graphql.Error{{
+			Message: "subscriptions are served as Server-Sent Events, send Accept: text/event-stream",
+		}}})
+		return
+	}
+
+	resp := h.schema.Execute(withLoaders(ctx, newLoaders(h.users, h.tasks)), req)
+	writeResponse(w, responseStatus(resp), resp)
+}
+
+// serveEvents отвечает потоком событий: event: next с ответом на каждое событие
+// подписки и event: complete в конце. Запрос query дает одно событие next.
+func (h *Handler) serveEvents(ctx context.Context, w http.ResponseWriter, req graphql.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeResponse(w, http.StatusInternalServerError, &graphql.Response{Errors: []*graphql.Error{{Message: "streaming is not supported"}}})
+		return
+	}
+
+	if !h.schema.IsSubscription(req) {
+		resp := h.schema.Execute(withLoaders(ctx, newLoaders(h.users, h.tasks)), req)
+		if resp.Data == nil {
+			writeResponse(w, http.StatusBadRequest, resp)
+			return
+		}
+		startEvents(w)
+		writeEvent(w, "next", resp)
+		writeEvent(w, "complete", nil)
+		flusher.Flush()
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sub, errResp := h.schema.Subscribe(ctx, req)
+	if errResp != nil {
+		writeResponse(w, responseStatus(errResp), errResp)
+		return
+	}
+	startEvents(w)
+	flusher.Flush()
+
+	type result struct {
+		resp *graphql.Response
+		err  error
+	}
+	results := make(chan result)
+	go func() {
+		for {
+			// у каждого события свои загрузчики: данные не должны устаревать между событиями
+			resp, err := sub.Next(withLoaders(ctx, newLoaders(h.users, h.tasks)))
+			select {
+			case results <- result{resp, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			io.WriteString(w, ":\n\n")
+			flusher.Flush()
+		case res := <-results:
+			if res.resp != nil {
+				writeEvent(w, "next", res.resp)
+			}
+			if res.err != nil {
+				writeEvent(w, "complete", nil)
+				flusher.Flush()
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// readRequest читает запрос из тела POST (JSON) или из строки запроса GET
+func readRequest(r *http.Request) (graphql.Request, error) {
+	var req graphql.Request
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if vars := q.Get("variables"); vars != "" {
+			if err := decodeJSON(strings.NewReader(vars), &req.Variables); err != nil {
+				return req, fmt.Errorf("invalid variables: %v", err)
+			}
+		}
+	case http.MethodPost:
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType != "application/json" {
+			return req, fmt.Errorf("Content-Type must be application/json")
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+		if err != nil {
+			return req, fmt.Errorf("failed to read request body: %v", err)
+		}
+		if len(body) > maxBodySize {
+			return req, fmt.Errorf("request body is larger than %d bytes", maxBodySize)
+		}
+		if err := decodeJSON(bytes.NewReader(body), &req); err != nil {
+			return req, fmt.Errorf("invalid request body: %v", err)
+		}
+	default:
+		return req, fmt.Errorf("method %s is not allowed, use GET or POST", r.Method)
+	}
+	if req.Query == "" {
+		return req, fmt.Errorf("query is required")
+	}
+	return req, nil
+}
+
+// decodeJSON сохраняет числа как json.Number: их тип определяет схема
+func decodeJSON(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// outgoingContext передает сервисам автора запроса и адрес клиента.
+// Пользователь уже проверен шлюзом, см. rest.Handler.guard.
+func outgoingContext(r *http.Request) context.Context {
+	ctx := r.Context()
+	pairs := []string{actorHeader, strconv.FormatUint(uint64(caller(ctx)), 10)}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		pairs = append(pairs, forwardedForHeader, host)
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs(pairs...))
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, _ := mime.ParseMediaType(strings.TrimSpace(part)); mediaType == "text/event-stream" {
+			return true
+		}
+	}
+	return false
+}
+
+// responseStatus — 400, если запрос не дошел до выполнения, иначе 200:
+// ошибки полей передаются в errors вместе с данными
+func responseStatus(resp *graphql.Response) int {
+	if resp.Data == nil {
+		return http.StatusBadRequest
+	}
+	return http.StatusOK
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp *graphql.Response) {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body = []byte(`{"errors":[{"message":"failed to encode response"}]}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func startEvents(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeEvent(w http.ResponseWriter, event string, resp *graphql.Response) {
+	data := []byte{}
+	if resp != nil {
+		var err error
+		if data, err = json.Marshal(resp); err != nil {
+			data = []byte(`{"errors":[{"message":"failed to encode response"}]}`)
+		}
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
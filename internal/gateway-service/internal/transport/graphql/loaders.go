@@ -0,0 +1,92 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/gateway-service/internal/dataloader"
+)
+
+const (
+	// loaderWait — сколько загрузчик собирает ключи соседних полей в пакет
+	loaderWait = 2 * time.Millisecond
+	// loaderMaxBatch — ключей в одном пакете
+	loaderMaxBatch = 50
+	// loaderMaxCalls — сколько вызовов сервисов один запрос GraphQL делает
+	// одновременно, во всех пакетах всех загрузчиков
+	loaderMaxCalls = 16
+)
+
+// loaders — загрузчики одного запроса GraphQL. Пакетных методов у сервисов нет,
+// поэтому пакет — это параллельные вызовы по разным ключам: повторные ключи
+// (owner у сотни задач одного пользователя) сводятся к одному вызову.
+// Задачи, проекты и теги загружаются списком пользователя целиком, и поля
+// parent, subtasks, project, tags отвечают из него без новых вызовов.
+type loaders struct {
+	users    *dataloader.Loader[uint32, *userpb.User]
+	tasks    *dataloader.Loader[uint32, []*taskspb.Task]
+	projects *dataloader.Loader[uint32, []*taskspb.Project]
+	tags     *dataloader.Loader[uint32, []*taskspb.Tag]
+}
+
+func newLoaders(users userpb.UserServiceClient, tasks taskspb.TasksServiceClient) *loaders {
+	calls := make(chan struct{}, loaderMaxCalls)
+	return &loaders{
+		users: dataloader.New(perKey(calls, func(ctx context.Context, id uint32) (*userpb.User, error) {
+			return users.GetUser(ctx, &userpb.GetUserRequest{Id: id})
+		}), loaderWait, loaderMaxBatch),
+		tasks: dataloader.New(perKey(calls, func(ctx context.Context, userID uint32) ([]*taskspb.Task, error) {
+			resp, err := tasks.ListTasksByUser(ctx, &taskspb.ListTasksByUserRequest{UserId: userID})
+			return resp.GetTasks(), err
+		}), loaderWait, loaderMaxBatch),
+		projects: dataloader.New(perKey(calls, func(ctx context.Context, userID uint32) ([]*taskspb.Project, error) {
+			resp, err := tasks.ListProjects(ctx, &taskspb.ListProjectsRequest{UserId: userID, IncludeArchived: true})
+			return resp.GetProjects(), err
+		}), loaderWait, loaderMaxBatch),
+		tags: dataloader.New(perKey(calls, func(ctx context.Context, userID uint32) ([]*taskspb.Tag, error) {
+			resp, err := tasks.ListTags(ctx, &taskspb.ListTagsRequest{UserId: userID})
+			return resp.GetTags(), err
+		}), loaderWait, loaderMaxBatch),
+	}
+}
+
+// perKey делает из вызова по одному ключу загрузку пакета параллельными вызовами.
+// calls ограничивает число одновременных вызовов; горутина заводится только на
+// занятое место, а не на каждый ключ.
+func perKey[V any](calls chan struct{}, get func(ctx context.Context, key uint32) (V, error)) dataloader.BatchFunc[uint32, V] {
+	return func(ctx context.Context, keys []uint32) ([]V, []error) {
+		values := make([]V, len(keys))
+		errs := make([]error, len(keys))
+
+		var wg sync.WaitGroup
+		for i, key := range keys {
+			select {
+			case calls <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-calls }()
+				values[i], errs[i] = get(ctx, key)
+			}()
+		}
+		wg.Wait()
+		return values, errs
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
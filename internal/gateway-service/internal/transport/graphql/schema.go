@@ -0,0 +1,453 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/gateway-service/internal/auth"
+	"github.com/your-org/gateway-service/internal/graphql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// queryLimits — пределы одного запроса: Task.parent.subtasks... можно обходить
+// бесконечно, а псевдонимы и фрагменты размножают поля вширь
+var queryLimits = graphql.Limits{
+	MaxDepth:       8,
+	MaxComplexity:  5000,
+	ListSize:       10,
+	MaxConcurrency: 64,
+}
+
+var dateTime = &graphql.Scalar{
+	Name:        "DateTime",
+	Description: "время в формате RFC 3339",
+	Serialize: func(v any) (any, error) {
+		switch v := v.(type) {
+		case *timestamppb.Timestamp:
+			return v.AsTime().Format(time.RFC3339Nano), nil
+		case time.Time:
+			return v.Format(time.RFC3339Nano), nil
+		}
+		return nil, fmt.Errorf("DateTime cannot represent %T", v)
+	},
+	Parse: func(v any) (any, error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("DateTime must be a string")
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, fmt.Errorf("DateTime must be in RFC 3339 format")
+		}
+		return t, nil
+	},
+}
+
+// protoEnum строит enum GraphQL из enum proto: значение 0 (UNSPECIFIED)
+// пропускается, общий префикс имен убирается
+func protoEnum[E ~int32](name, description, prefix string, names map[int32]string) *graphql.Enum {
+	nums := make([]int32, 0, len(names))
+	for n := range names {
+		if n != 0 {
+			nums = append(nums, n)
+		}
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+
+	e := &graphql.Enum{Name: name, Description: description}
+	for _, n := range nums {
+		e.Values = append(e.Values, graphql.EnumValue{Name: strings.TrimPrefix(names[n], prefix), Value: E(n)})
+	}
+	return e
+}
+
+var (
+	taskStatus    = protoEnum[taskspb.TaskStatus]("TaskStatus", "статус задачи", "TASK_STATUS_", taskspb.TaskStatus_name)
+	taskPriority  = protoEnum[taskspb.TaskPriority]("TaskPriority", "приоритет задачи", "TASK_PRIORITY_", taskspb.TaskPriority_name)
+	taskEventType = protoEnum[taskspb.TaskEventType]("TaskEventType", "что произошло с задачей", "TASK_EVENT_TYPE_", taskspb.TaskEventType_name)
+)
+
+// get — резолвер поля, которое берется из объекта source типа T
+func get[T any](fn func(T) any) graphql.ResolveFunc {
+	return func(_ context.Context, source any, _ map[string]any) (any, error) {
+		return fn(source.(T)), nil
+	}
+}
+
+// resolve — резолвер поля объекта source типа T с вызовом сервисов
+func resolve[T any](fn func(ctx context.Context, source T, args map[string]any) (any, error)) graphql.ResolveFunc {
+	return func(ctx context.Context, source any, args map[string]any) (any, error) {
+		return fn(ctx, source.(T), args)
+	}
+}
+
+func nonNull(t graphql.Type) graphql.Type {
+	return &graphql.NonNull{Of: t}
+}
+
+func listOf(t graphql.Type) graphql.Type {
+	return &graphql.NonNull{Of: &graphql.List{Of: &graphql.NonNull{Of: t}}}
+}
+
+// newSchema описывает API GraphQL. Все данные — данные пользователя токена:
+// корни запроса — me и объекты по id среди его данных, остальное достижимо
+// только по связям от них.
+func newSchema(tasks taskspb.TasksServiceClient) (*graphql.Schema, error) {
+	user := &graphql.Object{Name: "User", Description: "пользователь"}
+	task := &graphql.Object{Name: "Task", Description: "задача"}
+	project := &graphql.Object{Name: "Project", Description: "проект"}
+	tag := &graphql.Object{Name: "Tag", Description: "тег"}
+	taskEvent := &graphql.Object{Name: "TaskEvent", Description: "изменение задачи"}
+
+	user.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID), Resolve: get(func(u *userpb.User) any { return u.GetId() })},
+		{Name: "email", Type: nonNull(graphql.String), Resolve: get(func(u *userpb.User) any { return u.GetEmail() })},
+		{Name: "etag", Type: nonNull(graphql.String), Resolve: get(func(u *userpb.User) any { return u.GetEtag() })},
+		{
+			Name:        "tasks",
+			Description: "задачи пользователя, кроме удаленных",
+			Type:        listOf(task),
+			Args: []*graphql.Argument{
+				{Name: "status", Type: taskStatus},
+				{Name: "done", Type: graphql.Boolean},
+				{Name: "topLevel", Description: "только задачи без родителя", Type: graphql.Boolean, Default: false},
+			},
+			Resolve: resolve(func(ctx context.Context, u *userpb.User, args map[string]any) (any, error) {
+				all, err := loadersFrom(ctx).tasks.Load(ctx, u.GetId())
+				if err != nil {
+					return nil, err
+				}
+				return filterTasks(all, func(t *taskspb.Task) bool {
+					if s, ok := args["status"].(taskspb.TaskStatus); ok && t.GetStatus() != s {
+						return false
+					}
+					if done, ok := args["done"].(bool); ok && t.GetIsDone() != done {
+						return false
+					}
+					topLevel, _ := args["topLevel"].(bool)
+					return !topLevel || t.GetParentId() == 0
+				}), nil
+			}),
+		},
+		{
+			Name: "projects",
+			Type: listOf(project),
+			Args: []*graphql.Argument{
+				{Name: "includeArchived", Type: graphql.Boolean, Default: false},
+			},
+			Resolve: resolve(func(ctx context.Context, u *userpb.User, args map[string]any) (any, error) {
+				all, err := loadersFrom(ctx).projects.Load(ctx, u.GetId())
+				if err != nil {
+					return nil, err
+				}
+				includeArchived, _ := args["includeArchived"].(bool)
+				var out []*taskspb.Project
+				for _, p := range all {
+					if includeArchived || !p.GetArchived() {
+						out = append(out, p)
+					}
+				}
+				return out, nil
+			}),
+		},
+		{
+			Name: "tags",
+			Type: listOf(tag),
+			Resolve: resolve(func(ctx context.Context, u *userpb.User, _ map[string]any) (any, error) {
+				return loadersFrom(ctx).tags.Load(ctx, u.GetId())
+			}),
+		},
+	}
+
+	task.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID), Resolve: get(func(t *taskspb.Task) any { return t.GetId() })},
+		{Name: "title", Type: nonNull(graphql.String), Resolve: get(func(t *taskspb.Task) any { return t.GetTitle() })},
+		{Name: "isDone", Type: nonNull(graphql.Boolean), Resolve: get(func(t *taskspb.Task) any { return t.GetIsDone() })},
+		{Name: "status", Type: nonNull(taskStatus), Resolve: get(func(t *taskspb.Task) any { return t.GetStatus() })},
+		{Name: "priority", Type: nonNull(taskPriority), Resolve: get(func(t *taskspb.Task) any { return t.GetPriority() })},
+		{Name: "etag", Type: nonNull(graphql.String), Resolve: get(func(t *taskspb.Task) any { return t.GetEtag() })},
+		{Name: "dueAt", Type: dateTime, Resolve: get(func(t *taskspb.Task) any { return t.GetDueAt() })},
+		{Name: "remindAt", Type: dateTime, Resolve: get(func(t *taskspb.Task) any { return t.GetRemindAt() })},
+		{Name: "completedAt", Type: dateTime, Resolve: get(func(t *taskspb.Task) any { return t.GetCompletedAt() })},
+		{Name: "rrule", Description: "правило повторения, пусто у обычной задачи", Type: nonNull(graphql.String), Resolve: get(func(t *taskspb.Task) any { return t.GetRrule() })},
+		{
+			Name: "owner",
+			Type: nonNull(user),
+			Resolve: resolve(func(ctx context.Context, t *taskspb.Task, _ map[string]any) (any, error) {
+				return loadersFrom(ctx).users.Load(ctx, t.GetUserId())
+			}),
+		},
+		{
+			Name: "parent",
+			Type: task,
+			Resolve: resolve(func(ctx context.Context, t *taskspb.Task, _ map[string]any) (any, error) {
+				if t.GetParentId() == 0 {
+					return nil, nil
+				}
+				return findTask(ctx, t.GetUserId(), t.GetParentId())
+			}),
+		},
+		{
+			Name: "subtasks",
+			Type: listOf(task),
+			Resolve: resolve(func(ctx context.Context, t *taskspb.Task, _ map[string]any) (any, error) {
+				all, err := loadersFrom(ctx).tasks.Load(ctx, t.GetUserId())
+				if err != nil {
+					return nil, err
+				}
+				return filterTasks(all, func(sub *taskspb.Task) bool { return sub.GetParentId() == t.GetId() }), nil
+			}),
+		},
+		{
+			Name:        "blockers",
+			Description: "задачи, которые должны быть выполнены или отменены раньше этой",
+			Type:        listOf(task),
+			Resolve: resolve(func(ctx context.Context, t *taskspb.Task, _ map[string]any) (any, error) {
+				all, err := loadersFrom(ctx).tasks.Load(ctx, t.GetUserId())
+				if err != nil {
+					return nil, err
+				}
+				return filterTasks(all, func(b *taskspb.Task) bool { return contains(t.GetBlockerIds(), b.GetId()) }), nil
+			}),
+		},
+		{
+			Name: "project",
+			Type: project,
+			Resolve: resolve(func(ctx context.Context, t *taskspb.Task, _ map[string]any) (any, error) {
+				if t.GetProjectId() == 0 {
+					return nil, nil
+				}
+				return findProject(ctx, t.GetUserId(), t.GetProjectId())
+			}),
+		},
+		{
+			Name: "tags",
+			Type: listOf(tag),
+			Resolve: resolve(func(ctx context.Context, t *taskspb.Task, _ map[string]any) (any, error) {
+				all, err := loadersFrom(ctx).tags.Load(ctx, t.GetUserId())
+				if err != nil {
+					return nil, err
+				}
+				var out []*taskspb.Tag
+				for _, tg := range all {
+					if contains(t.GetTagIds(), tg.GetId()) {
+						out = append(out, tg)
+					}
+				}
+				return out, nil
+			}),
+		},
+	}
+
+	project.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID), Resolve: get(func(p *taskspb.Project) any { return p.GetId() })},
+		{Name: "name", Type: nonNull(graphql.String), Resolve: get(func(p *taskspb.Project) any { return p.GetName() })},
+		{Name: "archived", Type: nonNull(graphql.Boolean), Resolve: get(func(p *taskspb.Project) any { return p.GetArchived() })},
+		{Name: "position", Type: nonNull(graphql.Int), Resolve: get(func(p *taskspb.Project) any { return p.GetPosition() })},
+		{
+			Name: "owner",
+			Type: nonNull(user),
+			Resolve: resolve(func(ctx context.Context, p *taskspb.Project, _ map[string]any) (any, error) {
+				return loadersFrom(ctx).users.Load(ctx, p.GetUserId())
+			}),
+		},
+		{
+			Name: "tasks",
+			Type: listOf(task),
+			Resolve: resolve(func(ctx context.Context, p *taskspb.Project, _ map[string]any) (any, error) {
+				all, err := loadersFrom(ctx).tasks.Load(ctx, p.GetUserId())
+				if err != nil {
+					return nil, err
+				}
+				return filterTasks(all, func(t *taskspb.Task) bool { return t.GetProjectId() == p.GetId() }), nil
+			}),
+		},
+	}
+
+	tag.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(graphql.ID), Resolve: get(func(t *taskspb.Tag) any { return t.GetId() })},
+		{Name: "name", Type: nonNull(graphql.String), Resolve: get(func(t *taskspb.Tag) any { return t.GetName() })},
+		{
+			Name: "tasks",
+			Type: listOf(task),
+			Resolve: resolve(func(ctx context.Context, tg *taskspb.Tag, _ map[string]any) (any, error) {
+				all, err := loadersFrom(ctx).tasks.Load(ctx, tg.GetUserId())
+				if err != nil {
+					return nil, err
+				}
+				return filterTasks(all, func(t *taskspb.Task) bool { return contains(t.GetTagIds(), tg.GetId()) }), nil
+			}),
+		},
+	}
+
+	taskEvent.Fields = []*graphql.Field{
+		{
+			Name:        "revision",
+			Description: "номер события; передается в fromRevision при переподключении",
+			Type:        nonNull(graphql.String),
+			Resolve:     get(func(e *taskspb.TaskEvent) any { return strconv.FormatUint(e.GetRevision(), 10) }),
+		},
+		{Name: "type", Type: nonNull(taskEventType), Resolve: get(func(e *taskspb.TaskEvent) any { return e.GetType() })},
+		{Name: "task", Type: nonNull(task), Resolve: get(func(e *taskspb.TaskEvent) any { return e.GetTask() })},
+	}
+
+	query := &graphql.Object{
+		Name: "Query",
+		Fields: []*graphql.Field{
+			{
+				Name:        "me",
+				Description: "пользователь токена",
+				Type:        nonNull(user),
+				Resolve: func(ctx context.Context, _ any, _ map[string]any) (any, error) {
+					return loadersFrom(ctx).users.Load(ctx, caller(ctx))
+				},
+			},
+			{
+				Name:        "user",
+				Description: "доступен только пользователь токена",
+				Type:        user,
+				Args:        []*graphql.Argument{{Name: "id", Type: nonNull(graphql.ID)}},
+				Resolve: func(ctx context.Context, _ any, args map[string]any) (any, error) {
+					id, err := parseID(args["id"])
+					if err != nil {
+						return nil, err
+					}
+					if id != caller(ctx) {
+						return nil, status.Error(codes.PermissionDenied, "access to another user's data is denied")
+					}
+					return loadersFrom(ctx).users.Load(ctx, id)
+				},
+			},
+			{
+				Name:        "task",
+				Description: "задача пользователя токена; null, если такой нет",
+				Type:        task,
+				Args:        []*graphql.Argument{{Name: "id", Type: nonNull(graphql.ID)}},
+				Resolve: func(ctx context.Context, _ any, args map[string]any) (any, error) {
+					id, err := parseID(args["id"])
+					if err != nil {
+						return nil, err
+					}
+					return findTask(ctx, caller(ctx), id)
+				},
+			},
+			{
+				Name:        "project",
+				Description: "проект пользователя токена; null, если такого нет",
+				Type:        project,
+				Args:        []*graphql.Argument{{Name: "id", Type: nonNull(graphql.ID)}},
+				Resolve: func(ctx context.Context, _ any, args map[string]any) (any, error) {
+					id, err := parseID(args["id"])
+					if err != nil {
+						return nil, err
+					}
+					return findProject(ctx, caller(ctx), id)
+				},
+			},
+		},
+	}
+
+	subscription := &graphql.Object{
+		Name: "Subscription",
+		Fields: []*graphql.Field{
+			{
+				Name:        "taskChanged",
+				Description: "изменения задач пользователя токена; fromRevision — сначала события после этой ревизии",
+				Type:        nonNull(taskEvent),
+				Args:        []*graphql.Argument{{Name: "fromRevision", Type: graphql.String}},
+				Subscribe: func(ctx context.Context, args map[string]any) (graphql.Stream, error) {
+					var from uint64
+					if s, ok := args["fromRevision"].(string); ok {
+						var err error
+						if from, err = strconv.ParseUint(s, 10, 64); err != nil {
+							return nil, status.Error(codes.InvalidArgument, "fromRevision must be a revision number")
+						}
+					}
+					stream, err := tasks.WatchTasks(ctx, &taskspb.WatchTasksRequest{UserId: caller(ctx), FromRevision: from})
+					if err != nil {
+						return nil, err
+					}
+					return taskEvents{stream}, nil
+				},
+			},
+		},
+	}
+
+	return graphql.NewSchema(query, subscription, queryLimits)
+}
+
+// taskEvents — поток WatchTasks как источник событий подписки
+type taskEvents struct {
+	stream taskspb.TasksService_WatchTasksClient
+}
+
+func (e taskEvents) Next() (any, error) {
+	return e.stream.Recv()
+}
+
+// caller — id пользователя токена; запросы без токена шлюз не пропускает
+func caller(ctx context.Context) uint32 {
+	userID, _ := auth.UserFromContext(ctx)
+	return userID
+}
+
+func parseID(v any) (uint32, error) {
+	id, err := strconv.ParseUint(v.(string), 10, 32)
+	if err != nil || id == 0 {
+		return 0, status.Error(codes.InvalidArgument, "id must be a positive integer")
+	}
+	return uint32(id), nil
+}
+
+func findTask(ctx context.Context, userID, id uint32) (*taskspb.Task, error) {
+	all, err := loadersFrom(ctx).tasks.Load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range all {
+		if t.GetId() == id {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func findProject(ctx context.Context, userID, id uint32) (*taskspb.Project, error) {
+	all, err := loadersFrom(ctx).projects.Load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range all {
+		if p.GetId() == id {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func filterTasks(tasks []*taskspb.Task, keep func(*taskspb.Task) bool) []*taskspb.Task {
+	var out []*taskspb.Task
+	for _, t := range tasks {
+		if keep(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func contains(ids []uint32, id uint32) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
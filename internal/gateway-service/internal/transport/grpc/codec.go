@@ -0,0 +1,33 @@
+package grpc
+
+import "fmt"
+
+// frame — сообщение gRPC в том виде, в каком пришло по сети
+type frame struct {
+	payload []byte
+}
+
+// rawCodec передает сообщения между клиентом и сервисом без разбора. Имя "proto"
+// совпадает с кодеком по умолчанию, поэтому content-type запросов не меняется.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	f, ok := v.(*frame)
+	if !ok {
+		return nil, fmt.Errorf("rawCodec: unexpected message type %T", v)
+	}
+	return f.payload, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	f, ok := v.(*frame)
+	if !ok {
+		return fmt.Errorf("rawCodec: unexpected message type %T", v)
+	}
+	f.payload = append(f.payload[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,314 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/gateway-service/internal/auth"
+	"github.com/your-org/gateway-service/internal/backends"
+	"github.com/your-org/gateway-service/internal/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// authorizationHeader — токен клиента: "Bearer <token>"
+	authorizationHeader = "authorization"
+	// actorHeader — id пользователя для сервисов за шлюзом
+	actorHeader = "x-actor-id"
+	// forwardedForHeader — адрес клиента, сервисы доверяют ему только от локального шлюза
+	forwardedForHeader = "x-forwarded-for"
+	// accessTokenHeader и tokenExpiresHeader — заголовки ответа Authenticate с токеном доступа
+	accessTokenHeader  = "x-access-token"
+	tokenExpiresHeader = "x-access-token-expires"
+)
+
+// backend — соединение с сервисом и описание его методов из proto
+type backend struct {
+	conn    *grpc.ClientConn
+	service protoreflect.ServiceDescriptor
+}
+
+// Proxy передает вызовы gRPC сервисам по имени сервиса в пути метода. Сообщения
+// не перекодируются; запросы клиента разбираются только для проверки владельца.
+type Proxy struct {
+	issuer   *auth.Issuer
+	limiter  *ratelimit.Limiter
+	backends map[string]backend
+}
+
+func NewProxy(issuer *auth.Issuer, limiter *ratelimit.Limiter, b *backends.Backends) (*Proxy, error) {
+	p := &Proxy{
+		issuer:   issuer,
+		limiter:  limiter,
+		backends: make(map[string]backend),
+	}
+	for name, conn := range map[string]*grpc.ClientConn{usersService: b.UsersConn, tasksService: b.TasksConn} {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("grpc proxy: service %s: %w", name, err)
+		}
+		sd, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("grpc proxy: %s is not a service", name)
+		}
+		p.backends[name] = backend{conn: conn, service: sd}
+	}
+	return p, nil
+}
+
+// handle — обработчик всех методов сервера шлюза
+func (p *Proxy) handle(_ any, ss grpc.ServerStream) error {
+	fullMethod, ok := grpc.MethodFromServerStream(ss)
+	if !ok {
+		return status.Error(codes.Internal, "method is unknown")
+	}
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	b, ok := p.backends[service]
+	if !ok || blockedMethods[fullMethod] {
+		return status.Errorf(codes.Unimplemented, "method %s is not available", fullMethod)
+	}
+	md := b.service.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return status.Errorf(codes.Unimplemented, "method %s is not available", fullMethod)
+	}
+
+	ctx := ss.Context()
+	userID, err := p.authenticate(ctx, publicMethods[fullMethod])
+	if err != nil {
+		return err
+	}
+
+	key := "ip:" + clientIP(ctx)
+	if userID != 0 {
+		key = "user:" + strconv.FormatUint(uint64(userID), 10)
+	}
+	if ok, wait := p.limiter.Allow(key); !ok {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %s", wait.Round(time.Millisecond))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	desc := &grpc.StreamDesc{
+		StreamName:    method,
+		ServerStreams: md.IsStreamingServer(),
+		ClientStreams: md.IsStreamingClient(),
+	}
+	cs, err := b.conn.NewStream(outgoingContext(ctx, userID), desc, fullMethod, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return err
+	}
+
+	var owner *ownerField
+	if f, ok := ownerFieldFor(service, md.Name()); ok && userID != 0 {
+		owner = &f
+	}
+
+	c2s := make(chan error, 1)
+	s2c := make(chan error, 1)
+	go func() { c2s <- forwardRequests(ss, cs, md.Input(), owner, userID) }()
+	go func() { s2c <- p.forwardResponses(cs, ss, md) }()
+
+	for {
+		select {
+		case err := <-c2s:
+			if err != nil {
+				// cancel в defer обрывает вызов сервиса
+				return err
+			}
+			// клиент закончил отправку, ждем ответа сервиса
+			c2s = nil
+		case err := <-s2c:
+			ss.SetTrailer(cs.Trailer())
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// authenticate проверяет токен из метаданных и возвращает id пользователя;
+// 0 — вызов без токена, допустимый для public
+func (p *Proxy) authenticate(ctx context.Context, public bool) (uint32, error) {
+	values := metadata.ValueFromIncomingContext(ctx, authorizationHeader)
+	if len(values) == 0 || values[0] == "" {
+		if public {
+			return 0, nil
+		}
+		return 0, status.Error(codes.Unauthenticated, "access token is required")
+	}
+
+	token, ok := auth.BearerToken(values[0])
+	if !ok {
+		return 0, status.Errorf(codes.Unauthenticated, "%s must be a bearer token", authorizationHeader)
+	}
+	userID, err := p.issuer.Verify(token)
+	if err != nil {
+		return 0, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return userID, nil
+}
+
+// forwardRequests передает сообщения клиента сервису и проверяет в них владельца
+func forwardRequests(ss grpc.ServerStream, cs grpc.ClientStream, input protoreflect.MessageDescriptor, owner *ownerField, userID uint32) error {
+	for {
+		f := &frame{}
+		if err := ss.RecvMsg(f); err != nil {
+			if errors.Is(err, io.EOF) {
+				return cs.CloseSend()
+			}
+			return err
+		}
+		if owner != nil {
+			if err := checkOwner(f.payload, input, *owner, userID); err != nil {
+				return err
+			}
+		}
+		if err := cs.SendMsg(f); err != nil {
+			if errors.Is(err, io.EOF) {
+				// сервис уже завершил вызов, статус придет в forwardResponses
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// forwardResponses передает клиенту заголовки и сообщения сервиса. Ответ
+// Authenticate дополняется токеном доступа в заголовках x-access-token.
+func (p *Proxy) forwardResponses(cs grpc.ClientStream, ss grpc.ServerStream, md protoreflect.MethodDescriptor) error {
+	first := true
+	for {
+		f := &frame{}
+		err := cs.RecvMsg(f)
+		if first {
+			first = false
+			if header, hErr := cs.Header(); hErr == nil {
+				ss.SetHeader(header)
+			}
+			if err == nil && md.FullName() == authenticateMethod {
+				if err := p.setAccessToken(ss, f.payload, md.Output()); err != nil {
+					return err
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err := ss.SendMsg(f); err != nil {
+			return err
+		}
+	}
+}
+
+// authenticateMethod — вход: после него шлюз выдает токен
+var authenticateMethod = protoreflect.FullName(usersService + ".Authenticate")
+
+func (p *Proxy) setAccessToken(ss grpc.ServerStream, payload []byte, output protoreflect.MessageDescriptor) error {
+	user := dynamicpb.NewMessage(output)
+	if err := proto.Unmarshal(payload, user); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode user: %v", err)
+	}
+	userID := uint32(user.Get(output.Fields().ByName("id")).Uint())
+
+	token, expires, err := p.issuer.Issue(userID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to issue token: %v", err)
+	}
+	return ss.SetHeader(metadata.Pairs(
+		accessTokenHeader, token,
+		tokenExpiresHeader, expires.UTC().Format(time.RFC3339),
+	))
+}
+
+// checkOwner разбирает запрос и возвращает PermissionDenied, если поле владельца
+// указывает на другого пользователя. Незаполненное поле сервис проверит сам.
+func checkOwner(payload []byte, input protoreflect.MessageDescriptor, owner ownerField, userID uint32) error {
+	msg := dynamicpb.NewMessage(input)
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to decode request: %v", err)
+	}
+	if !ownedBy(msg, owner, userID) {
+		return status.Error(codes.PermissionDenied, "access to another user's data is denied")
+	}
+	return nil
+}
+
+func ownedBy(msg protoreflect.Message, owner ownerField, userID uint32) bool {
+	ok := true
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case slices.Contains(owner.names, fd.Name()) && fd.Kind() == protoreflect.Uint32Kind && !fd.IsList():
+			ok = uint32(v.Uint()) == userID
+		case !owner.nested || fd.Message() == nil:
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len() && ok; i++ {
+				ok = ownedBy(list.Get(i).Message(), owner, userID)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					ok = ownedBy(mv.Message(), owner, userID)
+					return ok
+				})
+			}
+		default:
+			ok = ownedBy(v.Message(), owner, userID)
+		}
+		return ok
+	})
+	return ok
+}
+
+// outgoingContext передает сервису метаданные клиента без токена, а вместо
+// присланных клиентом x-actor-id и x-forwarded-for — проверенные шлюзом
+func outgoingContext(ctx context.Context, userID uint32) context.Context {
+	in, _ := metadata.FromIncomingContext(ctx)
+	out := metadata.MD{}
+	for key, values := range in {
+		switch {
+		case key == authorizationHeader, key == actorHeader, key == forwardedForHeader,
+			key == "content-type", key == "user-agent", key == "te",
+			strings.HasPrefix(key, ":"), strings.HasPrefix(key, "grpc-"):
+			continue
+		}
+		out[key] = values
+	}
+	if userID != 0 {
+		out.Set(actorHeader, strconv.FormatUint(uint64(userID), 10))
+	}
+	if ip := clientIP(ctx); ip != "" {
+		out.Set(forwardedForHeader, ip)
+	}
+	return metadata.NewOutgoingContext(ctx, out)
+}
+
+// clientIP — адрес клиента без порта
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
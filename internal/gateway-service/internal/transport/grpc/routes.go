@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	usersService = userpb.UserService_ServiceDesc.ServiceName
+	tasksService = taskspb.TasksService_ServiceDesc.ServiceName
+)
+
+// publicMethods доступны без токена: регистрация и вход
+var publicMethods = map[string]bool{
+	"/" + usersService + "/CreateUser":   true,
+	"/" + usersService + "/Authenticate": true,
+}
+
+// blockedMethods наружу не публикуются: список всех пользователей и всех задач
+// и методы, которые вызывает только users-service
+var blockedMethods = map[string]bool{
+	"/" + usersService + "/ListUsers":      true,
+	"/" + tasksService + "/GetTaskList":    true,
+	"/" + tasksService + "/OnUserDeleted":  true,
+	"/" + tasksService + "/OnUserRestored": true,
+	"/" + tasksService + "/ExportUserData": true,
+	"/" + tasksService + "/EraseUserData":  true,
+}
+
+// ownerField — поля запроса с id пользователя, которому адресован вызов
+type ownerField struct {
+	names []protoreflect.Name
+	// nested — поле ищется и во вложенных сообщениях (пакетные методы)
+	nested bool
+}
+
+// userOwnerFields — методы UserService над одним пользователем. Остальные методы
+// UserService — администраторские, их доступ проверяет сам users-service.
+var userOwnerFields = map[protoreflect.Name]ownerField{
+	"GetUser":    {names: []protoreflect.Name{"id"}},
+	"UpdateUser": {names: []protoreflect.Name{"id"}},
+	"DeleteUser": {names: []protoreflect.Name{"id"}},
+}
+
+// tasksOwnerField — у TasksService владелец в поле user_id, у комментариев — в author_id.
+// Сущности по их id сверяет с x-actor-id сам tasks-service.
+var tasksOwnerField = ownerField{names: []protoreflect.Name{"user_id", "author_id"}, nested: true}
+
+// ownerFieldFor возвращает поле владельца метода; false — вызов не проверяется
+func ownerFieldFor(service string, method protoreflect.Name) (ownerField, bool) {
+	if service == tasksService {
+		return tasksOwnerField, true
+	}
+	f, ok := userOwnerFields[method]
+	return f, ok
+}
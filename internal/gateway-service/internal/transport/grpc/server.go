@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	server *grpc.Server
+	Port   int
+}
+
+// NewServer создает gRPC-сервер шлюза: все вызовы уходят в proxy
+func NewServer(port int, proxy *Proxy) *Server {
+	return &Server{
+		server: grpc.NewServer(
+			grpc.ForceServerCodec(rawCodec{}),
+			grpc.UnknownServiceHandler(proxy.handle),
+		),
+		Port: port,
+	}
+}
+
+func (s *Server) Start() error {
+	addr := net.JoinHostPort("", strconv.Itoa(s.Port))
+	ls, err := net.Listen("tcp", addr)
+
+	fmt.Println("gRPC gateway is running on port", s.Port)
+
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", s.Port, err)
+	}
+
+	if err := s.server.Serve(ls); err != nil {
+		return fmt.Errorf("failed to serve gRPC gateway: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) Stop() {
+	s.server.GracefulStop()
+	fmt.Println("gRPC gateway is stopped on port", s.Port)
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// httpStatuses — HTTP-статусы ответов с ошибкой по кодам gRPC, как у REST-шлюзов сервисов
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	if s, ok := httpStatuses[code]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
+
+// writeError отвечает ошибкой в том же виде, что и сервисы за шлюзом:
+// google.rpc.Status {"code", "message", "details"}
+func writeError(w http.ResponseWriter, err error) {
+	writeErrorStatus(w, httpStatusFromCode(status.Code(err)), err)
+}
+
+// writeErrorStatus — то же с HTTP-статусом, которого нет среди кодов gRPC
+func writeErrorStatus(w http.ResponseWriter, httpStatus int, err error) {
+	st := status.Convert(err)
+	body, mErr := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(st.Proto())
+	if mErr != nil {
+		body = []byte(`{"code":13,"message":"failed to encode error","details":[]}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(body)
+}
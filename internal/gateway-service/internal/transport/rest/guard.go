@@ -0,0 +1,205 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/your-org/gateway-service/internal/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxInspectedBody — тело больше этого сервисы как JSON не примут, шлюз отвечает 413
+const maxInspectedBody = 4 << 20
+
+// errBodyTooLarge — JSON-тело больше maxInspectedBody
+var errBodyTooLarge = fmt.Errorf("request body exceeds %d bytes", maxInspectedBody)
+
+// ownerFields — поля запроса с id пользователя, в JSON и в строке запроса;
+// authorId — автор комментария
+var ownerFields = []string{"userId", "user_id", "authorId", "author_id"}
+
+// guard проверяет токен, лимит запросов и что запрос касается только данных
+// пользователя токена: параметр пути rt.owner и поля userId и authorId в теле и строке
+// запроса. Тело маршрута rt.rawBody не разбирается, у остальных оно должно быть JSON.
+func (h *Handler) guard(rt proxyRoute, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var userID uint32
+		if header := r.Header.Get("Authorization"); header != "" {
+			token, ok := auth.BearerToken(header)
+			if !ok {
+				writeError(w, status.Error(codes.Unauthenticated, "authorization must be a bearer token"))
+				return
+			}
+			id, err := h.issuer.Verify(token)
+			if err != nil {
+				writeError(w, status.Error(codes.Unauthenticated, err.Error()))
+				return
+			}
+			userID = id
+		} else if !rt.public {
+			writeError(w, status.Error(codes.Unauthenticated, "access token is required"))
+			return
+		}
+
+		key := "ip:" + clientIP(r)
+		if userID != 0 {
+			key = "user:" + strconv.FormatUint(uint64(userID), 10)
+		}
+		if ok, wait := h.limiter.Allow(key); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %s", wait.Round(time.Millisecond)))
+			return
+		}
+
+		if userID != 0 {
+			if err := checkOwner(r, rt, userID); err != nil {
+				if errors.Is(err, errBodyTooLarge) {
+					writeErrorStatus(w, http.StatusRequestEntityTooLarge, status.Error(codes.InvalidArgument, err.Error()))
+					return
+				}
+				writeError(w, err)
+				return
+			}
+			r = r.WithContext(auth.WithUser(r.Context(), userID))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkOwner возвращает PermissionDenied, если запрос адресован другому пользователю,
+// InvalidArgument, если тело не JSON, и errBodyTooLarge
+func checkOwner(r *http.Request, rt proxyRoute, userID uint32) error {
+	denied := status.Error(codes.PermissionDenied, "access to another user's data is denied")
+
+	if rt.owner != "" && !sameUser(r.PathValue(rt.owner), userID) {
+		return denied
+	}
+	query := r.URL.Query()
+	for _, field := range ownerFields {
+		for _, v := range query[field] {
+			if !sameUser(v, userID) {
+				return denied
+			}
+		}
+	}
+
+	if rt.rawBody {
+		return nil
+	}
+	ids, err := bodyUserIDs(r)
+	if errors.Is(err, errBodyTooLarge) {
+		return err
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+	for _, v := range ids {
+		if !sameUser(v, userID) {
+			return denied
+		}
+	}
+	return nil
+}
+
+// bodyUserIDs возвращает значения полей userId и authorId на любой глубине JSON-тела.
+// Тело, которое не JSON, — ошибка, больше maxInspectedBody — errBodyTooLarge: иначе
+// такое тело ушло бы сервису непроверенным. Прочитанное тело возвращается в запрос.
+func bodyUserIDs(r *http.Request) ([]string, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	buf, err := io.ReadAll(io.LimitReader(r.Body, maxInspectedBody+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) > maxInspectedBody {
+		return nil, errBodyTooLarge
+	}
+	r.Body = readCloser{bytes.NewReader(buf), r.Body}
+	if len(bytes.TrimSpace(buf)) == 0 {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("body is not JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("body is not JSON: data after the top-level value")
+	}
+
+	var ids []string
+	collectUserIDs(v, &ids)
+	return ids, nil
+}
+
+func collectUserIDs(v any, ids *[]string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isOwnerField(key) {
+				switch id := value.(type) {
+				case json.Number:
+					*ids = append(*ids, id.String())
+				case string:
+					*ids = append(*ids, id)
+				case nil:
+				default:
+					// id не числом не сравнить с пользователем токена, такой запрос отклоняется
+					*ids = append(*ids, "")
+				}
+				continue
+			}
+			collectUserIDs(value, ids)
+		}
+	case []any:
+		for _, value := range v {
+			collectUserIDs(value, ids)
+		}
+	}
+}
+
+func isOwnerField(key string) bool {
+	for _, field := range ownerFields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
+
+// sameUser сообщает, что value — id пользователя userID. 0 в теле — поле не задано.
+func sameUser(value string, userID uint32) bool {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return false
+	}
+	return id == 0 || uint32(id) == userID
+}
+
+// clientIP — адрес клиента без порта
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// readCloser читает из r и закрывает исходное тело запроса
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBodyUserIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "no body", body: ""},
+		{name: "blank body", body: " \n"},
+		{name: "nested ids", body: `{"userId": 7, "tasks": [{"user_id": "7"}, {"authorId": 8}]}`, want: []string{"7", "7", "8"}},
+		{name: "id not a number", body: `{"userId": {"id": 2}}`, want: []string{""}},
+		{name: "null id", body: `{"userId": null}`},
+		{name: "not JSON", body: "title,due\nbuy milk,", wantErr: true},
+		{name: "trailing data", body: `{"userId": 7} {"userId": 8}`, wantErr: true},
+		{name: "truncated", body: `{"userId": 7`, wantErr: true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/v1/tasks", strings.NewReader(tt.body))
+		got, err := bodyUserIDs(r)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: ids = %q, want %q", tt.name, got, tt.want)
+		}
+		if err == nil {
+			// тело возвращается в запрос для сервиса
+			if rest, _ := io.ReadAll(r.Body); string(rest) != tt.body {
+				t.Errorf("%s: body after inspection = %q", tt.name, rest)
+			}
+		}
+	}
+
+	big := httptest.NewRequest("POST", "/v1/tasks", strings.NewReader(strings.Repeat(" ", maxInspectedBody+1)))
+	if _, err := bodyUserIDs(big); !errors.Is(err, errBodyTooLarge) {
+		t.Errorf("oversized body: err = %v, want errBodyTooLarge", err)
+	}
+}
+
+func TestCheckOwner(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   proxyRoute
+		url  string
+		body string
+		want codes.Code
+	}{
+		{"own path", proxyRoute{owner: "user_id"}, "/v1/users/7/tasks", "", codes.OK},
+		{"foreign path", proxyRoute{owner: "user_id"}, "/v1/users/8/tasks", "", codes.PermissionDenied},
+		{"foreign query", proxyRoute{}, "/v1/tasks?user_id=8", "", codes.PermissionDenied},
+		{"foreign body", proxyRoute{}, "/v1/tasks", `{"userId": 8}`, codes.PermissionDenied},
+		{"own body", proxyRoute{}, "/v1/tasks", `{"userId": 7, "title": "x"}`, codes.OK},
+		{"not JSON", proxyRoute{}, "/v1/tasks", "userId=8", codes.InvalidArgument},
+		// тело файла не разбирается, владельца задает путь
+		{"raw body", proxyRoute{owner: "user_id", rawBody: true}, "/v1/users/7/tasks/import", "userId\n8", codes.OK},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+		if tt.rt.owner != "" {
+			r.SetPathValue(tt.rt.owner, strings.Split(tt.url, "/")[3])
+		}
+		if got := status.Code(checkOwner(r, tt.rt, 7)); got != tt.want {
+			t.Errorf("%s: code = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProxyRoutesRegister(t *testing.T) {
+	// маршруты файлов пересекаются с общими префиксами, ServeMux не должен считать их конфликтом
+	mux := http.NewServeMux()
+	for _, rt := range proxyRoutes {
+		mux.Handle(rt.pattern, http.NotFoundHandler())
+	}
+	r := httptest.NewRequest("POST", "/v1/tasks/5/attachments", nil)
+	if _, pattern := mux.Handler(r); pattern != "POST /v1/tasks/{task_id}/attachments" {
+		t.Errorf("upload matched %q", pattern)
+	}
+}
@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/gateway-service/domain"
+	"github.com/your-org/gateway-service/internal/auth"
+	"github.com/your-org/gateway-service/internal/profile"
+	"github.com/your-org/gateway-service/internal/ratelimit"
+	"github.com/your-org/gateway-service/internal/transport/graphql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxLoginBodySize — предел тела запроса на вход
+const maxLoginBodySize = 64 << 10
+
+// Handler — HTTP API шлюза: вход, сводка о пользователе, GraphQL и маршруты сервисов
+type Handler struct {
+	issuer   *auth.Issuer
+	limiter  *ratelimit.Limiter
+	users    userpb.UserServiceClient
+	profiles *profile.Service
+	mux      *http.ServeMux
+}
+
+// NewHandler собирает маршруты шлюза. usersURL и tasksURL — адреса REST-шлюзов сервисов.
+func NewHandler(issuer *auth.Issuer, limiter *ratelimit.Limiter, users userpb.UserServiceClient,
+	profiles *profile.Service, graph *graphql.Handler, usersURL, tasksURL *url.URL) *Handler {
+	h := &Handler{
+		issuer:   issuer,
+		limiter:  limiter,
+		users:    users,
+		profiles: profiles,
+		mux:      http.NewServeMux(),
+	}
+
+	h.mux.Handle("POST /v1/auth/login", h.guard(proxyRoute{public: true}, http.HandlerFunc(h.login)))
+	h.mux.Handle("GET /v1/me", h.guard(proxyRoute{}, http.HandlerFunc(h.me)))
+
+	// GraphQL: запросы и подписки (Server-Sent Events) по данным пользователя токена
+	h.mux.Handle("/graphql", h.guard(proxyRoute{}, graph))
+	h.mux.Handle("GET /graphql/schema.graphql", h.guard(proxyRoute{public: true}, http.HandlerFunc(graph.ServeSchema)))
+
+	proxies := map[backend]http.Handler{
+		usersBackend: newProxy(usersURL, ""),
+		tasksBackend: newProxy(tasksURL, ""),
+	}
+	for _, rt := range proxyRoutes {
+		h.mux.Handle(rt.pattern, h.guard(rt, proxies[rt.backend]))
+	}
+
+	// описания API сервисов, пути в них — те же, что у шлюза
+	h.mux.Handle("GET /openapi/users.json", h.guard(proxyRoute{public: true}, newProxy(usersURL, openAPIPath)))
+	h.mux.Handle("GET /openapi/tasks.json", h.guard(proxyRoute{public: true}, newProxy(tasksURL, openAPIPath)))
+
+	// без этого ServeMux отвечает на неизвестные пути текстом, а не JSON
+	h.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, status.Errorf(codes.NotFound, "no route for %s %s", r.Method, r.URL.Path))
+	})
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// login проверяет email и пароль в users-service и выдает токен доступа
+func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxLoginBodySize))
+	if err != nil {
+		writeError(w, status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err))
+		return
+	}
+	var req userpb.AuthenticateRequest
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, &req); err != nil {
+		writeError(w, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+		return
+	}
+
+	// адрес клиента попадает в журнал аудита users-service
+	ctx := metadata.AppendToOutgoingContext(r.Context(), forwardedForHeader, clientIP(r))
+	user, err := h.users.Authenticate(ctx, &req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	token, expires, err := h.issuer.Issue(user.GetId())
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to issue token: %v", err))
+		return
+	}
+
+	writeJSON(w, domain.Session{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   expires,
+		User: domain.User{
+			ID:    user.GetId(),
+			Email: user.GetEmail(),
+			Etag:  user.GetEtag(),
+		},
+	})
+}
+
+// me отвечает сводкой о пользователе токена
+func (h *Handler) me(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserFromContext(r.Context())
+
+	p, err := h.profiles.Get(r.Context(), userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, p)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
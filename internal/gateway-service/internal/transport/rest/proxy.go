@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+
+	"github.com/your-org/gateway-service/internal/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// actorHeader — id пользователя для сервисов за шлюзом
+	actorHeader = "X-Actor-Id"
+	// forwardedForHeader — адрес клиента, сервисы доверяют ему только от локального шлюза
+	forwardedForHeader = "x-forwarded-for"
+	// openAPIPath — описание REST API у сервисов
+	openAPIPath = "/openapi.json"
+)
+
+// newProxy передает запрос REST-шлюзу сервиса target. Токен клиента сервису не
+// передается: вместо него — id пользователя в X-Actor-Id, присланный клиентом
+// X-Actor-Id отбрасывается. path, если задан, заменяет путь запроса.
+func newProxy(target *url.URL, path string) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			if path != "" {
+				r.Out.URL.Path, r.Out.URL.RawPath = path, ""
+			}
+			r.SetXForwarded()
+
+			r.Out.Header.Del("Authorization")
+			r.Out.Header.Del(actorHeader)
+			if userID, ok := auth.UserFromContext(r.In.Context()); ok {
+				r.Out.Header.Set(actorHeader, strconv.FormatUint(uint64(userID), 10))
+			}
+		},
+		// потоки строк JSON (watch, экспорт) отдаются клиенту без буферизации
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Printf("gateway: %s %s: %v", r.Method, r.URL.Path, err)
+			writeError(w, status.Error(codes.Unavailable, "service is unavailable"))
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package rest
+
+// backend — сервис, которому шлюз передает запрос
+type backend int
+
+const (
+	usersBackend backend = iota
+	tasksBackend
+)
+
+// proxyRoute — маршрут, который передается REST-шлюзу сервиса без изменений
+type proxyRoute struct {
+	pattern string
+	backend backend
+	// owner — параметр пути с id пользователя: запрос допускается только к своим данным
+	owner string
+	// public — маршрут доступен без токена
+	public bool
+	// rawBody — тело не JSON, а файл: шлюз его не разбирает, id пользователя
+	// у таких методов только в пути и строке запроса
+	rawBody bool
+}
+
+// proxyRoutes — маршруты сервисов, открытые через шлюз. Список всех пользователей
+// и всех задач, а также методы, которые вызывают только сервисы друг у друга, наружу
+// не публикуются. Владельца задач, проектов и т.п. по их id шлюз не знает: сервисы
+// получают автора запроса в x-actor-id.
+var proxyRoutes = []proxyRoute{
+	{pattern: "POST /v1/users", backend: usersBackend, public: true},
+	{pattern: "/v1/users/{id}", backend: usersBackend, owner: "id"},
+	// методы администратора дополнительно требуют x-admin-token, его проверяет users-service
+	{pattern: "/v1/admin/", backend: usersBackend},
+
+	{pattern: "/v1/users/{user_id}/", backend: tasksBackend, owner: "user_id"},
+	{pattern: "POST /v1/users/{user_id}/tasks/import", backend: tasksBackend, owner: "user_id", rawBody: true},
+	{pattern: "POST /v1/tasks/{task_id}/attachments", backend: tasksBackend, rawBody: true},
+	{pattern: "/v1/tasks/", backend: tasksBackend},
+	{pattern: "/v1/projects/", backend: tasksBackend},
+	{pattern: "/v1/tags/", backend: tasksBackend},
+	{pattern: "/v1/comments/", backend: tasksBackend},
+	{pattern: "/v1/attachments/", backend: tasksBackend},
+}
@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// Server — HTTP-сервер шлюза
+type Server struct {
+	srv  *http.Server
+	port int
+}
+
+func NewServer(port int, handler http.Handler) *Server {
+	return &Server{
+		srv:  &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: handler},
+		port: port,
+	}
+}
+
+func (s *Server) Start() error {
+	fmt.Println("HTTP gateway is running on port", s.port)
+
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve HTTP gateway: %w", err)
+	}
+	return nil
+}
+
+// Stop дожидается текущих запросов, долгие потоки обрываются по shutdownTimeout
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.srv.Shutdown(ctx); err != nil {
+		s.srv.Close()
+	}
+	fmt.Println("HTTP gateway is stopped on port", s.port)
+}
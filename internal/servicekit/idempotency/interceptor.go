@@ -0,0 +1,124 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Header — заголовок метаданных с ключом идемпотентности
+	Header       = "idempotency-key"
+	maxKeyLength = 128
+)
+
+// Method — метод, который учитывает idempotency-key
+type Method struct {
+	// NewResponse создает пустой ответ метода, в который читается сохраненный
+	NewResponse func() proto.Message
+	// Redact, если задан, убирает секреты из копии ответа перед сохранением:
+	// повтор запроса получает ответ без них
+	Redact func(resp proto.Message)
+	// Scope, если задан, возвращает пользователей, от имени которых выполняется запрос:
+	// ключ действует только для них, и чужой запрос с тем же ключом его не повторит.
+	// Без Scope ключи метода общие.
+	Scope func(req proto.Message) string
+	// Owners, если задан, возвращает пользователей, чьи данные в ответе: сохраненный
+	// ответ удаляется вместе с данными любого из них (см. DeleteOwnedBy)
+	Owners func(resp proto.Message) []uint32
+}
+
+// NewInterceptor возвращает interceptor, который для запросов методов methods
+// с заголовком idempotency-key возвращает сохраненный ответ при повторе и
+// FailedPrecondition, если тот же ключ пришел с другим телом запроса.
+func NewInterceptor(store Store, methods map[string]Method) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		method, ok := methods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		key := metadata.ValueFromIncomingContext(ctx, Header)
+		if len(key) == 0 || key[0] == "" {
+			return handler(ctx, req)
+		}
+		if len(key[0]) > maxKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", Header, maxKeyLength)
+		}
+
+		hash, err := requestHash(req)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash request: %v", err)
+		}
+
+		var scope string
+		if method.Scope != nil {
+			scope = method.Scope(req.(proto.Message))
+		}
+
+		saved, err := store.Begin(scope, key[0], info.FullMethod, hash)
+		switch {
+		case errors.Is(err, ErrKeyReused):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, ErrKeyInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "failed to check idempotency key: %v", err)
+		}
+
+		if saved != nil {
+			resp := method.NewResponse()
+			if err := proto.Unmarshal(saved, resp); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to decode saved response: %v", err)
+			}
+			return resp, nil
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			if releaseErr := store.Release(scope, key[0], info.FullMethod); releaseErr != nil {
+				log.Printf("idempotency: %v", releaseErr)
+			}
+			return nil, err
+		}
+
+		stored := resp.(proto.Message)
+		if method.Redact != nil {
+			stored = proto.Clone(stored)
+			method.Redact(stored)
+		}
+		var owners []uint32
+		if method.Owners != nil {
+			owners = method.Owners(resp.(proto.Message))
+		}
+		raw, err := proto.Marshal(stored)
+		if err == nil {
+			err = store.Complete(scope, key[0], info.FullMethod, owners, raw)
+		}
+		if err != nil {
+			// ответ уже получен, повтор с этим ключом вернет Aborted, пока не истечет резерв
+			log.Printf("idempotency: failed to save response for %s: %v", info.FullMethod, err)
+		}
+
+		return resp, nil
+	}
+}
+
+// requestHash возвращает SHA-256 детерминированной сериализации запроса
+func requestHash(req any) (string, error) {
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(req.(proto.Message))
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
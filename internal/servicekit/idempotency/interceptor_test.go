@@ -0,0 +1,146 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const testMethod = "/test.Service/Create"
+
+// memStore — Store в памяти
+type memStore struct {
+	records map[[3]string]*Record
+	owners  map[[3]string][]uint32
+}
+
+func newMemStore() *memStore {
+	return &memStore{records: make(map[[3]string]*Record), owners: make(map[[3]string][]uint32)}
+}
+
+func (s *memStore) Begin(scope, key, method, hash string) ([]byte, error) {
+	id := [3]string{scope, key, method}
+	rec, ok := s.records[id]
+	switch {
+	case !ok:
+		s.records[id] = &Record{Scope: scope, Key: key, Method: method, RequestHash: hash}
+		return nil, nil
+	case rec.RequestHash != hash:
+		return nil, ErrKeyReused
+	case rec.Response == nil:
+		return nil, ErrKeyInProgress
+	}
+	return rec.Response, nil
+}
+
+func (s *memStore) Complete(scope, key, method string, owners []uint32, response []byte) error {
+	id := [3]string{scope, key, method}
+	s.records[id].Response = response
+	s.owners[id] = owners
+	return nil
+}
+
+func (s *memStore) Release(scope, key, method string) error {
+	delete(s.records, [3]string{scope, key, method})
+	return nil
+}
+
+func (s *memStore) DeleteExpired() (int64, error) { return 0, nil }
+
+// testMethods: запрос — имя пользователя, он же scope; ответ — номер вызова обработчика
+var testMethods = map[string]Method{
+	testMethod: {
+		NewResponse: func() proto.Message { return &wrapperspb.UInt32Value{} },
+		Scope:       func(req proto.Message) string { return req.(*wrapperspb.StringValue).GetValue() },
+		Owners:      func(resp proto.Message) []uint32 { return []uint32{resp.(*wrapperspb.UInt32Value).GetValue()} },
+	},
+}
+
+func TestInterceptor(t *testing.T) {
+	store := newMemStore()
+	intercept := NewInterceptor(store, testMethods)
+
+	var calls uint32
+	var fail error
+	handler := func(ctx context.Context, req any) (any, error) {
+		if fail != nil {
+			return nil, fail
+		}
+		calls++
+		return wrapperspb.UInt32(calls), nil
+	}
+	call := func(key, user string) (uint32, error) {
+		ctx := context.Background()
+		if key != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(Header, key))
+		}
+		resp, err := intercept(ctx, wrapperspb.String(user), &grpc.UnaryServerInfo{FullMethod: testMethod}, handler)
+		if err != nil {
+			return 0, err
+		}
+		return resp.(*wrapperspb.UInt32Value).GetValue(), nil
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		user     string
+		fail     error
+		want     uint32
+		wantCode codes.Code
+	}{
+		{name: "first request", key: "k1", user: "alice", want: 1},
+		{name: "replay", key: "k1", user: "alice", want: 1},
+		{name: "same key, other payload", key: "k1", user: "bob", want: 2},
+		{name: "another user's replay", key: "k1", user: "bob", want: 2},
+		{name: "no key", user: "alice", want: 3},
+		{name: "failed request", key: "k2", user: "alice", fail: status.Error(codes.Unavailable, "down"), wantCode: codes.Unavailable},
+		{name: "retry after failure", key: "k2", user: "alice", want: 4},
+		{name: "long key", key: string(make([]byte, maxKeyLength+1)), user: "alice", wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		fail = tt.fail
+		got, err := call(tt.key, tt.user)
+		if code := status.Code(err); code != tt.wantCode {
+			t.Fatalf("%s: code = %v, want %v (%v)", tt.name, code, tt.wantCode, err)
+		}
+		if err == nil && got != tt.want {
+			t.Fatalf("%s: response = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if owners := store.owners[[3]string{"bob", "k1", testMethod}]; len(owners) != 1 || owners[0] != 2 {
+		t.Errorf("owners of bob's key = %v, want [2]", owners)
+	}
+}
+
+func TestInterceptorKeyReused(t *testing.T) {
+	store := newMemStore()
+	intercept := NewInterceptor(store, map[string]Method{
+		testMethod: {NewResponse: func() proto.Message { return &wrapperspb.UInt32Value{} }},
+	})
+	handler := func(ctx context.Context, req any) (any, error) { return wrapperspb.UInt32(1), nil }
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(Header, "k"))
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+
+	if _, err := intercept(ctx, wrapperspb.String("a"), info, handler); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	// без Scope ключи метода общие: другой запрос с тем же ключом отклоняется
+	_, err := intercept(ctx, wrapperspb.String("b"), info, handler)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("reused key: %v, want FailedPrecondition", err)
+	}
+
+	store.records[[3]string{"", "k", testMethod}].Response = nil
+	_, err = intercept(ctx, wrapperspb.String("a"), info, handler)
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("key in progress: %v, want Aborted", err)
+	}
+}
@@ -0,0 +1,37 @@
+package idempotency
+
+import "time"
+
+// Record — сохраненный результат запроса с ключом идемпотентности
+type Record struct {
+	// Scope — пользователи, от имени которых выполнен запрос (см. Method.Scope):
+	// одинаковые ключи разных пользователей не пересекаются
+	Scope       string `gorm:"primaryKey;type:text"`
+	Key         string `gorm:"primaryKey;type:varchar(128)"`
+	Method      string `gorm:"primaryKey;type:varchar(128)"`
+	RequestHash string `gorm:"type:char(64);not null"`
+	// Response пуст, пока первый запрос с этим ключом выполняется
+	Response []byte
+	// LockedUntil — до этого времени ключ занят выполняющимся запросом. Если ответа
+	// к этому времени нет, запрос считается прерванным и ключ можно занять снова.
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+// Owner — пользователь, чьи данные есть в сохраненном ответе. Строки удаляются
+// вместе с Record (ON DELETE CASCADE).
+type Owner struct {
+	Scope   string `gorm:"primaryKey;type:text"`
+	Key     string `gorm:"primaryKey;type:varchar(128)"`
+	Method  string `gorm:"primaryKey;type:varchar(128)"`
+	OwnerID uint32 `gorm:"primaryKey;index"`
+}
+
+func (Owner) TableName() string {
+	return "idempotency_key_owners"
+}
@@ -0,0 +1,177 @@
+// Package idempotency хранит ответы на запросы с заголовком idempotency-key,
+// чтобы повтор запроса не выполнял его второй раз
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrKeyInProgress = fmt.Errorf("request with this idempotency key is still in progress")
+	ErrKeyReused     = fmt.Errorf("idempotency key was already used with a different request")
+)
+
+// Store хранит ключи идемпотентности и ответы на запросы с ними
+type Store interface {
+	// Begin резервирует ключ пользователей scope за запросом с хешем hash. Если запрос
+	// с этим ключом уже завершился, возвращает сохраненный ответ, а если выполняется —
+	// ErrKeyInProgress. Резерв без ответа, который старше lease, занимается заново.
+	Begin(scope, key, method, hash string) ([]byte, error)
+	// Complete сохраняет ответ на зарезервированный ключ; owners — пользователи, чьи данные в ответе
+	Complete(scope, key, method string, owners []uint32, response []byte) error
+	// Release снимает резерв, чтобы запрос можно было повторить после ошибки
+	Release(scope, key, method string) error
+	// DeleteExpired удаляет ключи с истекшим сроком хранения
+	DeleteExpired() (int64, error)
+}
+
+type store struct {
+	db    *gorm.DB
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewStore создает хранилище: ответ хранится ttl, а ключ без ответа считается
+// занятым выполняющимся запросом не дольше lease
+func NewStore(db *gorm.DB, ttl, lease time.Duration) Store {
+	return &store{db: db, ttl: ttl, lease: lease}
+}
+
+func (s *store) Begin(scope, key, method, hash string) ([]byte, error) {
+	var response []byte
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		lockedUntil := now.Add(s.lease)
+
+		// просроченный ключ можно использовать заново
+		if err := tx.Where("scope = ? AND key = ? AND method = ? AND expires_at <= ?", scope, key, method, now).
+			Delete(&Record{}).Error; err != nil {
+			return fmt.Errorf("failed to delete expired key: %w", err)
+		}
+
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&Record{
+			Scope:       scope,
+			Key:         key,
+			Method:      method,
+			RequestHash: hash,
+			LockedUntil: &lockedUntil,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.ttl),
+		})
+		if res.Error != nil {
+			return fmt.Errorf("failed to reserve key: %w", res.Error)
+		}
+		if res.RowsAffected == 1 {
+			return nil
+		}
+
+		var rec Record
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("scope = ? AND key = ? AND method = ?", scope, key, method).First(&rec).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// ключ освободили между вставкой и чтением — клиент может повторить запрос
+				return ErrKeyInProgress
+			}
+			return fmt.Errorf("failed to load key: %w", err)
+		}
+
+		switch {
+		case rec.RequestHash != hash:
+			return ErrKeyReused
+		case rec.Response != nil:
+			response = rec.Response
+			return nil
+		case rec.LockedUntil != nil && rec.LockedUntil.After(now):
+			return ErrKeyInProgress
+		}
+
+		// запрос не завершился за lease: процесс упал или не смог сохранить ответ
+		if err := tx.Model(&rec).Update("locked_until", lockedUntil).Error; err != nil {
+			return fmt.Errorf("failed to reclaim key: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("idempotency.Begin: %w", err)
+	}
+
+	return response, nil
+}
+
+func (s *store) Complete(scope, key, method string, owners []uint32, response []byte) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Record{}).
+			Where("scope = ? AND key = ? AND method = ?", scope, key, method).
+			Updates(map[string]any{"response": response, "locked_until": nil}).Error; err != nil {
+			return err
+		}
+		if len(owners) == 0 {
+			return nil
+		}
+
+		rows := make([]Owner, len(owners))
+		for i, id := range owners {
+			rows[i] = Owner{Scope: scope, Key: key, Method: method, OwnerID: id}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+	if err != nil {
+		return fmt.Errorf("idempotency.Complete: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) Release(scope, key, method string) error {
+	if err := s.db.Where("scope = ? AND key = ? AND method = ? AND response IS NULL", scope, key, method).
+		Delete(&Record{}).Error; err != nil {
+		return fmt.Errorf("idempotency.Release: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) DeleteExpired() (int64, error) {
+	res := s.db.Where("expires_at <= ?", time.Now()).Delete(&Record{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("idempotency.DeleteExpired: %w", res.Error)
+	}
+
+	return res.RowsAffected, nil
+}
+
+// DeleteOwnedBy удаляет в транзакции tx сохраненные ответы, в которых есть данные
+// пользователя userID
+func DeleteOwnedBy(tx *gorm.DB, userID uint32) error {
+	owned := tx.Model(&Owner{}).Select("scope", "key", "method").Where("owner_id = ?", userID)
+	if err := tx.Where("(scope, key, method) IN (?)", owned).Delete(&Record{}).Error; err != nil {
+		return fmt.Errorf("idempotency.DeleteOwnedBy: %w", err)
+	}
+
+	return nil
+}
+
+// RunCleanup периодически удаляет просроченные ключи до отмены ctx
+func RunCleanup(ctx context.Context, s Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DeleteExpired(); err != nil {
+				log.Printf("idempotency cleanup: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package restgateway
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// scalarMessages — сообщения, которые в JSON и в параметрах запроса записываются строкой
+var scalarMessages = map[protoreflect.FullName]bool{
+	"google.protobuf.Timestamp": true,
+	"google.protobuf.Duration":  true,
+	"google.protobuf.FieldMask": true,
+}
+
+// bindRequest заполняет msg из параметров пути и строки запроса; у client-stream —
+// поле Head первого сообщения
+func bindRequest(r *http.Request, rt *StreamRoute, msg proto.Message) error {
+	target := msg.ProtoReflect()
+	if rt.Head != "" {
+		target = target.Mutable(target.Descriptor().Fields().ByName(rt.Head)).Message()
+	}
+
+	// параметры пути важнее одноименных параметров строки запроса
+	for key, values := range r.URL.Query() {
+		if err := setField(target, key, values); err != nil {
+			return err
+		}
+	}
+	for _, name := range rt.pathParams() {
+		if err := setField(target, name, []string{r.PathValue(name)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryField возвращает поле, которое можно задать параметром: по имени в proto или в JSON
+func queryField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	fd := fields.ByName(protoreflect.Name(name))
+	if fd == nil {
+		fd = fields.ByJSONName(name)
+	}
+	if fd == nil || fd.IsMap() {
+		return nil
+	}
+	if fd.Kind() == protoreflect.MessageKind && !scalarMessages[fd.Message().FullName()] {
+		return nil
+	}
+	return fd
+}
+
+// setField задает поле name строковыми значениями параметра. Повторяющиеся поля
+// принимают и несколько параметров, и значения через запятую.
+func setField(m protoreflect.Message, name string, values []string) error {
+	fd := queryField(m.Descriptor(), name)
+	if fd == nil {
+		return status.Errorf(codes.InvalidArgument, "unknown parameter %q", name)
+	}
+
+	if fd.IsList() {
+		list := m.Mutable(fd).List()
+		for _, value := range values {
+			for _, part := range strings.Split(value, ",") {
+				v, err := parseValue(m, fd, part)
+				if err != nil {
+					return err
+				}
+				list.Append(v)
+			}
+		}
+		return nil
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	v, err := parseValue(m, fd, values[len(values)-1])
+	if err != nil {
+		return err
+	}
+	m.Set(fd, v)
+	return nil
+}
+
+// parseValue разбирает строковое значение параметра по типу поля fd сообщения m
+func parseValue(m protoreflect.Message, fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	invalid := func(err error) (protoreflect.Value, error) {
+		return protoreflect.Value{}, status.Errorf(codes.InvalidArgument, "invalid value %q for %s: %v", s, fd.Name(), err)
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBool(v), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt32(int32(v)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt64(v), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint32(uint32(v)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint64(v), nil
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfFloat32(float32(v)), nil
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfFloat64(v), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		v, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			if v, err = base64.URLEncoding.DecodeString(s); err != nil {
+				return invalid(err)
+			}
+		}
+		return protoreflect.ValueOfBytes(v), nil
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.MessageKind:
+		// Timestamp, Duration и FieldMask в JSON — строки, их разбирает protojson
+		var v protoreflect.Value
+		if fd.IsList() {
+			v = m.Mutable(fd).List().NewElement()
+		} else {
+			v = m.NewField(fd)
+		}
+		if err := protojson.Unmarshal([]byte(strconv.Quote(s)), v.Message().Interface()); err != nil {
+			return invalid(err)
+		}
+		return v, nil
+	}
+
+	return invalid(errUnsupportedKind)
+}
+
+var errUnsupportedKind = errors.New("unsupported parameter type")
@@ -0,0 +1,449 @@
+// Package restgateway — HTTP/JSON шлюз к gRPC-сервису того же процесса на grpc-gateway.
+// Маршруты генерирует protoc-gen-grpc-gateway по аннотациям google.api.http; шлюз
+// передает сервису разрешенные заголовки и адрес клиента, отдает описание OpenAPI и
+// сам обслуживает методы, которые принимают или отдают файл потоком байт.
+package restgateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// OpenAPIPath — путь, по которому отдается описание REST API
+	OpenAPIPath = "/openapi.json"
+
+	// maxBodySize — предел JSON-тела запроса, как предел сообщения gRPC по умолчанию
+	maxBodySize = 4 << 20
+	// uploadChunkSize — размер куска тела в одном сообщении client-stream
+	uploadChunkSize = 64 << 10
+	// forwardedForHeader — адрес HTTP-клиента для gRPC-сервиса, который видит адрес шлюза
+	forwardedForHeader = "x-forwarded-for"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// marshaler — JSON запросов и ответов: поля со значением по умолчанию не опускаются,
+// неизвестные поля запроса отбрасываются
+var marshaler = &runtime.JSONPb{
+	MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+	UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+}
+
+// RegisterFunc регистрирует сгенерированные маршруты сервиса, например
+// userpb.RegisterUserServiceHandler
+type RegisterFunc func(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error
+
+// StreamRoute — маршрут метода, у которого тело запроса или ответа — файл.
+// grpc-gateway передает потоки только строками JSON, поэтому их обслуживает шлюз.
+type StreamRoute struct {
+	Method string
+	// Pattern — шаблон http.ServeMux, например "/v1/attachments/{id}/content"
+	Pattern string
+	RPC     protoreflect.Name
+	// Head — для client-stream: поле первого сообщения, которое заполняется из пути
+	// и строки запроса; тело запроса уходит следующими сообщениями в поле chunk
+	Head protoreflect.Name
+	// Download — для server-stream с полем chunk: Content-Type ответа, тело
+	// ответа — байты chunk подряд
+	Download string
+}
+
+// pathParams возвращает имена параметров пути в порядке появления
+func (rt *StreamRoute) pathParams() []string {
+	var names []string
+	for _, seg := range strings.Split(rt.Pattern, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			names = append(names, strings.Trim(seg, "{}"))
+		}
+	}
+	return names
+}
+
+// Config описывает шлюз к одному gRPC-сервису
+type Config struct {
+	// Host — адрес, на котором слушает шлюз; пустой — все интерфейсы
+	Host string
+	Port int
+	// GRPCAddr — адрес gRPC-сервиса
+	GRPCAddr string
+	Service  protoreflect.FullName
+	Register RegisterFunc
+	Streams  []StreamRoute
+	// OpenAPI — описание сгенерированных маршрутов от protoc-gen-openapiv2;
+	// маршруты Streams дописываются в него при запуске
+	OpenAPI []byte
+	// Headers — заголовки HTTP, которые передаются сервису метаданными gRPC
+	Headers []string
+	// TrustedProxies — сети прокси, которым шлюз верит X-Forwarded-For. У остальных
+	// клиентов заголовок отбрасывается, а адрес клиента — адрес соединения.
+	TrustedProxies []netip.Prefix
+}
+
+// Server — HTTP/JSON шлюз к gRPC-сервису того же процесса. Запросы идут через
+// gRPC-соединение, поэтому interceptor'ы сервиса работают и для REST.
+type Server struct {
+	srv  *http.Server
+	conn *grpc.ClientConn
+	port int
+}
+
+// NewServer создает шлюз по cfg
+func NewServer(cfg Config) (*Server, error) {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(cfg.Service)
+	if err != nil {
+		return nil, fmt.Errorf("gateway: service %s: %w", cfg.Service, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("gateway: %s is not a service", cfg.Service)
+	}
+
+	conn, err := grpc.NewClient(cfg.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("gateway: failed to connect to %s: %w", cfg.GRPCAddr, err)
+	}
+
+	gw := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
+		runtime.WithIncomingHeaderMatcher(cfg.matchHeader),
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			// сервис берет последний адрес: grpc-gateway добавляет свой, непроверенный
+			return metadata.Pairs(forwardedForHeader, cfg.clientAddr(r))
+		}),
+	)
+	if err := cfg.Register(context.Background(), gw, conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("gateway: failed to register %s: %w", cfg.Service, err)
+	}
+
+	mux := http.NewServeMux()
+	for i := range cfg.Streams {
+		rt := &cfg.Streams[i]
+		md := sd.Methods().ByName(rt.RPC)
+		if md == nil || !md.IsStreamingClient() && !md.IsStreamingServer() {
+			conn.Close()
+			return nil, fmt.Errorf("gateway: %s has no streaming method %s", cfg.Service, rt.RPC)
+		}
+		mux.Handle(rt.Method+" "+rt.Pattern, &streamHandler{cfg: &cfg, gw: gw, conn: conn, route: rt, method: md})
+	}
+
+	doc, err := openAPIDocument(cfg.OpenAPI, sd, cfg.Streams)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("gateway: %w", err)
+	}
+	mux.HandleFunc("GET "+OpenAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(doc)
+	})
+	mux.Handle("/", http.MaxBytesHandler(gw, maxBodySize))
+
+	return &Server{
+		srv:  &http.Server{Addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)), Handler: mux},
+		conn: conn,
+		port: cfg.Port,
+	}, nil
+}
+
+func (s *Server) Start() error {
+	fmt.Println("HTTP gateway is running on port", s.port)
+
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve HTTP gateway: %w", err)
+	}
+	return nil
+}
+
+// Stop дожидается текущих запросов, кроме долгих потоков, и закрывает соединение с gRPC
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.srv.Shutdown(ctx); err != nil {
+		s.srv.Close()
+	}
+	s.conn.Close()
+	fmt.Println("HTTP gateway is stopped on port", s.port)
+}
+
+// matchHeader пропускает к сервису только заголовки из Headers, под тем же именем.
+// Остальные, в том числе Grpc-Metadata-*, отбрасываются: через них клиент мог бы
+// подставить x-actor-id или x-forwarded-for.
+func (c *Config) matchHeader(key string) (string, bool) {
+	key = strings.ToLower(key)
+	return key, slices.Contains(c.Headers, key)
+}
+
+// streamHandler вызывает потоковый метод, у которого тело запроса или ответа — файл
+type streamHandler struct {
+	cfg    *Config
+	gw     *runtime.ServeMux
+	conn   *grpc.ClientConn
+	route  *StreamRoute
+	method protoreflect.MethodDescriptor
+}
+
+func (h *streamHandler) fullMethod() string {
+	return fmt.Sprintf("/%s/%s", h.method.Parent().FullName(), h.method.Name())
+}
+
+func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := h.cfg.outgoingContext(r)
+
+	req, err := newMessage(h.method.Input())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if err := bindRequest(r, h.route, req); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if h.method.IsStreamingClient() {
+		h.upload(ctx, w, r, req)
+		return
+	}
+	h.download(ctx, w, r, req)
+}
+
+// writeError отвечает ошибкой так же, как сгенерированные маршруты
+func (h *streamHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	runtime.HTTPError(r.Context(), h.gw, marshaler, w, r, err)
+}
+
+// download отдает содержимое полей chunk ответов server-stream подряд
+func (h *streamHandler) download(ctx context.Context, w http.ResponseWriter, r *http.Request, req proto.Message) {
+	stream, err := h.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, h.fullMethod())
+	if err == nil {
+		if err = stream.SendMsg(req); err == nil {
+			err = stream.CloseSend()
+		}
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	// статус ответа зависит от первого сообщения: ошибка до него — обычный ответ с ошибкой
+	first, err := h.recv(stream)
+	if err != nil && err != io.EOF {
+		h.writeError(w, r, err)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", h.route.Download)
+	w.WriteHeader(http.StatusOK)
+
+	for msg := first; msg != nil; {
+		if _, err := w.Write(chunkOf(msg)); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		msg, err = h.recv(stream)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// заголовки уже отправлены: оборванный ответ не примут за целый файл
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
+
+func (h *streamHandler) recv(stream grpc.ClientStream) (proto.Message, error) {
+	msg, err := newMessage(h.method.Output())
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.RecvMsg(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// upload отправляет первым сообщением head, затем тело запроса кусками в поле chunk
+func (h *streamHandler) upload(ctx context.Context, w http.ResponseWriter, r *http.Request, first proto.Message) {
+	stream, err := h.conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: true}, h.fullMethod())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	// io.EOF при отправке значит, что сервер уже ответил: статус придет в RecvMsg
+	err = stream.SendMsg(first)
+	buf := make([]byte, uploadChunkSize)
+	for err == nil {
+		n, readErr := r.Body.Read(buf)
+		if n > 0 {
+			var msg proto.Message
+			if msg, err = newMessage(h.method.Input()); err != nil {
+				break
+			}
+			setChunk(msg, buf[:n])
+			err = stream.SendMsg(msg)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			h.writeError(w, r, status.Errorf(codes.InvalidArgument, "failed to read request body: %v", readErr))
+			return
+		}
+	}
+	if err != nil && err != io.EOF {
+		h.writeError(w, r, err)
+		return
+	}
+	if err := stream.CloseSend(); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	resp, err := newMessage(h.method.Output())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if err := stream.RecvMsg(resp); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	body, err := marshaler.Marshal(resp)
+	if err != nil {
+		h.writeError(w, r, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", marshaler.ContentType(resp))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// outgoingContext переносит разрешенные заголовки HTTP в метаданные gRPC и
+// передает сервису адрес клиента
+func (c *Config) outgoingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, name := range c.Headers {
+		if values := r.Header.Values(name); len(values) > 0 {
+			md.Set(name, values...)
+		}
+	}
+	md.Set(forwardedForHeader, c.clientAddr(r))
+
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// clientAddr возвращает адрес клиента. Это адрес соединения, если оно не от
+// доверенного прокси; иначе X-Forwarded-For читается справа налево, пока адреса
+// принадлежат доверенным прокси: левее них адреса мог подставить сам клиент.
+func (c *Config) clientAddr(r *http.Request) string {
+	client := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		client = host
+	}
+
+	var hops []string
+	for _, value := range r.Header.Values(forwardedForHeader) {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0 && c.trusted(client); i-- {
+		hop := strings.TrimSpace(hops[i])
+		if _, err := netip.ParseAddr(hop); err != nil {
+			break
+		}
+		client = hop
+	}
+
+	return client
+}
+
+// trusted сообщает, что addr — адрес доверенного прокси
+func (c *Config) trusted(addr string) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, p := range c.TrustedProxies {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// newMessage создает сообщение сгенерированного типа по его описанию
+func newMessage(md protoreflect.MessageDescriptor) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unknown message %s: %v", md.FullName(), err)
+	}
+	return mt.New().Interface(), nil
+}
+
+// chunkOf возвращает поле chunk сообщения
+func chunkOf(msg proto.Message) []byte {
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("chunk")
+	if fd == nil {
+		return nil
+	}
+	return m.Get(fd).Bytes()
+}
+
+func setChunk(msg proto.Message, b []byte) {
+	m := msg.ProtoReflect()
+	// Set не копирует срез, а буфер чтения переиспользуется, поэтому копия
+	m.Set(m.Descriptor().Fields().ByName("chunk"), protoreflect.ValueOfBytes(append([]byte(nil), b...)))
+}
+
+// ParsePrefixes разбирает список сетей через запятую: CIDR или отдельные адреса
+func ParsePrefixes(s string) ([]netip.Prefix, error) {
+	var out []netip.Prefix
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if !strings.Contains(part, "/") {
+			addr, err := netip.ParseAddr(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %q: %w", part, err)
+			}
+			out = append(out, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", part, err)
+		}
+		out = append(out, p.Masked())
+	}
+	return out, nil
+}
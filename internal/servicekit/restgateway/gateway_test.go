@@ -0,0 +1,125 @@
+package restgateway
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestClientAddr(t *testing.T) {
+	trusted, err := ParsePrefixes("127.0.0.0/8, ::1, 10.1.0.0/16")
+	if err != nil {
+		t.Fatalf("ParsePrefixes: %v", err)
+	}
+	cfg := &Config{TrustedProxies: trusted}
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		want      string
+	}{
+		{"direct client", "203.0.113.7:5000", nil, "203.0.113.7"},
+		{"client header ignored", "203.0.113.7:5000", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"trusted proxy", "127.0.0.1:5000", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"ipv6 loopback proxy", "[::1]:5000", []string{"198.51.100.1"}, "198.51.100.1"},
+		// левее первого недоверенного адреса — то, что прислал сам клиент
+		{"spoofed hops", "127.0.0.1:5000", []string{"1.2.3.4, 198.51.100.1"}, "198.51.100.1"},
+		{"proxy chain", "127.0.0.1:5000", []string{"1.2.3.4, 198.51.100.1", "10.1.2.3"}, "198.51.100.1"},
+		{"all hops trusted", "127.0.0.1:5000", []string{"10.1.0.5"}, "10.1.0.5"},
+		{"garbage hop", "127.0.0.1:5000", []string{"198.51.100.1, unknown"}, "127.0.0.1"},
+		{"no header from proxy", "127.0.0.1:5000", nil, "127.0.0.1"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = tt.remote
+		for _, v := range tt.forwarded {
+			r.Header.Add("X-Forwarded-For", v)
+		}
+		if got := cfg.clientAddr(r); got != tt.want {
+			t.Errorf("%s: clientAddr = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParsePrefixesErrors(t *testing.T) {
+	for _, in := range []string{"localhost", "10.0.0.0/33", "10.0.0.1,nope"} {
+		if _, err := ParsePrefixes(in); err == nil {
+			t.Errorf("ParsePrefixes(%q) succeeded", in)
+		}
+	}
+	if got, err := ParsePrefixes(" "); err != nil || len(got) != 0 {
+		t.Errorf("ParsePrefixes(blank) = %v, %v; want none", got, err)
+	}
+}
+
+func TestMatchHeader(t *testing.T) {
+	cfg := &Config{Headers: []string{"x-actor-id", "idempotency-key"}}
+
+	tests := []struct {
+		key  string
+		want string
+		ok   bool
+	}{
+		{"X-Actor-Id", "x-actor-id", true},
+		{"Idempotency-Key", "idempotency-key", true},
+		{"Grpc-Metadata-X-Actor-Id", "", false},
+		{"X-Forwarded-For", "", false},
+		{"Authorization", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := cfg.matchHeader(tt.key)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("matchHeader(%q) = %q, %v; want %q, %v", tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	sd := healthpb.File_grpc_health_v1_health_proto.Services().ByName("Health")
+	base := []byte(`{"swagger": "2.0", "paths": {"/v1/health": {"get": {"operationId": "Health_Check"}}}}`)
+	streams := []StreamRoute{
+		{Method: "GET", Pattern: "/v1/health/{service}/watch", RPC: "Watch", Download: "application/octet-stream"},
+	}
+
+	out, err := openAPIDocument(base, sd, streams)
+	if err != nil {
+		t.Fatalf("openAPIDocument: %v", err)
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Produces   []string
+			Parameters []struct{ Name, In, Type string }
+			Responses  map[string]struct {
+				Schema map[string]string
+			}
+		}
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if _, ok := doc.Paths["/v1/health"]["get"]; !ok {
+		t.Error("generated route lost")
+	}
+	op, ok := doc.Paths["/v1/health/{service}/watch"]["get"]
+	if !ok {
+		t.Fatal("stream route missing")
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "service" || op.Parameters[0].In != "path" || op.Parameters[0].Type != "string" {
+		t.Errorf("parameters = %+v, want path parameter service", op.Parameters)
+	}
+	if len(op.Produces) != 1 || op.Produces[0] != "application/octet-stream" {
+		t.Errorf("produces = %v", op.Produces)
+	}
+	if op.Responses["200"].Schema["type"] != "file" {
+		t.Errorf("200 schema = %v, want file", op.Responses["200"].Schema)
+	}
+
+	bad := []StreamRoute{{Method: "GET", Pattern: "/v1/health/{name}/watch", RPC: "Watch", Download: "text/plain"}}
+	if _, err := openAPIDocument(base, sd, bad); err == nil {
+		t.Error("unknown path parameter accepted")
+	}
+}
@@ -0,0 +1,157 @@
+package restgateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// openAPIDocument дописывает в описание base, сгенерированное protoc-gen-openapiv2,
+// маршруты streams: у них нет аннотаций google.api.http, их обслуживает сам шлюз
+func openAPIDocument(base []byte, sd protoreflect.ServiceDescriptor, streams []StreamRoute) ([]byte, error) {
+	doc := map[string]any{}
+	if len(base) > 0 {
+		if err := json.Unmarshal(base, &doc); err != nil {
+			return nil, fmt.Errorf("malformed OpenAPI document: %w", err)
+		}
+	}
+	paths, _ := doc["paths"].(map[string]any)
+	if paths == nil {
+		paths = map[string]any{}
+		doc["paths"] = paths
+	}
+	definitions, _ := doc["definitions"].(map[string]any)
+
+	for i := range streams {
+		rt := &streams[i]
+		md := sd.Methods().ByName(rt.RPC)
+		in := md.Input()
+		if rt.Head != "" {
+			in = in.Fields().ByName(rt.Head).Message()
+		}
+
+		pathParams := rt.pathParams()
+		var params []any
+		for _, name := range pathParams {
+			fd := queryField(in, name)
+			if fd == nil {
+				return nil, fmt.Errorf("route %s %s: no field for path parameter %s", rt.Method, rt.Pattern, name)
+			}
+			params = append(params, parameter(fd, name, "path"))
+		}
+		fields := in.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			fd := fields.Get(j)
+			if slices.Contains(pathParams, string(fd.Name())) || queryField(in, string(fd.Name())) == nil {
+				continue
+			}
+			params = append(params, parameter(fd, string(fd.Name()), "query"))
+		}
+
+		op := map[string]any{
+			"operationId": fmt.Sprintf("%s_%s", sd.Name(), rt.RPC),
+			"tags":        []string{string(sd.Name())},
+			"responses": map[string]any{
+				"default": map[string]any{
+					"description": "An unexpected error response.",
+					"schema":      map[string]any{"$ref": "#/definitions/rpcStatus"},
+				},
+			},
+		}
+		if rt.Head != "" {
+			op["consumes"] = []string{"application/octet-stream"}
+			params = append(params, map[string]any{
+				"name": "body", "in": "body", "required": true,
+				"schema": map[string]any{"type": "string", "format": "binary"},
+			})
+			op["responses"].(map[string]any)["200"] = map[string]any{
+				"description": "A successful response.",
+				"schema":      definitionRef(md.Output(), definitions),
+			}
+		} else {
+			op["produces"] = []string{rt.Download}
+			op["responses"].(map[string]any)["200"] = map[string]any{
+				"description": "Содержимое файла",
+				"schema":      map[string]any{"type": "file"},
+			}
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		item, _ := paths[rt.Pattern].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[rt.Pattern] = item
+		}
+		item[strings.ToLower(rt.Method)] = op
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// definitionRef ссылается на описание сообщения md под именем, которое дает ему
+// protoc-gen-openapiv2: пакет proto и имя сообщения, например taskAttachment
+func definitionRef(md protoreflect.MessageDescriptor, definitions map[string]any) map[string]any {
+	name := string(md.ParentFile().Package()) + string(md.Name())
+	if _, ok := definitions[name]; !ok {
+		return map[string]any{"type": "object"}
+	}
+	return map[string]any{"$ref": "#/definitions/" + name}
+}
+
+// parameter описывает параметр пути или строки запроса по правилам JSON-отображения proto3
+func parameter(fd protoreflect.FieldDescriptor, name, in string) map[string]any {
+	p := map[string]any{"name": name, "in": in}
+	if in == "path" {
+		p["required"] = true
+	}
+
+	t := paramType(fd)
+	if fd.IsList() {
+		p["type"] = "array"
+		p["items"] = t
+		p["collectionFormat"] = "multi"
+		return p
+	}
+	for k, v := range t {
+		p[k] = v
+	}
+	return p
+}
+
+func paramType(fd protoreflect.FieldDescriptor) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == "google.protobuf.Timestamp" {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+	}
+	return map[string]any{"type": "string"}
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Attachment — метаданные файла, прикрепленного к задаче
+type Attachment struct {
+	ID          uint32
+	TaskID      uint32
+	FileName    string
+	ContentType string
+	Size        int64
+	// SHA256 — контрольная сумма содержимого в hex
+	SHA256 string
+	// StorageKey — ключ содержимого в хранилище файлов
+	StorageKey string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,24 @@
+package domain
+
+// BatchMode — как выполняется пакет операций над задачами
+type BatchMode int
+
+const (
+	// BatchAtomic выполняет пакет в одной транзакции: ошибка любого элемента откатывает пакет
+	BatchAtomic BatchMode = iota
+	// BatchBestEffort выполняет элементы независимо, у каждого свой результат
+	BatchBestEffort
+)
+
+// BatchUpdate — элемент пакетного изменения: задача, ожидаемая версия и изменение
+type BatchUpdate struct {
+	ID      uint32
+	Version uint32
+	Update  TaskUpdate
+}
+
+// BatchDelete — элемент пакетного удаления
+type BatchDelete struct {
+	ID       uint32
+	Children ChildrenPolicy
+}
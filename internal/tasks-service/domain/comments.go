@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Comment — комментарий пользователя AuthorID к задаче
+type Comment struct {
+	ID        uint32
+	TaskID    uint32
+	AuthorID  uint32
+	Body      string
+	CreatedAt time.Time
+	// EditedAt — время последней правки, nil если правок не было
+	EditedAt *time.Time
+}
+
+// CommentEdit — прежний текст комментария и время правки, которая его заменила
+type CommentEdit struct {
+	Body     string
+	EditedAt time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+// Типы событий изменения задачи
+const (
+	TaskCreated = "created"
+	TaskUpdated = "updated"
+	TaskDeleted = "deleted"
+	// TaskReminder — наступило время напоминания, отправляется один раз
+	TaskReminder = "reminder"
+	// TaskRestored — задача восстановлена из корзины
+	TaskRestored = "restored"
+)
+
+// TaskEvent — изменение задачи с ревизией, монотонно растущей в пределах пользователя.
+// По ревизии клиент возобновляет подписку после переподключения.
+type TaskEvent struct {
+	Revision uint64
+	Type     string
+	Task     Task
+}
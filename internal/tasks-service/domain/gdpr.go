@@ -0,0 +1,21 @@
+package domain
+
+// UserData — все данные пользователя в сервисе задач, включая корзину
+type UserData struct {
+	Tasks    []*Task
+	Trash    []*DeletedTask
+	Projects []*Project
+	Tags     []*Tag
+	// Comments — комментарии, написанные пользователем, в том числе к чужим задачам
+	Comments    []*Comment
+	Attachments []*Attachment
+}
+
+// ErasedData — сколько записей пользователя удалено окончательно
+type ErasedData struct {
+	Tasks       int
+	Comments    int
+	Projects    int
+	Tags        int
+	Attachments int
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// HistoryEntry — запись истории задачи: кто, когда и какие поля изменил
+type HistoryEntry struct {
+	ID     uint64
+	TaskID uint32
+	// Type — TaskCreated, TaskUpdated, TaskDeleted или TaskRestored
+	Type string
+	// ActorID — пользователь, от имени которого сделано изменение, 0 — система
+	ActorID   uint32
+	CreatedAt time.Time
+	Changes   []FieldChange
+}
+
+// FieldChange — изменение поля задачи, значения в текстовом виде, "" — не задано
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
@@ -0,0 +1,13 @@
+package domain
+
+// EntityKind — вид сущности, у которой есть владелец
+type EntityKind string
+
+const (
+	EntityUser       EntityKind = "user"
+	EntityTask       EntityKind = "task"
+	EntityProject    EntityKind = "project"
+	EntityTag        EntityKind = "tag"
+	EntityComment    EntityKind = "comment"
+	EntityAttachment EntityKind = "attachment"
+)
@@ -0,0 +1,17 @@
+package domain
+
+// Project — именованный список задач пользователя
+type Project struct {
+	ID       uint32
+	UserID   uint32
+	Name     string
+	Archived bool
+	// Position — место в списке проектов пользователя, от 0
+	Position int
+}
+
+// ProjectUpdate описывает частичное изменение проекта: nil-поля не меняются
+type ProjectUpdate struct {
+	Name     *string
+	Archived *bool
+}
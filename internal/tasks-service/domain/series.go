@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Series — серия повторяющихся задач: правило повторения и шаблон повторений
+type Series struct {
+	ID     uint32
+	UserID uint32
+	// RRule — правило повторения в формате RFC 5545, например "FREQ=WEEKLY;BYDAY=MO"
+	RRule string
+	// DTStart — время первого повторения, от него отсчитывается правило
+	DTStart   time.Time
+	Task      string
+	Priority  Priority
+	ProjectID *uint32
+	// Active сбрасывается, когда серия завершена или заменена новой
+	Active bool
+}
+
+// EditScope — к чему применяется изменение повторяющейся задачи
+type EditScope int
+
+const (
+	// ScopeOccurrence меняет только это повторение
+	ScopeOccurrence EditScope = iota
+	// ScopeSeries меняет серию: это и следующие открытые повторения
+	ScopeSeries
+)
+
+// SeriesUpdate описывает изменение серии: nil-поля не меняются
+type SeriesUpdate struct {
+	Task     *string
+	Priority *Priority
+	// RRule — новое каноническое правило, пустая строка завершает серию
+	RRule *string
+}
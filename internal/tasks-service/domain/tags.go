@@ -0,0 +1,18 @@
+package domain
+
+// Tag — метка из каталога тегов пользователя
+type Tag struct {
+	ID     uint32
+	UserID uint32
+	Name   string
+}
+
+// TagMatch задает, как фильтр по тегам сочетает несколько тегов
+type TagMatch int
+
+const (
+	// TagMatchAny — задача помечена хотя бы одним из тегов
+	TagMatchAny TagMatch = iota
+	// TagMatchAll — задача помечена всеми тегами
+	TagMatchAll
+)
@@ -1,8 +1,129 @@
 package domain
 
+import "time"
+
 type Task struct {
-	ID     uint32
-	Task   string
-	IsDone bool
-	UserID uint32
+	ID   uint32
+	Task string
+	// IsDone производное от Status, оставлено для старых клиентов
+	IsDone   bool
+	Status   TaskStatus
+	Priority Priority
+	UserID   uint32
+	// Version увеличивается при каждом обновлении, используется для оптимистичной блокировки
+	Version uint32
+	// DueAt — срок выполнения, RemindAt — когда напомнить о задаче
+	DueAt    *time.Time
+	RemindAt *time.Time
+	// RemindedAt проставляется, когда напоминание отправлено
+	RemindedAt *time.Time
+	// CompletedAt — когда задача перешла в done
+	CompletedAt *time.Time
+	// TagIDs — теги задачи, заполняются при чтении
+	TagIDs []uint32
+	// ParentID — родительская задача, nil у задачи верхнего уровня
+	ParentID *uint32
+	// BlockerIDs — задачи, блокирующие эту, заполняются при чтении
+	BlockerIDs []uint32
+	// ProjectID — проект задачи, nil у задачи вне проектов
+	ProjectID *uint32
+	// SeriesID — серия повторений, nil у обычной задачи; OccurrenceAt — плановое время повторения
+	SeriesID     *uint32
+	OccurrenceAt *time.Time
+	// RRule — правило повторения серии; при создании задает новую серию, при чтении заполняется из серии
+	RRule string
+}
+
+// TaskNode — задача в поддереве с прогрессом по прямым подзадачам
+type TaskNode struct {
+	Task  *Task
+	Depth int
+	// ChildrenTotal не учитывает отмененные подзадачи
+	ChildrenTotal int
+	ChildrenDone  int
+}
+
+// Progress возвращает процент выполненных прямых подзадач, 0 если их нет
+func (n *TaskNode) Progress() int {
+	if n.ChildrenTotal == 0 {
+		return 0
+	}
+	return n.ChildrenDone * 100 / n.ChildrenTotal
+}
+
+// Dependency — задача TaskID заблокирована задачей BlockerID
+type Dependency struct {
+	TaskID    uint32
+	BlockerID uint32
+}
+
+// ChildrenPolicy — что сделать с подзадачами при удалении задачи
+type ChildrenPolicy int
+
+const (
+	// ChildrenReject запрещает удалять задачу с подзадачами
+	ChildrenReject ChildrenPolicy = iota
+	// ChildrenCascade удаляет задачу вместе с поддеревом
+	ChildrenCascade
+	// ChildrenPromote переподчиняет подзадачи родителю удаляемой задачи
+	ChildrenPromote
+)
+
+// TaskStatus — состояние задачи в рабочем процессе
+type TaskStatus string
+
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	StatusDone       TaskStatus = "done"
+	StatusCanceled   TaskStatus = "canceled"
+)
+
+// Priority — приоритет задачи, больше — важнее
+type Priority int
+
+const (
+	PriorityLow Priority = iota + 1
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// TaskUpdate описывает частичное изменение задачи: nil-поля не меняются
+type TaskUpdate struct {
+	Task   *string
+	IsDone *bool
+	// Status имеет приоритет над IsDone, если заданы оба
+	Status   *TaskStatus
+	Priority *Priority
+	// для дат указатель на nil очищает поле
+	DueAt    **time.Time
+	RemindAt **time.Time
+	// указатель на nil делает задачу задачей верхнего уровня
+	ParentID **uint32
+	// RRule меняет правило серии, только со ScopeSeries; пустая строка завершает серию
+	RRule *string
+	Scope EditScope
+}
+
+// DueFilter отбирает задачи по сроку выполнения
+type DueFilter int
+
+const (
+	DueAny DueFilter = iota
+	// DueOverdue — срок прошел, задача не выполнена и не отменена
+	DueOverdue
+	// DueSoon — срок наступит в пределах окна, задача не выполнена и не отменена
+	DueSoon
+)
+
+// TaskFilter — условия выборки задач пользователя
+type TaskFilter struct {
+	Due DueFilter
+	// DueWithin — окно для DueSoon
+	DueWithin time.Duration
+	// TagIDs — если не пусто, только задачи с этими тегами по правилу TagMatch
+	TagIDs   []uint32
+	TagMatch TagMatch
 }
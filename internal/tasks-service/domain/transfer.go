@@ -0,0 +1,30 @@
+package domain
+
+// ImportRowStatus — итог строки импорта
+type ImportRowStatus int
+
+const (
+	ImportRowImported ImportRowStatus = iota
+	// ImportRowDuplicate — у пользователя уже есть задача с тем же названием и сроком
+	ImportRowDuplicate
+	ImportRowInvalid
+)
+
+// ImportRow — результат строки файла Line; Error — причина, если строка не импортирована
+type ImportRow struct {
+	Line   int
+	Status ImportRowStatus
+	// TaskID — созданная задача, 0 при пробном импорте
+	TaskID uint32
+	Error  string
+}
+
+// ImportReport — итог импорта задач по строкам файла
+type ImportReport struct {
+	Imported   int
+	Duplicates int
+	Invalid    int
+	// DryRun — импорт только проверен, задачи не созданы
+	DryRun bool
+	Rows   []ImportRow
+}
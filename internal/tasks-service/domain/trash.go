@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+// DeletedTask — мягко удаленная задача в корзине
+type DeletedTask struct {
+	Task      *Task
+	DeletedAt time.Time
+}
@@ -0,0 +1,103 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	const key = "tasks/7/abc"
+	if err := store.Put(ctx, key, strings.NewReader("hello"), 5); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := readBlob(t, store, key); got != "hello" {
+		t.Fatalf("Get = %q, want %q", got, "hello")
+	}
+
+	// повторный Put заменяет объект
+	if err := store.Put(ctx, key, strings.NewReader("bye"), 3); err != nil {
+		t.Fatalf("Put again: %v", err)
+	}
+	if got := readBlob(t, store, key); got != "bye" {
+		t.Fatalf("Get after replace = %q, want %q", got, "bye")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+	// отсутствие объекта — не ошибка
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete missing: %v", err)
+	}
+}
+
+func TestLocalStorePutSizeMismatch(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := NewLocalStore(root)
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	if err := store.Put(ctx, "tasks/1/short", strings.NewReader("abc"), 10); err == nil {
+		t.Fatal("Put with wrong size succeeded")
+	}
+	if _, err := store.Get(ctx, "tasks/1/short"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after failed Put: err = %v, want ErrNotFound", err)
+	}
+
+	// временные файлы оборванной записи не остаются
+	entries, err := os.ReadDir(filepath.Join(root, "tasks", "1"))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("blob dir has %d leftover files", len(entries))
+	}
+}
+
+func TestLocalStoreRejectsInvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	for _, key := range []string{"", "/", "../escape", "tasks/../../escape", "tasks/.."} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1); err == nil {
+			t.Errorf("Put(%q) succeeded", key)
+		}
+		if _, err := store.Get(ctx, key); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q): err = %v, want invalid key", key, err)
+		}
+	}
+}
+
+func readBlob(t *testing.T, store Store, key string) string {
+	t.Helper()
+	rc, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%q): %v", key, err)
+	}
+	defer rc.Close()
+
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read %q: %v", key, err)
+	}
+	return string(body)
+}
@@ -0,0 +1,298 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "eu-west-1"
+	testBucket    = "attachments"
+)
+
+// fakeS3 — S3-совместимое хранилище в памяти: проверяет подпись SigV4 и
+// хранит объекты по пути запроса. Отвечает на GET, PUT, DELETE и HEAD объекта.
+type fakeS3 struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	// fail — статус, которым отвечать на любой запрос, если не 0
+	fail int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *S3Store) {
+	t.Helper()
+	f := &fakeS3{t: t, objects: make(map[string][]byte)}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:  srv.URL + "/",
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	return f, store
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f.checkSignature(r); err != "" {
+		f.t.Errorf("%s %s: %s", r.Method, r.URL.EscapedPath(), err)
+		writeS3Error(w, r, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+	if f.fail != 0 {
+		writeS3Error(w, r, f.fail, "AccessDenied")
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/"+testBucket+"/") {
+		writeS3Error(w, r, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil || int64(len(body)) != r.ContentLength {
+			writeS3Error(w, r, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[key] = body
+		w.Header().Set("ETag", `"1"`)
+	case http.MethodGet, http.MethodHead:
+		body, ok := f.objects[key]
+		if !ok {
+			writeS3Error(w, r, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", `"1"`)
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(body)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// writeS3Error отвечает ошибкой в формате S3 REST API
+func writeS3Error(w http.ResponseWriter, r *http.Request, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message><Resource>%s</Resource></Error>",
+			code, code, r.URL.Path)
+	}
+}
+
+func (f *fakeS3) has(path string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.objects[path]
+	return ok
+}
+
+// checkSignature пересчитывает подпись AWS Signature V4 по запросу, каким его увидел сервер
+func (f *fakeS3) checkSignature(r *http.Request) string {
+	auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return "unexpected Authorization " + r.Header.Get("Authorization")
+	}
+	fields := make(map[string]string)
+	for _, part := range strings.Split(auth, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		fields[k] = v
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if len(amzDate) != len("20060102T150405Z") {
+		return "missing X-Amz-Date"
+	}
+	date := amzDate[:8]
+	scope := date + "/" + testRegion + "/s3/aws4_request"
+	if fields["Credential"] != testAccessKey+"/"+scope {
+		return "unexpected Credential " + fields["Credential"]
+	}
+
+	signed := strings.Split(fields["SignedHeaders"], ";")
+	if !slices.Contains(signed, "host") || !slices.Contains(signed, "x-amz-date") {
+		return "host and x-amz-date are not signed"
+	}
+	var headers strings.Builder
+	for _, name := range signed {
+		value := strings.Join(r.Header.Values(name), ",")
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonical := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		canonicalQuery(r.URL.Query()),
+		headers.String(),
+		fields["SignedHeaders"],
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	if want := signV4(testSecretKey, date, testRegion, amzDate, canonical); fields["Signature"] != want {
+		return "signature mismatch"
+	}
+	return ""
+}
+
+// canonicalQuery возвращает параметры запроса, отсортированные и закодированные по правилам SigV4
+func canonicalQuery(q url.Values) string {
+	keys := slices.Sorted(maps.Keys(q))
+	var parts []string
+	for _, k := range keys {
+		values := slices.Sorted(slices.Values(q[k]))
+		for _, v := range values {
+			parts = append(parts, sigV4Escape(k)+"="+sigV4Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func sigV4Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// signV4 возвращает подпись канонического запроса canonical
+func signV4(secret, date, region, amzDate, canonical string) string {
+	hashed := sha256.Sum256([]byte(canonical))
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + date + "/" + region + "/s3/aws4_request\n" +
+		hex.EncodeToString(hashed[:])
+
+	key := []byte("AWS4" + secret)
+	for _, part := range []string{date, region, "s3", "aws4_request", toSign} {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(part))
+		key = h.Sum(nil)
+	}
+	return hex.EncodeToString(key)
+}
+
+// TestSignV4KnownVector сверяет проверку подписи в fakeS3 с примером GET Object
+// из документации AWS (Signature Version 4, "Example: GET Object")
+func TestSignV4KnownVector(t *testing.T) {
+	canonical := strings.Join([]string{
+		"GET",
+		"/test.txt",
+		"",
+		"host:examplebucket.s3.amazonaws.com\n" +
+			"range:bytes=0-9\n" +
+			"x-amz-content-sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\n" +
+			"x-amz-date:20130524T000000Z\n",
+		"host;range;x-amz-content-sha256;x-amz-date",
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}, "\n")
+
+	got := signV4("wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY", "20130524", "us-east-1", "20130524T000000Z", canonical)
+	const want = "f0e8bdb87c964420e857bd35b5d6ed310bd44f0170aba48dd91039c6036bdb41"
+	if got != want {
+		t.Fatalf("signV4 = %s, want %s", got, want)
+	}
+}
+
+func TestS3StoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f, store := newFakeS3(t)
+
+	// ключ с символами, которые SigV4 кодирует
+	const key = "tasks/7/report (final)+v2.txt"
+	if err := store.Put(ctx, key, strings.NewReader("hello"), 5); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if !f.has("/" + testBucket + "/" + key) {
+		t.Fatal("object is not stored under the bucket path")
+	}
+	if got := readBlob(t, store, key); got != "hello" {
+		t.Fatalf("Get = %q, want %q", got, "hello")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete missing: %v", err)
+	}
+}
+
+func TestS3StorePutEmpty(t *testing.T) {
+	ctx := context.Background()
+	_, store := newFakeS3(t)
+
+	if err := store.Put(ctx, "tasks/1/empty", strings.NewReader(""), 0); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := readBlob(t, store, "tasks/1/empty"); got != "" {
+		t.Fatalf("Get = %q, want empty", got)
+	}
+}
+
+func TestS3StoreServerError(t *testing.T) {
+	ctx := context.Background()
+	f, store := newFakeS3(t)
+	// на 5xx minio-go повторяет запрос с паузами, поэтому отказ — 403
+	f.fail = http.StatusForbidden
+
+	err := store.Put(ctx, "tasks/1/x", strings.NewReader("x"), 1)
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("Put: err = %v, want error of the response", err)
+	}
+	if _, err := store.Get(ctx, "tasks/1/x"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get: err = %v, want server error", err)
+	}
+	if err := store.Delete(ctx, "tasks/1/x"); err == nil {
+		t.Fatal("Delete succeeded on server error")
+	}
+}
+
+func TestNewS3StoreRequiresEndpointAndBucket(t *testing.T) {
+	if _, err := NewS3Store(S3Config{Bucket: testBucket}); err == nil {
+		t.Error("NewS3Store without endpoint succeeded")
+	}
+	if _, err := NewS3Store(S3Config{Endpoint: "http://localhost:9000"}); err == nil {
+		t.Error("NewS3Store without bucket succeeded")
+	}
+	for _, endpoint := range []string{"localhost:9000", "ftp://localhost", "http://"} {
+		if _, err := NewS3Store(S3Config{Endpoint: endpoint, Bucket: testBucket}); err == nil {
+			t.Errorf("NewS3Store(%q) succeeded", endpoint)
+		}
+	}
+}
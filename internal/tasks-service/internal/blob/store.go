@@ -0,0 +1,19 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+var ErrNotFound = fmt.Errorf("blob not found")
+
+// Store хранит неизменяемые объекты по ключу. Ключ — путь из сегментов через '/'.
+type Store interface {
+	// Put сохраняет size байт из r под ключом key, заменяя прежний объект
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get открывает объект на чтение; вызывающий закрывает его
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект; отсутствие объекта не ошибка
+	Delete(ctx context.Context, key string) error
+}
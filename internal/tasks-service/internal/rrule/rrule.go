@@ -0,0 +1,306 @@
+// Package rrule разбирает подмножество правил повторения iCalendar (RFC 5545)
+// и перечисляет даты повторений.
+//
+// Поддерживаются FREQ=DAILY|WEEKLY|MONTHLY|YEARLY, INTERVAL, BYDAY (только для
+// WEEKLY, без порядковых номеров), BYMONTHDAY (только для MONTHLY, 1..31),
+// COUNT и UNTIL. Неделя начинается с понедельника.
+package rrule
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Freq int
+
+const (
+	Daily Freq = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+// maxPeriods ограничивает перебор периодов, чтобы редкое правило не зациклилось
+const maxPeriods = 100_000
+
+var freqs = map[string]Freq{"DAILY": Daily, "WEEKLY": Weekly, "MONTHLY": Monthly, "YEARLY": Yearly}
+
+var freqNames = map[Freq]string{Daily: "DAILY", Weekly: "WEEKLY", Monthly: "MONTHLY", Yearly: "YEARLY"}
+
+var dayNames = map[time.Weekday]string{
+	time.Monday: "MO", time.Tuesday: "TU", time.Wednesday: "WE", time.Thursday: "TH",
+	time.Friday: "FR", time.Saturday: "SA", time.Sunday: "SU",
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// Rule — разобранное правило повторения
+type Rule struct {
+	Freq       Freq
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	// Count — сколько всего повторений, 0 — без ограничения
+	Count int
+	// Until — последнее допустимое время повторения, nil — без ограничения
+	Until *time.Time
+	// UntilDate — UNTIL задан датой без времени: Until хранит эту дату в UTC,
+	// а серия заканчивается в конце этого дня в часовом поясе DTSTART
+	UntilDate bool
+}
+
+// Parse разбирает строку вида "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE".
+// Префикс "RRULE:" и завершающая ';' допускаются. Повторы в BYDAY и BYMONTHDAY
+// отбрасываются, дни упорядочиваются.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	s = strings.TrimSuffix(s, ";")
+	if s == "" {
+		return nil, fmt.Errorf("empty rule")
+	}
+
+	r := &Rule{Interval: 1}
+	hasFreq := false
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate rule part %s", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			f, ok := freqs[value]
+			if !ok {
+				return nil, fmt.Errorf("unsupported FREQ %q", value)
+			}
+			r.Freq, hasFreq = f, true
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 1000 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, date, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Until, r.UntilDate = &t, date
+		case "BYDAY":
+			for _, d := range strings.Split(value, ",") {
+				wd, ok := weekdays[d]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY %q", d)
+				}
+				if !slices.Contains(r.ByDay, wd) {
+					r.ByDay = append(r.ByDay, wd)
+				}
+			}
+			// неделя начинается с понедельника
+			slices.SortFunc(r.ByDay, func(a, b time.Weekday) int { return (int(a)+6)%7 - (int(b)+6)%7 })
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(d)
+				if err != nil || n < 1 || n > 31 {
+					return nil, fmt.Errorf("unsupported BYMONTHDAY %q", d)
+				}
+				if !slices.Contains(r.ByMonthDay, n) {
+					r.ByMonthDay = append(r.ByMonthDay, n)
+				}
+			}
+			slices.Sort(r.ByMonthDay)
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", name)
+		}
+	}
+
+	switch {
+	case !hasFreq:
+		return nil, fmt.Errorf("FREQ is required")
+	case r.Count > 0 && r.Until != nil:
+		return nil, fmt.Errorf("COUNT and UNTIL are mutually exclusive")
+	case len(r.ByDay) > 0 && r.Freq != Weekly:
+		return nil, fmt.Errorf("BYDAY is supported only with FREQ=WEEKLY")
+	case len(r.ByMonthDay) > 0 && r.Freq != Monthly:
+		return nil, fmt.Errorf("BYMONTHDAY is supported only with FREQ=MONTHLY")
+	}
+
+	return r, nil
+}
+
+// String возвращает правило в каноническом виде, пригодном для Parse
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + freqNames[r.Freq]}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			days[i] = dayNames[wd]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	switch {
+	case r.Until != nil && r.UntilDate:
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	case r.Until != nil:
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// parseUntil разбирает UNTIL; date сообщает, что задана дата без времени
+func parseUntil(v string) (t time.Time, date bool, err error) {
+	if t, err := time.Parse("20060102T150405Z", v); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("20060102", v); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid UNTIL %q", v)
+}
+
+// until возвращает последнее допустимое время повторения серии, начатой в dtstart.
+// Дата без времени включает весь день в часовом поясе dtstart.
+func (r *Rule) until(dtstart time.Time) time.Time {
+	if !r.UntilDate {
+		return *r.Until
+	}
+	y, m, d := r.Until.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, dtstart.Location()).Add(-time.Nanosecond)
+}
+
+// Between возвращает повторения серии, начатой в dtstart, из полуинтервала (after, until]
+func (r *Rule) Between(dtstart, after, until time.Time) []time.Time {
+	var out []time.Time
+	r.each(dtstart, func(t time.Time) bool {
+		if t.After(until) {
+			return false
+		}
+		if t.After(after) {
+			out = append(out, t)
+		}
+		return true
+	})
+	return out
+}
+
+// After возвращает первое повторение серии, начатой в dtstart, позже t
+func (r *Rule) After(dtstart, t time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.each(dtstart, func(o time.Time) bool {
+		if o.After(t) {
+			next, found = o, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// each передает в fn повторения по возрастанию, начиная с dtstart, пока fn
+// возвращает true и правило не исчерпано
+func (r *Rule) each(dtstart time.Time, fn func(time.Time) bool) {
+	var until time.Time
+	if r.Until != nil {
+		until = r.until(dtstart)
+	}
+
+	n := 0
+	for k := 0; k < maxPeriods; k++ {
+		for _, t := range r.period(dtstart, k) {
+			if t.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && t.After(until) {
+				return
+			}
+			n++
+			if !fn(t) || (r.Count > 0 && n >= r.Count) {
+				return
+			}
+		}
+	}
+}
+
+// period возвращает кандидатов k-го периода серии по возрастанию
+func (r *Rule) period(dtstart time.Time, k int) []time.Time {
+	step := k * r.Interval
+	h, m, s := dtstart.Clock()
+	at := func(y int, mo time.Month, d int) time.Time {
+		return time.Date(y, mo, d, h, m, s, dtstart.Nanosecond(), dtstart.Location())
+	}
+
+	switch r.Freq {
+	case Daily:
+		return []time.Time{dtstart.AddDate(0, 0, step)}
+	case Weekly:
+		start := dtstart.AddDate(0, 0, 7*step)
+		if len(r.ByDay) == 0 {
+			return []time.Time{start}
+		}
+		// понедельник недели периода
+		monday := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		out := make([]time.Time, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			out = append(out, monday.AddDate(0, 0, (int(wd)+6)%7))
+		}
+		sortTimes(out)
+		return out
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, dtstart.Location())
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{dtstart.Day()}
+		}
+		out := make([]time.Time, 0, len(days))
+		for _, d := range days {
+			// дни, которых нет в месяце (31 апреля), пропускаются, как в RFC 5545
+			if t := at(first.Year(), first.Month(), d); t.Month() == first.Month() {
+				out = append(out, t)
+			}
+		}
+		sortTimes(out)
+		return out
+	default:
+		y := dtstart.Year() + step
+		if t := at(y, dtstart.Month(), dtstart.Day()); t.Month() == dtstart.Month() {
+			return []time.Time{t}
+		}
+		return nil
+	}
+}
+
+func sortTimes(ts []time.Time) {
+	sort.Slice(ts, func(i, j int) bool { return ts[i].Before(ts[j]) })
+}
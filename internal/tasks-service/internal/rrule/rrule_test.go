@@ -0,0 +1,174 @@
+package rrule
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseCanonical(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"rrule:freq=weekly;interval=2;byday=we,mo", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{" FREQ=WEEKLY;BYDAY=SU,MO,SA ", "FREQ=WEEKLY;BYDAY=MO,SA,SU"},
+		{"FREQ=WEEKLY;BYDAY=MO,MO,FR,MO", "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{"FREQ=MONTHLY;BYMONTHDAY=15,1,15,31", "FREQ=MONTHLY;BYMONTHDAY=1,15,31"},
+		{"FREQ=DAILY;INTERVAL=1;COUNT=3;", "FREQ=DAILY;COUNT=3"},
+		{"FREQ=YEARLY;UNTIL=20270101T000000Z", "FREQ=YEARLY;UNTIL=20270101T000000Z"},
+		{"FREQ=DAILY;UNTIL=20270101", "FREQ=DAILY;UNTIL=20270101"},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if got := r.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+		// канонический вид разбирается в то же правило
+		again, err := Parse(tt.want)
+		if err != nil || again.String() != tt.want {
+			t.Errorf("Parse(%q) round trip = %v, %v", tt.want, again, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"RRULE:",
+		";",
+		"FREQ=DAILY;;COUNT=2",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=1001",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20270101",
+		"FREQ=DAILY;UNTIL=2027-01-01",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYDAY=MO,",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;WKST=MO",
+		"FREQ=DAILY;COUNT",
+		"FREQ=DAILY;COUNT=",
+	} {
+		if r, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", in, r)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 9, 30, 0, 0, time.UTC) }
+
+	tests := []struct {
+		rule         string
+		dtstart      time.Time
+		after, until time.Time
+		want         []time.Time
+	}{
+		{
+			// среда: понедельник первой недели раньше начала серии и пропускается
+			"FREQ=WEEKLY;BYDAY=MO,FR,MO", day(10, 21), day(10, 1), day(11, 3),
+			[]time.Time{day(10, 23), day(10, 26), day(10, 30), day(11, 2)},
+		},
+		{
+			"FREQ=WEEKLY;INTERVAL=2", day(10, 5), day(10, 5), day(11, 30),
+			[]time.Time{day(10, 19), day(11, 2), day(11, 16), day(11, 30)},
+		},
+		{
+			// в ноябре нет 31-го
+			"FREQ=MONTHLY;BYMONTHDAY=31,30", day(10, 1), day(9, 1), day(12, 31),
+			[]time.Time{day(10, 30), day(10, 31), day(11, 30), day(12, 30), day(12, 31)},
+		},
+		{
+			"FREQ=DAILY;COUNT=3", day(10, 1), day(9, 1), day(12, 31),
+			[]time.Time{day(10, 1), day(10, 2), day(10, 3)},
+		},
+		{
+			"FREQ=DAILY;UNTIL=20261003", day(10, 1), day(9, 1), day(12, 31),
+			[]time.Time{day(10, 1), day(10, 2), day(10, 3)},
+		},
+		{
+			// 29 февраля бывает только в високосные годы
+			"FREQ=YEARLY;COUNT=2", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2032, 2, 29, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.rule)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.rule, err)
+		}
+		if got := r.Between(tt.dtstart, tt.after, tt.until); !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+			t.Errorf("%s: Between = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestBetweenUntilDateInDTStartZone(t *testing.T) {
+	east := time.FixedZone("UTC+10", 10*3600)
+	west := time.FixedZone("UTC-10", -10*3600)
+	at := func(loc *time.Location, d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, loc) }
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		want    []time.Time
+	}{
+		{
+			// 4 октября 00:00 в UTC+10 — еще 3 октября по UTC, но уже после UNTIL
+			name: "date east", rule: "FREQ=DAILY;UNTIL=20261003", dtstart: at(east, 1, 0),
+			want: []time.Time{at(east, 1, 0), at(east, 2, 0), at(east, 3, 0)},
+		},
+		{
+			// 3 октября 15:00 в UTC-10 — уже 4 октября по UTC, но еще до конца UNTIL
+			name: "date west", rule: "FREQ=DAILY;UNTIL=20261003", dtstart: at(west, 1, 15),
+			want: []time.Time{at(west, 1, 15), at(west, 2, 15), at(west, 3, 15)},
+		},
+		{
+			// время с Z — точный момент: 3 октября 00:00 UTC — 2 октября 14:00 в UTC-10
+			name: "UTC time", rule: "FREQ=DAILY;UNTIL=20261003T000000Z", dtstart: at(west, 1, 12),
+			want: []time.Time{at(west, 1, 12), at(west, 2, 12)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			got := r.Between(tt.dtstart, tt.dtstart.AddDate(0, 0, -1), tt.dtstart.AddDate(0, 1, 0))
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("Between = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	dtstart := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	r, err := Parse("FREQ=DAILY;COUNT=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, ok := r.After(dtstart, dtstart)
+	if want := dtstart.AddDate(0, 0, 1); !ok || !next.Equal(want) {
+		t.Fatalf("After(dtstart) = %v, %v, want %v", next, ok, want)
+	}
+	// правило исчерпано
+	if next, ok := r.After(dtstart, dtstart.AddDate(0, 0, 1)); ok {
+		t.Fatalf("After(last) = %v, want none", next)
+	}
+}
@@ -0,0 +1,254 @@
+// Package taskfile читает и пишет задачи в переносимых форматах: CSV и JSON Lines (NDJSON).
+package taskfile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format — формат файла задач
+type Format int
+
+const (
+	CSV Format = iota
+	// JSONLines — по JSON-объекту задачи в строке; то же, что NDJSON
+	JSONLines
+)
+
+// ErrMalformed — файл нельзя читать дальше: нет заголовка CSV или слишком длинная строка
+var ErrMalformed = fmt.Errorf("malformed task file")
+
+// maxLineLen — наибольшая длина строки JSON Lines
+const maxLineLen = 1 << 20
+
+// tagSeparator разделяет теги в колонке tags CSV
+const tagSeparator = ";"
+
+// columns — колонки CSV в порядке записи; при чтении порядок берется из заголовка
+var columns = []string{"id", "title", "status", "priority", "due_at", "remind_at", "completed_at", "project", "tags"}
+
+// Record — задача в файле. Проект и теги задаются именами, даты — в RFC 3339.
+// ID заполняется при экспорте и не учитывается при импорте.
+type Record struct {
+	ID          uint32     `json:"id,omitempty"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status,omitempty"`
+	Priority    string     `json:"priority,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	RemindAt    *time.Time `json:"remind_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Project     string     `json:"project,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+}
+
+// RowError — строку файла line не удалось разобрать; чтение можно продолжить
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Writer пишет записи в выбранном формате
+type Writer interface {
+	Write(rec *Record) error
+	// Flush дописывает буферизованные данные
+	Flush() error
+}
+
+// NewWriter возвращает Writer формата format; CSV начинается с заголовка
+func NewWriter(w io.Writer, format Format) (Writer, error) {
+	switch format {
+	case CSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	case JSONLines:
+		bw := bufio.NewWriter(w)
+		return &jsonWriter{w: bw, enc: json.NewEncoder(bw)}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %d", format)
+	}
+}
+
+// Reader читает записи до io.EOF. Ошибка *RowError относится к одной строке,
+// остальные ошибки прерывают чтение.
+type Reader interface {
+	Read() (*Record, int, error)
+}
+
+// NewReader возвращает Reader формата format. У CSV первая строка — заголовок
+// с именами колонок; обязательна только колонка title, неизвестные пропускаются.
+func NewReader(r io.Reader, format Format) (Reader, error) {
+	switch format {
+	case CSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		header, err := cr.Read()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: csv has no header", ErrMalformed)
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("%w: csv header: %v", ErrMalformed, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		index := make(map[string]int, len(header))
+		for i, name := range header {
+			index[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		if _, ok := index["title"]; !ok {
+			return nil, fmt.Errorf("%w: csv header has no title column", ErrMalformed)
+		}
+		return &csvReader{r: cr, index: index}, nil
+	case JSONLines:
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 64<<10), maxLineLen)
+		return &jsonReader{sc: sc}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %d", format)
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) Write(rec *Record) error {
+	id := ""
+	if rec.ID != 0 {
+		id = strconv.FormatUint(uint64(rec.ID), 10)
+	}
+	return w.w.Write([]string{id, rec.Title, rec.Status, rec.Priority, formatTime(rec.DueAt),
+		formatTime(rec.RemindAt), formatTime(rec.CompletedAt), rec.Project, strings.Join(rec.Tags, tagSeparator)})
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (w *jsonWriter) Write(rec *Record) error {
+	return w.enc.Encode(rec)
+}
+
+func (w *jsonWriter) Flush() error {
+	return w.w.Flush()
+}
+
+type csvReader struct {
+	r     *csv.Reader
+	index map[string]int
+}
+
+func (r *csvReader) Read() (*Record, int, error) {
+	row, err := r.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, parseErr.StartLine, &RowError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+		return nil, 0, err
+	}
+	line, _ := r.r.FieldPos(0)
+
+	field := func(name string) string {
+		i, ok := r.index[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	rec := &Record{
+		Title:    field("title"),
+		Status:   field("status"),
+		Priority: field("priority"),
+		Project:  field("project"),
+	}
+	for name, dst := range map[string]**time.Time{"due_at": &rec.DueAt, "remind_at": &rec.RemindAt, "completed_at": &rec.CompletedAt} {
+		t, err := parseTime(field(name))
+		if err != nil {
+			return nil, line, &RowError{Line: line, Err: fmt.Errorf("invalid %s: %w", name, err)}
+		}
+		*dst = t
+	}
+	if tags := field("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, tagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				rec.Tags = append(rec.Tags, tag)
+			}
+		}
+	}
+
+	return rec, line, nil
+}
+
+type jsonReader struct {
+	sc   *bufio.Scanner
+	line int
+}
+
+func (r *jsonReader) Read() (*Record, int, error) {
+	for r.sc.Scan() {
+		r.line++
+		raw := strings.TrimSpace(r.sc.Text())
+		if raw == "" {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+			return nil, r.line, &RowError{Line: r.line, Err: err}
+		}
+		rec.ID = 0
+		return &rec, r.line, nil
+	}
+	if err := r.sc.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, r.line + 1, fmt.Errorf("%w: line %d is longer than %d bytes", ErrMalformed, r.line+1, maxLineLen)
+		}
+		return nil, 0, err
+	}
+	return nil, 0, io.EOF
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,251 @@
+package taskfile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// result — итог одного вызова Read
+type result struct {
+	rec  *Record
+	line int
+	err  error
+}
+
+// readAll читает записи до io.EOF или ошибки, прерывающей чтение
+func readAll(t *testing.T, r Reader) []result {
+	t.Helper()
+	var out []result
+	for {
+		rec, line, err := r.Read()
+		if err == io.EOF {
+			return out
+		}
+		out = append(out, result{rec, line, err})
+		var rowErr *RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			return out
+		}
+		if len(out) > 100 {
+			t.Fatal("reader does not stop")
+		}
+	}
+}
+
+func timePtr(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func TestRoundTrip(t *testing.T) {
+	records := []*Record{
+		{
+			ID:          7,
+			Title:       `Купить "молоко", хлеб`,
+			Status:      "done",
+			Priority:    "high",
+			DueAt:       timePtr("2026-10-20T09:00:00Z"),
+			RemindAt:    timePtr("2026-10-20T08:30:00Z"),
+			CompletedAt: timePtr("2026-10-19T18:00:00Z"),
+			Project:     "Дом",
+			Tags:        []string{"shop", "urgent"},
+		},
+		{ID: 8, Title: "многострочное\nописание"},
+	}
+
+	for _, format := range []Format{CSV, JSONLines} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, format)
+		if err != nil {
+			t.Fatalf("NewWriter(%d): %v", format, err)
+		}
+		for _, rec := range records {
+			if err := w.Write(rec); err != nil {
+				t.Fatalf("Write(%d): %v", format, err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush(%d): %v", format, err)
+		}
+
+		r, err := NewReader(&buf, format)
+		if err != nil {
+			t.Fatalf("NewReader(%d): %v", format, err)
+		}
+		got := readAll(t, r)
+		if len(got) != len(records) {
+			t.Fatalf("format %d: read %d records, want %d", format, len(got), len(records))
+		}
+		for i, res := range got {
+			if res.err != nil {
+				t.Fatalf("format %d: record %d: %v", format, i, res.err)
+			}
+			// ID при импорте не учитывается
+			want := *records[i]
+			want.ID = 0
+			if !reflect.DeepEqual(res.rec, &want) {
+				t.Errorf("format %d: record %d = %+v, want %+v", format, i, res.rec, &want)
+			}
+		}
+	}
+}
+
+func TestWriterCSVHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, CSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(&Record{Title: "a", Tags: []string{"x", "y"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,title,status,priority,due_at,remind_at,completed_at,project,tags\n,a,,,,,,,x;y\n"
+	if buf.String() != want {
+		t.Fatalf("csv = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCSVReaderColumns(t *testing.T) {
+	// порядок колонок берется из заголовка, регистр и пробелы в именах не важны,
+	// неизвестные колонки пропускаются, короткие строки допустимы
+	const in = " Tags ,extra,TITLE,due_at\n" +
+		"a; ;b,ignored,  Первая  ,2026-10-20T09:00:00+03:00\n" +
+		",,Вторая\n"
+	r, err := NewReader(strings.NewReader(in), CSV)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	got := readAll(t, r)
+	want := []result{
+		{&Record{Title: "Первая", Tags: []string{"a", "b"}, DueAt: timePtr("2026-10-20T06:00:00Z")}, 2, nil},
+		{&Record{Title: "Вторая"}, 3, nil},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("read %d records, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].err != nil || got[i].line != want[i].line {
+			t.Fatalf("record %d: line %d, err %v; want line %d", i, got[i].line, got[i].err, want[i].line)
+		}
+		if d := got[i].rec.DueAt; d != nil {
+			*d = d.UTC()
+		}
+		if !reflect.DeepEqual(got[i].rec, want[i].rec) {
+			t.Errorf("record %d = %+v, want %+v", i, got[i].rec, want[i].rec)
+		}
+	}
+}
+
+func TestCSVReaderRowErrors(t *testing.T) {
+	// после ошибки в строке чтение продолжается; номер строки учитывает
+	// многострочные поля в кавычках
+	const in = "title,due_at\n" +
+		"\"две\nстроки\",\n" +
+		"плохая дата,2026-10-20\n" +
+		"\"незакрытая, кавычка\"x,\n" +
+		"последняя,\n"
+	r, err := NewReader(strings.NewReader(in), CSV)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	got := readAll(t, r)
+	if len(got) != 4 {
+		t.Fatalf("read %d results, want 4: %+v", len(got), got)
+	}
+
+	if got[0].err != nil || got[0].rec.Title != "две\nстроки" || got[0].line != 2 {
+		t.Errorf("result 0 = %+v", got[0])
+	}
+	for i, line := range map[int]int{1: 4, 2: 5} {
+		var rowErr *RowError
+		if !errors.As(got[i].err, &rowErr) || rowErr.Line != line || got[i].line != line {
+			t.Errorf("result %d: line %d, err %v; want RowError on line %d", i, got[i].line, got[i].err, line)
+		}
+	}
+	if !strings.Contains(got[1].err.Error(), "invalid due_at") {
+		t.Errorf("result 1: err = %v, want invalid due_at", got[1].err)
+	}
+	if got[3].err != nil || got[3].rec.Title != "последняя" || got[3].line != 6 {
+		t.Errorf("result 3 = %+v", got[3])
+	}
+}
+
+func TestCSVReaderMalformed(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"status,due_at\nopen,\n",
+		"\"title\n",
+	} {
+		if _, err := NewReader(strings.NewReader(in), CSV); !errors.Is(err, ErrMalformed) {
+			t.Errorf("NewReader(%q): err = %v, want ErrMalformed", in, err)
+		}
+	}
+}
+
+func TestJSONLinesReader(t *testing.T) {
+	const in = `{"id": 5, "title": "первая", "tags": ["a"], "due_at": "2026-10-20T09:00:00Z"}` + "\n" +
+		"\n" +
+		"   \n" +
+		`{"title": "вторая", "due_at": "завтра"}` + "\n" +
+		`not json` + "\n" +
+		`{"title": "третья", "priority": "low"}`
+	r, err := NewReader(strings.NewReader(in), JSONLines)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	got := readAll(t, r)
+	if len(got) != 4 {
+		t.Fatalf("read %d results, want 4: %+v", len(got), got)
+	}
+
+	want := &Record{Title: "первая", Tags: []string{"a"}, DueAt: timePtr("2026-10-20T09:00:00Z")}
+	if got[0].err != nil || got[0].line != 1 || !reflect.DeepEqual(got[0].rec, want) {
+		t.Errorf("result 0 = %+v, want %+v on line 1", got[0], want)
+	}
+	// пустые строки пропускаются, но учитываются в номерах
+	for i, line := range map[int]int{1: 4, 2: 5} {
+		var rowErr *RowError
+		if !errors.As(got[i].err, &rowErr) || rowErr.Line != line || got[i].line != line {
+			t.Errorf("result %d: line %d, err %v; want RowError on line %d", i, got[i].line, got[i].err, line)
+		}
+	}
+	if got[3].err != nil || got[3].line != 6 || got[3].rec.Title != "третья" || got[3].rec.Priority != "low" {
+		t.Errorf("result 3 = %+v", got[3])
+	}
+}
+
+func TestJSONLinesReaderLineTooLong(t *testing.T) {
+	in := `{"title": "ok"}` + "\n" + `{"title": "` + strings.Repeat("x", maxLineLen) + `"}` + "\n"
+	r, err := NewReader(strings.NewReader(in), JSONLines)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	got := readAll(t, r)
+	if len(got) != 2 || got[0].err != nil {
+		t.Fatalf("results = %+v", got)
+	}
+	var rowErr *RowError
+	if !errors.Is(got[1].err, ErrMalformed) || errors.As(got[1].err, &rowErr) || got[1].line != 2 {
+		t.Fatalf("long line: line %d, err %v; want ErrMalformed on line 2", got[1].line, got[1].err)
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := NewWriter(io.Discard, Format(42)); err == nil {
+		t.Error("NewWriter with unknown format succeeded")
+	}
+	if _, err := NewReader(strings.NewReader(""), Format(42)); err == nil {
+		t.Error("NewReader with unknown format succeeded")
+	}
+}
@@ -0,0 +1,243 @@
+package tasks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/blob"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxFileNameLen = 255
+
+// CreateAttachment сохраняет метаданные вложения
+func (r *taskRepo) CreateAttachment(dm *domain.Attachment) (*domain.Attachment, error) {
+	a := Attachment{
+		TaskID:      dm.TaskID,
+		FileName:    dm.FileName,
+		ContentType: dm.ContentType,
+		Size:        dm.Size,
+		SHA256:      dm.SHA256,
+		StorageKey:  dm.StorageKey,
+	}
+	if err := r.db.Create(&a).Error; err != nil {
+		return nil, fmt.Errorf("CreateAttachment: failed to create attachment: %w", err)
+	}
+
+	return a.toDomain(), nil
+}
+
+// GetAttachment возвращает метаданные вложения по id
+func (r *taskRepo) GetAttachment(id uint32) (*domain.Attachment, error) {
+	var a Attachment
+	if err := r.db.First(&a, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("GetAttachment: failed to find attachment: %w", err)
+	}
+
+	return a.toDomain(), nil
+}
+
+// ListAttachments возвращает вложения задачи в порядке добавления
+func (r *taskRepo) ListAttachments(taskID uint32) ([]*domain.Attachment, error) {
+	var list []Attachment
+	if err := r.db.Where("task_id = ?", taskID).Order("id").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("ListAttachments: failed to get attachments: %w", err)
+	}
+
+	out := make([]*domain.Attachment, len(list))
+	for i := range list {
+		out[i] = list[i].toDomain()
+	}
+
+	return out, nil
+}
+
+// DeleteAttachment удаляет метаданные вложения и возвращает их, чтобы удалить содержимое
+func (r *taskRepo) DeleteAttachment(id uint32) (*domain.Attachment, error) {
+	var a Attachment
+	res := r.db.Clauses(clause.Returning{}).Where("id = ?", id).Delete(&a)
+	if res.Error != nil {
+		return nil, fmt.Errorf("DeleteAttachment: failed to delete attachment: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrAttachmentNotFound
+	}
+
+	return a.toDomain(), nil
+}
+
+// AttachmentsService — файлы, прикрепленные к задачам
+type AttachmentsService interface {
+	// UploadAttachment сохраняет содержимое из r и метаданные вложения a.
+	// Ненулевые a.Size и a.SHA256 сверяются с фактическими.
+	UploadAttachment(ctx context.Context, a *domain.Attachment, r io.Reader) (*domain.Attachment, error)
+	// OpenAttachment открывает содержимое вложения. Если содержимое не совпадает
+	// с контрольной суммой, чтение в конце вернет ErrChecksumMismatch.
+	OpenAttachment(ctx context.Context, id uint32) (*domain.Attachment, io.ReadCloser, error)
+	ListAttachments(taskID uint32) ([]*domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, id uint32) error
+}
+
+type attachmentsService struct {
+	repo    TasksRepo
+	store   blob.Store
+	maxSize int64
+}
+
+func NewAttachmentsService(r TasksRepo, store blob.Store, maxSize int64) AttachmentsService {
+	return &attachmentsService{repo: r, store: store, maxSize: maxSize}
+}
+
+func (s *attachmentsService) UploadAttachment(ctx context.Context, a *domain.Attachment, r io.Reader) (*domain.Attachment, error) {
+	name := strings.TrimSpace(a.FileName)
+	if name == "" || utf8.RuneCountInString(name) > maxFileNameLen || strings.ContainsAny(name, "/\\") {
+		return nil, ErrInvalidAttachment
+	}
+	if a.Size > s.maxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if _, err := s.repo.GetByID(a.TaskID); err != nil {
+		return nil, err
+	}
+
+	// содержимое сначала пишется во временный файл: так известны размер и
+	// контрольная сумма до записи в хранилище, и туда не попадают оборванные загрузки
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, fmt.Errorf("UploadAttachment: failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	sum := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, sum), io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("UploadAttachment: failed to receive content: %w", err)
+	}
+	if size > s.maxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if a.Size > 0 && a.Size != size {
+		return nil, ErrAttachmentSizeMismatch
+	}
+	checksum := hex.EncodeToString(sum.Sum(nil))
+	if a.SHA256 != "" && !strings.EqualFold(a.SHA256, checksum) {
+		return nil, ErrChecksumMismatch
+	}
+
+	key, err := newStorageKey(a.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("UploadAttachment: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("UploadAttachment: failed to rewind temp file: %w", err)
+	}
+	if err := s.store.Put(ctx, key, tmp, size); err != nil {
+		return nil, fmt.Errorf("UploadAttachment: %w", err)
+	}
+
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	created, err := s.repo.CreateAttachment(&domain.Attachment{
+		TaskID:      a.TaskID,
+		FileName:    name,
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      checksum,
+		StorageKey:  key,
+	})
+	if err != nil {
+		// без метаданных содержимое никто не найдет
+		if delErr := s.store.Delete(ctx, key); delErr != nil {
+			log.Printf("attachments: failed to delete orphan blob %s: %v", key, delErr)
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *attachmentsService) OpenAttachment(ctx context.Context, id uint32) (*domain.Attachment, io.ReadCloser, error) {
+	a, err := s.repo.GetAttachment(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.repo.GetByID(a.TaskID); err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := s.store.Get(ctx, a.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("OpenAttachment: %w", err)
+	}
+
+	return a, &checksumReader{rc: rc, sum: sha256.New(), want: a.SHA256}, nil
+}
+
+func (s *attachmentsService) ListAttachments(taskID uint32) ([]*domain.Attachment, error) {
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListAttachments(taskID)
+}
+
+// DeleteAttachment удаляет метаданные, затем содержимое. Если содержимое удалить
+// не удалось, вложение уже недоступно, а файл остается сиротой в хранилище.
+func (s *attachmentsService) DeleteAttachment(ctx context.Context, id uint32) error {
+	a, err := s.repo.DeleteAttachment(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.Delete(ctx, a.StorageKey); err != nil {
+		log.Printf("attachments: failed to delete blob %s: %v", a.StorageKey, err)
+	}
+	return nil
+}
+
+// newStorageKey возвращает случайный ключ содержимого вложения задачи
+func newStorageKey(taskID uint32) (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(b[:])), nil
+}
+
+// checksumReader считает SHA-256 прочитанного и в конце сверяет с ожидаемым
+type checksumReader struct {
+	rc   io.ReadCloser
+	sum  hash.Hash
+	want string
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	r.sum.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(r.sum.Sum(nil)) != r.want {
+		return n, ErrChecksumMismatch
+	}
+	return n, err
+}
+
+func (r *checksumReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,183 @@
+package tasks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/blob"
+)
+
+// attachmentsRepo — репозиторий в памяти с одной задачей и её вложениями
+type attachmentsRepo struct {
+	TasksRepo
+
+	task        *domain.Task
+	attachments map[uint32]*domain.Attachment
+	createErr   error
+}
+
+func (r *attachmentsRepo) GetByID(id uint32) (*domain.Task, error) {
+	if r.task == nil || r.task.ID != id {
+		return nil, ErrTaskNotFound
+	}
+	return r.task, nil
+}
+
+func (r *attachmentsRepo) CreateAttachment(a *domain.Attachment) (*domain.Attachment, error) {
+	if r.createErr != nil {
+		return nil, r.createErr
+	}
+	created := *a
+	created.ID = uint32(len(r.attachments) + 1)
+	r.attachments[created.ID] = &created
+	return &created, nil
+}
+
+func (r *attachmentsRepo) GetAttachment(id uint32) (*domain.Attachment, error) {
+	a, ok := r.attachments[id]
+	if !ok {
+		return nil, ErrAttachmentNotFound
+	}
+	return a, nil
+}
+
+func newTestAttachments(t *testing.T, maxSize int64) (*attachmentsRepo, blob.Store, AttachmentsService) {
+	t.Helper()
+	store, err := blob.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	repo := &attachmentsRepo{
+		task:        &domain.Task{ID: 1, UserID: 1},
+		attachments: make(map[uint32]*domain.Attachment),
+	}
+	return repo, store, NewAttachmentsService(repo, store, maxSize)
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestUploadAndOpenAttachment(t *testing.T) {
+	ctx := context.Background()
+	_, _, svc := newTestAttachments(t, 1<<10)
+
+	const content = "attachment body"
+	a, err := svc.UploadAttachment(ctx, &domain.Attachment{
+		TaskID:   1,
+		FileName: " notes.txt ",
+		Size:     int64(len(content)),
+		SHA256:   strings.ToUpper(sha256Hex(content)),
+	}, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	if a.FileName != "notes.txt" || a.Size != int64(len(content)) || a.SHA256 != sha256Hex(content) ||
+		a.ContentType != "application/octet-stream" || a.StorageKey == "" {
+		t.Fatalf("unexpected attachment %+v", a)
+	}
+
+	got, rc, err := svc.OpenAttachment(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("OpenAttachment: %v", err)
+	}
+	defer rc.Close()
+	if got.ID != a.ID {
+		t.Fatalf("OpenAttachment returned attachment %d, want %d", got.ID, a.ID)
+	}
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read attachment: %v", err)
+	}
+	if string(body) != content {
+		t.Fatalf("content = %q, want %q", body, content)
+	}
+}
+
+func TestOpenAttachmentDetectsCorruption(t *testing.T) {
+	ctx := context.Background()
+	_, store, svc := newTestAttachments(t, 1<<10)
+
+	a, err := svc.UploadAttachment(ctx, &domain.Attachment{TaskID: 1, FileName: "a.bin"}, strings.NewReader("original"))
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	// содержимое в хранилище подменено после загрузки
+	if err := store.Put(ctx, a.StorageKey, strings.NewReader("tampered"), 8); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	_, rc, err := svc.OpenAttachment(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("OpenAttachment: %v", err)
+	}
+	defer rc.Close()
+	if _, err := io.ReadAll(rc); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("read tampered attachment: err = %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestUploadAttachmentRejectsBadContent(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		a       domain.Attachment
+		content string
+		want    error
+	}{
+		{"checksum", domain.Attachment{TaskID: 1, FileName: "a", SHA256: sha256Hex("other")}, "content", ErrChecksumMismatch},
+		{"declared size", domain.Attachment{TaskID: 1, FileName: "a", Size: 3}, "content", ErrAttachmentSizeMismatch},
+		{"declared too large", domain.Attachment{TaskID: 1, FileName: "a", Size: 100}, "x", ErrAttachmentTooLarge},
+		{"content too large", domain.Attachment{TaskID: 1, FileName: "a"}, strings.Repeat("x", 17), ErrAttachmentTooLarge},
+		{"file name", domain.Attachment{TaskID: 1, FileName: "dir/a"}, "content", ErrInvalidAttachment},
+		{"task", domain.Attachment{TaskID: 2, FileName: "a"}, "content", ErrTaskNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, _, svc := newTestAttachments(t, 16)
+			if _, err := svc.UploadAttachment(ctx, &tt.a, strings.NewReader(tt.content)); !errors.Is(err, tt.want) {
+				t.Fatalf("UploadAttachment: err = %v, want %v", err, tt.want)
+			}
+			if len(repo.attachments) != 0 {
+				t.Fatalf("attachment metadata saved for rejected upload")
+			}
+		})
+	}
+}
+
+func TestUploadAttachmentDeletesOrphanBlob(t *testing.T) {
+	ctx := context.Background()
+	repo, store, svc := newTestAttachments(t, 1<<10)
+	repo.createErr = errors.New("db is down")
+
+	var put string
+	svc.(*attachmentsService).store = recordingStore{Store: store, put: &put}
+	if _, err := svc.UploadAttachment(ctx, &domain.Attachment{TaskID: 1, FileName: "a"}, strings.NewReader("x")); err == nil {
+		t.Fatal("UploadAttachment succeeded without metadata")
+	}
+	if put == "" {
+		t.Fatal("content was not stored")
+	}
+	if _, err := store.Get(ctx, put); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("orphan blob: err = %v, want ErrNotFound", err)
+	}
+}
+
+// recordingStore запоминает ключ последнего сохраненного объекта
+type recordingStore struct {
+	blob.Store
+	put *string
+}
+
+func (s recordingStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	*s.put = key
+	return s.Store.Put(ctx, key, r, size)
+}
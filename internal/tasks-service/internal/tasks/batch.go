@@ -0,0 +1,99 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+)
+
+// MaxBatchSize — наибольшее число элементов в пакетной операции
+const MaxBatchSize = 100
+
+// BatchResult — результат элемента пакета: задача после операции или ошибка
+type BatchResult struct {
+	Task *domain.Task
+	Err  error
+}
+
+// BatchItemError — элемент Index атомарного пакета не выполнен, пакет откатан
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("batch item %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// InTx выполняет fn с репозиторием, привязанным к одной транзакции.
+// Транзакции методов внутри fn становятся точками сохранения.
+func (r *taskRepo) InTx(fn func(repo TasksRepo) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&taskRepo{db: tx})
+	})
+}
+
+// BatchCreateTasks создает задачи пакетом
+func (s *tasksService) BatchCreateTasks(tasks []*domain.Task, mode domain.BatchMode) ([]BatchResult, error) {
+	return s.runBatch(len(tasks), mode, func(svc TasksService, i int) (*domain.Task, error) {
+		return svc.CreateTask(tasks[i])
+	})
+}
+
+// BatchUpdateTasks меняет задачи пакетом, у каждой проверяется своя версия
+func (s *tasksService) BatchUpdateTasks(updates []domain.BatchUpdate, mode domain.BatchMode) ([]BatchResult, error) {
+	return s.runBatch(len(updates), mode, func(svc TasksService, i int) (*domain.Task, error) {
+		return svc.UpdateTask(updates[i].ID, updates[i].Version, updates[i].Update)
+	})
+}
+
+// BatchDeleteTasks удаляет задачи пакетом
+func (s *tasksService) BatchDeleteTasks(deletes []domain.BatchDelete, mode domain.BatchMode) ([]BatchResult, error) {
+	return s.runBatch(len(deletes), mode, func(svc TasksService, i int) (*domain.Task, error) {
+		return nil, svc.DeleteTask(deletes[i].ID, deletes[i].Children)
+	})
+}
+
+// runBatch выполняет n элементов пакета. В атомарном режиме элементы идут в одной
+// транзакции и первая ошибка возвращается как *BatchItemError; в режиме best effort
+// каждый элемент выполняется отдельно, ошибки попадают в результаты.
+func (s *tasksService) runBatch(n int, mode domain.BatchMode, item func(svc TasksService, i int) (*domain.Task, error)) ([]BatchResult, error) {
+	if n == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if n > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	results := make([]BatchResult, n)
+	if mode == domain.BatchBestEffort {
+		for i := range results {
+			t, err := item(s, i)
+			results[i] = BatchResult{Task: t, Err: err}
+		}
+		return results, nil
+	}
+
+	err := s.repo.InTx(func(repo TasksRepo) error {
+		svc := &tasksService{repo: repo, broadcaster: s.broadcaster, store: s.store}
+		for i := range results {
+			t, err := item(svc, i)
+			if err != nil {
+				return &BatchItemError{Index: i, Err: err}
+			}
+			results[i].Task = t
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// элементы будили подписчиков до фиксации транзакции
+	s.broadcaster.Notify()
+	return results, nil
+}
@@ -0,0 +1,77 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+// txRepo считает транзакции InTx; fn получает тот же репозиторий
+type txRepo struct {
+	TasksRepo
+	txs int
+}
+
+func (r *txRepo) InTx(fn func(repo TasksRepo) error) error {
+	r.txs++
+	return fn(r)
+}
+
+func TestRunBatch(t *testing.T) {
+	errItem := errors.New("item failed")
+
+	tests := []struct {
+		name    string
+		n       int
+		mode    domain.BatchMode
+		fail    map[int]bool
+		wantErr error
+		// wantIndex — номер элемента в *BatchItemError
+		wantIndex int
+		wantTxs   int
+		wantRuns  int
+	}{
+		{name: "atomic", n: 3, mode: domain.BatchAtomic, wantTxs: 1, wantRuns: 3},
+		{name: "atomic stops at the first error", n: 4, mode: domain.BatchAtomic, fail: map[int]bool{1: true, 3: true},
+			wantErr: errItem, wantIndex: 1, wantTxs: 1, wantRuns: 2},
+		{name: "best effort runs every item", n: 4, mode: domain.BatchBestEffort, fail: map[int]bool{1: true, 3: true},
+			wantRuns: 4},
+		{name: "empty", n: 0, mode: domain.BatchAtomic, wantErr: ErrEmptyBatch},
+		{name: "too large", n: MaxBatchSize + 1, mode: domain.BatchBestEffort, wantErr: ErrBatchTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &txRepo{}
+			s := &tasksService{repo: repo, broadcaster: NewBroadcaster(repo)}
+
+			runs := 0
+			results, err := s.runBatch(tt.n, tt.mode, func(svc TasksService, i int) (*domain.Task, error) {
+				runs++
+				if tt.fail[i] {
+					return nil, errItem
+				}
+				return &domain.Task{ID: uint32(i + 1)}, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("runBatch() = %v, want %v", err, tt.wantErr)
+			}
+			if repo.txs != tt.wantTxs || runs != tt.wantRuns {
+				t.Errorf("transactions %d, items run %d; want %d, %d", repo.txs, runs, tt.wantTxs, tt.wantRuns)
+			}
+
+			var itemErr *BatchItemError
+			if errors.As(err, &itemErr) && itemErr.Index != tt.wantIndex {
+				t.Errorf("failed item = %d, want %d", itemErr.Index, tt.wantIndex)
+			}
+			if err != nil {
+				return
+			}
+			for i, r := range results {
+				if tt.fail[i] != (r.Err != nil) || (r.Err == nil) != (r.Task != nil && r.Task.ID == uint32(i+1)) {
+					t.Errorf("result %d = %+v", i, r)
+				}
+			}
+		})
+	}
+}
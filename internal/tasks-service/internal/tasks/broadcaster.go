@@ -0,0 +1,230 @@
+package tasks
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+const (
+	eventsBatchSize    = 500
+	subscriptionBuffer = 64
+	// страховочный опрос журнала на случай потерянного NOTIFY
+	pollInterval = 5 * time.Second
+	// cursorsPerQuery — сколько пользователей читается из журнала одним запросом
+	cursorsPerQuery = 1000
+)
+
+// Broadcaster раздает события изменения задач подписчикам WatchTasks.
+// Единственный источник событий — журнал task_events: по каждому сигналу
+// (локальная мутация или NOTIFY с другой реплики) Broadcaster дочитывает журнал
+// каждого пользователя с подписками от его курсора, поэтому подписчики получают
+// события по порядку и без дублей.
+type Broadcaster struct {
+	repo TasksRepo
+	wake chan struct{}
+
+	mu     sync.Mutex
+	nextID uint64
+	subs   map[uint64]*Subscription
+	// cursors — ревизия последнего разданного события каждого пользователя с подписками.
+	// Ревизии растут в пределах пользователя, общего порядка событий нет.
+	cursors map[uint32]uint64
+}
+
+func NewBroadcaster(repo TasksRepo) *Broadcaster {
+	return &Broadcaster{
+		repo:    repo,
+		wake:    make(chan struct{}, 1),
+		subs:    make(map[uint64]*Subscription),
+		cursors: make(map[uint32]uint64),
+	}
+}
+
+// Subscription — подписка на события задач одного пользователя
+type Subscription struct {
+	id     uint64
+	userID uint32
+	events chan *domain.TaskEvent
+	b      *Broadcaster
+	err    error
+}
+
+// Events возвращает канал событий. Канал закрывается, если подписчик не успевает
+// читать события (тогда Err вернет ErrWatchLagging) или Broadcaster остановлен.
+func (s *Subscription) Events() <-chan *domain.TaskEvent {
+	return s.events
+}
+
+// Err возвращает причину закрытия канала событий
+func (s *Subscription) Err() error {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+
+	return s.err
+}
+
+// Close отписывается от событий
+func (s *Subscription) Close() {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+
+	if _, ok := s.b.subs[s.id]; ok {
+		s.b.remove(s)
+	}
+}
+
+// Subscribe подписывается на события задач пользователя userID. Подписка получает
+// события, записанные после её создания.
+func (b *Broadcaster) Subscribe(userID uint32) (*Subscription, error) {
+	b.mu.Lock()
+	_, tracked := b.cursors[userID]
+	b.mu.Unlock()
+
+	var last uint64
+	if !tracked {
+		var err error
+		if last, err = b.repo.LastEventRevision(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.cursors[userID]; !ok {
+		b.cursors[userID] = last
+	}
+	b.nextID++
+	sub := &Subscription{
+		id:     b.nextID,
+		userID: userID,
+		events: make(chan *domain.TaskEvent, subscriptionBuffer),
+		b:      b,
+	}
+	b.subs[sub.id] = sub
+
+	return sub, nil
+}
+
+// Notify сообщает, что в журнале появились новые события. Не блокируется.
+func (b *Broadcaster) Notify() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run читает журнал и раздает события до отмены ctx
+func (b *Broadcaster) Run(ctx context.Context) {
+	defer b.closeAll()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.wake:
+		case <-ticker.C:
+		}
+
+		b.drain()
+	}
+}
+
+// drain раздает все события пользователей с подписками после их курсоров
+func (b *Broadcaster) drain() {
+	b.mu.Lock()
+	userIDs := make([]uint32, 0, len(b.cursors))
+	for userID := range b.cursors {
+		userIDs = append(userIDs, userID)
+	}
+	b.mu.Unlock()
+
+	for len(userIDs) > 0 {
+		n := min(len(userIDs), cursorsPerQuery)
+		b.drainUsers(userIDs[:n])
+		userIDs = userIDs[n:]
+	}
+}
+
+func (b *Broadcaster) drainUsers(userIDs []uint32) {
+	for {
+		b.mu.Lock()
+		cursors := make(map[uint32]uint64, len(userIDs))
+		for _, userID := range userIDs {
+			if cursor, ok := b.cursors[userID]; ok {
+				cursors[userID] = cursor
+			}
+		}
+		b.mu.Unlock()
+
+		batch, err := b.repo.ListEventsAfter(cursors, eventsBatchSize)
+		if err != nil {
+			log.Printf("broadcaster: %v", err)
+			return
+		}
+
+		for _, ev := range batch {
+			b.dispatch(ev)
+		}
+
+		if len(batch) < eventsBatchSize {
+			return
+		}
+	}
+}
+
+func (b *Broadcaster) dispatch(ev *domain.TaskEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	userID := ev.Task.UserID
+	// пользователь мог отписаться и подписаться заново с более новым курсором
+	if cursor, ok := b.cursors[userID]; !ok || ev.Revision <= cursor {
+		return
+	}
+	b.cursors[userID] = ev.Revision
+
+	for _, sub := range b.subs {
+		if sub.userID != userID {
+			continue
+		}
+
+		select {
+		case sub.events <- ev:
+		default:
+			// медленный подписчик отключается и переподключается с последней ревизии
+			sub.err = ErrWatchLagging
+			b.remove(sub)
+		}
+	}
+}
+
+// remove закрывает подписку и забывает курсор пользователя без подписок.
+// Вызывается под b.mu.
+func (b *Broadcaster) remove(sub *Subscription) {
+	delete(b.subs, sub.id)
+	close(sub.events)
+
+	for _, other := range b.subs {
+		if other.userID == sub.userID {
+			return
+		}
+	}
+	delete(b.cursors, sub.userID)
+}
+
+func (b *Broadcaster) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		b.remove(sub)
+	}
+}
@@ -0,0 +1,201 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+// eventLog — журнал task_events в памяти
+type eventLog struct {
+	TasksRepo
+
+	mu     sync.Mutex
+	events []*domain.TaskEvent
+	// onReplay вызывается при первом чтении журнала подпиской
+	onReplay func()
+}
+
+func (l *eventLog) append(userID uint32) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var last uint64
+	for _, ev := range l.events {
+		if ev.Task.UserID == userID {
+			last = ev.Revision
+		}
+	}
+	l.events = append(l.events, &domain.TaskEvent{Revision: last + 1, Type: "updated", Task: domain.Task{UserID: userID}})
+}
+
+func (l *eventLog) LastEventRevision(userID uint32) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var last uint64
+	for _, ev := range l.events {
+		if ev.Task.UserID == userID {
+			last = ev.Revision
+		}
+	}
+	return last, nil
+}
+
+func (l *eventLog) ListEventsSince(userID uint32, revision uint64, limit int) ([]*domain.TaskEvent, error) {
+	if l.onReplay != nil {
+		l.onReplay()
+		l.onReplay = nil
+	}
+	return l.ListEventsAfter(map[uint32]uint64{userID: revision}, limit)
+}
+
+func (l *eventLog) ListEventsAfter(cursors map[uint32]uint64, limit int) ([]*domain.TaskEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []*domain.TaskEvent
+	for _, ev := range l.events {
+		if cursor, ok := cursors[ev.Task.UserID]; ok && ev.Revision > cursor && len(out) < limit {
+			out = append(out, ev)
+		}
+	}
+	return out, nil
+}
+
+// waitSubscribed ждет, пока у b появится n подписок
+func waitSubscribed(t *testing.T, b *Broadcaster, n int) {
+	t.Helper()
+	for range 1000 {
+		b.mu.Lock()
+		got := len(b.subs)
+		b.mu.Unlock()
+		if got == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("no %d subscriptions", n)
+}
+
+func TestWatchTasksReplayThenLive(t *testing.T) {
+	tests := []struct {
+		name         string
+		fromRevision uint64
+		// concurrent — событие пишется между подпиской и чтением журнала
+		concurrent bool
+		want       []uint64
+	}{
+		{name: "live only", fromRevision: 0, want: []uint64{5, 6}},
+		{name: "replay then live", fromRevision: 2, want: []uint64{3, 4, 5, 6}},
+		{name: "up to date", fromRevision: 4, want: []uint64{5, 6}},
+		{name: "written during replay", fromRevision: 3, concurrent: true, want: []uint64{4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &eventLog{}
+			for range 4 {
+				repo.append(1)
+				repo.append(2)
+			}
+			b := NewBroadcaster(repo)
+			if tt.concurrent {
+				// событие попадет и в журнал подписки, и в живые события
+				repo.onReplay = func() {
+					repo.append(1)
+					b.drain()
+				}
+			}
+			s := NewTasksService(repo, b, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			got := make(chan uint64, 16)
+			done := make(chan error, 1)
+			go func() {
+				done <- s.WatchTasks(ctx, 1, tt.fromRevision, func(ev *domain.TaskEvent) error {
+					if ev.Task.UserID != 1 {
+						t.Errorf("event of user %d", ev.Task.UserID)
+					}
+					got <- ev.Revision
+					return nil
+				})
+			}()
+			waitSubscribed(t, b, 1)
+
+			// живые события обоих пользователей
+			for range 2 {
+				repo.append(2)
+				repo.append(1)
+			}
+			b.drain()
+
+			var revisions []uint64
+			for len(revisions) < len(tt.want) {
+				select {
+				case r := <-got:
+					revisions = append(revisions, r)
+				case <-time.After(time.Second):
+					t.Fatalf("got %v, want %v", revisions, tt.want)
+				}
+			}
+			cancel()
+			if err := <-done; !errors.Is(err, context.Canceled) {
+				t.Errorf("WatchTasks() = %v", err)
+			}
+			// дублей после ожидаемых событий нет
+			close(got)
+			for r := range got {
+				revisions = append(revisions, r)
+			}
+			if !slices.Equal(revisions, tt.want) {
+				t.Errorf("revisions %v, want %v", revisions, tt.want)
+			}
+		})
+	}
+}
+
+func TestBroadcasterDropsLaggingSubscriber(t *testing.T) {
+	repo := &eventLog{}
+	b := NewBroadcaster(repo)
+
+	slow, err := b.Subscribe(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := b.Subscribe(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range subscriptionBuffer + 1 {
+		repo.append(1)
+	}
+	repo.append(2)
+	b.drain()
+
+	n := 0
+	for range slow.Events() {
+		n++
+	}
+	if n != subscriptionBuffer || !errors.Is(slow.Err(), ErrWatchLagging) {
+		t.Errorf("slow subscriber got %d events, Err() = %v", n, slow.Err())
+	}
+
+	// подписки других пользователей не затронуты
+	select {
+	case ev := <-other.Events():
+		if ev.Revision != 1 || other.Err() != nil {
+			t.Errorf("other subscriber got %d, Err() = %v", ev.Revision, other.Err())
+		}
+	default:
+		t.Error("other subscriber got no events")
+	}
+	other.Close()
+}
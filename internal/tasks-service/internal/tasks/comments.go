@@ -0,0 +1,209 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxCommentLen = 10000
+
+	defaultCommentsPage = 50
+	maxCommentsPage     = 200
+)
+
+// CreateComment добавляет комментарий к задаче
+func (r *taskRepo) CreateComment(dm *domain.Comment) (*domain.Comment, error) {
+	comment := Comment{TaskID: dm.TaskID, AuthorID: dm.AuthorID, Body: dm.Body}
+	if err := r.db.Create(&comment).Error; err != nil {
+		return nil, fmt.Errorf("CreateComment: failed to create comment: %w", err)
+	}
+
+	return comment.toDomain(), nil
+}
+
+// GetComment возвращает неудаленный комментарий по id
+func (r *taskRepo) GetComment(id uint32) (*domain.Comment, error) {
+	var comment Comment
+	if err := r.db.First(&comment, uint(id)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("GetComment: failed to find comment: %w", err)
+	}
+
+	return comment.toDomain(), nil
+}
+
+// EditComment меняет текст комментария автора authorID и сохраняет прежний текст в истории
+func (r *taskRepo) EditComment(id, authorID uint32, body string) (*domain.Comment, error) {
+	var comment Comment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&comment, uint(id)).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCommentNotFound
+			}
+			return fmt.Errorf("failed to find comment: %w", err)
+		}
+		if comment.AuthorID != authorID {
+			return ErrNotCommentAuthor
+		}
+		if comment.Body == body {
+			return nil
+		}
+
+		now := time.Now()
+		if err := tx.Create(&CommentEdit{CommentID: id, Body: comment.Body, EditedAt: now}).Error; err != nil {
+			return fmt.Errorf("failed to save comment history: %w", err)
+		}
+		if err := tx.Model(&comment).Updates(map[string]any{"body": body, "edited_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		comment.Body, comment.EditedAt = body, &now
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("EditComment: %w", err)
+	}
+
+	return comment.toDomain(), nil
+}
+
+// DeleteComment мягко удаляет комментарий автора authorID
+func (r *taskRepo) DeleteComment(id, authorID uint32) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var comment Comment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&comment, uint(id)).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCommentNotFound
+			}
+			return fmt.Errorf("failed to find comment: %w", err)
+		}
+		if comment.AuthorID != authorID {
+			return ErrNotCommentAuthor
+		}
+		if err := tx.Delete(&comment).Error; err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteComment: %w", err)
+	}
+
+	return nil
+}
+
+// ListComments возвращает до limit комментариев задачи с id больше afterID, от старых к новым
+func (r *taskRepo) ListComments(taskID, afterID uint32, limit int) ([]*domain.Comment, error) {
+	var comments []Comment
+	if err := r.db.Where("task_id = ? AND id > ?", taskID, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("ListComments: failed to get comments: %w", err)
+	}
+
+	out := make([]*domain.Comment, len(comments))
+	for i := range comments {
+		out[i] = comments[i].toDomain()
+	}
+
+	return out, nil
+}
+
+// ListCommentEdits возвращает историю правок комментария от старых к новым
+func (r *taskRepo) ListCommentEdits(commentID uint32) ([]*domain.CommentEdit, error) {
+	var edits []CommentEdit
+	if err := r.db.Where("comment_id = ?", commentID).Order("id").Find(&edits).Error; err != nil {
+		return nil, fmt.Errorf("ListCommentEdits: failed to get comment history: %w", err)
+	}
+
+	out := make([]*domain.CommentEdit, len(edits))
+	for i, e := range edits {
+		out[i] = &domain.CommentEdit{Body: e.Body, EditedAt: e.EditedAt}
+	}
+
+	return out, nil
+}
+
+// normalizeCommentBody обрезает пробелы по краям и проверяет длину комментария
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLen {
+		return "", ErrInvalidComment
+	}
+	return body, nil
+}
+
+// CreateComment добавляет комментарий к существующей задаче.
+// Существование автора проверяет транспорт через users-service.
+func (s *tasksService) CreateComment(c *domain.Comment) (*domain.Comment, error) {
+	body, err := normalizeCommentBody(c.Body)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(c.TaskID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateComment(&domain.Comment{TaskID: c.TaskID, AuthorID: c.AuthorID, Body: body})
+}
+
+// EditComment меняет текст комментария, прежний текст попадает в историю правок
+func (s *tasksService) EditComment(id, authorID uint32, body string) (*domain.Comment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.EditComment(id, authorID, body)
+}
+
+// DeleteComment мягко удаляет комментарий, история правок сохраняется
+func (s *tasksService) DeleteComment(id, authorID uint32) error {
+	return s.repo.DeleteComment(id, authorID)
+}
+
+// ListComments возвращает страницу комментариев задачи после комментария afterID
+// и id, с которого начнется следующая страница (0 — страниц больше нет)
+func (s *tasksService) ListComments(taskID, afterID uint32, pageSize int) ([]*domain.Comment, uint32, error) {
+	if pageSize <= 0 {
+		pageSize = defaultCommentsPage
+	}
+	if pageSize > maxCommentsPage {
+		pageSize = maxCommentsPage
+	}
+
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, 0, err
+	}
+
+	// берем на один больше, чтобы знать, есть ли следующая страница
+	comments, err := s.repo.ListComments(taskID, afterID, pageSize+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(comments) <= pageSize {
+		return comments, 0, nil
+	}
+
+	comments = comments[:pageSize]
+	return comments, comments[pageSize-1].ID, nil
+}
+
+// GetCommentHistory возвращает прежние версии текста неудаленного комментария
+func (s *tasksService) GetCommentHistory(id uint32) ([]*domain.CommentEdit, error) {
+	if _, err := s.repo.GetComment(id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListCommentEdits(id)
+}
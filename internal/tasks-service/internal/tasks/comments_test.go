@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+func TestNormalizeCommentBody(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "готово", want: "готово"},
+		{in: "\n  купить молоко \n", want: "купить молоко"},
+		{in: strings.Repeat("ж", maxCommentLen), want: strings.Repeat("ж", maxCommentLen)},
+		{in: strings.Repeat("ж", maxCommentLen+1), wantErr: true},
+		{in: "  ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeCommentBody(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("normalizeCommentBody(%.20q) = %.20q, %v; want %.20q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// commentRepo — комментарии задачи 1 с id 1..n в памяти
+type commentRepo struct {
+	TasksRepo
+	n int
+}
+
+func (r *commentRepo) GetByID(id uint32) (*domain.Task, error) {
+	if id != 1 {
+		return nil, ErrTaskNotFound
+	}
+	return &domain.Task{ID: 1}, nil
+}
+
+func (r *commentRepo) ListComments(taskID, afterID uint32, limit int) ([]*domain.Comment, error) {
+	var out []*domain.Comment
+	for id := afterID + 1; id <= uint32(r.n) && len(out) < limit; id++ {
+		out = append(out, &domain.Comment{ID: id, TaskID: taskID})
+	}
+	return out, nil
+}
+
+func TestListCommentsPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		afterID  uint32
+		pageSize int
+		wantIDs  []uint32
+		wantNext uint32
+	}{
+		{name: "first page", total: 5, pageSize: 2, wantIDs: []uint32{1, 2}, wantNext: 2},
+		{name: "middle page", total: 5, afterID: 2, pageSize: 2, wantIDs: []uint32{3, 4}, wantNext: 4},
+		{name: "last page", total: 5, afterID: 4, pageSize: 2, wantIDs: []uint32{5}},
+		{name: "exactly one page", total: 4, afterID: 2, pageSize: 2, wantIDs: []uint32{3, 4}},
+		{name: "default size", total: defaultCommentsPage + 1, wantNext: defaultCommentsPage},
+		{name: "size capped", total: maxCommentsPage + 10, pageSize: maxCommentsPage + 5, wantNext: maxCommentsPage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTasksService(&commentRepo{n: tt.total}, nil, nil)
+			comments, next, err := s.ListComments(1, tt.afterID, tt.pageSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next != tt.wantNext {
+				t.Errorf("next = %d, want %d", next, tt.wantNext)
+			}
+			if tt.wantIDs == nil {
+				return
+			}
+			var ids []uint32
+			for _, c := range comments {
+				ids = append(ids, c.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	s := NewTasksService(&commentRepo{}, nil, nil)
+	if _, _, err := s.ListComments(2, 0, 10); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("ListComments of a missing task = %v, want ErrTaskNotFound", err)
+	}
+}
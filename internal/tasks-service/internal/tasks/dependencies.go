@@ -0,0 +1,291 @@
+package tasks
+
+import (
+	"container/heap"
+	"fmt"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dependenciesLockKey — ключ advisory lock изменений графа зависимостей пользователя.
+// Без него две встречные зависимости (A ждет B и B ждет A) прошли бы проверку цикла одновременно.
+const dependenciesLockKey = 28_003
+
+// AddDependency отмечает, что taskID заблокирована blockerID, и возвращает задачу.
+// Повторное добавление той же зависимости ничего не меняет.
+func (r *taskRepo) AddDependency(taskID, blockerID uint32) (*domain.Task, error) {
+	task, err := r.changeDependencies(taskID, blockerID, func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&TaskDependency{TaskID: taskID, BlockerID: blockerID})
+	}, true)
+	if err != nil {
+		return nil, fmt.Errorf("AddDependency: %w", err)
+	}
+
+	return task, nil
+}
+
+// RemoveDependency снимает блокировку taskID задачей blockerID и возвращает задачу
+func (r *taskRepo) RemoveDependency(taskID, blockerID uint32) (*domain.Task, error) {
+	task, err := r.changeDependencies(taskID, blockerID, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("task_id = ? AND blocker_id = ?", taskID, blockerID).Delete(&TaskDependency{})
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("RemoveDependency: %w", err)
+	}
+
+	return task, nil
+}
+
+// changeDependencies применяет change к зависимостям задачи под блокировкой графа
+// пользователя и, если зависимости изменились, пишет событие обновления задачи
+func (r *taskRepo) changeDependencies(taskID, blockerID uint32, change func(tx *gorm.DB) *gorm.DB, checkCycle bool) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var pair []Task
+		if err := tx.Where("id IN ?", []uint32{taskID, blockerID}).Find(&pair).Error; err != nil {
+			return fmt.Errorf("failed to find tasks: %w", err)
+		}
+		if len(pair) != 2 {
+			return ErrTaskNotFound
+		}
+		if pair[0].UserID != pair[1].UserID {
+			return ErrDependencyOwnerMismatch
+		}
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", dependenciesLockKey, int32(pair[0].UserID)).Error; err != nil {
+			return fmt.Errorf("failed to lock task dependencies: %w", err)
+		}
+
+		if checkCycle {
+			// цикл появится, если blockerID уже ждет taskID, напрямую или через другие задачи
+			var cycle bool
+			err := tx.Raw(`
+				WITH RECURSIVE chain AS (
+					SELECT blocker_id AS id FROM task_dependencies WHERE task_id = ?
+					UNION
+					SELECT d.blocker_id FROM task_dependencies d JOIN chain c ON d.task_id = c.id
+				)
+				SELECT EXISTS (SELECT 1 FROM chain WHERE id = ?)`, blockerID, taskID).
+				Scan(&cycle).Error
+			if err != nil {
+				return fmt.Errorf("failed to check task dependencies: %w", err)
+			}
+			if cycle {
+				return ErrDependencyCycle
+			}
+		}
+
+		res := change(tx)
+		if res.Error != nil {
+			return fmt.Errorf("failed to change task dependencies: %w", res.Error)
+		}
+
+		for i := range pair {
+			if uint32(pair[i].ID) == taskID {
+				task = pair[i].toDomain()
+			}
+		}
+		if err := loadRelations(tx, task); err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		return appendEvents(tx, domain.TaskUpdated, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// checkOpenBlockers не дает завершить задачу, пока её блокирующие задачи не выполнены
+// и не отменены. Удаленные блокирующие задачи не учитываются.
+func checkOpenBlockers(tx *gorm.DB, taskID uint32) error {
+	var count int64
+	err := tx.Model(&Task{}).
+		Where("id IN (?)", tx.Model(&TaskDependency{}).Select("blocker_id").Where("task_id = ?", taskID)).
+		Where("status NOT IN ?", closedStatuses).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to count blockers: %w", err)
+	}
+	if count > 0 {
+		return ErrOpenBlockers
+	}
+
+	return nil
+}
+
+// ListDependencies возвращает все зависимости между задачами пользователя
+func (r *taskRepo) ListDependencies(userID uint32) ([]domain.Dependency, error) {
+	var deps []TaskDependency
+	err := r.db.Where("task_id IN (?)", r.db.Model(&Task{}).Select("id").Where("user_id = ?", userID)).
+		Find(&deps).Error
+	if err != nil {
+		return nil, fmt.Errorf("ListDependencies: failed to get dependencies: %w", err)
+	}
+
+	out := make([]domain.Dependency, len(deps))
+	for i, d := range deps {
+		out[i] = domain.Dependency{TaskID: d.TaskID, BlockerID: d.BlockerID}
+	}
+
+	return out, nil
+}
+
+// loadBlockerIDs заполняет BlockerIDs задач одним запросом
+func loadBlockerIDs(db *gorm.DB, tasks ...*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[uint32]*domain.Task, len(tasks))
+	ids := make([]uint32, 0, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+		ids = append(ids, t.ID)
+	}
+
+	// удаленные блокирующие задачи лежат в корзине и задачу не блокируют
+	var deps []TaskDependency
+	if err := db.Where("task_id IN ?", ids).
+		Where("blocker_id IN (?)", db.Model(&Task{}).Select("id")).
+		Order("blocker_id").Find(&deps).Error; err != nil {
+		return fmt.Errorf("failed to load task dependencies: %w", err)
+	}
+
+	for _, d := range deps {
+		t := byID[d.TaskID]
+		t.BlockerIDs = append(t.BlockerIDs, d.BlockerID)
+	}
+
+	return nil
+}
+
+// loadRelations заполняет теги, блокирующие задачи и правила повторения
+func loadRelations(db *gorm.DB, tasks ...*domain.Task) error {
+	if err := loadTagIDs(db, tasks...); err != nil {
+		return err
+	}
+	if err := loadBlockerIDs(db, tasks...); err != nil {
+		return err
+	}
+	return loadSeriesRules(db, tasks...)
+}
+
+// AddDependency отмечает, что задача taskID ждет выполнения blockerID
+func (s *tasksService) AddDependency(taskID, blockerID uint32) (*domain.Task, error) {
+	if taskID == blockerID {
+		return nil, ErrDependencyCycle
+	}
+
+	task, err := s.repo.AddDependency(taskID, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return task, nil
+}
+
+// RemoveDependency снимает зависимость задачи taskID от blockerID
+func (s *tasksService) RemoveDependency(taskID, blockerID uint32) (*domain.Task, error) {
+	task, err := s.repo.RemoveDependency(taskID, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return task, nil
+}
+
+// GetPlan возвращает открытые задачи пользователя в порядке выполнения:
+// каждая задача идет после своих открытых блокирующих задач, из доступных
+// первой берется более приоритетная, затем с более ранним сроком.
+func (s *tasksService) GetPlan(userID uint32) ([]*domain.Task, error) {
+	all, err := s.repo.ListTasksByUser(userID, domain.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	deps, err := s.repo.ListDependencies(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	open := make(map[uint32]*domain.Task, len(all))
+	for _, t := range all {
+		if t.Status != domain.StatusDone && t.Status != domain.StatusCanceled {
+			open[t.ID] = t
+		}
+	}
+
+	// закрытые блокирующие задачи уже не мешают, учитываем только ребра между открытыми
+	waiting := make(map[uint32]int, len(open))
+	blocks := make(map[uint32][]uint32)
+	for _, d := range deps {
+		if open[d.TaskID] == nil || open[d.BlockerID] == nil {
+			continue
+		}
+		waiting[d.TaskID]++
+		blocks[d.BlockerID] = append(blocks[d.BlockerID], d.TaskID)
+	}
+
+	ready := &planQueue{}
+	for id, t := range open {
+		if waiting[id] == 0 {
+			heap.Push(ready, t)
+		}
+	}
+
+	plan := make([]*domain.Task, 0, len(open))
+	for ready.Len() > 0 {
+		t := heap.Pop(ready).(*domain.Task)
+		plan = append(plan, t)
+		for _, next := range blocks[t.ID] {
+			waiting[next]--
+			if waiting[next] == 0 {
+				heap.Push(ready, open[next])
+			}
+		}
+	}
+
+	if len(plan) != len(open) {
+		return nil, fmt.Errorf("GetPlan: %w", ErrDependencyCycle)
+	}
+
+	return plan, nil
+}
+
+// planQueue — очередь готовых к выполнению задач: приоритет, срок, id
+type planQueue []*domain.Task
+
+func (q planQueue) Len() int { return len(q) }
+
+func (q planQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	switch {
+	case a.DueAt != nil && b.DueAt != nil && !a.DueAt.Equal(*b.DueAt):
+		return a.DueAt.Before(*b.DueAt)
+	case a.DueAt != nil && b.DueAt == nil:
+		return true
+	case a.DueAt == nil && b.DueAt != nil:
+		return false
+	}
+	return a.ID < b.ID
+}
+
+func (q planQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *planQueue) Push(x any) { *q = append(*q, x.(*domain.Task)) }
+
+func (q *planQueue) Pop() any {
+	old := *q
+	t := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return t
+}
@@ -0,0 +1,122 @@
+package tasks
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+// planRepo — задачи и зависимости одного пользователя в памяти
+type planRepo struct {
+	TasksRepo
+	tasks []*domain.Task
+	deps  []domain.Dependency
+}
+
+func (r *planRepo) ListTasksByUser(userID uint32, filter domain.TaskFilter) ([]*domain.Task, error) {
+	return r.tasks, nil
+}
+
+func (r *planRepo) ListDependencies(userID uint32) ([]domain.Dependency, error) {
+	return r.deps, nil
+}
+
+func TestGetPlan(t *testing.T) {
+	soon := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	later := soon.Add(24 * time.Hour)
+	task := func(id uint32, status domain.TaskStatus, priority domain.Priority, due *time.Time) *domain.Task {
+		return &domain.Task{ID: id, Status: status, Priority: priority, DueAt: due}
+	}
+	dep := func(taskID, blockerID uint32) domain.Dependency {
+		return domain.Dependency{TaskID: taskID, BlockerID: blockerID}
+	}
+
+	tests := []struct {
+		name    string
+		tasks   []*domain.Task
+		deps    []domain.Dependency
+		want    []uint32
+		wantErr error
+	}{
+		{
+			name: "priority, then due date, then id",
+			tasks: []*domain.Task{
+				task(1, domain.StatusTodo, domain.PriorityLow, nil),
+				task(2, domain.StatusTodo, domain.PriorityHigh, &later),
+				task(3, domain.StatusTodo, domain.PriorityHigh, &soon),
+				task(4, domain.StatusTodo, domain.PriorityHigh, nil),
+				task(5, domain.StatusTodo, domain.PriorityLow, nil),
+			},
+			want: []uint32{3, 2, 4, 1, 5},
+		},
+		{
+			name: "blockers first",
+			tasks: []*domain.Task{
+				task(1, domain.StatusTodo, domain.PriorityUrgent, nil),
+				task(2, domain.StatusTodo, domain.PriorityLow, nil),
+				task(3, domain.StatusTodo, domain.PriorityMedium, nil),
+			},
+			// 1 ждет 2, 2 ждет 3
+			deps: []domain.Dependency{dep(1, 2), dep(2, 3)},
+			want: []uint32{3, 2, 1},
+		},
+		{
+			name: "closed tasks and their edges are skipped",
+			tasks: []*domain.Task{
+				task(1, domain.StatusTodo, domain.PriorityLow, nil),
+				task(2, domain.StatusDone, domain.PriorityHigh, nil),
+				task(3, domain.StatusCanceled, domain.PriorityHigh, nil),
+				task(4, domain.StatusBlocked, domain.PriorityHigh, nil),
+			},
+			deps: []domain.Dependency{dep(1, 2), dep(4, 3)},
+			want: []uint32{4, 1},
+		},
+		{
+			name: "cycle",
+			tasks: []*domain.Task{
+				task(1, domain.StatusTodo, domain.PriorityLow, nil),
+				task(2, domain.StatusTodo, domain.PriorityLow, nil),
+				task(3, domain.StatusTodo, domain.PriorityLow, nil),
+				task(4, domain.StatusTodo, domain.PriorityLow, nil),
+			},
+			deps:    []domain.Dependency{dep(1, 2), dep(2, 3), dep(3, 1)},
+			wantErr: ErrDependencyCycle,
+		},
+		{
+			name: "cycle through a closed task is broken",
+			tasks: []*domain.Task{
+				task(1, domain.StatusTodo, domain.PriorityLow, nil),
+				task(2, domain.StatusDone, domain.PriorityLow, nil),
+			},
+			deps: []domain.Dependency{dep(1, 2), dep(2, 1)},
+			want: []uint32{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTasksService(&planRepo{tasks: tt.tasks, deps: tt.deps}, nil, nil)
+
+			plan, err := s.GetPlan(1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetPlan() error = %v, want %v", err, tt.wantErr)
+			}
+			var ids []uint32
+			for _, task := range plan {
+				ids = append(ids, task.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("GetPlan() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddDependencyOnItself(t *testing.T) {
+	s := NewTasksService(&planRepo{}, nil, nil)
+	if _, err := s.AddDependency(3, 3); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("AddDependency(3, 3) = %v, want ErrDependencyCycle", err)
+	}
+}
@@ -4,3 +4,45 @@ import "fmt"
 
 var ErrTaskNotFound = fmt.Errorf("task not found")
 var ErrInvalidInput = fmt.Errorf("task has no title")
+var ErrInvalidEvent = fmt.Errorf("event has no id or user id")
+var ErrWatchLagging = fmt.Errorf("watcher fell behind, resume from the last received revision")
+var ErrVersionConflict = fmt.Errorf("task was modified concurrently")
+var ErrInvalidReminder = fmt.Errorf("remind_at must not be after due_at")
+var ErrInvalidStatus = fmt.Errorf("unknown task status")
+var ErrInvalidPriority = fmt.Errorf("unknown task priority")
+var ErrInvalidTransition = fmt.Errorf("task status transition is not allowed")
+var ErrTagNotFound = fmt.Errorf("tag not found")
+var ErrTagExists = fmt.Errorf("tag with this name already exists")
+var ErrInvalidTagName = fmt.Errorf("tag name must be 1-64 characters")
+var ErrTagOwnerMismatch = fmt.Errorf("tag and task belong to different users")
+var ErrParentNotFound = fmt.Errorf("parent task not found")
+var ErrParentOwnerMismatch = fmt.Errorf("parent task belongs to another user")
+var ErrHierarchyCycle = fmt.Errorf("task cannot be moved under itself or its subtask")
+var ErrTaskHasChildren = fmt.Errorf("task has subtasks")
+var ErrDependencyCycle = fmt.Errorf("dependency would create a cycle")
+var ErrDependencyOwnerMismatch = fmt.Errorf("tasks of a dependency belong to different users")
+var ErrOpenBlockers = fmt.Errorf("task is blocked by open tasks")
+var ErrProjectNotFound = fmt.Errorf("project not found")
+var ErrInvalidProjectName = fmt.Errorf("project name must be 1-128 characters")
+var ErrProjectArchived = fmt.Errorf("project is archived")
+var ErrProjectOwnerMismatch = fmt.Errorf("project and task belong to different users")
+var ErrInvalidProjectOrder = fmt.Errorf("project order must list every project of the user once")
+var ErrInvalidMove = fmt.Errorf("neighbour tasks must be other tasks of the same list in order")
+var ErrCommentNotFound = fmt.Errorf("comment not found")
+var ErrInvalidComment = fmt.Errorf("comment body must be 1-10000 characters")
+var ErrNotCommentAuthor = fmt.Errorf("only the author can change the comment")
+var ErrAttachmentNotFound = fmt.Errorf("attachment not found")
+var ErrInvalidAttachment = fmt.Errorf("attachment needs a file name of 1-255 characters")
+var ErrAttachmentTooLarge = fmt.Errorf("attachment is too large")
+var ErrAttachmentSizeMismatch = fmt.Errorf("attachment size does not match the declared size")
+var ErrChecksumMismatch = fmt.Errorf("attachment checksum does not match")
+var ErrInvalidRecurrence = fmt.Errorf("invalid recurrence rule")
+var ErrRecurrenceOptions = fmt.Errorf("recurring task cannot have a parent, a reminder or a status other than todo")
+var ErrNotRecurring = fmt.Errorf("task is not an occurrence of a recurring series")
+var ErrSeriesEnded = fmt.Errorf("recurring series has ended")
+var ErrInvalidEditScope = fmt.Errorf("series edits may change only title, priority and rrule; rrule only for the series")
+var ErrTaskNotDeleted = fmt.Errorf("task is not in the trash")
+var ErrEmptyBatch = fmt.Errorf("batch has no items")
+var ErrBatchTooLarge = fmt.Errorf("batch has too many items")
+var ErrInvalidImport = fmt.Errorf("import file cannot be read")
+var ErrImportTooLarge = fmt.Errorf("import file has too many rows")
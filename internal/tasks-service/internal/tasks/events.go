@@ -0,0 +1,154 @@
+package tasks
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+)
+
+// EventsChannel — канал Postgres NOTIFY, в который пишется пользователь и ревизия
+// последнего нового события в виде "user_id:revision"
+const EventsChannel = "task_events"
+
+// eventsLockKey — первый ключ advisory lock записи событий, второй — id пользователя.
+// Транзакции, пишущие события одного пользователя, фиксируются по очереди, поэтому
+// его ревизии становятся видны строго по возрастанию и подписчик не пропускает
+// событие с меньшей ревизией. События разных пользователей пишутся параллельно.
+const eventsLockKey = 28_001
+
+// appendEvents пишет события изменения задач в журнал и историю в рамках транзакции tx.
+// NOTIFY доставляется слушателям только после фиксации транзакции.
+func appendEvents(tx *gorm.DB, eventType string, tasks ...*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	// напоминание не меняет задачу и в историю не попадает
+	if eventType != domain.TaskReminder {
+		if err := appendHistory(tx, eventType, tasks...); err != nil {
+			return err
+		}
+	}
+
+	byUser := make(map[uint32][]*domain.Task)
+	for _, t := range tasks {
+		byUser[t.UserID] = append(byUser[t.UserID], t)
+	}
+	// пользователи блокируются в одном порядке, чтобы транзакции не ждали друг друга по кругу
+	userIDs := make([]uint32, 0, len(byUser))
+	for userID := range byUser {
+		userIDs = append(userIDs, userID)
+	}
+	slices.Sort(userIDs)
+
+	for _, userID := range userIDs {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", eventsLockKey, int32(userID)).Error; err != nil {
+			return fmt.Errorf("failed to lock task events: %w", err)
+		}
+
+		var last uint64
+		if err := tx.Model(&TaskEvent{}).
+			Where("user_id = ?", userID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error; err != nil {
+			return fmt.Errorf("failed to get last revision: %w", err)
+		}
+
+		for _, t := range byUser[userID] {
+			ev, err := newTaskEvent(eventType, t)
+			if err != nil {
+				return fmt.Errorf("failed to build task event: %w", err)
+			}
+			last++
+			ev.Revision = last
+			if err := tx.Create(ev).Error; err != nil {
+				return fmt.Errorf("failed to append task event: %w", err)
+			}
+		}
+
+		payload := strconv.FormatUint(uint64(userID), 10) + ":" + strconv.FormatUint(last, 10)
+		if err := tx.Exec("SELECT pg_notify(?, ?)", EventsChannel, payload).Error; err != nil {
+			return fmt.Errorf("failed to notify task events: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListEventsSince возвращает до limit событий пользователя userID с ревизией больше
+// revision в порядке возрастания
+func (r *taskRepo) ListEventsSince(userID uint32, revision uint64, limit int) ([]*domain.TaskEvent, error) {
+	var rows []TaskEvent
+	if err := r.db.Where("user_id = ? AND revision > ?", userID, revision).
+		Order("revision").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("ListEventsSince: failed to get events: %w", err)
+	}
+
+	out, err := eventsToDomain(rows)
+	if err != nil {
+		return nil, fmt.Errorf("ListEventsSince: %w", err)
+	}
+	return out, nil
+}
+
+// ListEventsAfter возвращает до limit событий пользователей из cursors с ревизией больше
+// ревизии пользователя в cursors, по пользователям и по возрастанию ревизии
+func (r *taskRepo) ListEventsAfter(cursors map[uint32]uint64, limit int) ([]*domain.TaskEvent, error) {
+	if len(cursors) == 0 {
+		return nil, nil
+	}
+
+	values := make([]string, 0, len(cursors))
+	args := make([]any, 0, 2*len(cursors)+1)
+	for userID, revision := range cursors {
+		values = append(values, "(CAST(? AS integer), CAST(? AS bigint))")
+		args = append(args, userID, revision)
+	}
+	args = append(args, limit)
+
+	var rows []TaskEvent
+	if err := r.db.Raw(`SELECT e.* FROM task_events e
+		JOIN (VALUES `+strings.Join(values, ", ")+`) AS c (user_id, revision)
+			ON e.user_id = c.user_id AND e.revision > c.revision
+		ORDER BY e.user_id, e.revision
+		LIMIT ?`, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("ListEventsAfter: failed to get events: %w", err)
+	}
+
+	out, err := eventsToDomain(rows)
+	if err != nil {
+		return nil, fmt.Errorf("ListEventsAfter: %w", err)
+	}
+	return out, nil
+}
+
+// LastEventRevision возвращает ревизию последнего события пользователя или 0, если событий нет
+func (r *taskRepo) LastEventRevision(userID uint32) (uint64, error) {
+	var revision uint64
+	if err := r.db.Model(&TaskEvent{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&revision).Error; err != nil {
+		return 0, fmt.Errorf("LastEventRevision: %w", err)
+	}
+
+	return revision, nil
+}
+
+func eventsToDomain(rows []TaskEvent) ([]*domain.TaskEvent, error) {
+	out := make([]*domain.TaskEvent, 0, len(rows))
+	for i := range rows {
+		dm, err := rows[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, dm)
+	}
+	return out, nil
+}
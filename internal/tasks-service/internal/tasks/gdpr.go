@@ -0,0 +1,143 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/your-org/servicekit/idempotency"
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+)
+
+// UserData собирает все данные пользователя: задачи с корзиной, проекты, теги,
+// его комментарии и метаданные вложений его задач
+func (r *taskRepo) UserData(userID uint32) (*domain.UserData, error) {
+	var rows []Task
+	if err := r.db.Unscoped().Where("user_id = ?", userID).Order("id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("UserData: failed to get tasks: %w", err)
+	}
+
+	data := &domain.UserData{}
+	all := make([]*domain.Task, len(rows))
+	for i := range rows {
+		all[i] = rows[i].toDomain()
+		if rows[i].DeletedAt.Valid {
+			data.Trash = append(data.Trash, &domain.DeletedTask{Task: all[i], DeletedAt: rows[i].DeletedAt.Time})
+		} else {
+			data.Tasks = append(data.Tasks, all[i])
+		}
+	}
+	if err := loadRelations(r.db, all...); err != nil {
+		return nil, fmt.Errorf("UserData: %w", err)
+	}
+
+	var err error
+	if data.Projects, err = r.ListProjects(userID, true); err != nil {
+		return nil, fmt.Errorf("UserData: %w", err)
+	}
+	if data.Tags, err = r.ListTags(userID); err != nil {
+		return nil, fmt.Errorf("UserData: %w", err)
+	}
+
+	var comments []Comment
+	if err := r.db.Unscoped().Where("author_id = ?", userID).Order("id").Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("UserData: failed to get comments: %w", err)
+	}
+	for i := range comments {
+		data.Comments = append(data.Comments, comments[i].toDomain())
+	}
+
+	var attachments []Attachment
+	if err := r.db.Where("task_id IN (?)", r.db.Unscoped().Model(&Task{}).Select("id").Where("user_id = ?", userID)).
+		Order("id").Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("UserData: failed to get attachments: %w", err)
+	}
+	for i := range attachments {
+		data.Attachments = append(data.Attachments, attachments[i].toDomain())
+	}
+
+	return data, nil
+}
+
+// EraseUserData окончательно удаляет все данные пользователя, включая корзину,
+// историю и журнал событий его задач, и возвращает ключи содержимого вложений.
+// Повторный вызов ничего не находит и возвращает нули.
+func (r *taskRepo) EraseUserData(userID uint32) (*domain.ErasedData, []string, error) {
+	erased := &domain.ErasedData{}
+	var keys []string
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockHierarchy(tx, userID); err != nil {
+			return err
+		}
+
+		userTasks := tx.Unscoped().Model(&Task{}).Select("id").Where("user_id = ?", userID)
+		if err := tx.Model(&Attachment{}).Where("task_id IN (?)", userTasks).Pluck("storage_key", &keys).Error; err != nil {
+			return fmt.Errorf("failed to get attachments: %w", err)
+		}
+		erased.Attachments = len(keys)
+
+		// комментарии к задачам пользователя удалятся каскадом, здесь — к чужим задачам
+		res := tx.Unscoped().Where("author_id = ?", userID).Delete(&Comment{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to erase comments: %w", res.Error)
+		}
+		erased.Comments = int(res.RowsAffected)
+
+		if err := tx.Where("user_id = ?", userID).Delete(&TaskHistory{}).Error; err != nil {
+			return fmt.Errorf("failed to erase task history: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&TaskEvent{}).Error; err != nil {
+			return fmt.Errorf("failed to erase task events: %w", err)
+		}
+		// сохраненные ответы на запросы с idempotency-key содержат задачи и комментарии
+		if err := idempotency.DeleteOwnedBy(tx, userID); err != nil {
+			return fmt.Errorf("failed to erase idempotency keys: %w", err)
+		}
+
+		// теги, зависимости, комментарии и вложения задач удаляются каскадом
+		res = tx.Unscoped().Where("user_id = ?", userID).Delete(&Task{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to erase tasks: %w", res.Error)
+		}
+		erased.Tasks = int(res.RowsAffected)
+
+		if err := tx.Where("user_id = ?", userID).Delete(&TaskSeries{}).Error; err != nil {
+			return fmt.Errorf("failed to erase series: %w", err)
+		}
+
+		res = tx.Where("user_id = ?", userID).Delete(&Project{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to erase projects: %w", res.Error)
+		}
+		erased.Projects = int(res.RowsAffected)
+
+		res = tx.Where("user_id = ?", userID).Delete(&Tag{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to erase tags: %w", res.Error)
+		}
+		erased.Tags = int(res.RowsAffected)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("EraseUserData: %w", err)
+	}
+
+	return erased, keys, nil
+}
+
+// ExportUserData возвращает все данные пользователя для выгрузки по его запросу
+func (s *tasksService) ExportUserData(userID uint32) (*domain.UserData, error) {
+	return s.repo.UserData(userID)
+}
+
+// EraseUserData окончательно удаляет данные пользователя, затем содержимое вложений
+func (s *tasksService) EraseUserData(ctx context.Context, userID uint32) (*domain.ErasedData, error) {
+	erased, keys, err := s.repo.EraseUserData(userID)
+	if err != nil {
+		return nil, err
+	}
+	deleteBlobs(ctx, s.store, keys)
+	return erased, nil
+}
@@ -0,0 +1,170 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// hierarchyLockKey — ключ advisory lock изменений иерархии задач пользователя.
+// Без него два встречных переноса (A под B и B под A) прошли бы проверку цикла одновременно.
+const hierarchyLockKey = 28_002
+
+// lockHierarchy сериализует изменения иерархии задач пользователя до конца транзакции
+func lockHierarchy(tx *gorm.DB, userID uint32) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", hierarchyLockKey, int32(userID)).Error; err != nil {
+		return fmt.Errorf("failed to lock task hierarchy: %w", err)
+	}
+	return nil
+}
+
+// checkParent проверяет, что parentID — задача того же пользователя и не лежит
+// в поддереве taskID. Для новой задачи taskID равен 0.
+func checkParent(tx *gorm.DB, taskID, userID, parentID uint32) error {
+	if parentID == taskID {
+		return ErrHierarchyCycle
+	}
+	if err := lockHierarchy(tx, userID); err != nil {
+		return err
+	}
+
+	var parent Task
+	if err := tx.First(&parent, uint(parentID)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrParentNotFound
+		}
+		return fmt.Errorf("failed to find parent task: %w", err)
+	}
+	if parent.UserID != userID {
+		return ErrParentOwnerMismatch
+	}
+	if taskID == 0 {
+		return nil
+	}
+
+	// цикл появится, если taskID — предок нового родителя
+	var cycle bool
+	err := tx.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM tasks WHERE id = ?
+			UNION
+			SELECT t.id, t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = ?)`, parentID, taskID).
+		Scan(&cycle).Error
+	if err != nil {
+		return fmt.Errorf("failed to check task hierarchy: %w", err)
+	}
+	if cycle {
+		return ErrHierarchyCycle
+	}
+
+	return nil
+}
+
+// subtreeRow — задача поддерева с глубиной от корня
+type subtreeRow struct {
+	Task
+	Depth int
+}
+
+// subtreeRows возвращает неудаленное поддерево задачи id в порядке обхода в ширину,
+// корень первый. Пустой результат означает, что задачи нет.
+func subtreeRows(tx *gorm.DB, id uint32) ([]subtreeRow, error) {
+	var rows []subtreeRow
+	err := tx.Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT t.*, 0 AS depth FROM tasks t WHERE t.id = ? AND t.deleted_at IS NULL
+			UNION ALL
+			SELECT c.*, s.depth + 1 FROM tasks c JOIN subtree s ON c.parent_id = s.id
+			WHERE c.deleted_at IS NULL
+		)
+		SELECT * FROM subtree ORDER BY depth, id`, id).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtree: %w", err)
+	}
+
+	return rows, nil
+}
+
+// promoteChildren переподчиняет прямые подзадачи parentID его родителю newParent
+func promoteChildren(tx *gorm.DB, parentID uint32, newParent *uint32) error {
+	var children []Task
+	err := tx.Model(&children).
+		Clauses(clause.Returning{}).
+		Where("parent_id = ?", parentID).
+		Updates(map[string]any{
+			"parent_id": newParent,
+			"version":   gorm.Expr("version + 1"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to promote subtasks: %w", err)
+	}
+
+	updated := make([]*domain.Task, len(children))
+	for i := range children {
+		updated[i] = children[i].toDomain()
+	}
+	if err := loadRelations(tx, updated...); err != nil {
+		return err
+	}
+
+	return appendEvents(tx, domain.TaskUpdated, updated...)
+}
+
+// GetSubtree возвращает задачу id со всеми подзадачами и прогрессом каждого узла
+func (r *taskRepo) GetSubtree(id uint32) ([]*domain.TaskNode, error) {
+	rows, err := subtreeRows(r.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetSubtree: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrTaskNotFound
+	}
+
+	nodes := make([]*domain.TaskNode, len(rows))
+	tasks := make([]*domain.Task, len(rows))
+	for i := range rows {
+		t := rows[i].Task.toDomain()
+		nodes[i] = &domain.TaskNode{Task: t, Depth: rows[i].Depth}
+		tasks[i] = t
+	}
+
+	countProgress(nodes)
+
+	if err := loadRelations(r.db, tasks...); err != nil {
+		return nil, fmt.Errorf("GetSubtree: %w", err)
+	}
+
+	return nodes, nil
+}
+
+// countProgress считает прогресс каждого узла поддерева nodes (корень первый) по его
+// прямым подзадачам; отмененные подзадачи не учитываются
+func countProgress(nodes []*domain.TaskNode) {
+	byID := make(map[uint32]*domain.TaskNode, len(nodes))
+	for _, n := range nodes {
+		byID[n.Task.ID] = n
+	}
+
+	for _, n := range nodes[1:] {
+		parent := byID[*n.Task.ParentID]
+		switch n.Task.Status {
+		case domain.StatusCanceled:
+		case domain.StatusDone:
+			parent.ChildrenTotal++
+			parent.ChildrenDone++
+		default:
+			parent.ChildrenTotal++
+		}
+	}
+}
+
+// GetSubtree возвращает задачу id с подзадачами всех уровней
+func (s *tasksService) GetSubtree(id uint32) ([]*domain.TaskNode, error) {
+	return s.repo.GetSubtree(id)
+}
@@ -0,0 +1,52 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+func TestCountProgress(t *testing.T) {
+	// 1
+	// ├── 2 done
+	// ├── 3 canceled
+	// └── 4 in progress
+	//     ├── 5 done
+	//     ├── 6 todo
+	//     └── 7 done
+	node := func(id, parent uint32, status domain.TaskStatus, depth int) *domain.TaskNode {
+		t := &domain.Task{ID: id, Status: status}
+		if parent != 0 {
+			t.ParentID = &parent
+		}
+		return &domain.TaskNode{Task: t, Depth: depth}
+	}
+	nodes := []*domain.TaskNode{
+		node(1, 0, domain.StatusTodo, 0),
+		node(2, 1, domain.StatusDone, 1),
+		node(3, 1, domain.StatusCanceled, 1),
+		node(4, 1, domain.StatusInProgress, 1),
+		node(5, 4, domain.StatusDone, 2),
+		node(6, 4, domain.StatusTodo, 2),
+		node(7, 4, domain.StatusDone, 2),
+	}
+	countProgress(nodes)
+
+	tests := []struct {
+		id                uint32
+		total, done, perc int
+	}{
+		{id: 1, total: 2, done: 1, perc: 50},
+		{id: 2},
+		{id: 3},
+		{id: 4, total: 3, done: 2, perc: 66},
+		{id: 5},
+	}
+	for _, tt := range tests {
+		n := nodes[tt.id-1]
+		if n.ChildrenTotal != tt.total || n.ChildrenDone != tt.done || n.Progress() != tt.perc {
+			t.Errorf("task %d: total %d, done %d, progress %d; want %d, %d, %d",
+				tt.id, n.ChildrenTotal, n.ChildrenDone, n.Progress(), tt.total, tt.done, tt.perc)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+)
+
+// actorSetting — ключ настройки gorm, в которой репозиторий хранит автора изменений
+const actorSetting = "tasks:actor_id"
+
+const (
+	defaultHistoryPage = 50
+	maxHistoryPage     = 200
+)
+
+// historyFields — поля задачи, изменения которых попадают в историю, и их текстовые значения
+var historyFields = []struct {
+	name  string
+	value func(t *domain.Task) string
+}{
+	{"title", func(t *domain.Task) string { return t.Task }},
+	{"status", func(t *domain.Task) string { return string(t.Status) }},
+	{"priority", func(t *domain.Task) string { return strconv.Itoa(int(t.Priority)) }},
+	{"due_at", func(t *domain.Task) string { return formatHistoryTime(t.DueAt) }},
+	{"remind_at", func(t *domain.Task) string { return formatHistoryTime(t.RemindAt) }},
+	{"completed_at", func(t *domain.Task) string { return formatHistoryTime(t.CompletedAt) }},
+	{"parent_id", func(t *domain.Task) string { return formatHistoryID(t.ParentID) }},
+	{"project_id", func(t *domain.Task) string { return formatHistoryID(t.ProjectID) }},
+	{"series_id", func(t *domain.Task) string { return formatHistoryID(t.SeriesID) }},
+	{"tag_ids", func(t *domain.Task) string { return formatHistoryIDs(t.TagIDs) }},
+	{"blocker_ids", func(t *domain.Task) string { return formatHistoryIDs(t.BlockerIDs) }},
+}
+
+// WithActor возвращает репозиторий, который записывает изменения в историю от имени actorID
+func (r *taskRepo) WithActor(actorID uint32) TasksRepo {
+	return &taskRepo{db: r.db.Set(actorSetting, actorID).Session(&gorm.Session{})}
+}
+
+// actorOf возвращает автора изменений транзакции tx, 0 — система
+func actorOf(tx *gorm.DB) uint32 {
+	v, ok := tx.Get(actorSetting)
+	if !ok {
+		return 0
+	}
+	id, _ := v.(uint32)
+	return id
+}
+
+// appendHistory пишет в историю изменения задач в рамках транзакции tx.
+// Вызывается до записи событий: прежнее состояние задач без истории берется из журнала.
+func appendHistory(tx *gorm.DB, eventType string, tasks ...*domain.Task) error {
+	ids := make([]uint32, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	before, err := lastSnapshots(tx, ids)
+	if err != nil {
+		return err
+	}
+
+	actor := actorOf(tx)
+	rows := make([]TaskHistory, 0, len(tasks))
+	for _, t := range tasks {
+		changes := []domain.FieldChange{}
+		switch eventType {
+		case domain.TaskCreated:
+			changes = diffTasks(nil, t)
+		case domain.TaskUpdated:
+			changes = diffTasks(before[t.ID], t)
+			// изменения без видимых полей, например перестановка в списке, не записываются
+			if len(changes) == 0 {
+				continue
+			}
+		case domain.TaskRestored:
+			// восстановленная задача могла потерять удаленного родителя
+			changes = diffTasks(before[t.ID], t)
+		}
+
+		snapshot, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Errorf("failed to encode task: %w", err)
+		}
+		diff, err := json.Marshal(changes)
+		if err != nil {
+			return fmt.Errorf("failed to encode changes: %w", err)
+		}
+		rows = append(rows, TaskHistory{TaskID: t.ID, UserID: t.UserID, Type: eventType, ActorID: actor,
+			Changes: diff, Task: snapshot})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to append task history: %w", err)
+	}
+	return nil
+}
+
+// lastSnapshots возвращает последнее записанное состояние задач ids
+func lastSnapshots(tx *gorm.DB, ids []uint32) (map[uint32]*domain.Task, error) {
+	var rows []TaskHistory
+	if err := tx.Raw(`SELECT DISTINCT ON (task_id) task_id, task FROM task_history
+		WHERE task_id IN ? ORDER BY task_id, id DESC`, ids).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load task history: %w", err)
+	}
+
+	snapshots := make(map[uint32]*domain.Task, len(ids))
+	for _, row := range rows {
+		var t domain.Task
+		if err := json.Unmarshal(row.Task, &t); err != nil {
+			return nil, fmt.Errorf("task history of %d: %w", row.TaskID, err)
+		}
+		snapshots[row.TaskID] = &t
+	}
+
+	// задачи, измененные до появления истории
+	var missing []uint32
+	for _, id := range ids {
+		if _, ok := snapshots[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return snapshots, nil
+	}
+
+	var events []TaskEvent
+	if err := tx.Raw(`SELECT DISTINCT ON (task_id) task_id, task FROM task_events
+		WHERE task_id IN ? AND type <> ? ORDER BY task_id, revision DESC`, missing, domain.TaskReminder).
+		Scan(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to load task events: %w", err)
+	}
+	for _, ev := range events {
+		var t domain.Task
+		if err := json.Unmarshal(ev.Task, &t); err != nil {
+			return nil, fmt.Errorf("task event of %d: %w", ev.TaskID, err)
+		}
+		snapshots[ev.TaskID] = &t
+	}
+
+	return snapshots, nil
+}
+
+// diffTasks возвращает поля, которые отличаются у before и after; before == nil — новая задача
+func diffTasks(before, after *domain.Task) []domain.FieldChange {
+	changes := []domain.FieldChange{}
+	for _, f := range historyFields {
+		was := ""
+		if before != nil {
+			was = f.value(before)
+		}
+		if now := f.value(after); now != was {
+			changes = append(changes, domain.FieldChange{Field: f.name, Before: was, After: now})
+		}
+	}
+	return changes
+}
+
+func formatHistoryTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatHistoryID(id *uint32) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+func formatHistoryIDs(ids []uint32) string {
+	sorted := append([]uint32(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	parts := make([]string, len(sorted))
+	for i, id := range sorted {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ListTaskHistory возвращает до limit записей истории задачи с id больше afterID, от старых
+// к новым. История удаленной задачи остается доступной; ErrTaskNotFound — задачи не было.
+func (r *taskRepo) ListTaskHistory(taskID uint32, afterID uint64, limit int) ([]*domain.HistoryEntry, error) {
+	var rows []TaskHistory
+	if err := r.db.Where("task_id = ? AND id > ?", taskID, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("ListTaskHistory: failed to get history: %w", err)
+	}
+
+	if len(rows) == 0 && afterID == 0 {
+		var count int64
+		if err := r.db.Unscoped().Model(&Task{}).Where("id = ?", taskID).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("ListTaskHistory: failed to check task: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrTaskNotFound
+		}
+	}
+
+	out := make([]*domain.HistoryEntry, len(rows))
+	for i := range rows {
+		entry, err := rows[i].toDomain()
+		if err != nil {
+			return nil, fmt.Errorf("ListTaskHistory: %w", err)
+		}
+		out[i] = entry
+	}
+
+	return out, nil
+}
+
+// WithActor возвращает сервис, который записывает изменения задач от имени actorID
+func (s *tasksService) WithActor(actorID uint32) TasksService {
+	return &tasksService{repo: s.repo.WithActor(actorID), broadcaster: s.broadcaster, store: s.store}
+}
+
+// GetTaskHistory возвращает страницу истории задачи после записи afterID
+// и id, с которого начнется следующая страница (0 — страниц больше нет)
+func (s *tasksService) GetTaskHistory(taskID uint32, afterID uint64, pageSize int) ([]*domain.HistoryEntry, uint64, error) {
+	if pageSize <= 0 {
+		pageSize = defaultHistoryPage
+	}
+	if pageSize > maxHistoryPage {
+		pageSize = maxHistoryPage
+	}
+
+	// берем на одну больше, чтобы знать, есть ли следующая страница
+	entries, err := s.repo.ListTaskHistory(taskID, afterID, pageSize+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(entries) <= pageSize {
+		return entries, 0, nil
+	}
+
+	entries = entries[:pageSize]
+	return entries, entries[pageSize-1].ID, nil
+}
@@ -0,0 +1,131 @@
+package tasks
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+func TestDiffTasks(t *testing.T) {
+	due := time.Date(2026, 10, 20, 9, 0, 0, 0, time.FixedZone("MSK", 3*3600))
+	parent := uint32(7)
+	base := func() *domain.Task {
+		return &domain.Task{ID: 1, Task: "Купить хлеб", Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+			TagIDs: []uint32{3, 1}}
+	}
+
+	tests := []struct {
+		name   string
+		before *domain.Task
+		change func(t *domain.Task)
+		want   []domain.FieldChange
+	}{
+		{
+			name:   "created",
+			change: func(t *domain.Task) {},
+			want: []domain.FieldChange{
+				{Field: "title", After: "Купить хлеб"},
+				{Field: "status", After: "todo"},
+				{Field: "priority", After: "2"},
+				{Field: "tag_ids", After: "1,3"},
+			},
+		},
+		{
+			name:   "nothing changed",
+			before: base(),
+			change: func(t *domain.Task) { t.Version++ },
+			want:   []domain.FieldChange{},
+		},
+		{
+			name:   "done",
+			before: base(),
+			change: func(t *domain.Task) { t.Status = domain.StatusDone },
+			want:   []domain.FieldChange{{Field: "status", Before: "todo", After: "done"}},
+		},
+		{
+			name:   "dates in UTC, parent set",
+			before: base(),
+			change: func(t *domain.Task) { t.DueAt = &due; t.ParentID = &parent },
+			want: []domain.FieldChange{
+				{Field: "due_at", After: "2026-10-20T06:00:00Z"},
+				{Field: "parent_id", After: "7"},
+			},
+		},
+		{
+			name:   "tags reordered",
+			before: base(),
+			change: func(t *domain.Task) { t.TagIDs = []uint32{1, 3} },
+			want:   []domain.FieldChange{},
+		},
+		{
+			name:   "tag removed",
+			before: base(),
+			change: func(t *domain.Task) { t.TagIDs = []uint32{3} },
+			want:   []domain.FieldChange{{Field: "tag_ids", Before: "1,3", After: "3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := base()
+			tt.change(after)
+			if got := diffTasks(tt.before, after); !slices.Equal(got, tt.want) {
+				t.Errorf("diffTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// historyRepo — история одной задачи в памяти
+type historyRepo struct {
+	TasksRepo
+	entries []*domain.HistoryEntry
+}
+
+func (r *historyRepo) ListTaskHistory(taskID uint32, afterID uint64, limit int) ([]*domain.HistoryEntry, error) {
+	var out []*domain.HistoryEntry
+	for _, e := range r.entries {
+		if e.ID > afterID && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func TestGetTaskHistoryPages(t *testing.T) {
+	repo := &historyRepo{}
+	for id := uint64(1); id <= 5; id++ {
+		repo.entries = append(repo.entries, &domain.HistoryEntry{ID: id * 10, TaskID: 1})
+	}
+	s := NewTasksService(repo, nil, nil)
+
+	tests := []struct {
+		afterID  uint64
+		pageSize int
+		want     []uint64
+		wantNext uint64
+	}{
+		{afterID: 0, pageSize: 2, want: []uint64{10, 20}, wantNext: 20},
+		{afterID: 20, pageSize: 2, want: []uint64{30, 40}, wantNext: 40},
+		{afterID: 40, pageSize: 2, want: []uint64{50}},
+		{afterID: 0, pageSize: 5, want: []uint64{10, 20, 30, 40, 50}},
+		// размер страницы по умолчанию
+		{afterID: 30, pageSize: 0, want: []uint64{40, 50}},
+		{afterID: 50, pageSize: 2},
+	}
+	for _, tt := range tests {
+		entries, next, err := s.GetTaskHistory(1, tt.afterID, tt.pageSize)
+		if err != nil {
+			t.Fatalf("GetTaskHistory(%d, %d): %v", tt.afterID, tt.pageSize, err)
+		}
+		var ids []uint64
+		for _, e := range entries {
+			ids = append(ids, e.ID)
+		}
+		if !slices.Equal(ids, tt.want) || next != tt.wantNext {
+			t.Errorf("GetTaskHistory(%d, %d) = %v, next %d; want %v, next %d",
+				tt.afterID, tt.pageSize, ids, next, tt.want, tt.wantNext)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ListenEvents слушает EventsChannel и будит Broadcaster, когда другая реплика
+// записала события. Работает до отмены ctx, при обрыве соединения переподключается.
+func ListenEvents(ctx context.Context, dsn string, b *Broadcaster) {
+	for {
+		if err := listenEvents(ctx, dsn, b); err != nil && ctx.Err() == nil {
+			log.Printf("task events listener: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func listenEvents(ctx context.Context, dsn string, b *Broadcaster) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{EventsChannel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	// события, записанные пока соединения не было
+	b.Notify()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+		b.Notify()
+	}
+}
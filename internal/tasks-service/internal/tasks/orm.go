@@ -1,26 +1,247 @@
 package tasks
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"github.com/your-org/tasks-service/domain"
 	"gorm.io/gorm"
 )
 
 type Task struct {
 	gorm.Model
-	Task   string `gorm:"type:varchar(255);not null" json:"task"`
-	IsDone bool   `gorm:"default:false" json:"is_done"`
-	UserID uint32 `gorm:"not null;index" json:"user_id"`
+	Task         string     `gorm:"type:varchar(255);not null" json:"task"`
+	IsDone       bool       `gorm:"default:false" json:"is_done"`
+	Status       string     `gorm:"type:varchar(16);not null;default:todo" json:"status"`
+	Priority     int16      `gorm:"not null;default:2" json:"priority"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	UserID       uint32     `gorm:"not null;index" json:"user_id"`
+	Version      uint32     `gorm:"not null;default:1" json:"version"`
+	DueAt        *time.Time `json:"due_at"`
+	RemindAt     *time.Time `json:"remind_at"`
+	RemindedAt   *time.Time `json:"reminded_at"`
+	ParentID     *uint32    `json:"parent_id"`
+	ProjectID    *uint32    `json:"project_id"`
+	Rank         string     `gorm:"type:varchar(64);not null;default:''" json:"rank"`
+	SeriesID     *uint32    `json:"series_id"`
+	OccurrenceAt *time.Time `json:"occurrence_at"`
+	// DeletedWithUser — задача удалена вместе с пользователем и вернется при его восстановлении
+	DeletedWithUser bool `gorm:"not null;default:false" json:"-"`
 }
 
 func (t *Task) toDomain() *domain.Task {
 	return &domain.Task{
-		ID:     uint32(t.ID),
-		Task:   t.Task,
-		IsDone: t.IsDone,
-		UserID: t.UserID,
+		ID:           uint32(t.ID),
+		Task:         t.Task,
+		IsDone:       t.IsDone,
+		Status:       domain.TaskStatus(t.Status),
+		Priority:     domain.Priority(t.Priority),
+		CompletedAt:  t.CompletedAt,
+		UserID:       t.UserID,
+		Version:      t.Version,
+		DueAt:        t.DueAt,
+		RemindAt:     t.RemindAt,
+		RemindedAt:   t.RemindedAt,
+		ParentID:     t.ParentID,
+		ProjectID:    t.ProjectID,
+		SeriesID:     t.SeriesID,
+		OccurrenceAt: t.OccurrenceAt,
 	}
 }
 
 func (t *Task) toORM(dm *domain.Task) *Task {
-	return &Task{Model: gorm.Model{ID: uint(dm.ID)}, Task: dm.Task, IsDone: dm.IsDone, UserID: dm.UserID}
+	return &Task{
+		Model:        gorm.Model{ID: uint(dm.ID)},
+		Task:         dm.Task,
+		IsDone:       dm.IsDone,
+		Status:       string(dm.Status),
+		Priority:     int16(dm.Priority),
+		CompletedAt:  dm.CompletedAt,
+		UserID:       dm.UserID,
+		Version:      dm.Version,
+		DueAt:        dm.DueAt,
+		RemindAt:     dm.RemindAt,
+		RemindedAt:   dm.RemindedAt,
+		ParentID:     dm.ParentID,
+		ProjectID:    dm.ProjectID,
+		SeriesID:     dm.SeriesID,
+		OccurrenceAt: dm.OccurrenceAt,
+	}
+}
+
+// ProcessedEvent отмечает уже обработанное входящее событие
+type ProcessedEvent struct {
+	EventID     string `gorm:"primaryKey;type:varchar(64)"`
+	ProcessedAt time.Time
+}
+
+// TaskEvent — строка журнала изменений задач, Task хранит снимок задачи в JSON
+type TaskEvent struct {
+	// Revision растет в пределах пользователя, см. appendEvents
+	Revision  uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Type      string `gorm:"type:varchar(16);not null"`
+	TaskID    uint32 `gorm:"not null"`
+	UserID    uint32 `gorm:"primaryKey;autoIncrement:false"`
+	Task      []byte `gorm:"type:jsonb;not null"`
+	CreatedAt time.Time
+}
+
+func (e *TaskEvent) toDomain() (*domain.TaskEvent, error) {
+	dm := &domain.TaskEvent{Revision: e.Revision, Type: e.Type}
+	if err := json.Unmarshal(e.Task, &dm.Task); err != nil {
+		return nil, fmt.Errorf("task event %d: %w", e.Revision, err)
+	}
+
+	return dm, nil
+}
+
+// TaskHistory — неизменяемая запись истории задачи, Task хранит состояние после изменения
+type TaskHistory struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	TaskID    uint32 `gorm:"not null"`
+	UserID    uint32 `gorm:"not null"`
+	Type      string `gorm:"type:varchar(16);not null"`
+	ActorID   uint32 `gorm:"not null;default:0"`
+	Changes   []byte `gorm:"type:jsonb;not null"`
+	Task      []byte `gorm:"type:jsonb;not null"`
+	CreatedAt time.Time
+}
+
+func (TaskHistory) TableName() string {
+	return "task_history"
+}
+
+func (h *TaskHistory) toDomain() (*domain.HistoryEntry, error) {
+	dm := &domain.HistoryEntry{ID: h.ID, TaskID: h.TaskID, Type: h.Type, ActorID: h.ActorID, CreatedAt: h.CreatedAt}
+	if err := json.Unmarshal(h.Changes, &dm.Changes); err != nil {
+		return nil, fmt.Errorf("task history %d: %w", h.ID, err)
+	}
+
+	return dm, nil
+}
+
+func newTaskEvent(eventType string, dm *domain.Task) (*TaskEvent, error) {
+	snapshot, err := json.Marshal(dm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskEvent{Type: eventType, TaskID: dm.ID, UserID: dm.UserID, Task: snapshot}, nil
+}
+
+// Tag — тег из каталога пользователя
+type Tag struct {
+	ID        uint32 `gorm:"primaryKey"`
+	UserID    uint32 `gorm:"not null"`
+	Name      string `gorm:"type:varchar(64);not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (t *Tag) toDomain() *domain.Tag {
+	return &domain.Tag{ID: t.ID, UserID: t.UserID, Name: t.Name}
+}
+
+// TaskTag — связь задачи и тега
+type TaskTag struct {
+	TaskID uint32 `gorm:"primaryKey"`
+	TagID  uint32 `gorm:"primaryKey"`
+}
+
+// Project — проект пользователя
+type Project struct {
+	ID        uint32 `gorm:"primaryKey"`
+	UserID    uint32 `gorm:"not null"`
+	Name      string `gorm:"type:varchar(128);not null"`
+	Archived  bool   `gorm:"not null;default:false"`
+	Position  int    `gorm:"not null;default:0"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (p *Project) toDomain() *domain.Project {
+	return &domain.Project{ID: p.ID, UserID: p.UserID, Name: p.Name, Archived: p.Archived, Position: p.Position}
+}
+
+// TaskSeries — серия повторяющихся задач
+type TaskSeries struct {
+	ID        uint32    `gorm:"primaryKey"`
+	UserID    uint32    `gorm:"not null"`
+	RRule     string    `gorm:"column:rrule;type:varchar(255);not null"`
+	DTStart   time.Time `gorm:"column:dtstart;not null"`
+	Task      string    `gorm:"type:varchar(255);not null"`
+	Priority  int16     `gorm:"not null;default:2"`
+	ProjectID *uint32
+	Active    bool `gorm:"not null;default:true"`
+	// PausedWithUser — серия остановлена удалением пользователя и продолжится при его восстановлении
+	PausedWithUser bool      `gorm:"not null;default:false"`
+	GeneratedUntil time.Time `gorm:"not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (s *TaskSeries) toDomain() *domain.Series {
+	return &domain.Series{ID: s.ID, UserID: s.UserID, RRule: s.RRule, DTStart: s.DTStart, Task: s.Task,
+		Priority: domain.Priority(s.Priority), ProjectID: s.ProjectID, Active: s.Active}
+}
+
+// Comment — комментарий к задаче, удаляется мягко
+type Comment struct {
+	gorm.Model
+	TaskID   uint32 `gorm:"not null"`
+	AuthorID uint32 `gorm:"not null"`
+	Body     string `gorm:"type:text;not null"`
+	EditedAt *time.Time
+}
+
+func (c *Comment) toDomain() *domain.Comment {
+	return &domain.Comment{
+		ID:        uint32(c.ID),
+		TaskID:    c.TaskID,
+		AuthorID:  c.AuthorID,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+		EditedAt:  c.EditedAt,
+	}
+}
+
+// CommentEdit — текст комментария до правки
+type CommentEdit struct {
+	ID        uint32 `gorm:"primaryKey"`
+	CommentID uint32 `gorm:"not null"`
+	Body      string `gorm:"type:text;not null"`
+	EditedAt  time.Time
+}
+
+// Attachment — метаданные вложения задачи
+type Attachment struct {
+	ID          uint32 `gorm:"primaryKey"`
+	TaskID      uint32 `gorm:"not null"`
+	FileName    string `gorm:"type:varchar(255);not null"`
+	ContentType string `gorm:"type:varchar(255);not null"`
+	Size        int64  `gorm:"not null"`
+	SHA256      string `gorm:"column:sha256;type:char(64);not null"`
+	StorageKey  string `gorm:"type:varchar(512);not null"`
+	CreatedAt   time.Time
+}
+
+func (a *Attachment) toDomain() *domain.Attachment {
+	return &domain.Attachment{
+		ID:          a.ID,
+		TaskID:      a.TaskID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.SHA256,
+		StorageKey:  a.StorageKey,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// TaskDependency — задача TaskID заблокирована задачей BlockerID
+type TaskDependency struct {
+	TaskID    uint32 `gorm:"primaryKey"`
+	BlockerID uint32 `gorm:"primaryKey"`
+	CreatedAt time.Time
 }
@@ -0,0 +1,49 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+)
+
+// OwnerOf возвращает id пользователя, которому принадлежит сущность. Комментарий
+// и вложение принадлежат владельцу своей задачи; задачи в корзине тоже учитываются.
+func (r *taskRepo) OwnerOf(kind domain.EntityKind, id uint32) (uint32, error) {
+	var (
+		q        *gorm.DB
+		notFound error
+	)
+	tasks := r.db.Unscoped().Model(&Task{})
+	switch kind {
+	case domain.EntityUser:
+		return id, nil
+	case domain.EntityTask:
+		q, notFound = tasks.Where("id = ?", id), ErrTaskNotFound
+	case domain.EntityProject:
+		q, notFound = r.db.Model(&Project{}).Where("id = ?", id), ErrProjectNotFound
+	case domain.EntityTag:
+		q, notFound = r.db.Model(&Tag{}).Where("id = ?", id), ErrTagNotFound
+	case domain.EntityComment:
+		comment := r.db.Model(&Comment{}).Select("task_id").Where("id = ?", id)
+		q, notFound = tasks.Where("id = (?)", comment), ErrCommentNotFound
+	case domain.EntityAttachment:
+		attachment := r.db.Model(&Attachment{}).Select("task_id").Where("id = ?", id)
+		q, notFound = tasks.Where("id = (?)", attachment), ErrAttachmentNotFound
+	default:
+		return 0, fmt.Errorf("OwnerOf: unknown entity %q", kind)
+	}
+
+	var owners []uint32
+	if err := q.Limit(1).Pluck("user_id", &owners).Error; err != nil {
+		return 0, fmt.Errorf("OwnerOf: failed to find owner: %w", err)
+	}
+	if len(owners) == 0 {
+		return 0, notFound
+	}
+	return owners[0], nil
+}
+
+func (s *tasksService) OwnerOf(kind domain.EntityKind, id uint32) (uint32, error) {
+	return s.repo.OwnerOf(kind, id)
+}
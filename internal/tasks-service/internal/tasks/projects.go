@@ -0,0 +1,367 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxProjectNameLen = 128
+
+// projectsLockKey — ключ advisory lock списка проектов пользователя, под ним
+// новый проект получает следующую позицию, а порядок меняется целиком
+const projectsLockKey = 28_004
+
+func lockProjects(tx *gorm.DB, userID uint32) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", projectsLockKey, int32(userID)).Error; err != nil {
+		return fmt.Errorf("failed to lock projects: %w", err)
+	}
+	return nil
+}
+
+// checkProject проверяет, что в проект projectID можно положить задачу пользователя userID.
+// Строка проекта блокируется до конца транзакции, чтобы его не удалили и не архивировали параллельно.
+func checkProject(tx *gorm.DB, userID, projectID uint32) error {
+	var project Project
+	if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).First(&project, projectID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to find project: %w", err)
+	}
+	if project.UserID != userID {
+		return ErrProjectOwnerMismatch
+	}
+	if project.Archived {
+		return ErrProjectArchived
+	}
+
+	return nil
+}
+
+// CreateProject добавляет проект в конец списка проектов пользователя
+func (r *taskRepo) CreateProject(dm *domain.Project) (*domain.Project, error) {
+	project := Project{UserID: dm.UserID, Name: dm.Name}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockProjects(tx, dm.UserID); err != nil {
+			return err
+		}
+		if err := tx.Model(&Project{}).
+			Select("COALESCE(MAX(position) + 1, 0)").
+			Where("user_id = ?", dm.UserID).
+			Scan(&project.Position).Error; err != nil {
+			return fmt.Errorf("failed to get next position: %w", err)
+		}
+		if err := tx.Create(&project).Error; err != nil {
+			return fmt.Errorf("failed to create project: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateProject: %w", err)
+	}
+
+	return project.toDomain(), nil
+}
+
+// GetProject возвращает проект по id
+func (r *taskRepo) GetProject(id uint32) (*domain.Project, error) {
+	var project Project
+	if err := r.db.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("GetProject: failed to find project: %w", err)
+	}
+
+	return project.toDomain(), nil
+}
+
+// UpdateProject сохраняет имя и флаг архива проекта
+func (r *taskRepo) UpdateProject(dm *domain.Project) (*domain.Project, error) {
+	project := Project{ID: dm.ID}
+	res := r.db.Model(&project).
+		Clauses(clause.Returning{}).
+		Updates(map[string]any{"name": dm.Name, "archived": dm.Archived})
+	if res.Error != nil {
+		return nil, fmt.Errorf("UpdateProject: failed to update project: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrProjectNotFound
+	}
+
+	return project.toDomain(), nil
+}
+
+// DeleteProject удаляет проект, его задачи остаются без проекта
+func (r *taskRepo) DeleteProject(id uint32) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var project Project
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrProjectNotFound
+			}
+			return fmt.Errorf("failed to find project: %w", err)
+		}
+
+		var detached []Task
+		if err := tx.Model(&detached).
+			Clauses(clause.Returning{}).
+			Where("project_id = ?", id).
+			Updates(map[string]any{
+				"project_id": nil,
+				"version":    gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to detach tasks: %w", err)
+		}
+
+		if err := tx.Delete(&project).Error; err != nil {
+			return fmt.Errorf("failed to delete project: %w", err)
+		}
+
+		updated := make([]*domain.Task, len(detached))
+		for i := range detached {
+			updated[i] = detached[i].toDomain()
+		}
+		if err := loadRelations(tx, updated...); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.TaskUpdated, updated...)
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteProject: %w", err)
+	}
+
+	return nil
+}
+
+// ListProjects возвращает проекты пользователя в заданном им порядке
+func (r *taskRepo) ListProjects(userID uint32, includeArchived bool) ([]*domain.Project, error) {
+	q := r.db.Where("user_id = ?", userID)
+	if !includeArchived {
+		q = q.Where("NOT archived")
+	}
+
+	var projects []Project
+	if err := q.Order("position, id").Find(&projects).Error; err != nil {
+		return nil, fmt.Errorf("ListProjects: failed to get projects: %w", err)
+	}
+
+	out := make([]*domain.Project, len(projects))
+	for i := range projects {
+		out[i] = projects[i].toDomain()
+	}
+
+	return out, nil
+}
+
+// ReorderProjects расставляет проекты пользователя в порядке ids.
+// ids должен содержать каждый проект пользователя ровно один раз.
+func (r *taskRepo) ReorderProjects(userID uint32, ids []uint32) ([]*domain.Project, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockProjects(tx, userID); err != nil {
+			return err
+		}
+
+		var current []uint32
+		if err := tx.Model(&Project{}).Where("user_id = ?", userID).Pluck("id", &current).Error; err != nil {
+			return fmt.Errorf("failed to get projects: %w", err)
+		}
+		if len(current) != len(ids) {
+			return ErrInvalidProjectOrder
+		}
+		known := uniqueIDs(current)
+		for _, id := range ids {
+			if _, ok := known[id]; !ok {
+				return ErrInvalidProjectOrder
+			}
+		}
+
+		for pos, id := range ids {
+			if err := tx.Model(&Project{ID: id}).Update("position", pos).Error; err != nil {
+				return fmt.Errorf("failed to update project position: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ReorderProjects: %w", err)
+	}
+
+	return r.ListProjects(userID, true)
+}
+
+// ListTasksByProject возвращает задачи проекта
+func (r *taskRepo) ListTasksByProject(projectID uint32) ([]*domain.Task, error) {
+	var tasks []Task
+	if err := r.db.Where("project_id = ?", projectID).Order("rank, id").Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("ListTasksByProject: failed to get tasks: %w", err)
+	}
+
+	out := make([]*domain.Task, len(tasks))
+	for i := range tasks {
+		out[i] = tasks[i].toDomain()
+	}
+	if err := loadRelations(r.db, out...); err != nil {
+		return nil, fmt.Errorf("ListTasksByProject: %w", err)
+	}
+
+	return out, nil
+}
+
+// MoveTaskToProject переносит задачу вместе с поддеревом в проект projectID
+// (nil — убрать из проекта). Перенос выполняется одной транзакцией:
+// либо переезжает всё поддерево, либо ничего.
+func (r *taskRepo) MoveTaskToProject(taskID uint32, projectID *uint32) (*domain.Task, error) {
+	var moved *domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ormTask Task
+		if err := tx.First(&ormTask, uint(taskID)).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskNotFound
+			}
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+		// поддерево не должно меняться, пока переносим
+		if err := lockHierarchy(tx, ormTask.UserID); err != nil {
+			return err
+		}
+		if projectID != nil {
+			if err := checkProject(tx, ormTask.UserID, *projectID); err != nil {
+				return err
+			}
+		}
+
+		rows, err := subtreeRows(tx, taskID)
+		if err != nil {
+			return err
+		}
+		ids := make([]uint32, len(rows))
+		for i, row := range rows {
+			ids[i] = uint32(row.ID)
+		}
+
+		var updated []Task
+		if err := tx.Model(&updated).
+			Clauses(clause.Returning{}).
+			Where("id IN ?", ids).
+			Where("project_id IS DISTINCT FROM ?", projectID).
+			Updates(map[string]any{
+				"project_id": projectID,
+				"version":    gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to move tasks: %w", err)
+		}
+
+		changed := make([]*domain.Task, len(updated))
+		for i := range updated {
+			changed[i] = updated[i].toDomain()
+			if changed[i].ID == taskID {
+				moved = changed[i]
+			}
+		}
+		if err := loadRelations(tx, changed...); err != nil {
+			return err
+		}
+		if moved == nil {
+			// задача уже была в этом проекте
+			moved = ormTask.toDomain()
+			if err := loadRelations(tx, moved); err != nil {
+				return err
+			}
+		}
+		return appendEvents(tx, domain.TaskUpdated, changed...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("MoveTaskToProject: %w", err)
+	}
+
+	return moved, nil
+}
+
+// normalizeProjectName обрезает пробелы и проверяет длину имени проекта
+func normalizeProjectName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxProjectNameLen {
+		return "", ErrInvalidProjectName
+	}
+	return name, nil
+}
+
+// CreateProject добавляет проект в конец списка проектов пользователя
+func (s *tasksService) CreateProject(userID uint32, name string) (*domain.Project, error) {
+	name, err := normalizeProjectName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateProject(&domain.Project{UserID: userID, Name: name})
+}
+
+// UpdateProject меняет переданные в upd поля проекта
+func (s *tasksService) UpdateProject(id uint32, upd domain.ProjectUpdate) (*domain.Project, error) {
+	project, err := s.repo.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.Name != nil {
+		name, err := normalizeProjectName(*upd.Name)
+		if err != nil {
+			return nil, err
+		}
+		project.Name = name
+	}
+	if upd.Archived != nil {
+		project.Archived = *upd.Archived
+	}
+
+	return s.repo.UpdateProject(project)
+}
+
+// DeleteProject удаляет проект, задачи остаются без проекта
+func (s *tasksService) DeleteProject(id uint32) error {
+	if err := s.repo.DeleteProject(id); err != nil {
+		return err
+	}
+	s.broadcaster.Notify()
+	return nil
+}
+
+// ListProjects возвращает проекты пользователя по порядку
+func (s *tasksService) ListProjects(userID uint32, includeArchived bool) ([]*domain.Project, error) {
+	return s.repo.ListProjects(userID, includeArchived)
+}
+
+// ReorderProjects задает новый порядок всех проектов пользователя
+func (s *tasksService) ReorderProjects(userID uint32, ids []uint32) ([]*domain.Project, error) {
+	if len(uniqueIDs(ids)) != len(ids) {
+		return nil, ErrInvalidProjectOrder
+	}
+
+	return s.repo.ReorderProjects(userID, ids)
+}
+
+// ListTasksByProject возвращает задачи существующего проекта
+func (s *tasksService) ListTasksByProject(projectID uint32) ([]*domain.Task, error) {
+	if _, err := s.repo.GetProject(projectID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListTasksByProject(projectID)
+}
+
+// MoveTaskToProject переносит задачу с подзадачами в проект, nil — убрать из проекта
+func (s *tasksService) MoveTaskToProject(taskID uint32, projectID *uint32) (*domain.Task, error) {
+	task, err := s.repo.MoveTaskToProject(taskID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return task, nil
+}
@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+// projectRepo — один проект в памяти
+type projectRepo struct {
+	TasksRepo
+	project   domain.Project
+	reordered []uint32
+}
+
+func (r *projectRepo) GetProject(id uint32) (*domain.Project, error) {
+	if id != r.project.ID {
+		return nil, ErrProjectNotFound
+	}
+	p := r.project
+	return &p, nil
+}
+
+func (r *projectRepo) UpdateProject(dm *domain.Project) (*domain.Project, error) {
+	r.project = *dm
+	return dm, nil
+}
+
+func (r *projectRepo) ReorderProjects(userID uint32, ids []uint32) ([]*domain.Project, error) {
+	r.reordered = ids
+	return nil, nil
+}
+
+func TestUpdateProject(t *testing.T) {
+	name := func(s string) *string { return &s }
+	archived := true
+
+	tests := []struct {
+		name         string
+		id           uint32
+		upd          domain.ProjectUpdate
+		wantName     string
+		wantArchived bool
+		wantErr      error
+	}{
+		{name: "rename", id: 1, upd: domain.ProjectUpdate{Name: name("  Работа ")}, wantName: "Работа"},
+		{name: "archive keeps name", id: 1, upd: domain.ProjectUpdate{Archived: &archived}, wantName: "Дом",
+			wantArchived: true},
+		{name: "empty name", id: 1, upd: domain.ProjectUpdate{Name: name(" ")}, wantErr: ErrInvalidProjectName},
+		{name: "long name", id: 1, upd: domain.ProjectUpdate{Name: name(strings.Repeat("x", maxProjectNameLen+1))},
+			wantErr: ErrInvalidProjectName},
+		{name: "no project", id: 2, upd: domain.ProjectUpdate{Name: name("Работа")}, wantErr: ErrProjectNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &projectRepo{project: domain.Project{ID: 1, UserID: 1, Name: "Дом"}}
+			p, err := NewTasksService(repo, nil, nil).UpdateProject(tt.id, tt.upd)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateProject() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if repo.project.Name != "Дом" || repo.project.Archived {
+					t.Errorf("project changed on error: %+v", repo.project)
+				}
+				return
+			}
+			if p.Name != tt.wantName || p.Archived != tt.wantArchived {
+				t.Errorf("UpdateProject() = %q, archived %v; want %q, %v", p.Name, p.Archived, tt.wantName, tt.wantArchived)
+			}
+		})
+	}
+}
+
+func TestReorderProjectsRejectsDuplicates(t *testing.T) {
+	tests := []struct {
+		ids     []uint32
+		wantErr error
+	}{
+		{ids: []uint32{3, 1, 2}},
+		{ids: nil},
+		{ids: []uint32{1, 2, 1}, wantErr: ErrInvalidProjectOrder},
+	}
+	for _, tt := range tests {
+		repo := &projectRepo{}
+		_, err := NewTasksService(repo, nil, nil).ReorderProjects(1, tt.ids)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("ReorderProjects(%v) = %v, want %v", tt.ids, err, tt.wantErr)
+		}
+		if reordered := repo.reordered != nil; reordered != (tt.wantErr == nil && tt.ids != nil) {
+			t.Errorf("ReorderProjects(%v): repository called = %v", tt.ids, reordered)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+)
+
+// rankDigits — алфавит рангов по возрастанию; ранги сравниваются побайтово
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const rankBase = len(rankDigits)
+
+// rankBetween возвращает ранг строго между a и b. Пустой a — начало списка,
+// пустой b — конец. Ранги не заканчиваются на '0', поэтому между любыми двумя
+// соседними рангами всегда найдется место.
+func rankBetween(a, b string) string {
+	// в начало и в конец списка вставляют чаще всего, там одна цифра сдвигается
+	// на единицу, а не делит промежуток пополам, чтобы длина росла медленнее
+	if b == "" && a != "" {
+		// первая не максимальная цифра a увеличивается, хвост отбрасывается
+		i := strings.IndexFunc(a, func(r rune) bool { return r != rune(rankDigits[rankBase-1]) })
+		if i < 0 {
+			return a + string(rankDigits[rankBase/2])
+		}
+		return a[:i] + string(rankDigits[rankDigitAt(a, i)+1])
+	}
+	if a == "" && b != "" {
+		// первая ненулевая цифра b уменьшается, если не станет нулем
+		i := strings.IndexFunc(b, func(r rune) bool { return r != rune(rankDigits[0]) })
+		if d := rankDigitAt(b, i); d > 1 {
+			return b[:i] + string(rankDigits[d-1])
+		}
+	}
+	return rankMidpoint(a, b)
+}
+
+// rankMidpoint возвращает ранг посередине между a и b
+func rankMidpoint(a, b string) string {
+	if b != "" {
+		// общий префикс переносится как есть, a дополняется нулями
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == rankDigitAt(b, n) {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + rankMidpoint(rest, b[n:])
+		}
+	}
+
+	da := rankDigitAt(a, 0)
+	db := rankBase
+	if b != "" {
+		db = rankDigitAt(b, 0)
+	}
+	if db-da > 1 {
+		return string(rankDigits[(da+db)/2])
+	}
+	// цифры соседние: если b длиннее одной цифры, подходит её первая цифра
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(rankDigits[da]) + rankMidpoint(rest, "")
+}
+
+func rankDigitAt(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	return strings.IndexByte(rankDigits, s[i])
+}
+
+// spreadRanks возвращает n возрастающих рангов одинаковой длины, равномерно
+// распределенных по пространству рангов
+func spreadRanks(n int) []string {
+	width, capacity := 1, rankBase
+	for capacity <= n+1 {
+		width++
+		capacity *= rankBase
+	}
+	step := capacity / (n + 1)
+
+	ranks := make([]string, n)
+	buf := make([]byte, width)
+	for i := range ranks {
+		v := (i + 1) * step
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = rankDigits[v%rankBase]
+			v /= rankBase
+		}
+		// ранг не должен заканчиваться на '0'
+		ranks[i] = strings.TrimRight(string(buf), "0")
+	}
+	return ranks
+}
+
+// maxRankLen — длина колонки rank; более длинный ранг требует перебалансировки списка
+const maxRankLen = 64
+
+// ranksLockKey — ключ advisory lock порядка задач пользователя
+const ranksLockKey = 28_005
+
+func lockRanks(tx *gorm.DB, userID uint32) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", ranksLockKey, int32(userID)).Error; err != nil {
+		return fmt.Errorf("failed to lock task order: %w", err)
+	}
+	return nil
+}
+
+// inList ограничивает выборку списком задач: задачи пользователя в проекте projectID
+func inList(q *gorm.DB, userID uint32, projectID *uint32) *gorm.DB {
+	return q.Where("user_id = ? AND project_id IS NOT DISTINCT FROM ?", userID, projectID)
+}
+
+// appendRank возвращает ранг для новой задачи в конце списка
+func appendRank(tx *gorm.DB, userID uint32, projectID *uint32) (string, error) {
+	if err := lockRanks(tx, userID); err != nil {
+		return "", err
+	}
+
+	for attempt := 0; ; attempt++ {
+		var last []string
+		if err := inList(tx.Model(&Task{}), userID, projectID).
+			Order("rank DESC, id DESC").Limit(1).Pluck("rank", &last).Error; err != nil {
+			return "", fmt.Errorf("failed to get last rank: %w", err)
+		}
+
+		prev := ""
+		if len(last) > 0 {
+			prev = last[0]
+		}
+		rank := rankBetween(prev, "")
+		if len(rank) <= maxRankLen || attempt > 0 {
+			return rank, nil
+		}
+		if err := rebalanceList(tx, userID, projectID); err != nil {
+			return "", err
+		}
+	}
+}
+
+// rebalanceList заново расставляет ранги списка с равными промежутками, сохраняя порядок.
+// Порядок для клиента не меняется, поэтому версии задач и события не трогаются.
+func rebalanceList(tx *gorm.DB, userID uint32, projectID *uint32) error {
+	var ids []uint32
+	if err := inList(tx.Model(&Task{}), userID, projectID).
+		Order("rank, id").Pluck("id", &ids).Error; err != nil {
+		return fmt.Errorf("failed to get list order: %w", err)
+	}
+
+	for i, rank := range spreadRanks(len(ids)) {
+		if err := tx.Model(&Task{}).Where("id = ?", ids[i]).UpdateColumn("rank", rank).Error; err != nil {
+			return fmt.Errorf("failed to rebalance ranks: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// MoveTask ставит задачу id в её списке после beforeID и перед afterID (0 — соседа нет).
+// Обычно меняется ранг одной задачи; если между соседями нет места, список
+// перебалансируется в той же транзакции.
+func (r *taskRepo) MoveTask(id, beforeID, afterID uint32) (*domain.Task, error) {
+	var moved *domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var task Task
+		if err := tx.First(&task, uint(id)).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskNotFound
+			}
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+		if err := lockRanks(tx, task.UserID); err != nil {
+			return err
+		}
+
+		for attempt := 0; ; attempt++ {
+			lower, upper, err := moveBounds(tx, &task, beforeID, afterID)
+			if err != nil {
+				return err
+			}
+
+			if upper == "" || lower < upper {
+				rank := rankBetween(lower, upper)
+				if len(rank) <= maxRankLen || attempt > 0 {
+					task.Rank = rank
+					break
+				}
+			} else if attempt > 0 {
+				// соседи стоят в обратном порядке
+				return ErrInvalidMove
+			}
+			// равные ранги или слишком длинный ранг — перебалансируем и считаем заново
+			if err := rebalanceList(tx, task.UserID, task.ProjectID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&task).UpdateColumn("rank", task.Rank).Error; err != nil {
+			return fmt.Errorf("failed to move task: %w", err)
+		}
+
+		moved = task.toDomain()
+		if err := loadRelations(tx, moved); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.TaskUpdated, moved)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("MoveTask: %w", err)
+	}
+
+	return moved, nil
+}
+
+// moveBounds возвращает ранги, между которыми должна встать задача.
+// Если задан только один сосед, второй — ближайшая к нему задача списка.
+func moveBounds(tx *gorm.DB, task *Task, beforeID, afterID uint32) (string, string, error) {
+	var before, after *Task
+	for _, n := range []struct {
+		id  uint32
+		dst **Task
+	}{{beforeID, &before}, {afterID, &after}} {
+		if n.id == 0 {
+			continue
+		}
+		if n.id == uint32(task.ID) {
+			return "", "", ErrInvalidMove
+		}
+		var neighbour Task
+		err := inList(tx, task.UserID, task.ProjectID).First(&neighbour, n.id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", "", ErrInvalidMove
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to find neighbour task: %w", err)
+		}
+		*n.dst = &neighbour
+	}
+
+	others := inList(tx.Model(&Task{}), task.UserID, task.ProjectID).Where("id <> ?", task.ID)
+	var ranks []string
+	switch {
+	case before != nil && after != nil:
+		return before.Rank, after.Rank, nil
+	case before != nil:
+		if err := others.Where("(rank, id) > (?, ?)", before.Rank, before.ID).
+			Order("rank, id").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+			return "", "", fmt.Errorf("failed to find next task: %w", err)
+		}
+		if len(ranks) == 0 {
+			return before.Rank, "", nil
+		}
+		return before.Rank, ranks[0], nil
+	case after != nil:
+		if err := others.Where("(rank, id) < (?, ?)", after.Rank, after.ID).
+			Order("rank DESC, id DESC").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+			return "", "", fmt.Errorf("failed to find previous task: %w", err)
+		}
+		if len(ranks) == 0 {
+			return "", after.Rank, nil
+		}
+		return ranks[0], after.Rank, nil
+	default:
+		return "", "", ErrInvalidMove
+	}
+}
+
+// RebalanceRanks перебалансирует до limit списков, где ранги длиннее maxLen,
+// и возвращает число обработанных списков
+func (r *taskRepo) RebalanceRanks(maxLen, limit int) (int, error) {
+	type list struct {
+		UserID    uint32
+		ProjectID *uint32
+	}
+	var lists []list
+	if err := r.db.Model(&Task{}).
+		Select("user_id, project_id").
+		Group("user_id, project_id").
+		Having("MAX(length(rank)) > ?", maxLen).
+		Limit(limit).
+		Scan(&lists).Error; err != nil {
+		return 0, fmt.Errorf("RebalanceRanks: failed to find lists: %w", err)
+	}
+
+	for _, l := range lists {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			if err := lockRanks(tx, l.UserID); err != nil {
+				return err
+			}
+			return rebalanceList(tx, l.UserID, l.ProjectID)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("RebalanceRanks: %w", err)
+		}
+	}
+
+	return len(lists), nil
+}
+
+// MoveTask меняет место задачи в её списке
+func (s *tasksService) MoveTask(id, beforeID, afterID uint32) (*domain.Task, error) {
+	if beforeID == 0 && afterID == 0 {
+		return nil, ErrInvalidMove
+	}
+
+	task, err := s.repo.MoveTask(id, beforeID, afterID)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return task, nil
+}
+
+// RankRebalancer периодически укорачивает ранги в списках, где они разрослись
+// после многих перестановок, чтобы MoveTask почти всегда менял одну строку
+type RankRebalancer struct {
+	repo      TasksRepo
+	interval  time.Duration
+	maxLen    int
+	batchSize int
+}
+
+func NewRankRebalancer(repo TasksRepo, interval time.Duration, maxLen, batchSize int) *RankRebalancer {
+	return &RankRebalancer{repo: repo, interval: interval, maxLen: maxLen, batchSize: batchSize}
+}
+
+// Run перебалансирует списки до отмены ctx
+func (b *RankRebalancer) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			n, err := b.repo.RebalanceRanks(b.maxLen, b.batchSize)
+			if err != nil {
+				log.Printf("rank rebalancer: %v", err)
+				break
+			}
+			if n < b.batchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package tasks
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// checkBetween проверяет, что r лежит строго между a и b и не заканчивается на '0'
+func checkBetween(t *testing.T, a, b, r string) {
+	t.Helper()
+	if r <= a || (b != "" && r >= b) || strings.HasSuffix(r, "0") {
+		t.Fatalf("rankBetween(%q, %q) = %q", a, b, r)
+	}
+}
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		a, b, want string
+	}{
+		{"", "", "i"},
+		{"i", "", "j"},
+		{"z", "", "zi"},
+		{"zz5", "", "zz6"},
+		{"", "i", "h"},
+		{"", "1", "0i"},
+		{"", "01", "00i"},
+		{"a", "c", "b"},
+		{"a", "b", "ai"},
+		{"a", "b1", "b"},
+		{"ab", "ac", "abi"},
+		{"a1", "a2", "a1i"},
+		{"1", "11", "10i"},
+	}
+	for _, tt := range tests {
+		got := rankBetween(tt.a, tt.b)
+		if got != tt.want {
+			t.Errorf("rankBetween(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+		checkBetween(t, tt.a, tt.b, got)
+	}
+}
+
+func TestRankBetweenRandomInserts(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	ranks := []string{rankBetween("", "")}
+	for range 2000 {
+		i := rnd.Intn(len(ranks) + 1)
+		var a, b string
+		if i > 0 {
+			a = ranks[i-1]
+		}
+		if i < len(ranks) {
+			b = ranks[i]
+		}
+		r := rankBetween(a, b)
+		checkBetween(t, a, b, r)
+		ranks = append(ranks[:i], append([]string{r}, ranks[i:]...)...)
+	}
+}
+
+func TestRankBetweenAppendGrowth(t *testing.T) {
+	// вставка в конец и в начало сдвигает одну цифру: ранг удлиняется на цифру
+	// не чаще, чем раз в rankBase/2-1 вставок
+	const n = 1000
+	last, first := "", ""
+	for range n {
+		last = rankBetween(last, "")
+		first = rankBetween("", first)
+	}
+	if maxLen := 1 + n/(rankBase/2-1); len(last) > maxLen || len(first) > maxLen {
+		t.Errorf("after %d inserts: last %q (%d), first %q (%d), want at most %d",
+			n, last, len(last), first, len(first), maxLen)
+	}
+}
+
+func TestSpreadRanks(t *testing.T) {
+	tests := []struct {
+		n         int
+		wantWidth int
+	}{
+		{n: 1, wantWidth: 1},
+		{n: 34, wantWidth: 1},
+		{n: 35, wantWidth: 2},
+		{n: 1000, wantWidth: 2},
+		{n: 1295, wantWidth: 3},
+	}
+	for _, tt := range tests {
+		ranks := spreadRanks(tt.n)
+		if len(ranks) != tt.n {
+			t.Fatalf("spreadRanks(%d) returned %d ranks", tt.n, len(ranks))
+		}
+		prev := ""
+		for i, r := range ranks {
+			if r <= prev || strings.HasSuffix(r, "0") || len(r) > tt.wantWidth {
+				t.Fatalf("spreadRanks(%d)[%d] = %q after %q", tt.n, i, r, prev)
+			}
+			prev = r
+		}
+		// между соседними рангами и по краям остается место
+		checkBetween(t, "", ranks[0], rankBetween("", ranks[0]))
+		checkBetween(t, ranks[tt.n-1], "", rankBetween(ranks[tt.n-1], ""))
+	}
+}
@@ -0,0 +1,481 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/rrule"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateSeries создает серию и её первое повторение в момент first
+func (r *taskRepo) CreateSeries(s *domain.Series, first time.Time) (*domain.Task, error) {
+	var created *domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if s.ProjectID != nil {
+			if err := checkProject(tx, s.UserID, *s.ProjectID); err != nil {
+				return err
+			}
+		}
+
+		series := TaskSeries{UserID: s.UserID, RRule: s.RRule, DTStart: s.DTStart, Task: s.Task,
+			Priority: int16(s.Priority), ProjectID: s.ProjectID, Active: true, GeneratedUntil: first}
+		if err := tx.Create(&series).Error; err != nil {
+			return fmt.Errorf("failed to create series: %w", err)
+		}
+
+		occurrences, err := insertOccurrences(tx, &series, first)
+		if err != nil {
+			return err
+		}
+		created = occurrences[0]
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateSeries: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateSeries меняет серию, начиная с повторения taskID: шаблон серии, само повторение
+// и следующие открытые. Новое правило начинает с этого повторения новую серию, а
+// будущие повторения старой в статусе todo удаляются; пустое правило завершает серию.
+func (r *taskRepo) UpdateSeries(taskID, version uint32, upd domain.SeriesUpdate) (*domain.Task, error) {
+	var edited *domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ormTask Task
+		if err := tx.First(&ormTask, uint(taskID)).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskNotFound
+			}
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+		if ormTask.SeriesID == nil || ormTask.OccurrenceAt == nil {
+			return ErrNotRecurring
+		}
+
+		series, err := lockSeries(tx, *ormTask.SeriesID)
+		if err != nil {
+			return err
+		}
+		if !series.Active {
+			return ErrSeriesEnded
+		}
+		from := *ormTask.OccurrenceAt
+
+		if upd.Task != nil {
+			series.Task = *upd.Task
+		}
+		if upd.Priority != nil {
+			series.Priority = int16(*upd.Priority)
+		}
+
+		values := map[string]any{
+			"task":     series.Task,
+			"priority": series.Priority,
+			"version":  gorm.Expr("version + 1"),
+		}
+		if upd.RRule != nil && *upd.RRule != series.RRule {
+			if err := tx.Model(series).UpdateColumn("active", false).Error; err != nil {
+				return fmt.Errorf("failed to end series: %w", err)
+			}
+			series.Active = false
+			if err := deleteOccurrencesAfter(tx, series.ID, from); err != nil {
+				return err
+			}
+
+			if *upd.RRule != "" {
+				series = &TaskSeries{UserID: series.UserID, RRule: *upd.RRule, DTStart: from, Task: series.Task,
+					Priority: series.Priority, ProjectID: series.ProjectID, Active: true, GeneratedUntil: from}
+				if err := tx.Create(series).Error; err != nil {
+					return fmt.Errorf("failed to create series: %w", err)
+				}
+				values["series_id"] = series.ID
+			}
+		} else if err := tx.Model(series).Updates(map[string]any{
+			"task":     series.Task,
+			"priority": series.Priority,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update series: %w", err)
+		}
+
+		var updated []Task
+		res := tx.Model(&updated).
+			Clauses(clause.Returning{}).
+			Where("id = ? AND version = ?", taskID, version).
+			Updates(values)
+		if res.Error != nil {
+			return fmt.Errorf("failed to update task: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return r.updateMissError(tx, taskID)
+		}
+
+		// следующие открытые повторения получают новый шаблон
+		if series.Active && (upd.Task != nil || upd.Priority != nil) {
+			var following []Task
+			if err := tx.Model(&following).
+				Clauses(clause.Returning{}).
+				Where("series_id = ? AND occurrence_at > ? AND status NOT IN ?", series.ID, from, closedStatuses).
+				Updates(map[string]any{
+					"task":     series.Task,
+					"priority": series.Priority,
+					"version":  gorm.Expr("version + 1"),
+				}).Error; err != nil {
+				return fmt.Errorf("failed to update occurrences: %w", err)
+			}
+			updated = append(updated, following...)
+		}
+
+		changed := make([]*domain.Task, len(updated))
+		for i := range updated {
+			changed[i] = updated[i].toDomain()
+		}
+		if err := loadRelations(tx, changed...); err != nil {
+			return err
+		}
+		edited = changed[0]
+		return appendEvents(tx, domain.TaskUpdated, changed...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("UpdateSeries: %w", err)
+	}
+	return edited, nil
+}
+
+// GenerateOccurrences создает повторения до now+horizon для не больше limit серий,
+// у которых они ещё не созданы. Прошедшие повторения не создаются. Каждая серия
+// обрабатывается своей транзакцией, чтобы не держать блокировки всей пачки.
+// Возвращает число обработанных серий и созданных задач, в том числе при ошибке;
+// серии берутся с SKIP LOCKED, поэтому генератор может работать на нескольких репликах.
+func (r *taskRepo) GenerateOccurrences(now time.Time, horizon time.Duration, limit int) (int, int, error) {
+	until := now.Add(horizon)
+	var processed, created int
+
+	for processed < limit {
+		n, found, err := r.generateSeriesOccurrences(now, until)
+		if err != nil {
+			return processed, created, fmt.Errorf("GenerateOccurrences: %w", err)
+		}
+		if !found {
+			break
+		}
+		processed++
+		created += n
+	}
+
+	return processed, created, nil
+}
+
+// generateSeriesOccurrences создает повторения до until для одной серии, у которой
+// они ещё не созданы. found = false, если таких серий нет.
+func (r *taskRepo) generateSeriesOccurrences(now, until time.Time) (created int, found bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var due []TaskSeries
+		if err := tx.Where("active AND generated_until < ?", until).
+			Order("generated_until").
+			Limit(1).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&due).Error; err != nil {
+			return fmt.Errorf("failed to find series: %w", err)
+		}
+		if len(due) == 0 {
+			return nil
+		}
+		found = true
+
+		s := &due[0]
+		values := map[string]any{"generated_until": until}
+
+		rule, err := rrule.Parse(s.RRule)
+		if err != nil {
+			// правило проверяется при записи, сюда попадает только испорченная строка
+			log.Printf("occurrence generator: series %d: %v", s.ID, err)
+			values["active"] = false
+		} else {
+			after := s.GeneratedUntil
+			if after.Before(now) {
+				after = now
+			}
+			occurrences, err := insertOccurrences(tx, s, rule.Between(s.DTStart, after, until)...)
+			if err != nil {
+				return err
+			}
+			created = len(occurrences)
+
+			// правило исчерпано (COUNT или UNTIL) — серия завершена
+			if _, ok := rule.After(s.DTStart, until); !ok {
+				values["active"] = false
+			}
+		}
+
+		if err := tx.Model(s).UpdateColumns(values).Error; err != nil {
+			return fmt.Errorf("failed to update series %d: %w", s.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	return created, found, nil
+}
+
+// lockSeries блокирует серию до конца транзакции
+func lockSeries(tx *gorm.DB, id uint32) (*TaskSeries, error) {
+	var s TaskSeries
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&s, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotRecurring
+		}
+		return nil, fmt.Errorf("failed to lock series: %w", err)
+	}
+	return &s, nil
+}
+
+// insertOccurrences создает повторения серии в моменты times и пишет события.
+// Уже существующие повторения, в том числе удаленные пользователем, пропускаются.
+func insertOccurrences(tx *gorm.DB, s *TaskSeries, times ...time.Time) ([]*domain.Task, error) {
+	created := make([]*domain.Task, 0, len(times))
+	for _, at := range times {
+		at := at
+		rank, err := appendRank(tx, s.UserID, s.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		t := Task{Task: s.Task, Status: string(domain.StatusTodo), Priority: s.Priority, UserID: s.UserID,
+			DueAt: &at, ProjectID: s.ProjectID, SeriesID: &s.ID, OccurrenceAt: &at, Rank: rank}
+		res := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "series_id"}, {Name: "occurrence_at"}},
+			DoNothing: true,
+		}).Create(&t)
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to create occurrence: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+
+		dm := t.toDomain()
+		if s.Active {
+			dm.RRule = s.RRule
+		}
+		created = append(created, dm)
+	}
+
+	return created, appendEvents(tx, domain.TaskCreated, created...)
+}
+
+// spawnNextOccurrence создает повторение, следующее за выполненным t.
+// Пропущенные повторения не создаются: следующее ищется после планового времени t
+// и после момента его выполнения.
+func spawnNextOccurrence(tx *gorm.DB, t *domain.Task) error {
+	series, err := lockSeries(tx, *t.SeriesID)
+	if err != nil {
+		return err
+	}
+	if !series.Active {
+		return nil
+	}
+
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return fmt.Errorf("series %d: %w", series.ID, err)
+	}
+
+	after := *t.OccurrenceAt
+	if t.CompletedAt != nil && t.CompletedAt.After(after) {
+		after = *t.CompletedAt
+	}
+	next, ok := rule.After(series.DTStart, after)
+	if !ok {
+		if err := tx.Model(series).UpdateColumn("active", false).Error; err != nil {
+			return fmt.Errorf("failed to end series: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := insertOccurrences(tx, series, next); err != nil {
+		return err
+	}
+	if next.After(series.GeneratedUntil) {
+		if err := tx.Model(series).UpdateColumn("generated_until", next).Error; err != nil {
+			return fmt.Errorf("failed to update series: %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteOccurrencesAfter мягко удаляет повторения серии в статусе todo после from.
+// Повторения с подзадачами остаются.
+func deleteOccurrencesAfter(tx *gorm.DB, seriesID uint32, from time.Time) error {
+	var future []Task
+	if err := tx.Where("series_id = ? AND occurrence_at > ? AND status = ?", seriesID, from, string(domain.StatusTodo)).
+		Where("NOT EXISTS (SELECT 1 FROM tasks c WHERE c.parent_id = tasks.id AND c.deleted_at IS NULL)").
+		Find(&future).Error; err != nil {
+		return fmt.Errorf("failed to find occurrences: %w", err)
+	}
+	if len(future) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(future))
+	removed := make([]*domain.Task, len(future))
+	for i := range future {
+		ids[i] = future[i].ID
+		removed[i] = future[i].toDomain()
+	}
+	if err := tx.Where("id IN ?", ids).Delete(&Task{}).Error; err != nil {
+		return fmt.Errorf("failed to delete occurrences: %w", err)
+	}
+
+	return appendEvents(tx, domain.TaskDeleted, removed...)
+}
+
+// loadSeriesRules заполняет RRule повторений действующих серий одним запросом
+func loadSeriesRules(db *gorm.DB, tasks ...*domain.Task) error {
+	var ids []uint32
+	for _, t := range tasks {
+		if t.SeriesID != nil {
+			ids = append(ids, *t.SeriesID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var series []TaskSeries
+	if err := db.Select("id", "rrule").Where("id IN ? AND active", uniqueIDs(ids)).Find(&series).Error; err != nil {
+		return fmt.Errorf("failed to load series: %w", err)
+	}
+
+	rules := make(map[uint32]string, len(series))
+	for _, s := range series {
+		rules[s.ID] = s.RRule
+	}
+	for _, t := range tasks {
+		if t.SeriesID != nil {
+			t.RRule = rules[*t.SeriesID]
+		}
+	}
+
+	return nil
+}
+
+// parseRule проверяет правило повторения и возвращает его каноническую запись
+func parseRule(s string) (*rrule.Rule, string, error) {
+	rule, err := rrule.Parse(s)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	return rule, rule.String(), nil
+}
+
+// createSeries создает серию повторений по шаблону t и возвращает её первое повторение.
+// Правило отсчитывается от due_at, без него — от текущего времени.
+func (s *tasksService) createSeries(t *domain.Task, priority domain.Priority) (*domain.Task, error) {
+	if t.ParentID != nil || t.RemindAt != nil || t.IsDone || (t.Status != "" && t.Status != domain.StatusTodo) {
+		return nil, ErrRecurrenceOptions
+	}
+
+	rule, canonical, err := parseRule(t.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	dtstart := time.Now().UTC().Truncate(time.Second)
+	if t.DueAt != nil {
+		dtstart = *t.DueAt
+	}
+	first, ok := rule.After(dtstart, dtstart.Add(-time.Nanosecond))
+	if !ok {
+		return nil, fmt.Errorf("%w: rule has no occurrences after its start", ErrInvalidRecurrence)
+	}
+
+	created, err := s.repo.CreateSeries(&domain.Series{UserID: t.UserID, RRule: canonical, DTStart: dtstart,
+		Task: t.Task, Priority: priority, ProjectID: t.ProjectID}, first)
+	if err != nil {
+		return nil, fmt.Errorf("CreateTask: failed to create the series: %w", err)
+	}
+	s.broadcaster.Notify()
+	return created, nil
+}
+
+// updateSeries применяет изменение upd ко всей серии повторения id
+func (s *tasksService) updateSeries(id, version uint32, upd domain.TaskUpdate) (*domain.Task, error) {
+	if upd.IsDone != nil || upd.Status != nil || upd.DueAt != nil || upd.RemindAt != nil || upd.ParentID != nil {
+		return nil, ErrInvalidEditScope
+	}
+	if upd.Task != nil && strings.TrimSpace(*upd.Task) == "" {
+		return nil, ErrInvalidInput
+	}
+	if upd.Priority != nil && !validPriority(*upd.Priority) {
+		return nil, ErrInvalidPriority
+	}
+
+	series := domain.SeriesUpdate{Task: upd.Task, Priority: upd.Priority, RRule: upd.RRule}
+	if upd.RRule != nil && *upd.RRule != "" {
+		_, canonical, err := parseRule(*upd.RRule)
+		if err != nil {
+			return nil, err
+		}
+		series.RRule = &canonical
+	}
+
+	updated, err := s.repo.UpdateSeries(id, version, series)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return updated, nil
+}
+
+// OccurrenceGenerator периодически создает повторения серий на horizon вперед,
+// чтобы ближайшие повторения были видны в списках заранее
+type OccurrenceGenerator struct {
+	repo        TasksRepo
+	broadcaster *Broadcaster
+	interval    time.Duration
+	horizon     time.Duration
+	batchSize   int
+}
+
+func NewOccurrenceGenerator(repo TasksRepo, b *Broadcaster, interval, horizon time.Duration, batchSize int) *OccurrenceGenerator {
+	return &OccurrenceGenerator{repo: repo, broadcaster: b, interval: interval, horizon: horizon, batchSize: batchSize}
+}
+
+// Run создает повторения до отмены ctx
+func (g *OccurrenceGenerator) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			n, created, err := g.repo.GenerateOccurrences(time.Now(), g.horizon, g.batchSize)
+			// серии до ошибки уже сохранены
+			if created > 0 {
+				g.broadcaster.Notify()
+			}
+			if err != nil {
+				log.Printf("occurrence generator: %v", err)
+				break
+			}
+			if n < g.batchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+// seriesRepo запоминает изменение серии, переданное в UpdateSeries
+type seriesRepo struct {
+	TasksRepo
+	updated *domain.SeriesUpdate
+}
+
+func (r *seriesRepo) UpdateSeries(taskID, version uint32, upd domain.SeriesUpdate) (*domain.Task, error) {
+	r.updated = &upd
+	return &domain.Task{ID: taskID, Version: version + 1}, nil
+}
+
+func TestUpdateTaskSeriesScope(t *testing.T) {
+	str := func(s string) *string { return &s }
+	priority := func(p domain.Priority) *domain.Priority { return &p }
+	done, status := true, domain.StatusDone
+	var noDate *time.Time
+
+	tests := []struct {
+		name     string
+		upd      domain.TaskUpdate
+		wantErr  error
+		wantRule *string
+	}{
+		{
+			name: "title and priority",
+			upd:  domain.TaskUpdate{Task: str("Спорт"), Priority: priority(domain.PriorityHigh), Scope: domain.ScopeSeries},
+		},
+		{
+			name:     "rule is stored canonical",
+			upd:      domain.TaskUpdate{RRule: str("rrule:freq=weekly;byday=fr,mo"), Scope: domain.ScopeSeries},
+			wantRule: str("FREQ=WEEKLY;BYDAY=MO,FR"),
+		},
+		{
+			name:     "empty rule ends the series",
+			upd:      domain.TaskUpdate{RRule: str(""), Scope: domain.ScopeSeries},
+			wantRule: str(""),
+		},
+		{
+			name:    "invalid rule",
+			upd:     domain.TaskUpdate{RRule: str("FREQ=HOURLY"), Scope: domain.ScopeSeries},
+			wantErr: ErrInvalidRecurrence,
+		},
+		{
+			name:    "rule for one occurrence",
+			upd:     domain.TaskUpdate{RRule: str("FREQ=DAILY")},
+			wantErr: ErrInvalidEditScope,
+		},
+		{
+			name:    "status of the series",
+			upd:     domain.TaskUpdate{Status: &status, Scope: domain.ScopeSeries},
+			wantErr: ErrInvalidEditScope,
+		},
+		{
+			name:    "is_done of the series",
+			upd:     domain.TaskUpdate{IsDone: &done, Scope: domain.ScopeSeries},
+			wantErr: ErrInvalidEditScope,
+		},
+		{
+			name:    "due date of the series",
+			upd:     domain.TaskUpdate{DueAt: &noDate, Scope: domain.ScopeSeries},
+			wantErr: ErrInvalidEditScope,
+		},
+		{
+			name:    "reminder of the series",
+			upd:     domain.TaskUpdate{RemindAt: &noDate, Scope: domain.ScopeSeries},
+			wantErr: ErrInvalidEditScope,
+		},
+		{
+			name:    "empty title",
+			upd:     domain.TaskUpdate{Task: str("  "), Scope: domain.ScopeSeries},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "unknown priority",
+			upd:     domain.TaskUpdate{Priority: priority(7), Scope: domain.ScopeSeries},
+			wantErr: ErrInvalidPriority,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &seriesRepo{}
+			s := NewTasksService(repo, NewBroadcaster(repo), nil)
+
+			_, err := s.UpdateTask(1, 3, tt.upd)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateTask() = %v, want %v", err, tt.wantErr)
+			}
+			if (repo.updated != nil) != (tt.wantErr == nil) {
+				t.Fatalf("series updated = %v", repo.updated != nil)
+			}
+			if err != nil {
+				return
+			}
+			if got := repo.updated; got.Task != tt.upd.Task || got.Priority != tt.upd.Priority {
+				t.Errorf("title, priority = %v, %v; want %v, %v", got.Task, got.Priority, tt.upd.Task, tt.upd.Priority)
+			}
+			if got := repo.updated.RRule; (got == nil) != (tt.wantRule == nil) || got != nil && *got != *tt.wantRule {
+				t.Errorf("RRule = %v, want %v", got, tt.wantRule)
+			}
+		})
+	}
+}
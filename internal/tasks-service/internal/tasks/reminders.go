@@ -0,0 +1,98 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClaimDueReminders отмечает до limit незавершенных задач с наступившим remind_at как
+// напомненные и в той же транзакции пишет для каждой событие reminder. Отметка и событие
+// фиксируются вместе, поэтому напоминание отправляется ровно один раз, в том числе
+// после перезапуска и при нескольких репликах (строки берутся с SKIP LOCKED).
+func (r *taskRepo) ClaimDueReminders(now time.Time, limit int) (int, error) {
+	var claimed int
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var due []Task
+		// о выполненных и отмененных задачах не напоминаем
+		if err := tx.Where("remind_at <= ? AND reminded_at IS NULL", now).
+			Where("status NOT IN ?", closedStatuses).
+			Order("remind_at").
+			Limit(limit).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&due).Error; err != nil {
+			return fmt.Errorf("failed to find due reminders: %w", err)
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(due))
+		reminded := make([]*domain.Task, len(due))
+		for i := range due {
+			ids[i] = due[i].ID
+			due[i].RemindedAt = &now
+			reminded[i] = due[i].toDomain()
+		}
+
+		// UpdateColumn не трогает updated_at и версию: напоминание не меняет задачу для клиента
+		if err := tx.Model(&Task{}).Where("id IN ?", ids).UpdateColumn("reminded_at", now).Error; err != nil {
+			return fmt.Errorf("failed to mark reminders: %w", err)
+		}
+
+		claimed = len(due)
+		return appendEvents(tx, domain.TaskReminder, reminded...)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("ClaimDueReminders: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// ReminderScheduler периодически отправляет наступившие напоминания о задачах
+// подписчикам WatchTasks
+type ReminderScheduler struct {
+	repo        TasksRepo
+	broadcaster *Broadcaster
+	interval    time.Duration
+	batchSize   int
+}
+
+func NewReminderScheduler(repo TasksRepo, b *Broadcaster, interval time.Duration, batchSize int) *ReminderScheduler {
+	return &ReminderScheduler{repo: repo, broadcaster: b, interval: interval, batchSize: batchSize}
+}
+
+// Run отправляет напоминания до отмены ctx
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			claimed, err := s.repo.ClaimDueReminders(time.Now(), s.batchSize)
+			if err != nil {
+				log.Printf("reminder scheduler: %v", err)
+				break
+			}
+			if claimed > 0 {
+				s.broadcaster.Notify()
+			}
+			if claimed < s.batchSize {
+				break
+			}
+		}
+	}
+}
@@ -3,20 +3,78 @@ package tasks
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/your-org/tasks-service/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TasksRepo interface {
 	CreateTask(t *domain.Task) (*domain.Task, error)
 	GetAllTasks() ([]*domain.Task, error)
 	UpdateTask(t *domain.Task) (*domain.Task, error)
-	DeleteTask(id uint32) error
+	DeleteTask(id uint32, children domain.ChildrenPolicy) error
+	GetSubtree(id uint32) ([]*domain.TaskNode, error)
+	AddDependency(taskID, blockerID uint32) (*domain.Task, error)
+	RemoveDependency(taskID, blockerID uint32) (*domain.Task, error)
+	ListDependencies(userID uint32) ([]domain.Dependency, error)
+	CreateProject(p *domain.Project) (*domain.Project, error)
+	GetProject(id uint32) (*domain.Project, error)
+	UpdateProject(p *domain.Project) (*domain.Project, error)
+	DeleteProject(id uint32) error
+	ListProjects(userID uint32, includeArchived bool) ([]*domain.Project, error)
+	ReorderProjects(userID uint32, ids []uint32) ([]*domain.Project, error)
+	ListTasksByProject(projectID uint32) ([]*domain.Task, error)
+	MoveTaskToProject(taskID uint32, projectID *uint32) (*domain.Task, error)
+	MoveTask(id, beforeID, afterID uint32) (*domain.Task, error)
+	RebalanceRanks(maxLen, limit int) (int, error)
+	CreateComment(c *domain.Comment) (*domain.Comment, error)
+	GetComment(id uint32) (*domain.Comment, error)
+	EditComment(id, authorID uint32, body string) (*domain.Comment, error)
+	DeleteComment(id, authorID uint32) error
+	ListComments(taskID, afterID uint32, limit int) ([]*domain.Comment, error)
+	ListCommentEdits(commentID uint32) ([]*domain.CommentEdit, error)
+	CreateAttachment(a *domain.Attachment) (*domain.Attachment, error)
+	GetAttachment(id uint32) (*domain.Attachment, error)
+	ListAttachments(taskID uint32) ([]*domain.Attachment, error)
+	DeleteAttachment(id uint32) (*domain.Attachment, error)
+	CreateSeries(s *domain.Series, first time.Time) (*domain.Task, error)
+	UpdateSeries(taskID, version uint32, upd domain.SeriesUpdate) (*domain.Task, error)
+	GenerateOccurrences(now time.Time, horizon time.Duration, limit int) (int, int, error)
+	ListTaskHistory(taskID uint32, afterID uint64, limit int) ([]*domain.HistoryEntry, error)
+	WithActor(actorID uint32) TasksRepo
 	GetByID(id uint32) (*domain.Task, error)
-	ListTasksByUser(userId uint32) ([]*domain.Task, error)
+	ListTasksByUser(userId uint32, filter domain.TaskFilter) ([]*domain.Task, error)
+	ListTasksAfter(userID, afterID uint32, limit int) ([]*domain.Task, error)
+	DeleteTasksByUser(eventID string, userID uint32) (int64, error)
+	RestoreTasksByUser(eventID string, userID uint32) (int64, error)
+	ListEventsSince(userID uint32, revision uint64, limit int) ([]*domain.TaskEvent, error)
+	ListEventsAfter(cursors map[uint32]uint64, limit int) ([]*domain.TaskEvent, error)
+	LastEventRevision(userID uint32) (uint64, error)
+	ClaimDueReminders(now time.Time, limit int) (int, error)
+	CreateTag(t *domain.Tag) (*domain.Tag, error)
+	GetTagByID(id uint32) (*domain.Tag, error)
+	RenameTag(id uint32, name string) (*domain.Tag, error)
+	DeleteTag(id uint32) error
+	ListTags(userID uint32) ([]*domain.Tag, error)
+	AttachTag(taskID, tagID uint32) (*domain.Task, error)
+	DetachTag(taskID, tagID uint32) (*domain.Task, error)
+	ListDeletedTasks(userID, afterID uint32, limit int) ([]*domain.DeletedTask, error)
+	RestoreTask(userID, id uint32) (*domain.Task, error)
+	PurgeTask(userID, id uint32) ([]string, error)
+	PurgeDeletedBefore(cutoff time.Time, limit int) (int, []string, error)
+	UserData(userID uint32) (*domain.UserData, error)
+	EraseUserData(userID uint32) (*domain.ErasedData, []string, error)
+	OwnerOf(kind domain.EntityKind, id uint32) (uint32, error)
+	// InTx выполняет fn с репозиторием, привязанным к одной транзакции
+	InTx(fn func(repo TasksRepo) error) error
 }
 
+// closedStatuses — статусы, для которых срок выполнения уже не важен
+var closedStatuses = []string{string(domain.StatusDone), string(domain.StatusCanceled)}
+
 type taskRepo struct {
 	db *gorm.DB
 }
@@ -28,8 +86,29 @@ func NewTaskRepo(db *gorm.DB) TasksRepo {
 // CreateTask создает запись и возвращает domain модель
 func (r *taskRepo) CreateTask(dm *domain.Task) (*domain.Task, error) {
 	ormTask := (&Task{}).toORM(dm)
-	if err := r.db.Create(ormTask).Error; err != nil {
-		return nil, fmt.Errorf("CreateTask: failed to create task: %w", err)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if dm.ParentID != nil {
+			if err := checkParent(tx, 0, dm.UserID, *dm.ParentID); err != nil {
+				return err
+			}
+		}
+		if dm.ProjectID != nil {
+			if err := checkProject(tx, dm.UserID, *dm.ProjectID); err != nil {
+				return err
+			}
+		}
+		rank, err := appendRank(tx, dm.UserID, dm.ProjectID)
+		if err != nil {
+			return err
+		}
+		ormTask.Rank = rank
+		if err := tx.Create(ormTask).Error; err != nil {
+			return fmt.Errorf("failed to create task: %w", err)
+		}
+		return appendEvents(tx, domain.TaskCreated, ormTask.toDomain())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CreateTask: %w", err)
 	}
 	return ormTask.toDomain(), nil
 }
@@ -45,30 +124,149 @@ func (r *taskRepo) GetAllTasks() ([]*domain.Task, error) {
 	for i := range ormTasks {
 		domainTasks[i] = ormTasks[i].toDomain()
 	}
+	if err := loadRelations(r.db, domainTasks...); err != nil {
+		return nil, fmt.Errorf("GetAllTasks: %w", err)
+	}
 	return domainTasks, nil
 }
 
-// UpdateTask обновляет orm модель на основе domain и возвращает domain
+// UpdateTask обновляет задачу, если её версия в БД всё ещё равна dm.Version,
+// и увеличивает версию. Иначе возвращает ErrVersionConflict.
 func (r *taskRepo) UpdateTask(dm *domain.Task) (*domain.Task, error) {
-	ormTask := (&Task{}).toORM(dm)
-	if err := r.db.Save(ormTask).Error; err != nil {
-		return nil, fmt.Errorf("UpdateTask: failed to save task: %w", err)
+	ormTask := Task{Model: gorm.Model{ID: uint(dm.ID)}}
+	var updated *domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if dm.ParentID != nil {
+			if err := checkParent(tx, dm.ID, dm.UserID, *dm.ParentID); err != nil {
+				return err
+			}
+		}
+		// завершение проверяется под блокировкой строки задачи и графа зависимостей:
+		// между проверкой блокирующих задач и записью статуса их набор не меняется.
+		// Завершение повторения серии создает следующее в той же транзакции.
+		spawnNext := false
+		if dm.Status == domain.StatusDone {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", dependenciesLockKey, int32(dm.UserID)).Error; err != nil {
+				return fmt.Errorf("failed to lock task dependencies: %w", err)
+			}
+			var prev []string
+			if err := tx.Model(&Task{}).
+				Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ? AND version = ?", dm.ID, dm.Version).
+				Pluck("status", &prev).Error; err != nil {
+				return fmt.Errorf("failed to check task status: %w", err)
+			}
+			if len(prev) == 1 && prev[0] != string(domain.StatusDone) {
+				if err := checkOpenBlockers(tx, dm.ID); err != nil {
+					return err
+				}
+				spawnNext = dm.SeriesID != nil
+			}
+		}
+		res := tx.Model(&ormTask).
+			Clauses(clause.Returning{}).
+			Where("version = ?", dm.Version).
+			Updates(map[string]any{
+				"task":         dm.Task,
+				"is_done":      dm.IsDone,
+				"status":       string(dm.Status),
+				"priority":     int16(dm.Priority),
+				"completed_at": dm.CompletedAt,
+				"due_at":       dm.DueAt,
+				"remind_at":    dm.RemindAt,
+				"reminded_at":  dm.RemindedAt,
+				"parent_id":    dm.ParentID,
+				"version":      gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return fmt.Errorf("failed to update task: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return r.updateMissError(tx, dm.ID)
+		}
+		updated = ormTask.toDomain()
+		updated.TagIDs = dm.TagIDs
+		updated.BlockerIDs = dm.BlockerIDs
+		updated.RRule = dm.RRule
+		if err := appendEvents(tx, domain.TaskUpdated, updated); err != nil {
+			return err
+		}
+		if spawnNext {
+			return spawnNextOccurrence(tx, updated)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("UpdateTask: %w", err)
 	}
-	return ormTask.toDomain(), nil
+	return updated, nil
 }
 
-// DeleteTask удаляет запись по id
-func (r *taskRepo) DeleteTask(id uint32) error {
-	var ormTask Task
-	if err := r.db.First(&ormTask, uint(id)).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrTaskNotFound
-		}
-		return fmt.Errorf("DeleteTask: failed to find task: %w", err)
+// updateMissError объясняет, почему условное обновление не затронуло строку
+func (r *taskRepo) updateMissError(tx *gorm.DB, id uint32) error {
+	var count int64
+	if err := tx.Model(&Task{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check task: %w", err)
 	}
+	if count == 0 {
+		return ErrTaskNotFound
+	}
+	return ErrVersionConflict
+}
+
+// DeleteTask удаляет запись по id, подзадачи обрабатываются по правилу children
+func (r *taskRepo) DeleteTask(id uint32, children domain.ChildrenPolicy) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ormTask Task
+		if err := tx.First(&ormTask, uint(id)).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskNotFound
+			}
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+		if err := lockHierarchy(tx, ormTask.UserID); err != nil {
+			return err
+		}
 
-	if err := r.db.Delete(&ormTask).Error; err != nil {
-		return fmt.Errorf("DeleteTask: failed to delete task: %w", err)
+		deleted := []*domain.Task{ormTask.toDomain()}
+		switch children {
+		case domain.ChildrenCascade:
+			rows, err := subtreeRows(tx, id)
+			if err != nil {
+				return err
+			}
+			// потомки идут после корня, корень уже в deleted
+			for _, row := range rows[1:] {
+				deleted = append(deleted, row.Task.toDomain())
+			}
+		case domain.ChildrenPromote:
+			if err := promoteChildren(tx, id, ormTask.ParentID); err != nil {
+				return err
+			}
+		default:
+			var count int64
+			if err := tx.Model(&Task{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+				return fmt.Errorf("failed to count subtasks: %w", err)
+			}
+			if count > 0 {
+				return ErrTaskHasChildren
+			}
+		}
+
+		ids := make([]uint32, len(deleted))
+		for i, t := range deleted {
+			ids[i] = t.ID
+		}
+		if err := loadRelations(tx, deleted...); err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", ids).Delete(&Task{}).Error; err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+		return appendEvents(tx, domain.TaskDeleted, deleted...)
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteTask: %w", err)
 	}
 
 	return nil
@@ -84,13 +282,32 @@ func (r *taskRepo) GetByID(id uint32) (*domain.Task, error) {
 		return nil, fmt.Errorf("GetByID: failed to find task: %w", err)
 	}
 
-	return ormTask.toDomain(), nil
+	dm := ormTask.toDomain()
+	if err := loadRelations(r.db, dm); err != nil {
+		return nil, fmt.Errorf("GetByID: %w", err)
+	}
+	return dm, nil
 }
 
-func (r *taskRepo) ListTasksByUser(userID uint32) ([]*domain.Task, error) {
+// ListTasksByUser возвращает задачи пользователя, отобранные по filter
+func (r *taskRepo) ListTasksByUser(userID uint32, filter domain.TaskFilter) ([]*domain.Task, error) {
 	var tasks []Task
 
-	if err := r.db.Where("user_id =?", userID).Find(&tasks).Error; err != nil {
+	q := r.db.Where("user_id = ?", userID)
+
+	now := time.Now()
+	switch filter.Due {
+	case domain.DueOverdue:
+		q = q.Where("status NOT IN ? AND due_at < ?", closedStatuses, now).Order("due_at")
+	case domain.DueSoon:
+		q = q.Where("status NOT IN ? AND due_at >= ? AND due_at < ?", closedStatuses, now, now.Add(filter.DueWithin)).Order("due_at")
+	default:
+		// списки идут друг за другом, внутри списка — ручной порядок
+		q = q.Order("project_id NULLS FIRST, rank, id")
+	}
+	q = filterByTags(q, filter)
+
+	if err := q.Find(&tasks).Error; err != nil {
 		return nil, fmt.Errorf("ListTasksByUser: failed to get tasks: %w", err)
 	}
 
@@ -100,6 +317,139 @@ func (r *taskRepo) ListTasksByUser(userID uint32) ([]*domain.Task, error) {
 		dm := t.toDomain()
 		out = append(out, dm)
 	}
+	if err := loadRelations(r.db, out...); err != nil {
+		return nil, fmt.Errorf("ListTasksByUser: %w", err)
+	}
 
 	return out, nil
 }
+
+// DeleteTasksByUser мягко удаляет все задачи пользователя в ответ на событие eventID.
+// Повторная доставка того же события ничего не меняет и возвращает 0.
+func (r *taskRepo) DeleteTasksByUser(eventID string, userID uint32) (int64, error) {
+	var deleted int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		mark := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&ProcessedEvent{EventID: eventID, ProcessedAt: time.Now()})
+		if mark.Error != nil {
+			return fmt.Errorf("failed to mark event: %w", mark.Error)
+		}
+		if mark.RowsAffected == 0 {
+			return nil
+		}
+
+		// серии удаленного пользователя больше не создают повторений, пока он не восстановлен
+		if err := tx.Model(&TaskSeries{}).Where("user_id = ? AND active", userID).
+			Updates(map[string]any{"active": false, "paused_with_user": true}).Error; err != nil {
+			return fmt.Errorf("failed to end series: %w", err)
+		}
+
+		var ormTasks []Task
+		if err := tx.Where("user_id = ?", userID).Find(&ormTasks).Error; err != nil {
+			return fmt.Errorf("failed to find tasks: %w", err)
+		}
+		if len(ormTasks) == 0 {
+			return nil
+		}
+
+		// отметка отличает задачи, удаленные вместе с пользователем, от удаленных раньше им самим
+		if err := tx.Model(&Task{}).Where("user_id = ?", userID).Update("deleted_with_user", true).Error; err != nil {
+			return fmt.Errorf("failed to mark tasks: %w", err)
+		}
+		res := tx.Where("user_id = ?", userID).Delete(&Task{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete tasks: %w", res.Error)
+		}
+		deleted = res.RowsAffected
+
+		removed := make([]*domain.Task, len(ormTasks))
+		for i := range ormTasks {
+			removed[i] = ormTasks[i].toDomain()
+		}
+
+		return appendEvents(tx, domain.TaskDeleted, removed...)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("DeleteTasksByUser: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// RestoreTasksByUser возвращает из корзины задачи, удаленные вместе с пользователем
+// (DeleteTasksByUser), и продолжает остановленные тогда серии. Задачи, которые
+// пользователь удалил сам, остаются в корзине; задача, чей родитель остался в
+// корзине, становится задачей верхнего уровня. Повторная доставка события возвращает 0.
+func (r *taskRepo) RestoreTasksByUser(eventID string, userID uint32) (int64, error) {
+	var restored int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		mark := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&ProcessedEvent{EventID: eventID, ProcessedAt: time.Now()})
+		if mark.Error != nil {
+			return fmt.Errorf("failed to mark event: %w", mark.Error)
+		}
+		if mark.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&TaskSeries{}).Where("user_id = ? AND paused_with_user", userID).
+			Updates(map[string]any{"active": true, "paused_with_user": false}).Error; err != nil {
+			return fmt.Errorf("failed to resume series: %w", err)
+		}
+
+		if err := lockHierarchy(tx, userID); err != nil {
+			return err
+		}
+		var ormTasks []Task
+		if err := tx.Unscoped().Model(&ormTasks).
+			Clauses(clause.Returning{}).
+			Where("user_id = ? AND deleted_with_user AND deleted_at IS NOT NULL", userID).
+			Updates(map[string]any{
+				"deleted_at":        nil,
+				"deleted_with_user": false,
+				"version":           gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to restore tasks: %w", err)
+		}
+		if len(ormTasks) == 0 {
+			return nil
+		}
+		restored = int64(len(ormTasks))
+
+		ids := make([]uint, len(ormTasks))
+		for i := range ormTasks {
+			ids[i] = ormTasks[i].ID
+		}
+		var orphans []uint
+		if err := tx.Model(&Task{}).
+			Where("id IN ? AND parent_id IS NOT NULL", ids).
+			Where("parent_id NOT IN (?)", tx.Model(&Task{}).Select("id").Where("user_id = ?", userID)).
+			Pluck("id", &orphans).Error; err != nil {
+			return fmt.Errorf("failed to check parents: %w", err)
+		}
+		if len(orphans) > 0 {
+			if err := tx.Model(&Task{}).Where("id IN ?", orphans).Update("parent_id", nil).Error; err != nil {
+				return fmt.Errorf("failed to detach tasks from deleted parents: %w", err)
+			}
+		}
+
+		out := make([]*domain.Task, len(ormTasks))
+		for i := range ormTasks {
+			if slices.Contains(orphans, ormTasks[i].ID) {
+				ormTasks[i].ParentID = nil
+			}
+			out[i] = ormTasks[i].toDomain()
+		}
+		if err := loadRelations(tx, out...); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.TaskRestored, out...)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("RestoreTasksByUser: %w", err)
+	}
+
+	return restored, nil
+}
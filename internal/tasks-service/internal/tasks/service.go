@@ -1,48 +1,135 @@
 package tasks
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/blob"
+	"github.com/your-org/tasks-service/internal/taskfile"
 )
 
+// defaultDueWithin — окно "скоро срок" по умолчанию
+const defaultDueWithin = 24 * time.Hour
+
 type tasksService struct {
-	repo TasksRepo
+	repo        TasksRepo
+	broadcaster *Broadcaster
+	// store — содержимое вложений, удаляется вместе с задачей при очистке корзины
+	store blob.Store
 }
 
 type TasksService interface {
-	CreateTask(task string, isDone bool, userID uint32) (*domain.Task, error)
+	CreateTask(t *domain.Task) (*domain.Task, error)
 	GetAllTasks() ([]*domain.Task, error)
-	UpdateTask(task string, isDone bool, id uint32) (*domain.Task, error)
-	DeleteTask(id uint32) error
-	ListTasksByUser(userId uint32) ([]*domain.Task, error)
+	UpdateTask(id uint32, version uint32, upd domain.TaskUpdate) (*domain.Task, error)
+	DeleteTask(id uint32, children domain.ChildrenPolicy) error
+	GetSubtree(id uint32) ([]*domain.TaskNode, error)
+	AddDependency(taskID, blockerID uint32) (*domain.Task, error)
+	RemoveDependency(taskID, blockerID uint32) (*domain.Task, error)
+	GetPlan(userID uint32) ([]*domain.Task, error)
+	CreateProject(userID uint32, name string) (*domain.Project, error)
+	UpdateProject(id uint32, upd domain.ProjectUpdate) (*domain.Project, error)
+	DeleteProject(id uint32) error
+	ListProjects(userID uint32, includeArchived bool) ([]*domain.Project, error)
+	ReorderProjects(userID uint32, ids []uint32) ([]*domain.Project, error)
+	ListTasksByProject(projectID uint32) ([]*domain.Task, error)
+	MoveTaskToProject(taskID uint32, projectID *uint32) (*domain.Task, error)
+	MoveTask(id, beforeID, afterID uint32) (*domain.Task, error)
+	GetTaskHistory(taskID uint32, afterID uint64, pageSize int) ([]*domain.HistoryEntry, uint64, error)
+	CreateComment(c *domain.Comment) (*domain.Comment, error)
+	EditComment(id, authorID uint32, body string) (*domain.Comment, error)
+	DeleteComment(id, authorID uint32) error
+	ListComments(taskID, afterID uint32, pageSize int) ([]*domain.Comment, uint32, error)
+	GetCommentHistory(id uint32) ([]*domain.CommentEdit, error)
+	ListTasksByUser(userId uint32, filter domain.TaskFilter) ([]*domain.Task, error)
+	HandleUserDeleted(eventID string, userID uint32) (int64, error)
+	// HandleUserRestored возвращает задачи, удаленные вместе с пользователем
+	HandleUserRestored(eventID string, userID uint32) (int64, error)
+	WatchTasks(ctx context.Context, userID uint32, fromRevision uint64, send func(*domain.TaskEvent) error) error
+	CreateTag(userID uint32, name string) (*domain.Tag, error)
+	RenameTag(id uint32, name string) (*domain.Tag, error)
+	DeleteTag(id uint32) error
+	ListTags(userID uint32) ([]*domain.Tag, error)
+	AttachTag(taskID, tagID uint32) (*domain.Task, error)
+	DetachTag(taskID, tagID uint32) (*domain.Task, error)
+	ListDeletedTasks(userID, afterID uint32, pageSize int) ([]*domain.DeletedTask, uint32, error)
+	RestoreTask(userID, id uint32) (*domain.Task, error)
+	PurgeTask(ctx context.Context, userID, id uint32) error
+	BatchCreateTasks(tasks []*domain.Task, mode domain.BatchMode) ([]BatchResult, error)
+	BatchUpdateTasks(updates []domain.BatchUpdate, mode domain.BatchMode) ([]BatchResult, error)
+	BatchDeleteTasks(deletes []domain.BatchDelete, mode domain.BatchMode) ([]BatchResult, error)
+	ExportTasks(userID uint32, w io.Writer, format taskfile.Format) (int, error)
+	ImportTasks(userID uint32, r io.Reader, format taskfile.Format, dryRun bool) (*domain.ImportReport, error)
+	ExportUserData(userID uint32) (*domain.UserData, error)
+	EraseUserData(ctx context.Context, userID uint32) (*domain.ErasedData, error)
+	// OwnerOf возвращает id владельца сущности; нет сущности — ErrTaskNotFound, ErrTagNotFound и т.п.
+	OwnerOf(kind domain.EntityKind, id uint32) (uint32, error)
+	// WithActor возвращает сервис, который записывает изменения в историю от имени actorID
+	WithActor(actorID uint32) TasksService
 }
 
-func NewTasksService(r TasksRepo) TasksService {
-	return &tasksService{repo: r}
+func NewTasksService(r TasksRepo, b *Broadcaster, store blob.Store) TasksService {
+	return &tasksService{repo: r, broadcaster: b, store: store}
 }
 
 func (s *tasksService) GetAllTasks() ([]*domain.Task, error) {
 	return s.repo.GetAllTasks()
 }
 
-func (s *tasksService) CreateTask(task string, isDone bool, userID uint32) (*domain.Task, error) {
-	if strings.TrimSpace(task) == "" {
+// CreateTask создает задачу из заполненных клиентом полей t
+func (s *tasksService) CreateTask(t *domain.Task) (*domain.Task, error) {
+	if strings.TrimSpace(t.Task) == "" {
 		return nil, ErrInvalidInput
 	}
+	if err := validateSchedule(t.DueAt, t.RemindAt); err != nil {
+		return nil, err
+	}
+
+	priority := t.Priority
+	if priority == 0 {
+		priority = domain.PriorityMedium
+	}
+	if !validPriority(priority) {
+		return nil, ErrInvalidPriority
+	}
+
+	if t.RRule != "" {
+		return s.createSeries(t, priority)
+	}
 
-	taskToCreate := domain.Task{Task: task, IsDone: isDone, UserID: userID}
+	// новая задача стартует из todo, в запрошенный статус переходит по обычным правилам
+	status := t.Status
+	if status == "" {
+		status = statusFromIsDone(domain.StatusTodo, t.IsDone)
+	}
+
+	taskToCreate := domain.Task{Task: t.Task, Status: domain.StatusTodo, Priority: priority, UserID: t.UserID,
+		DueAt: t.DueAt, RemindAt: t.RemindAt, ParentID: t.ParentID, ProjectID: t.ProjectID}
+	if err := setStatus(&taskToCreate, status, time.Now()); err != nil {
+		return nil, err
+	}
 
 	createdTask, err := s.repo.CreateTask(&taskToCreate)
 	if err != nil {
 		return nil, fmt.Errorf("CreateTask: failed to create the task: %w", err)
 	}
+	s.broadcaster.Notify()
 	return createdTask, nil
 }
 
-func (s *tasksService) UpdateTask(task string, isDone bool, id uint32) (*domain.Task, error) {
-	if strings.TrimSpace(task) == "" {
+// UpdateTask меняет только переданные в upd поля задачи, если её текущая версия равна version
+func (s *tasksService) UpdateTask(id uint32, version uint32, upd domain.TaskUpdate) (*domain.Task, error) {
+	if upd.Scope == domain.ScopeSeries {
+		return s.updateSeries(id, version, upd)
+	}
+	if upd.RRule != nil {
+		return nil, ErrInvalidEditScope
+	}
+	if upd.Task != nil && strings.TrimSpace(*upd.Task) == "" {
 		return nil, ErrInvalidInput
 	}
 
@@ -51,16 +138,168 @@ func (s *tasksService) UpdateTask(task string, isDone bool, id uint32) (*domain.
 		return nil, err
 	}
 
-	dm.Task = task
-	dm.IsDone = isDone
+	if dm.Version != version {
+		return nil, ErrVersionConflict
+	}
 
-	return s.repo.UpdateTask(dm)
+	if upd.Task == nil && upd.IsDone == nil && upd.Status == nil && upd.Priority == nil &&
+		upd.DueAt == nil && upd.RemindAt == nil && upd.ParentID == nil {
+		return dm, nil
+	}
+
+	if upd.Task != nil {
+		dm.Task = *upd.Task
+	}
+	switch {
+	case upd.Status != nil:
+		if err := setStatus(dm, *upd.Status, time.Now()); err != nil {
+			return nil, err
+		}
+	case upd.IsDone != nil:
+		if err := setStatus(dm, statusFromIsDone(dm.Status, *upd.IsDone), time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if upd.Priority != nil {
+		if !validPriority(*upd.Priority) {
+			return nil, ErrInvalidPriority
+		}
+		dm.Priority = *upd.Priority
+	}
+	if upd.DueAt != nil {
+		dm.DueAt = *upd.DueAt
+	}
+	if upd.RemindAt != nil && !sameTime(dm.RemindAt, *upd.RemindAt) {
+		dm.RemindAt = *upd.RemindAt
+		// новое время напоминания — напоминание отправится заново
+		dm.RemindedAt = nil
+	}
+
+	if upd.ParentID != nil {
+		// цикл и владельца родителя проверяет репозиторий под блокировкой иерархии
+		dm.ParentID = *upd.ParentID
+	}
+
+	if err := validateSchedule(dm.DueAt, dm.RemindAt); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateTask(dm)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return updated, nil
 }
 
-func (s *tasksService) DeleteTask(id uint32) error {
-	return s.repo.DeleteTask(id)
+// DeleteTask удаляет задачу, подзадачи обрабатываются по правилу children
+func (s *tasksService) DeleteTask(id uint32, children domain.ChildrenPolicy) error {
+	if err := s.repo.DeleteTask(id, children); err != nil {
+		return err
+	}
+	s.broadcaster.Notify()
+	return nil
+}
+
+func (s *tasksService) ListTasksByUser(userId uint32, filter domain.TaskFilter) ([]*domain.Task, error) {
+	if filter.Due == domain.DueSoon && filter.DueWithin <= 0 {
+		filter.DueWithin = defaultDueWithin
+	}
+
+	return s.repo.ListTasksByUser(userId, filter)
+}
+
+// validateSchedule проверяет, что напоминание не позже срока выполнения
+func validateSchedule(dueAt, remindAt *time.Time) error {
+	if dueAt != nil && remindAt != nil && remindAt.After(*dueAt) {
+		return ErrInvalidReminder
+	}
+
+	return nil
+}
+
+// sameTime сравнивает моменты времени, nil равен только nil
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// HandleUserDeleted удаляет задачи удалённого пользователя, событие обрабатывается идемпотентно
+func (s *tasksService) HandleUserDeleted(eventID string, userID uint32) (int64, error) {
+	if strings.TrimSpace(eventID) == "" || userID == 0 {
+		return 0, ErrInvalidEvent
+	}
+
+	deleted, err := s.repo.DeleteTasksByUser(eventID, userID)
+	if err != nil {
+		return 0, err
+	}
+	s.broadcaster.Notify()
+	return deleted, nil
 }
 
-func (s *tasksService) ListTasksByUser(userId uint32) ([]*domain.Task, error) {
-	return s.repo.ListTasksByUser(userId)
+// HandleUserRestored возвращает из корзины задачи восстановленного пользователя,
+// событие обрабатывается идемпотентно
+func (s *tasksService) HandleUserRestored(eventID string, userID uint32) (int64, error) {
+	if strings.TrimSpace(eventID) == "" || userID == 0 {
+		return 0, ErrInvalidEvent
+	}
+
+	restored, err := s.repo.RestoreTasksByUser(eventID, userID)
+	if err != nil {
+		return 0, err
+	}
+	s.broadcaster.Notify()
+	return restored, nil
+}
+
+// WatchTasks передает в send изменения задач пользователя до отмены ctx.
+// Если fromRevision > 0, сначала отдаются события из журнала после этой ревизии,
+// затем живые события; каждое событие отдается один раз и по порядку ревизий.
+func (s *tasksService) WatchTasks(ctx context.Context, userID uint32, fromRevision uint64, send func(*domain.TaskEvent) error) error {
+	// подписываемся до чтения журнала, чтобы не потерять события между ними
+	sub, err := s.broadcaster.Subscribe(userID)
+	if err != nil {
+		return fmt.Errorf("WatchTasks: %w", err)
+	}
+	defer sub.Close()
+
+	last := fromRevision
+	if fromRevision > 0 {
+		for {
+			batch, err := s.repo.ListEventsSince(userID, last, eventsBatchSize)
+			if err != nil {
+				return fmt.Errorf("WatchTasks: %w", err)
+			}
+			for _, ev := range batch {
+				if err := send(ev); err != nil {
+					return err
+				}
+				last = ev.Revision
+			}
+			if len(batch) < eventsBatchSize {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return sub.Err()
+			}
+			if ev.Revision <= last {
+				continue
+			}
+			if err := send(ev); err != nil {
+				return err
+			}
+			last = ev.Revision
+		}
+	}
 }
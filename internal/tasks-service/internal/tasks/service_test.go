@@ -0,0 +1,67 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	at := func(h int) *time.Time {
+		v := time.Date(2026, 10, 19, h, 0, 0, 0, time.UTC)
+		return &v
+	}
+
+	tests := []struct {
+		name            string
+		dueAt, remindAt *time.Time
+		wantErr         error
+	}{
+		{name: "no dates"},
+		{name: "due only", dueAt: at(12)},
+		{name: "reminder only", remindAt: at(12)},
+		{name: "reminder before due", dueAt: at(12), remindAt: at(9)},
+		{name: "reminder at due", dueAt: at(12), remindAt: at(12)},
+		{name: "reminder after due", dueAt: at(12), remindAt: at(13), wantErr: ErrInvalidReminder},
+	}
+	for _, tt := range tests {
+		if err := validateSchedule(tt.dueAt, tt.remindAt); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: validateSchedule() = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// filterRepo запоминает фильтр последнего ListTasksByUser
+type filterRepo struct {
+	TasksRepo
+	filter domain.TaskFilter
+}
+
+func (r *filterRepo) ListTasksByUser(userID uint32, filter domain.TaskFilter) ([]*domain.Task, error) {
+	r.filter = filter
+	return nil, nil
+}
+
+func TestListTasksByUserDueWindow(t *testing.T) {
+	tests := []struct {
+		filter domain.TaskFilter
+		want   time.Duration
+	}{
+		{filter: domain.TaskFilter{Due: domain.DueSoon}, want: defaultDueWithin},
+		{filter: domain.TaskFilter{Due: domain.DueSoon, DueWithin: -time.Hour}, want: defaultDueWithin},
+		{filter: domain.TaskFilter{Due: domain.DueSoon, DueWithin: 2 * time.Hour}, want: 2 * time.Hour},
+		// окно нужно только для DueSoon
+		{filter: domain.TaskFilter{Due: domain.DueOverdue}, want: 0},
+	}
+	for _, tt := range tests {
+		repo := &filterRepo{}
+		if _, err := NewTasksService(repo, nil, nil).ListTasksByUser(1, tt.filter); err != nil {
+			t.Fatal(err)
+		}
+		if repo.filter.DueWithin != tt.want {
+			t.Errorf("ListTasksByUser(%+v): DueWithin = %v, want %v", tt.filter, repo.filter.DueWithin, tt.want)
+		}
+	}
+}
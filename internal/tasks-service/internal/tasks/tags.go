@@ -0,0 +1,325 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/your-org/tasks-service/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxTagNameLen = 64
+
+// isUniqueViolation сообщает, что запись нарушила уникальный индекс
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// CreateTag добавляет тег в каталог пользователя
+func (r *taskRepo) CreateTag(dm *domain.Tag) (*domain.Tag, error) {
+	tag := Tag{UserID: dm.UserID, Name: dm.Name}
+	if err := r.db.Create(&tag).Error; err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrTagExists
+		}
+		return nil, fmt.Errorf("CreateTag: failed to create tag: %w", err)
+	}
+
+	return tag.toDomain(), nil
+}
+
+// GetTagByID возвращает тег по id
+func (r *taskRepo) GetTagByID(id uint32) (*domain.Tag, error) {
+	var tag Tag
+	if err := r.db.First(&tag, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, fmt.Errorf("GetTagByID: failed to find tag: %w", err)
+	}
+
+	return tag.toDomain(), nil
+}
+
+// RenameTag меняет имя тега
+func (r *taskRepo) RenameTag(id uint32, name string) (*domain.Tag, error) {
+	tag := Tag{ID: id}
+	res := r.db.Model(&tag).Clauses(clause.Returning{}).Update("name", name)
+	if res.Error != nil {
+		if isUniqueViolation(res.Error) {
+			return nil, ErrTagExists
+		}
+		return nil, fmt.Errorf("RenameTag: failed to rename tag: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrTagNotFound
+	}
+
+	return tag.toDomain(), nil
+}
+
+// DeleteTag удаляет тег, связи с задачами удаляются каскадно. Помеченные задачи
+// получают событие обновления в той же транзакции.
+func (r *taskRepo) DeleteTag(id uint32) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var tag Tag
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&tag, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTagNotFound
+			}
+			return fmt.Errorf("failed to find tag: %w", err)
+		}
+
+		var taskIDs []uint32
+		if err := tx.Model(&TaskTag{}).Where("tag_id = ?", id).Pluck("task_id", &taskIDs).Error; err != nil {
+			return fmt.Errorf("failed to find tagged tasks: %w", err)
+		}
+
+		if err := tx.Delete(&tag).Error; err != nil {
+			return fmt.Errorf("failed to delete tag: %w", err)
+		}
+		if len(taskIDs) == 0 {
+			return nil
+		}
+
+		var tagged []Task
+		if err := tx.Where("id IN ?", taskIDs).Order("id").Find(&tagged).Error; err != nil {
+			return fmt.Errorf("failed to load tagged tasks: %w", err)
+		}
+		updated := make([]*domain.Task, len(tagged))
+		for i := range tagged {
+			updated[i] = tagged[i].toDomain()
+		}
+		if err := loadRelations(tx, updated...); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.TaskUpdated, updated...)
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteTag: %w", err)
+	}
+
+	return nil
+}
+
+// ListTags возвращает каталог тегов пользователя по имени
+func (r *taskRepo) ListTags(userID uint32) ([]*domain.Tag, error) {
+	var tags []Tag
+	if err := r.db.Where("user_id = ?", userID).Order("lower(name)").Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("ListTags: failed to get tags: %w", err)
+	}
+
+	out := make([]*domain.Tag, len(tags))
+	for i := range tags {
+		out[i] = tags[i].toDomain()
+	}
+
+	return out, nil
+}
+
+// AttachTag помечает задачу тегом и возвращает задачу, повторная пометка ничего не меняет
+func (r *taskRepo) AttachTag(taskID, tagID uint32) (*domain.Task, error) {
+	task, err := r.changeTaskTags(taskID, func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&TaskTag{TaskID: taskID, TagID: tagID})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AttachTag: %w", err)
+	}
+
+	return task, nil
+}
+
+// DetachTag снимает тег с задачи и возвращает задачу
+func (r *taskRepo) DetachTag(taskID, tagID uint32) (*domain.Task, error) {
+	task, err := r.changeTaskTags(taskID, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("task_id = ? AND tag_id = ?", taskID, tagID).Delete(&TaskTag{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DetachTag: %w", err)
+	}
+
+	return task, nil
+}
+
+// changeTaskTags применяет change к связям задачи и, если связи изменились,
+// пишет событие обновления задачи в той же транзакции
+func (r *taskRepo) changeTaskTags(taskID uint32, change func(tx *gorm.DB) *gorm.DB) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ormTask Task
+		if err := tx.First(&ormTask, uint(taskID)).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTaskNotFound
+			}
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+
+		res := change(tx)
+		if res.Error != nil {
+			return fmt.Errorf("failed to change task tags: %w", res.Error)
+		}
+
+		task = ormTask.toDomain()
+		if err := loadRelations(tx, task); err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		return appendEvents(tx, domain.TaskUpdated, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// loadTagIDs заполняет TagIDs задач одним запросом
+func loadTagIDs(db *gorm.DB, tasks ...*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[uint32]*domain.Task, len(tasks))
+	ids := make([]uint32, 0, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+		ids = append(ids, t.ID)
+	}
+
+	var links []TaskTag
+	if err := db.Where("task_id IN ?", ids).Order("tag_id").Find(&links).Error; err != nil {
+		return fmt.Errorf("failed to load task tags: %w", err)
+	}
+
+	for _, l := range links {
+		t := byID[l.TaskID]
+		t.TagIDs = append(t.TagIDs, l.TagID)
+	}
+
+	return nil
+}
+
+// filterByTags ограничивает выборку задач тегами из filter
+func filterByTags(q *gorm.DB, filter domain.TaskFilter) *gorm.DB {
+	if len(filter.TagIDs) == 0 {
+		return q
+	}
+
+	if filter.TagMatch == domain.TagMatchAll {
+		return q.Where("id IN (?)", q.Session(&gorm.Session{NewDB: true}).
+			Model(&TaskTag{}).
+			Select("task_id").
+			Where("tag_id IN ?", filter.TagIDs).
+			Group("task_id").
+			Having("COUNT(DISTINCT tag_id) = ?", len(uniqueIDs(filter.TagIDs))))
+	}
+
+	return q.Where("id IN (?)", q.Session(&gorm.Session{NewDB: true}).
+		Model(&TaskTag{}).
+		Select("task_id").
+		Where("tag_id IN ?", filter.TagIDs))
+}
+
+func uniqueIDs(ids []uint32) map[uint32]struct{} {
+	set := make(map[uint32]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// normalizeTagName обрезает пробелы и проверяет длину имени тега
+func normalizeTagName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxTagNameLen {
+		return "", ErrInvalidTagName
+	}
+	return name, nil
+}
+
+// CreateTag добавляет тег в каталог пользователя
+func (s *tasksService) CreateTag(userID uint32, name string) (*domain.Tag, error) {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateTag(&domain.Tag{UserID: userID, Name: name})
+}
+
+// RenameTag меняет имя тега
+func (s *tasksService) RenameTag(id uint32, name string) (*domain.Tag, error) {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.RenameTag(id, name)
+}
+
+// DeleteTag удаляет тег и снимает его со всех задач
+func (s *tasksService) DeleteTag(id uint32) error {
+	if err := s.repo.DeleteTag(id); err != nil {
+		return err
+	}
+	s.broadcaster.Notify()
+	return nil
+}
+
+// ListTags возвращает каталог тегов пользователя
+func (s *tasksService) ListTags(userID uint32) ([]*domain.Tag, error) {
+	return s.repo.ListTags(userID)
+}
+
+// AttachTag помечает задачу тегом того же пользователя и возвращает задачу
+func (s *tasksService) AttachTag(taskID, tagID uint32) (*domain.Task, error) {
+	if err := s.checkTagOwner(taskID, tagID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.AttachTag(taskID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return task, nil
+}
+
+// DetachTag снимает тег с задачи и возвращает задачу
+func (s *tasksService) DetachTag(taskID, tagID uint32) (*domain.Task, error) {
+	if err := s.checkTagOwner(taskID, tagID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.DetachTag(taskID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return task, nil
+}
+
+// checkTagOwner проверяет, что задача и тег существуют и принадлежат одному пользователю
+func (s *tasksService) checkTagOwner(taskID, tagID uint32) error {
+	task, err := s.repo.GetByID(taskID)
+	if err != nil {
+		return err
+	}
+	tag, err := s.repo.GetTagByID(tagID)
+	if err != nil {
+		return err
+	}
+	if task.UserID != tag.UserID {
+		return ErrTagOwnerMismatch
+	}
+
+	return nil
+}
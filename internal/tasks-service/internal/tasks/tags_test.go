@@ -0,0 +1,89 @@
+package tasks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+func TestNormalizeTagName(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "shop", want: "shop"},
+		{in: "  дом ", want: "дом"},
+		{in: strings.Repeat("я", maxTagNameLen), want: strings.Repeat("я", maxTagNameLen)},
+		{in: strings.Repeat("я", maxTagNameLen+1), wantErr: true},
+		{in: "", wantErr: true},
+		{in: " \t ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeTagName(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("normalizeTagName(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidTagName) {
+			t.Errorf("normalizeTagName(%q) error = %v, want ErrInvalidTagName", tt.in, err)
+		}
+	}
+}
+
+// tagRepo — задачи и теги в памяти; запоминает, какой тег повешен на задачу
+type tagRepo struct {
+	TasksRepo
+	tasks    map[uint32]*domain.Task
+	tags     map[uint32]*domain.Tag
+	attached [][2]uint32
+}
+
+func (r *tagRepo) GetByID(id uint32) (*domain.Task, error) {
+	if t, ok := r.tasks[id]; ok {
+		return t, nil
+	}
+	return nil, ErrTaskNotFound
+}
+
+func (r *tagRepo) GetTagByID(id uint32) (*domain.Tag, error) {
+	if tag, ok := r.tags[id]; ok {
+		return tag, nil
+	}
+	return nil, ErrTagNotFound
+}
+
+func (r *tagRepo) AttachTag(taskID, tagID uint32) (*domain.Task, error) {
+	r.attached = append(r.attached, [2]uint32{taskID, tagID})
+	return r.tasks[taskID], nil
+}
+
+func TestAttachTagOwner(t *testing.T) {
+	tests := []struct {
+		name          string
+		taskID, tagID uint32
+		wantErr       error
+	}{
+		{name: "same user", taskID: 1, tagID: 10},
+		{name: "tag of another user", taskID: 1, tagID: 20, wantErr: ErrTagOwnerMismatch},
+		{name: "no task", taskID: 5, tagID: 10, wantErr: ErrTaskNotFound},
+		{name: "no tag", taskID: 1, tagID: 30, wantErr: ErrTagNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &tagRepo{
+				tasks: map[uint32]*domain.Task{1: {ID: 1, UserID: 1}},
+				tags:  map[uint32]*domain.Tag{10: {ID: 10, UserID: 1}, 20: {ID: 20, UserID: 2}},
+			}
+			s := NewTasksService(repo, NewBroadcaster(repo), nil)
+
+			_, err := s.AttachTag(tt.taskID, tt.tagID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AttachTag() = %v, want %v", err, tt.wantErr)
+			}
+			if attached := len(repo.attached) > 0; attached != (tt.wantErr == nil) {
+				t.Errorf("tag attached = %v", attached)
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/taskfile"
+)
+
+const (
+	// maxImportRows — наибольшее число строк в файле импорта
+	maxImportRows = 10000
+	// maxTitleLen — длина колонки task
+	maxTitleLen = 255
+	// exportBatch — сколько задач экспорт читает из базы за раз
+	exportBatch = 500
+)
+
+// errDryRun откатывает транзакцию пробного импорта
+var errDryRun = fmt.Errorf("dry run")
+
+// priorityNames — приоритеты в файлах задач
+var priorityNames = map[domain.Priority]string{
+	domain.PriorityLow:    "low",
+	domain.PriorityMedium: "medium",
+	domain.PriorityHigh:   "high",
+	domain.PriorityUrgent: "urgent",
+}
+
+// importRow — строка импорта, прошедшая проверку полей
+type importRow struct {
+	// report — номер строки в ImportReport.Rows
+	report  int
+	task    *domain.Task
+	project string
+	tags    []string
+}
+
+// ListTasksAfter возвращает до limit задач пользователя с id больше afterID, по возрастанию id
+func (r *taskRepo) ListTasksAfter(userID, afterID uint32, limit int) ([]*domain.Task, error) {
+	var rows []Task
+	if err := r.db.Where("user_id = ? AND id > ?", userID, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("ListTasksAfter: failed to get tasks: %w", err)
+	}
+
+	out := make([]*domain.Task, len(rows))
+	for i := range rows {
+		out[i] = rows[i].toDomain()
+	}
+	if err := loadRelations(r.db, out...); err != nil {
+		return nil, fmt.Errorf("ListTasksAfter: %w", err)
+	}
+
+	return out, nil
+}
+
+// ExportTasks пишет задачи пользователя в w в формате format и возвращает их число.
+// Задачи читаются из базы порциями по exportBatch в порядке id.
+func (s *tasksService) ExportTasks(userID uint32, w io.Writer, format taskfile.Format) (int, error) {
+	projects, err := s.repo.ListProjects(userID, true)
+	if err != nil {
+		return 0, err
+	}
+	tags, err := s.repo.ListTags(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	projectNames := make(map[uint32]string, len(projects))
+	for _, p := range projects {
+		projectNames[p.ID] = p.Name
+	}
+	tagNames := make(map[uint32]string, len(tags))
+	for _, t := range tags {
+		tagNames[t.ID] = t.Name
+	}
+
+	out, err := taskfile.NewWriter(w, format)
+	if err != nil {
+		return 0, fmt.Errorf("ExportTasks: %w", err)
+	}
+	var count int
+	var afterID uint32
+	for {
+		list, err := s.repo.ListTasksAfter(userID, afterID, exportBatch)
+		if err != nil {
+			return 0, err
+		}
+		for _, t := range list {
+			if err := out.Write(exportRecord(t, projectNames, tagNames)); err != nil {
+				return 0, fmt.Errorf("ExportTasks: %w", err)
+			}
+			count++
+		}
+		if len(list) < exportBatch {
+			break
+		}
+		afterID = list[len(list)-1].ID
+	}
+	if err := out.Flush(); err != nil {
+		return 0, fmt.Errorf("ExportTasks: %w", err)
+	}
+
+	return count, nil
+}
+
+// exportRecord переводит задачу в запись файла, подставляя имена проекта и тегов
+func exportRecord(t *domain.Task, projectNames, tagNames map[uint32]string) *taskfile.Record {
+	rec := &taskfile.Record{
+		ID:          t.ID,
+		Title:       t.Task,
+		Status:      string(t.Status),
+		Priority:    priorityNames[t.Priority],
+		DueAt:       t.DueAt,
+		RemindAt:    t.RemindAt,
+		CompletedAt: t.CompletedAt,
+	}
+	if t.ProjectID != nil {
+		rec.Project = projectNames[*t.ProjectID]
+	}
+	for _, id := range t.TagIDs {
+		rec.Tags = append(rec.Tags, tagNames[id])
+	}
+	return rec
+}
+
+// ImportTasks создает задачи пользователя из файла r формата format. Строки с ошибками
+// и дубли существующих задач (то же название без учета регистра и тот же срок)
+// пропускаются и попадают в отчет. Остальные строки создаются в одной транзакции;
+// при dryRun транзакция откатывается. Проекты и теги ищутся по имени и создаются, если их нет.
+func (s *tasksService) ImportTasks(userID uint32, r io.Reader, format taskfile.Format, dryRun bool) (*domain.ImportReport, error) {
+	reader, err := taskfile.NewReader(r, format)
+	if err != nil {
+		return nil, importReadError(err)
+	}
+
+	report := &domain.ImportReport{DryRun: dryRun}
+	var rows []importRow
+	for {
+		rec, line, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if len(report.Rows) == maxImportRows {
+			return nil, ErrImportTooLarge
+		}
+
+		var rowErr *taskfile.RowError
+		if errors.As(err, &rowErr) {
+			report.Rows = append(report.Rows, domain.ImportRow{Line: line, Status: domain.ImportRowInvalid, Error: rowErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, importReadError(err)
+		}
+
+		row, err := importRowFromRecord(userID, rec)
+		if err != nil {
+			report.Rows = append(report.Rows, domain.ImportRow{Line: line, Status: domain.ImportRowInvalid, Error: err.Error()})
+			continue
+		}
+		row.report = len(report.Rows)
+		report.Rows = append(report.Rows, domain.ImportRow{Line: line, Status: domain.ImportRowImported})
+		rows = append(rows, row)
+	}
+
+	if len(rows) > 0 {
+		err = s.repo.InTx(func(repo TasksRepo) error {
+			svc := &tasksService{repo: repo, broadcaster: s.broadcaster, store: s.store}
+			if err := svc.importRows(userID, rows, report); err != nil {
+				return err
+			}
+			if dryRun {
+				return errDryRun
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errDryRun) {
+			return nil, fmt.Errorf("ImportTasks: %w", err)
+		}
+	}
+
+	for i := range report.Rows {
+		switch report.Rows[i].Status {
+		case domain.ImportRowImported:
+			report.Imported++
+			if dryRun {
+				report.Rows[i].TaskID = 0
+			}
+		case domain.ImportRowDuplicate:
+			report.Duplicates++
+		case domain.ImportRowInvalid:
+			report.Invalid++
+		}
+	}
+	if report.Imported > 0 && !dryRun {
+		s.broadcaster.Notify()
+	}
+
+	return report, nil
+}
+
+// importRows создает задачи rows в транзакции сервиса s и отмечает итог строк в report
+func (s *tasksService) importRows(userID uint32, rows []importRow, report *domain.ImportReport) error {
+	existing, err := s.repo.ListTasksByUser(userID, domain.TaskFilter{})
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(existing)+len(rows))
+	for _, t := range existing {
+		seen[duplicateKey(t)] = true
+	}
+
+	projects, err := s.repo.ListProjects(userID, true)
+	if err != nil {
+		return err
+	}
+	projectIDs := make(map[string]uint32, len(projects))
+	for _, p := range projects {
+		// из одноименных проектов предпочитается неархивный
+		key := strings.ToLower(p.Name)
+		if _, ok := projectIDs[key]; !ok || !p.Archived {
+			projectIDs[key] = p.ID
+		}
+	}
+
+	tags, err := s.repo.ListTags(userID)
+	if err != nil {
+		return err
+	}
+	tagIDs := make(map[string]uint32, len(tags))
+	for _, t := range tags {
+		tagIDs[strings.ToLower(t.Name)] = t.ID
+	}
+
+	for _, row := range rows {
+		result := &report.Rows[row.report]
+		key := duplicateKey(row.task)
+		if seen[key] {
+			result.Status = domain.ImportRowDuplicate
+			result.Error = "task with this title and due date already exists"
+			continue
+		}
+
+		if row.project != "" {
+			id, ok := projectIDs[strings.ToLower(row.project)]
+			if !ok {
+				p, err := s.CreateProject(userID, row.project)
+				if err != nil {
+					return err
+				}
+				id = p.ID
+				projectIDs[strings.ToLower(row.project)] = id
+			}
+			row.task.ProjectID = &id
+		}
+
+		created, err := s.repo.CreateTask(row.task)
+		if errors.Is(err, ErrProjectArchived) {
+			result.Status = domain.ImportRowInvalid
+			result.Error = err.Error()
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, name := range row.tags {
+			id, ok := tagIDs[strings.ToLower(name)]
+			if !ok {
+				tag, err := s.CreateTag(userID, name)
+				if err != nil {
+					return err
+				}
+				id = tag.ID
+				tagIDs[strings.ToLower(name)] = id
+			}
+			if _, err := s.repo.AttachTag(created.ID, id); err != nil {
+				return err
+			}
+		}
+
+		seen[key] = true
+		result.TaskID = created.ID
+	}
+
+	return nil
+}
+
+// importRowFromRecord проверяет поля записи и возвращает задачу для создания
+func importRowFromRecord(userID uint32, rec *taskfile.Record) (importRow, error) {
+	title := strings.TrimSpace(rec.Title)
+	if title == "" {
+		return importRow{}, fmt.Errorf("title is required")
+	}
+	if utf8.RuneCountInString(title) > maxTitleLen {
+		return importRow{}, fmt.Errorf("title is longer than %d characters", maxTitleLen)
+	}
+
+	status := domain.StatusTodo
+	if rec.Status != "" {
+		status = domain.TaskStatus(strings.ToLower(rec.Status))
+		if !validStatus(status) {
+			return importRow{}, fmt.Errorf("unknown status %q", rec.Status)
+		}
+	}
+	priority, err := parsePriority(rec.Priority)
+	if err != nil {
+		return importRow{}, err
+	}
+	if err := validateSchedule(rec.DueAt, rec.RemindAt); err != nil {
+		return importRow{}, err
+	}
+
+	// статус переносится как есть, без правил переходов: это состояние из другой системы
+	task := &domain.Task{Task: title, Status: status, Priority: priority, UserID: userID,
+		DueAt: rec.DueAt, RemindAt: rec.RemindAt, IsDone: status == domain.StatusDone}
+	if task.IsDone {
+		completedAt := time.Now()
+		if rec.CompletedAt != nil {
+			completedAt = *rec.CompletedAt
+		}
+		task.CompletedAt = &completedAt
+	}
+
+	row := importRow{task: task}
+	if rec.Project != "" {
+		if row.project, err = normalizeProjectName(rec.Project); err != nil {
+			return importRow{}, err
+		}
+	}
+	names := make(map[string]bool, len(rec.Tags))
+	for _, tag := range rec.Tags {
+		name, err := normalizeTagName(tag)
+		if err != nil {
+			return importRow{}, err
+		}
+		if !names[strings.ToLower(name)] {
+			names[strings.ToLower(name)] = true
+			row.tags = append(row.tags, name)
+		}
+	}
+
+	return row, nil
+}
+
+// parsePriority принимает имя приоритета или его номер, пустое значение — medium
+func parsePriority(s string) (domain.Priority, error) {
+	if s == "" {
+		return domain.PriorityMedium, nil
+	}
+	for p, name := range priorityNames {
+		if strings.EqualFold(s, name) {
+			return p, nil
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil && validPriority(domain.Priority(n)) {
+		return domain.Priority(n), nil
+	}
+	return 0, fmt.Errorf("unknown priority %q", s)
+}
+
+// duplicateKey — задачи с одинаковым ключом при импорте считаются дублями
+func duplicateKey(t *domain.Task) string {
+	return strings.ToLower(strings.TrimSpace(t.Task)) + "\x00" + formatHistoryTime(t.DueAt)
+}
+
+func importReadError(err error) error {
+	if errors.Is(err, taskfile.ErrMalformed) {
+		return fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	return fmt.Errorf("ImportTasks: failed to read file: %w", err)
+}
@@ -0,0 +1,75 @@
+package tasks
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/taskfile"
+)
+
+// exportRepo — задачи одного пользователя в памяти; считает порции чтения
+type exportRepo struct {
+	TasksRepo
+
+	tasks   []*domain.Task
+	batches int
+}
+
+func (r *exportRepo) ListTasksAfter(userID, afterID uint32, limit int) ([]*domain.Task, error) {
+	r.batches++
+	var out []*domain.Task
+	for _, t := range r.tasks {
+		if t.UserID == userID && t.ID > afterID && len(out) < limit {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (r *exportRepo) ListProjects(userID uint32, includeArchived bool) ([]*domain.Project, error) {
+	return []*domain.Project{{ID: 1, UserID: userID, Name: "Дом"}}, nil
+}
+
+func (r *exportRepo) ListTags(userID uint32) ([]*domain.Tag, error) {
+	return []*domain.Tag{{ID: 3, UserID: userID, Name: "shop"}}, nil
+}
+
+func TestExportTasksReadsInBatches(t *testing.T) {
+	project := uint32(1)
+	repo := &exportRepo{}
+	for id := uint32(1); id <= 2*exportBatch+1; id++ {
+		task := &domain.Task{ID: id, UserID: 1, Task: "t", Status: domain.StatusTodo, Priority: domain.PriorityHigh}
+		if id == 1 {
+			task.ProjectID = &project
+			task.TagIDs = []uint32{3}
+		}
+		repo.tasks = append(repo.tasks, task)
+	}
+	// задача другого пользователя не попадает в файл
+	repo.tasks = append(repo.tasks, &domain.Task{ID: 5000, UserID: 2, Task: "чужая"})
+
+	var buf bytes.Buffer
+	n, err := NewTasksService(repo, nil, nil).ExportTasks(1, &buf, taskfile.JSONLines)
+	if err != nil {
+		t.Fatalf("ExportTasks: %v", err)
+	}
+	if n != 2*exportBatch+1 {
+		t.Fatalf("ExportTasks = %d, want %d", n, 2*exportBatch+1)
+	}
+	if repo.batches != 3 {
+		t.Fatalf("read %d batches, want 3", repo.batches)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != n {
+		t.Fatalf("file has %d lines, want %d", len(lines), n)
+	}
+	if want := `{"id":1,"title":"t","status":"todo","priority":"high","project":"Дом","tags":["shop"]}`; lines[0] != want {
+		t.Fatalf("first line = %s, want %s", lines[0], want)
+	}
+	if strings.Contains(buf.String(), "чужая") {
+		t.Fatal("export contains another user's task")
+	}
+}
@@ -0,0 +1,277 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/blob"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultTrashPage = 50
+	maxTrashPage     = 200
+)
+
+// ListDeletedTasks возвращает до limit удаленных задач пользователя с id больше afterID
+func (r *taskRepo) ListDeletedTasks(userID, afterID uint32, limit int) ([]*domain.DeletedTask, error) {
+	var rows []Task
+	if err := r.db.Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL AND id > ?", userID, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("ListDeletedTasks: failed to get tasks: %w", err)
+	}
+
+	out := make([]*domain.DeletedTask, len(rows))
+	list := make([]*domain.Task, len(rows))
+	for i := range rows {
+		list[i] = rows[i].toDomain()
+		out[i] = &domain.DeletedTask{Task: list[i], DeletedAt: rows[i].DeletedAt.Time}
+	}
+	if err := loadRelations(r.db, list...); err != nil {
+		return nil, fmt.Errorf("ListDeletedTasks: %w", err)
+	}
+
+	return out, nil
+}
+
+// RestoreTask возвращает задачу из корзины в конец её списка. Если родитель
+// задачи удален, она становится задачей верхнего уровня; подзадачи, удаленные
+// вместе с ней, остаются в корзине.
+func (r *taskRepo) RestoreTask(userID, id uint32) (*domain.Task, error) {
+	var restored *domain.Task
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		ormTask, err := lockDeletedTask(tx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := lockHierarchy(tx, ormTask.UserID); err != nil {
+			return err
+		}
+
+		parentID := ormTask.ParentID
+		if parentID != nil {
+			var count int64
+			if err := tx.Model(&Task{}).Where("id = ?", *parentID).Count(&count).Error; err != nil {
+				return fmt.Errorf("failed to check parent: %w", err)
+			}
+			if count == 0 {
+				parentID = nil
+			}
+		}
+		rank, err := appendRank(tx, ormTask.UserID, ormTask.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(ormTask).
+			Clauses(clause.Returning{}).
+			Updates(map[string]any{
+				"deleted_at":        nil,
+				"deleted_with_user": false,
+				"parent_id":         parentID,
+				"rank":              rank,
+				"version":           gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to restore task: %w", err)
+		}
+
+		restored = ormTask.toDomain()
+		if err := loadRelations(tx, restored); err != nil {
+			return err
+		}
+		return appendEvents(tx, domain.TaskRestored, restored)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("RestoreTask: %w", err)
+	}
+
+	return restored, nil
+}
+
+// PurgeTask окончательно удаляет задачу из корзины и возвращает ключи содержимого её вложений
+func (r *taskRepo) PurgeTask(userID, id uint32) ([]string, error) {
+	var keys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockDeletedTask(tx, userID, id); err != nil {
+			return err
+		}
+		var err error
+		keys, err = purgeTasks(tx, []uint32{id})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("PurgeTask: %w", err)
+	}
+
+	return keys, nil
+}
+
+// PurgeDeletedBefore окончательно удаляет до limit задач и до limit комментариев,
+// удаленных раньше cutoff. Возвращает число удаленных строк и ключи содержимого
+// вложений удаленных задач.
+func (r *taskRepo) PurgeDeletedBefore(cutoff time.Time, limit int) (int, []string, error) {
+	var (
+		purged int
+		keys   []string
+	)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uint32
+		if err := tx.Unscoped().Model(&Task{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("deleted_at < ?", cutoff).
+			Order("id").
+			Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to find deleted tasks: %w", err)
+		}
+		if len(ids) > 0 {
+			var err error
+			if keys, err = purgeTasks(tx, ids); err != nil {
+				return err
+			}
+		}
+
+		res := tx.Exec(`DELETE FROM comments WHERE id IN (
+			SELECT id FROM comments WHERE deleted_at < ? ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED)`, cutoff, limit)
+		if res.Error != nil {
+			return fmt.Errorf("failed to purge comments: %w", res.Error)
+		}
+
+		purged = len(ids) + int(res.RowsAffected)
+		return nil
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("PurgeDeletedBefore: %w", err)
+	}
+
+	return purged, keys, nil
+}
+
+// lockDeletedTask блокирует задачу пользователя userID из корзины до конца транзакции tx
+func lockDeletedTask(tx *gorm.DB, userID, id uint32) (*Task, error) {
+	var ormTask Task
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).First(&ormTask, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+	if !ormTask.DeletedAt.Valid {
+		return nil, ErrTaskNotDeleted
+	}
+	return &ormTask, nil
+}
+
+// purgeTasks удаляет строки задач ids; теги, зависимости, комментарии и метаданные
+// вложений удаляются каскадом. Возвращает ключи содержимого вложений.
+func purgeTasks(tx *gorm.DB, ids []uint32) ([]string, error) {
+	var keys []string
+	if err := tx.Model(&Attachment{}).Where("task_id IN ?", ids).Pluck("storage_key", &keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	if err := tx.Unscoped().Where("id IN ?", ids).Delete(&Task{}).Error; err != nil {
+		return nil, fmt.Errorf("failed to purge tasks: %w", err)
+	}
+	return keys, nil
+}
+
+// ListDeletedTasks возвращает страницу корзины пользователя после задачи afterID
+// и id, с которого начнется следующая страница (0 — страниц больше нет)
+func (s *tasksService) ListDeletedTasks(userID, afterID uint32, pageSize int) ([]*domain.DeletedTask, uint32, error) {
+	if pageSize <= 0 {
+		pageSize = defaultTrashPage
+	}
+	if pageSize > maxTrashPage {
+		pageSize = maxTrashPage
+	}
+
+	// берем на одну больше, чтобы знать, есть ли следующая страница
+	deleted, err := s.repo.ListDeletedTasks(userID, afterID, pageSize+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(deleted) <= pageSize {
+		return deleted, 0, nil
+	}
+
+	deleted = deleted[:pageSize]
+	return deleted, deleted[pageSize-1].Task.ID, nil
+}
+
+// RestoreTask восстанавливает задачу из корзины
+func (s *tasksService) RestoreTask(userID, id uint32) (*domain.Task, error) {
+	restored, err := s.repo.RestoreTask(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	s.broadcaster.Notify()
+	return restored, nil
+}
+
+// PurgeTask окончательно удаляет задачу из корзины, затем содержимое её вложений
+func (s *tasksService) PurgeTask(ctx context.Context, userID, id uint32) error {
+	keys, err := s.repo.PurgeTask(userID, id)
+	if err != nil {
+		return err
+	}
+	deleteBlobs(ctx, s.store, keys)
+	return nil
+}
+
+// deleteBlobs удаляет содержимое вложений; неудаленные файлы остаются сиротами в хранилище
+func deleteBlobs(ctx context.Context, store blob.Store, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			log.Printf("trash: failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// TrashPurger окончательно удаляет задачи и комментарии, пролежавшие в корзине дольше retention
+type TrashPurger struct {
+	repo      TasksRepo
+	store     blob.Store
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+}
+
+func NewTrashPurger(repo TasksRepo, store blob.Store, interval, retention time.Duration, batchSize int) *TrashPurger {
+	return &TrashPurger{repo: repo, store: store, interval: interval, retention: retention, batchSize: batchSize}
+}
+
+// Run очищает корзину до отмены ctx
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cutoff := time.Now().Add(-p.retention)
+		for {
+			n, keys, err := p.repo.PurgeDeletedBefore(cutoff, p.batchSize)
+			if err != nil {
+				log.Printf("trash purger: %v", err)
+				break
+			}
+			deleteBlobs(ctx, p.store, keys)
+			if n < p.batchSize {
+				break
+			}
+		}
+	}
+}
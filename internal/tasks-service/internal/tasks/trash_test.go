@@ -0,0 +1,121 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+// trashRepo — корзина пользователя в памяти
+type trashRepo struct {
+	TasksRepo
+	deleted []*domain.DeletedTask
+	keys    []string
+}
+
+func (r *trashRepo) ListDeletedTasks(userID, afterID uint32, limit int) ([]*domain.DeletedTask, error) {
+	var out []*domain.DeletedTask
+	for _, d := range r.deleted {
+		if d.Task.ID > afterID && len(out) < limit {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (r *trashRepo) PurgeTask(userID, id uint32) ([]string, error) {
+	if id != 1 {
+		return nil, ErrTaskNotFound
+	}
+	return r.keys, nil
+}
+
+func TestListDeletedTasksPages(t *testing.T) {
+	repo := &trashRepo{}
+	for _, id := range []uint32{2, 4, 6} {
+		repo.deleted = append(repo.deleted, &domain.DeletedTask{Task: &domain.Task{ID: id}})
+	}
+	s := NewTasksService(repo, nil, nil)
+
+	tests := []struct {
+		afterID  uint32
+		pageSize int
+		want     []uint32
+		wantNext uint32
+	}{
+		{afterID: 0, pageSize: 2, want: []uint32{2, 4}, wantNext: 4},
+		{afterID: 4, pageSize: 2, want: []uint32{6}},
+		{afterID: 0, pageSize: 3, want: []uint32{2, 4, 6}},
+		{afterID: 1, pageSize: -1, want: []uint32{2, 4, 6}},
+		{afterID: 6, pageSize: 2},
+	}
+	for _, tt := range tests {
+		deleted, next, err := s.ListDeletedTasks(1, tt.afterID, tt.pageSize)
+		if err != nil {
+			t.Fatalf("ListDeletedTasks(%d, %d): %v", tt.afterID, tt.pageSize, err)
+		}
+		var ids []uint32
+		for _, d := range deleted {
+			ids = append(ids, d.Task.ID)
+		}
+		if !slices.Equal(ids, tt.want) || next != tt.wantNext {
+			t.Errorf("ListDeletedTasks(%d, %d) = %v, next %d; want %v, next %d",
+				tt.afterID, tt.pageSize, ids, next, tt.want, tt.wantNext)
+		}
+	}
+}
+
+// deleteStore запоминает удаленные ключи; удаление ключей из fail завершается ошибкой
+type deleteStore struct {
+	deleted []string
+	fail    map[string]bool
+}
+
+func (s *deleteStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	return errors.New("not implemented")
+}
+
+func (s *deleteStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *deleteStore) Delete(ctx context.Context, key string) error {
+	if s.fail[key] {
+		return errors.New("storage is down")
+	}
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+func TestPurgeTaskDeletesBlobs(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          uint32
+		fail        map[string]bool
+		wantErr     error
+		wantDeleted []string
+	}{
+		{name: "all blobs", id: 1, wantDeleted: []string{"tasks/1/a", "tasks/1/b"}},
+		// содержимое, которое не удалось удалить, не отменяет удаления задачи
+		{name: "storage error", id: 1, fail: map[string]bool{"tasks/1/a": true}, wantDeleted: []string{"tasks/1/b"}},
+		{name: "not in trash", id: 2, wantErr: ErrTaskNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &deleteStore{fail: tt.fail}
+			repo := &trashRepo{keys: []string{"tasks/1/a", "tasks/1/b"}}
+			s := NewTasksService(repo, nil, store)
+
+			if err := s.PurgeTask(context.Background(), 1, tt.id); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PurgeTask() = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(store.deleted, tt.wantDeleted) {
+				t.Errorf("deleted blobs = %v, want %v", store.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
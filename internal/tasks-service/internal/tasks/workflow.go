@@ -0,0 +1,73 @@
+package tasks
+
+import (
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+// transitions — разрешенные переходы между статусами задачи
+var transitions = map[domain.TaskStatus][]domain.TaskStatus{
+	domain.StatusTodo:       {domain.StatusInProgress, domain.StatusBlocked, domain.StatusDone, domain.StatusCanceled},
+	domain.StatusInProgress: {domain.StatusTodo, domain.StatusBlocked, domain.StatusDone, domain.StatusCanceled},
+	domain.StatusBlocked:    {domain.StatusTodo, domain.StatusInProgress, domain.StatusCanceled},
+	domain.StatusDone:       {domain.StatusTodo, domain.StatusInProgress},
+	domain.StatusCanceled:   {domain.StatusTodo},
+}
+
+func validStatus(s domain.TaskStatus) bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+func validPriority(p domain.Priority) bool {
+	return p >= domain.PriorityLow && p <= domain.PriorityUrgent
+}
+
+// canTransition сообщает, можно ли перевести задачу из from в to
+func canTransition(from, to domain.TaskStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// setStatus переводит задачу в статус to и поддерживает производные поля
+// IsDone и CompletedAt
+func setStatus(dm *domain.Task, to domain.TaskStatus, now time.Time) error {
+	if !validStatus(to) {
+		return ErrInvalidStatus
+	}
+	if !canTransition(dm.Status, to) {
+		return ErrInvalidTransition
+	}
+
+	if to == domain.StatusDone && dm.Status != domain.StatusDone {
+		dm.CompletedAt = &now
+	}
+	if to != domain.StatusDone {
+		dm.CompletedAt = nil
+	}
+
+	dm.Status = to
+	dm.IsDone = to == domain.StatusDone
+
+	return nil
+}
+
+// statusFromIsDone переводит is_done старых клиентов в статус:
+// true — done, false — вернуть в todo, если задача была выполнена
+func statusFromIsDone(current domain.TaskStatus, isDone bool) domain.TaskStatus {
+	if isDone {
+		return domain.StatusDone
+	}
+	if current == domain.StatusDone {
+		return domain.StatusTodo
+	}
+	return current
+}
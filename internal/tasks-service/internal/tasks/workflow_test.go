@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/tasks-service/domain"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to domain.TaskStatus
+		want     bool
+	}{
+		{domain.StatusTodo, domain.StatusTodo, true},
+		{domain.StatusTodo, domain.StatusInProgress, true},
+		{domain.StatusTodo, domain.StatusDone, true},
+		{domain.StatusInProgress, domain.StatusBlocked, true},
+		{domain.StatusBlocked, domain.StatusInProgress, true},
+		{domain.StatusBlocked, domain.StatusDone, false},
+		{domain.StatusDone, domain.StatusTodo, true},
+		{domain.StatusDone, domain.StatusBlocked, false},
+		{domain.StatusDone, domain.StatusCanceled, false},
+		{domain.StatusCanceled, domain.StatusTodo, true},
+		{domain.StatusCanceled, domain.StatusDone, false},
+		{domain.StatusCanceled, domain.StatusInProgress, false},
+	}
+	for _, tt := range tests {
+		if got := canTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		from          domain.TaskStatus
+		completedAt   *time.Time
+		to            domain.TaskStatus
+		wantErr       error
+		wantCompleted *time.Time
+	}{
+		{name: "done", from: domain.StatusInProgress, to: domain.StatusDone, wantCompleted: &now},
+		{name: "done again keeps time", from: domain.StatusDone, completedAt: &earlier, to: domain.StatusDone,
+			wantCompleted: &earlier},
+		{name: "reopened", from: domain.StatusDone, completedAt: &earlier, to: domain.StatusTodo},
+		{name: "not allowed", from: domain.StatusCanceled, to: domain.StatusDone, wantErr: ErrInvalidTransition},
+		{name: "unknown status", from: domain.StatusTodo, to: "archived", wantErr: ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm := &domain.Task{Status: tt.from, IsDone: tt.from == domain.StatusDone, CompletedAt: tt.completedAt}
+			err := setStatus(dm, tt.to, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("setStatus() = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if dm.Status != tt.from {
+					t.Errorf("Status changed to %s on error", dm.Status)
+				}
+				return
+			}
+			if dm.Status != tt.to || dm.IsDone != (tt.to == domain.StatusDone) {
+				t.Errorf("Status, IsDone = %s, %v", dm.Status, dm.IsDone)
+			}
+			if (dm.CompletedAt == nil) != (tt.wantCompleted == nil) ||
+				dm.CompletedAt != nil && !dm.CompletedAt.Equal(*tt.wantCompleted) {
+				t.Errorf("CompletedAt = %v, want %v", dm.CompletedAt, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestStatusFromIsDone(t *testing.T) {
+	tests := []struct {
+		current domain.TaskStatus
+		isDone  bool
+		want    domain.TaskStatus
+	}{
+		{domain.StatusTodo, true, domain.StatusDone},
+		{domain.StatusBlocked, true, domain.StatusDone},
+		{domain.StatusDone, false, domain.StatusTodo},
+		{domain.StatusInProgress, false, domain.StatusInProgress},
+		{domain.StatusCanceled, false, domain.StatusCanceled},
+	}
+	for _, tt := range tests {
+		if got := statusFromIsDone(tt.current, tt.isDone); got != tt.want {
+			t.Errorf("statusFromIsDone(%s, %v) = %s, want %s", tt.current, tt.isDone, got, tt.want)
+		}
+	}
+}
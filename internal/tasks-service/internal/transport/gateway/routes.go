@@ -0,0 +1,39 @@
+package gateway
+
+import (
+	"net/netip"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/blastuha/test-service-proto/openapi"
+	"github.com/your-org/servicekit/restgateway"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewServer создает REST-шлюз на адресе host:port к TasksService по адресу grpcAddr.
+// Маршруты заданы аннотациями google.api.http в task.proto; OnUserDeleted, OnUserRestored,
+// ExportUserData и EraseUserData вызывает только users-service, аннотаций у них нет.
+// X-Forwarded-For принимается только от прокси из сетей trustedProxies.
+func NewServer(host string, port int, grpcAddr string, trustedProxies []netip.Prefix) (*restgateway.Server, error) {
+	return restgateway.NewServer(restgateway.Config{
+		Host:           host,
+		Port:           port,
+		GRPCAddr:       grpcAddr,
+		Service:        protoreflect.FullName(taskspb.TasksService_ServiceDesc.ServiceName),
+		Register:       taskspb.RegisterTasksServiceHandler,
+		Streams:        streams,
+		OpenAPI:        openapi.TasksService,
+		Headers:        forwardedHeaders,
+		TrustedProxies: trustedProxies,
+	})
+}
+
+// forwardedHeaders — заголовки HTTP, которые передаются сервису метаданными gRPC
+var forwardedHeaders = []string{"x-actor-id", "idempotency-key"}
+
+// streams — маршруты методов, которые принимают или отдают файл потоком байт
+var streams = []restgateway.StreamRoute{
+	{Method: "GET", Pattern: "/v1/users/{user_id}/tasks/export", RPC: "ExportTasks", Download: "application/octet-stream"},
+	{Method: "POST", Pattern: "/v1/users/{user_id}/tasks/import", RPC: "ImportTasks", Head: "options"},
+	{Method: "POST", Pattern: "/v1/tasks/{task_id}/attachments", RPC: "UploadAttachment", Head: "info"},
+	{Method: "GET", Pattern: "/v1/attachments/{id}/content", RPC: "DownloadAttachment", Download: "application/octet-stream"},
+}
@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// actorHeader — заголовок с id пользователя, от имени которого выполняется запрос
+const actorHeader = "x-actor-id"
+
+type actorKey struct{}
+
+// NewActorInterceptor возвращает interceptor, который кладет id из заголовка x-actor-id
+// в контекст запроса. Без заголовка изменения записываются в историю от имени системы.
+func NewActorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id, err := actorFromMetadata(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			return handler(ctx, req)
+		}
+
+		return handler(context.WithValue(ctx, actorKey{}, id), req)
+	}
+}
+
+// actorFromMetadata разбирает заголовок x-actor-id; 0 — заголовка нет
+func actorFromMetadata(ctx context.Context) (uint32, error) {
+	values := metadata.ValueFromIncomingContext(ctx, actorHeader)
+	if len(values) == 0 || values[0] == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(values[0], 10, 32)
+	if err != nil || id == 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be a positive user id", actorHeader)
+	}
+	return uint32(id), nil
+}
+
+// actorFromContext возвращает id автора запроса, 0 — не задан
+func actorFromContext(ctx context.Context) uint32 {
+	id, _ := ctx.Value(actorKey{}).(uint32)
+	return id
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestActorInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		want     uint32
+		wantCode codes.Code
+	}{
+		{name: "no header"},
+		{name: "empty header", md: metadata.Pairs(actorHeader, "")},
+		{name: "user", md: metadata.Pairs(actorHeader, "42"), want: 42},
+		{name: "zero", md: metadata.Pairs(actorHeader, "0"), wantCode: codes.InvalidArgument},
+		{name: "negative", md: metadata.Pairs(actorHeader, "-1"), wantCode: codes.InvalidArgument},
+		{name: "too large", md: metadata.Pairs(actorHeader, "4294967296"), wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var got uint32
+			_, err := NewActorInterceptor()(ctx, nil, &grpc.UnaryServerInfo{},
+				func(ctx context.Context, req any) (any, error) {
+					got = actorFromContext(ctx)
+					return nil, nil
+				})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("interceptor error = %v, want %v", err, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("actor = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/blob"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// downloadChunkSize — размер куска содержимого в одном сообщении DownloadAttachment
+const downloadChunkSize = 64 << 10
+
+var errUnexpectedInfo = fmt.Errorf("info is allowed only in the first message")
+
+func toPBAttachment(a *domain.Attachment) *taskspb.Attachment {
+	return &taskspb.Attachment{
+		Id:          a.ID,
+		TaskId:      a.TaskID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        uint64(a.Size),
+		Sha256:      a.SHA256,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+	}
+}
+
+// chunkReader читает содержимое из сообщений chunk клиентского потока
+type chunkReader struct {
+	stream taskspb.TasksService_UploadAttachmentServer
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetInfo() != nil {
+			return 0, errUnexpectedInfo
+		}
+		r.buf = msg.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// UploadAttachment принимает вложение потоком: сначала info, затем содержимое кусками
+func (h *Handler) UploadAttachment(stream taskspb.TasksService_UploadAttachmentServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "empty upload stream")
+	}
+	if err != nil {
+		return err
+	}
+
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "first message must carry attachment info")
+	}
+	if info.GetTaskId() == 0 {
+		return status.Error(codes.InvalidArgument, "task id must be > 0")
+	}
+
+	dm, err := h.attachments.UploadAttachment(stream.Context(), &domain.Attachment{
+		TaskID:      info.GetTaskId(),
+		FileName:    info.GetFileName(),
+		ContentType: info.GetContentType(),
+		Size:        int64(info.GetSize()),
+		SHA256:      info.GetSha256(),
+	}, &chunkReader{stream: stream})
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrTaskNotFound):
+			return status.Errorf(codes.NotFound, "task with id %d not found", info.GetTaskId())
+		case errors.Is(err, tasks.ErrInvalidAttachment), errors.Is(err, tasks.ErrAttachmentSizeMismatch),
+			errors.Is(err, tasks.ErrChecksumMismatch), errors.Is(err, errUnexpectedInfo):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, tasks.ErrAttachmentTooLarge):
+			return status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return status.FromContextError(err).Err()
+		default:
+			return status.Errorf(codes.Internal, "failed to upload attachment: %v", err)
+		}
+	}
+
+	return stream.SendAndClose(toPBAttachment(dm))
+}
+
+// DownloadAttachment отдает вложение потоком: сначала метаданные, затем содержимое кусками
+func (h *Handler) DownloadAttachment(req *taskspb.DownloadAttachmentRequest, stream taskspb.TasksService_DownloadAttachmentServer) error {
+	if req.GetId() == 0 {
+		return status.Error(codes.InvalidArgument, "id must be > 0")
+	}
+
+	dm, content, err := h.attachments.OpenAttachment(stream.Context(), req.GetId())
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrAttachmentNotFound), errors.Is(err, tasks.ErrTaskNotFound):
+			return status.Errorf(codes.NotFound, "attachment with id %d not found", req.GetId())
+		case errors.Is(err, blob.ErrNotFound):
+			return status.Error(codes.DataLoss, "attachment content is missing")
+		default:
+			return status.Errorf(codes.Internal, "failed to open attachment: %v", err)
+		}
+	}
+	defer content.Close()
+
+	msg := &taskspb.DownloadAttachmentResponse{Data: &taskspb.DownloadAttachmentResponse_Attachment{Attachment: toPBAttachment(dm)}}
+	if err := stream.Send(msg); err != nil {
+		return err
+	}
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := io.ReadFull(content, buf)
+		if n > 0 {
+			chunk := &taskspb.DownloadAttachmentResponse{Data: &taskspb.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]}}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		switch {
+		case err == io.EOF || err == io.ErrUnexpectedEOF:
+			return nil
+		case errors.Is(err, tasks.ErrChecksumMismatch):
+			return status.Error(codes.DataLoss, "attachment content does not match its checksum")
+		case err != nil:
+			return status.Errorf(codes.Internal, "failed to read attachment: %v", err)
+		}
+	}
+}
+
+func (h *Handler) ListAttachments(ctx context.Context, req *taskspb.ListAttachmentsRequest) (*taskspb.ListAttachmentsResponse, error) {
+	if req.GetTaskId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id must be > 0")
+	}
+
+	list, err := h.attachments.ListAttachments(req.GetTaskId())
+	if err != nil {
+		if errors.Is(err, tasks.ErrTaskNotFound) {
+			return nil, status.Errorf(codes.NotFound, "task with id %d not found", req.GetTaskId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get attachments: %v", err)
+	}
+
+	out := make([]*taskspb.Attachment, 0, len(list))
+	for _, a := range list {
+		out = append(out, toPBAttachment(a))
+	}
+
+	return &taskspb.ListAttachmentsResponse{Attachments: out}, nil
+}
+
+// DeleteAttachment удаляет вложение вместе с содержимым
+func (h *Handler) DeleteAttachment(ctx context.Context, req *taskspb.DeleteAttachmentRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be > 0")
+	}
+
+	if err := h.attachments.DeleteAttachment(ctx, req.GetId()); err != nil {
+		if errors.Is(err, tasks.ErrAttachmentNotFound) {
+			return nil, status.Errorf(codes.NotFound, "attachment with id %d not found", req.GetId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete attachment: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var batchModes = map[taskspb.BatchMode]domain.BatchMode{
+	taskspb.BatchMode_BATCH_MODE_ATOMIC:      domain.BatchAtomic,
+	taskspb.BatchMode_BATCH_MODE_BEST_EFFORT: domain.BatchBestEffort,
+}
+
+// BatchCreateTasks создает задачи пакетом; каждый пользователь проверяется в user-service один раз
+func (h *Handler) BatchCreateTasks(ctx context.Context, req *taskspb.BatchCreateTasksRequest) (*taskspb.BatchTasksResponse, error) {
+	mode, err := batchMode(req.GetMode(), len(req.GetTasks()))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.Task, len(req.GetTasks()))
+	itemErrs := make([]error, len(items))
+	users := make(map[uint32]error)
+	for i, r := range req.GetTasks() {
+		items[i], itemErrs[i] = taskFromCreateRequest(r)
+		if itemErrs[i] != nil {
+			continue
+		}
+		userErr, checked := users[r.GetUserId()]
+		if !checked {
+			userErr = h.checkUser(ctx, r.GetUserId())
+			users[r.GetUserId()] = userErr
+		}
+		itemErrs[i] = userErr
+	}
+
+	svc := h.tasksFor(ctx)
+	return runBatch(mode, itemErrs, createTaskError, func(valid []int) ([]tasks.BatchResult, error) {
+		batch := make([]*domain.Task, len(valid))
+		for i, idx := range valid {
+			batch[i] = items[idx]
+		}
+		return svc.BatchCreateTasks(batch, mode)
+	})
+}
+
+// BatchUpdateTasks меняет задачи пакетом, у каждой задачи свой etag
+func (h *Handler) BatchUpdateTasks(ctx context.Context, req *taskspb.BatchUpdateTasksRequest) (*taskspb.BatchTasksResponse, error) {
+	mode, err := batchMode(req.GetMode(), len(req.GetTasks()))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.BatchUpdate, len(req.GetTasks()))
+	itemErrs := make([]error, len(items))
+	for i, r := range req.GetTasks() {
+		items[i].ID, items[i].Version, items[i].Update, itemErrs[i] = updateArgs(r)
+	}
+
+	svc := h.tasksFor(ctx)
+	return runBatch(mode, itemErrs, updateTaskError, func(valid []int) ([]tasks.BatchResult, error) {
+		batch := make([]domain.BatchUpdate, len(valid))
+		for i, idx := range valid {
+			batch[i] = items[idx]
+		}
+		return svc.BatchUpdateTasks(batch, mode)
+	})
+}
+
+// BatchDeleteTasks удаляет задачи пакетом
+func (h *Handler) BatchDeleteTasks(ctx context.Context, req *taskspb.BatchDeleteTasksRequest) (*taskspb.BatchTasksResponse, error) {
+	mode, err := batchMode(req.GetMode(), len(req.GetTasks()))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.BatchDelete, len(req.GetTasks()))
+	itemErrs := make([]error, len(items))
+	for i, r := range req.GetTasks() {
+		items[i].ID, items[i].Children, itemErrs[i] = deleteArgs(r)
+	}
+
+	svc := h.tasksFor(ctx)
+	return runBatch(mode, itemErrs, deleteTaskError, func(valid []int) ([]tasks.BatchResult, error) {
+		batch := make([]domain.BatchDelete, len(valid))
+		for i, idx := range valid {
+			batch[i] = items[idx]
+		}
+		return svc.BatchDeleteTasks(batch, mode)
+	})
+}
+
+// batchMode проверяет режим и размер пакета
+func batchMode(mode taskspb.BatchMode, n int) (domain.BatchMode, error) {
+	m, ok := batchModes[mode]
+	if !ok {
+		return 0, status.Errorf(codes.InvalidArgument, "unknown batch mode %v", mode)
+	}
+	if n == 0 {
+		return 0, status.Error(codes.InvalidArgument, "batch has no items")
+	}
+	if n > tasks.MaxBatchSize {
+		return 0, status.Errorf(codes.InvalidArgument, "batch has %d items, at most %d allowed", n, tasks.MaxBatchSize)
+	}
+	return m, nil
+}
+
+// runBatch передает в run номера элементов, прошедших проверку (itemErrs[i] == nil),
+// и собирает результаты в порядке запроса. В атомарном режиме ошибка любого элемента,
+// в том числе при проверке, — ошибка всего запроса с номером элемента.
+func runBatch(mode domain.BatchMode, itemErrs []error, toStatus func(error) error,
+	run func(valid []int) ([]tasks.BatchResult, error)) (*taskspb.BatchTasksResponse, error) {
+	valid := make([]int, 0, len(itemErrs))
+	for i, err := range itemErrs {
+		if err == nil {
+			valid = append(valid, i)
+			continue
+		}
+		if mode == domain.BatchAtomic {
+			return nil, batchItemStatus(i, err)
+		}
+	}
+
+	var results []tasks.BatchResult
+	if len(valid) > 0 {
+		var err error
+		results, err = run(valid)
+		if err != nil {
+			var itemErr *tasks.BatchItemError
+			if errors.As(err, &itemErr) {
+				return nil, batchItemStatus(valid[itemErr.Index], toStatus(itemErr.Err))
+			}
+			return nil, status.Errorf(codes.Internal, "failed to run batch: %v", err)
+		}
+	}
+
+	out := make([]*taskspb.BatchTaskResult, len(itemErrs))
+	for i, err := range itemErrs {
+		if err != nil {
+			out[i] = batchResultError(err)
+		}
+	}
+	for k, idx := range valid {
+		if results[k].Err != nil {
+			out[idx] = batchResultError(toStatus(results[k].Err))
+			continue
+		}
+		out[idx] = &taskspb.BatchTaskResult{}
+		if results[k].Task != nil {
+			out[idx].Task = toPBTask(results[k].Task)
+		}
+	}
+
+	return &taskspb.BatchTasksResponse{Results: out}, nil
+}
+
+// batchItemStatus добавляет к статусу ошибки номер элемента пакета
+func batchItemStatus(i int, err error) error {
+	st := status.Convert(err)
+	return status.Errorf(st.Code(), "item %d: %s", i, st.Message())
+}
+
+func batchResultError(err error) *taskspb.BatchTaskResult {
+	st := status.Convert(err)
+	return &taskspb.BatchTaskResult{Code: int32(st.Code()), Message: st.Message()}
+}
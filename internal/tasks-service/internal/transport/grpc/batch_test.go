@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"strings"
+	"testing"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchMode(t *testing.T) {
+	tests := []struct {
+		mode     taskspb.BatchMode
+		n        int
+		want     domain.BatchMode
+		wantCode codes.Code
+	}{
+		{mode: taskspb.BatchMode_BATCH_MODE_ATOMIC, n: 1, want: domain.BatchAtomic},
+		{mode: taskspb.BatchMode_BATCH_MODE_BEST_EFFORT, n: tasks.MaxBatchSize, want: domain.BatchBestEffort},
+		{mode: taskspb.BatchMode(42), n: 1, wantCode: codes.InvalidArgument},
+		{mode: taskspb.BatchMode_BATCH_MODE_ATOMIC, n: 0, wantCode: codes.InvalidArgument},
+		{mode: taskspb.BatchMode_BATCH_MODE_ATOMIC, n: tasks.MaxBatchSize + 1, wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		got, err := batchMode(tt.mode, tt.n)
+		if status.Code(err) != tt.wantCode || err == nil && got != tt.want {
+			t.Errorf("batchMode(%v, %d) = %v, %v; want %v, %v", tt.mode, tt.n, got, err, tt.want, tt.wantCode)
+		}
+	}
+}
+
+func TestRunBatchResults(t *testing.T) {
+	invalid := status.Error(codes.InvalidArgument, "bad item")
+	toStatus := func(err error) error { return status.Error(codes.NotFound, err.Error()) }
+
+	tests := []struct {
+		name      string
+		mode      domain.BatchMode
+		itemErrs  []error
+		wantCode  codes.Code
+		wantCodes []codes.Code
+		// wantValid — элементы, дошедшие до сервиса
+		wantValid []int
+	}{
+		{
+			name:      "best effort keeps request order",
+			mode:      domain.BatchBestEffort,
+			itemErrs:  []error{nil, invalid, nil, nil},
+			wantCodes: []codes.Code{codes.OK, codes.InvalidArgument, codes.NotFound, codes.OK},
+			wantValid: []int{0, 2, 3},
+		},
+		{
+			name:     "atomic fails on an invalid item",
+			mode:     domain.BatchAtomic,
+			itemErrs: []error{nil, invalid},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:      "best effort with no valid items",
+			mode:      domain.BatchBestEffort,
+			itemErrs:  []error{invalid},
+			wantCodes: []codes.Code{codes.InvalidArgument},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotValid []int
+			resp, err := runBatch(tt.mode, tt.itemErrs, toStatus, func(valid []int) ([]tasks.BatchResult, error) {
+				gotValid = valid
+				// второй из допущенных элементов не найден
+				results := make([]tasks.BatchResult, len(valid))
+				for k, idx := range valid {
+					results[k].Task = &domain.Task{ID: uint32(idx + 1)}
+					if k == 1 {
+						results[k] = tasks.BatchResult{Err: tasks.ErrTaskNotFound}
+					}
+				}
+				return results, nil
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("runBatch() = %v, want %v", err, tt.wantCode)
+			}
+			if len(gotValid) != len(tt.wantValid) {
+				t.Errorf("valid items = %v, want %v", gotValid, tt.wantValid)
+			}
+			if err != nil {
+				return
+			}
+			for i, r := range resp.GetResults() {
+				if codes.Code(r.GetCode()) != tt.wantCodes[i] {
+					t.Errorf("result %d code = %v, want %v", i, codes.Code(r.GetCode()), tt.wantCodes[i])
+				}
+				if r.GetCode() == 0 && r.GetTask().GetId() != uint32(i+1) {
+					t.Errorf("result %d task = %v", i, r.GetTask())
+				}
+			}
+		})
+	}
+}
+
+func TestRunBatchItemIndex(t *testing.T) {
+	toStatus := func(err error) error { return status.Error(codes.NotFound, err.Error()) }
+	// первый элемент не прошел проверку, сервис получил элементы 1 и 2
+	itemErrs := []error{status.Error(codes.InvalidArgument, "bad item"), nil, nil}
+
+	_, err := runBatch(domain.BatchBestEffort, itemErrs, toStatus, func(valid []int) ([]tasks.BatchResult, error) {
+		return nil, &tasks.BatchItemError{Index: 1, Err: tasks.ErrTaskNotFound}
+	})
+	if st := status.Convert(err); st.Code() != codes.NotFound || !strings.HasPrefix(st.Message(), "item 2:") {
+		t.Errorf("runBatch() = %v, want NotFound for item 2", err)
+	}
+}
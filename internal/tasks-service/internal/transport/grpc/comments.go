@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toPBComment(c *domain.Comment) *taskspb.Comment {
+	return &taskspb.Comment{
+		Id:        c.ID,
+		TaskId:    c.TaskID,
+		AuthorId:  c.AuthorID,
+		Body:      c.Body,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		EditedAt:  toPBTime(c.EditedAt),
+	}
+}
+
+// formatPageToken возвращает токен страницы, начинающейся после комментария id
+func formatPageToken(id uint32) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// parsePageToken возвращает id комментария, после которого начинается страница
+func parsePageToken(token string) (uint32, error) {
+	if token == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(token, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("malformed page token %q", token)
+	}
+	return uint32(v), nil
+}
+
+// CreateComment добавляет комментарий к задаче от имени существующего пользователя
+func (h *Handler) CreateComment(ctx context.Context, req *taskspb.CreateCommentRequest) (*taskspb.Comment, error) {
+	if req.GetTaskId() == 0 || req.GetAuthorId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id and author id must be > 0")
+	}
+
+	if _, err := h.client.GetUser(ctx, req.GetAuthorId()); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user with id %d not found", req.GetAuthorId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	comment, err := h.svc.CreateComment(&domain.Comment{
+		TaskID:   req.GetTaskId(),
+		AuthorID: req.GetAuthorId(),
+		Body:     req.GetBody(),
+	})
+	if err != nil {
+		return nil, commentError(err)
+	}
+
+	return toPBComment(comment), nil
+}
+
+// EditComment меняет текст комментария, прежний текст остается в истории
+func (h *Handler) EditComment(ctx context.Context, req *taskspb.EditCommentRequest) (*taskspb.Comment, error) {
+	if req.GetId() == 0 || req.GetAuthorId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id and author id must be > 0")
+	}
+
+	comment, err := h.svc.EditComment(req.GetId(), req.GetAuthorId(), req.GetBody())
+	if err != nil {
+		return nil, commentError(err)
+	}
+
+	return toPBComment(comment), nil
+}
+
+// DeleteComment мягко удаляет комментарий
+func (h *Handler) DeleteComment(ctx context.Context, req *taskspb.DeleteCommentRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 || req.GetAuthorId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id and author id must be > 0")
+	}
+
+	if err := h.svc.DeleteComment(req.GetId(), req.GetAuthorId()); err != nil {
+		return nil, commentError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListComments возвращает комментарии задачи постранично, от старых к новым
+func (h *Handler) ListComments(ctx context.Context, req *taskspb.ListCommentsRequest) (*taskspb.ListCommentsResponse, error) {
+	if req.GetTaskId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id must be > 0")
+	}
+	after, err := parsePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	comments, next, err := h.svc.ListComments(req.GetTaskId(), after, int(req.GetPageSize()))
+	if err != nil {
+		return nil, commentError(err)
+	}
+
+	out := make([]*taskspb.Comment, 0, len(comments))
+	for _, c := range comments {
+		out = append(out, toPBComment(c))
+	}
+
+	return &taskspb.ListCommentsResponse{Comments: out, NextPageToken: formatPageToken(next)}, nil
+}
+
+// GetCommentHistory возвращает прежние версии текста комментария
+func (h *Handler) GetCommentHistory(ctx context.Context, req *taskspb.GetCommentHistoryRequest) (*taskspb.CommentHistoryResponse, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be > 0")
+	}
+
+	edits, err := h.svc.GetCommentHistory(req.GetId())
+	if err != nil {
+		return nil, commentError(err)
+	}
+
+	out := make([]*taskspb.CommentEdit, 0, len(edits))
+	for _, e := range edits {
+		out = append(out, &taskspb.CommentEdit{Body: e.Body, EditedAt: timestamppb.New(e.EditedAt)})
+	}
+
+	return &taskspb.CommentHistoryResponse{Edits: out}, nil
+}
+
+// commentError переводит ошибку работы с комментариями в gRPC статус
+func commentError(err error) error {
+	switch {
+	case errors.Is(err, tasks.ErrTaskNotFound):
+		return status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, tasks.ErrCommentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, tasks.ErrInvalidComment):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tasks.ErrNotCommentAuthor):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "comment operation failed: %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package grpc
+
+import "testing"
+
+func TestPageToken(t *testing.T) {
+	tests := []struct {
+		token   string
+		want    uint32
+		wantErr bool
+	}{
+		{token: "", want: 0},
+		{token: formatPageToken(0), want: 0},
+		{token: formatPageToken(42), want: 42},
+		{token: "abc", wantErr: true},
+		{token: "-1", wantErr: true},
+		{token: "4294967296", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parsePageToken(tt.token)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parsePageToken(%q) = %d, %v; want %d, error %v", tt.token, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package grpc
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toPBTask конвертирует domain задачу в gRPC модель
+func toPBTask(t *domain.Task) *taskspb.Task {
+	return &taskspb.Task{
+		Id:           t.ID,
+		Title:        t.Task,
+		IsDone:       t.IsDone,
+		UserId:       t.UserID,
+		Etag:         formatETag(t.Version),
+		DueAt:        toPBTime(t.DueAt),
+		RemindAt:     toPBTime(t.RemindAt),
+		Status:       toPBStatus[t.Status],
+		Priority:     taskspb.TaskPriority(t.Priority),
+		CompletedAt:  toPBTime(t.CompletedAt),
+		TagIds:       t.TagIDs,
+		ParentId:     derefID(t.ParentID),
+		BlockerIds:   t.BlockerIDs,
+		ProjectId:    derefID(t.ProjectID),
+		SeriesId:     derefID(t.SeriesID),
+		OccurrenceAt: toPBTime(t.OccurrenceAt),
+		Rrule:        t.RRule,
+	}
+}
+
+// optionalID возвращает nil для нулевого id
+func optionalID(id uint32) *uint32 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// derefID возвращает 0 для отсутствующего id
+func derefID(id *uint32) uint32 {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+// toPBTag конвертирует domain тег в gRPC модель
+func toPBTag(t *domain.Tag) *taskspb.Tag {
+	return &taskspb.Tag{Id: t.ID, UserId: t.UserID, Name: t.Name}
+}
+
+var toPBStatus = map[domain.TaskStatus]taskspb.TaskStatus{
+	domain.StatusTodo:       taskspb.TaskStatus_TASK_STATUS_TODO,
+	domain.StatusInProgress: taskspb.TaskStatus_TASK_STATUS_IN_PROGRESS,
+	domain.StatusBlocked:    taskspb.TaskStatus_TASK_STATUS_BLOCKED,
+	domain.StatusDone:       taskspb.TaskStatus_TASK_STATUS_DONE,
+	domain.StatusCanceled:   taskspb.TaskStatus_TASK_STATUS_CANCELED,
+}
+
+// fromPBStatus возвращает статус задачи; UNSPECIFIED дает пустой статус
+func fromPBStatus(st taskspb.TaskStatus) (domain.TaskStatus, error) {
+	if st == taskspb.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		return "", nil
+	}
+	for dm, pb := range toPBStatus {
+		if pb == st {
+			return dm, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status %v", st)
+}
+
+// fromPBPriority возвращает приоритет задачи; UNSPECIFIED дает 0
+func fromPBPriority(p taskspb.TaskPriority) (domain.Priority, error) {
+	if p < taskspb.TaskPriority_TASK_PRIORITY_UNSPECIFIED || p > taskspb.TaskPriority_TASK_PRIORITY_URGENT {
+		return 0, fmt.Errorf("unknown priority %v", p)
+	}
+	return domain.Priority(p), nil
+}
+
+// toPBTime конвертирует необязательное время в Timestamp
+func toPBTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// fromPBTime конвертирует необязательный Timestamp во время
+func fromPBTime(ts *timestamppb.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, err
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
+
+// formatETag возвращает etag для версии задачи
+func formatETag(version uint32) string {
+	return strconv.FormatUint(uint64(version), 10)
+}
+
+// parseETag возвращает версию задачи из etag
+func parseETag(etag string) (uint32, error) {
+	v, err := strconv.ParseUint(etag, 10, 32)
+	if err != nil || v == 0 {
+		return 0, fmt.Errorf("malformed etag %q", etag)
+	}
+	return uint32(v), nil
+}
+
+var eventTypes = map[string]taskspb.TaskEventType{
+	domain.TaskCreated:  taskspb.TaskEventType_TASK_EVENT_TYPE_CREATED,
+	domain.TaskUpdated:  taskspb.TaskEventType_TASK_EVENT_TYPE_UPDATED,
+	domain.TaskDeleted:  taskspb.TaskEventType_TASK_EVENT_TYPE_DELETED,
+	domain.TaskReminder: taskspb.TaskEventType_TASK_EVENT_TYPE_REMINDER,
+	domain.TaskRestored: taskspb.TaskEventType_TASK_EVENT_TYPE_RESTORED,
+}
+
+var dueFilters = map[taskspb.DueFilter]domain.DueFilter{
+	taskspb.DueFilter_DUE_FILTER_UNSPECIFIED: domain.DueAny,
+	taskspb.DueFilter_DUE_FILTER_OVERDUE:     domain.DueOverdue,
+	taskspb.DueFilter_DUE_FILTER_DUE_SOON:    domain.DueSoon,
+}
+
+var tagMatches = map[taskspb.TagMatch]domain.TagMatch{
+	taskspb.TagMatch_TAG_MATCH_UNSPECIFIED: domain.TagMatchAny,
+	taskspb.TagMatch_TAG_MATCH_ANY:         domain.TagMatchAny,
+	taskspb.TagMatch_TAG_MATCH_ALL:         domain.TagMatchAll,
+}
+
+var childrenPolicies = map[taskspb.ChildrenPolicy]domain.ChildrenPolicy{
+	taskspb.ChildrenPolicy_CHILDREN_POLICY_UNSPECIFIED: domain.ChildrenReject,
+	taskspb.ChildrenPolicy_CHILDREN_POLICY_REJECT:      domain.ChildrenReject,
+	taskspb.ChildrenPolicy_CHILDREN_POLICY_CASCADE:     domain.ChildrenCascade,
+	taskspb.ChildrenPolicy_CHILDREN_POLICY_PROMOTE:     domain.ChildrenPromote,
+}
+
+var editScopes = map[taskspb.EditScope]domain.EditScope{
+	taskspb.EditScope_EDIT_SCOPE_OCCURRENCE: domain.ScopeOccurrence,
+	taskspb.EditScope_EDIT_SCOPE_SERIES:     domain.ScopeSeries,
+}
+
+// toPBTaskEvent конвертирует событие изменения задачи в gRPC модель
+func toPBTaskEvent(ev *domain.TaskEvent) *taskspb.TaskEvent {
+	return &taskspb.TaskEvent{
+		Revision: ev.Revision,
+		Type:     eventTypes[ev.Type],
+		Task:     toPBTask(&ev.Task),
+	}
+}
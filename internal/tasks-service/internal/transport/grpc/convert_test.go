@@ -0,0 +1,26 @@
+package grpc
+
+import "testing"
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		etag    string
+		want    uint32
+		wantErr bool
+	}{
+		{etag: "1", want: 1},
+		{etag: "17", want: 17},
+		{etag: formatETag(4294967295), want: 4294967295},
+		{etag: "", wantErr: true},
+		{etag: "0", wantErr: true},
+		{etag: "-2", wantErr: true},
+		{etag: "4294967296", wantErr: true},
+		{etag: "W/\"5\"", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseETag(tt.etag)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseETag(%q) = %d, %v; want %d, error %v", tt.etag, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddDependency отмечает, что задача ждет выполнения blocker_id
+func (h *Handler) AddDependency(ctx context.Context, req *taskspb.DependencyRequest) (*taskspb.TaskResponse, error) {
+	return h.changeDependency(req, h.tasksFor(ctx).AddDependency)
+}
+
+// RemoveDependency снимает зависимость задачи от blocker_id
+func (h *Handler) RemoveDependency(ctx context.Context, req *taskspb.DependencyRequest) (*taskspb.TaskResponse, error) {
+	return h.changeDependency(req, h.tasksFor(ctx).RemoveDependency)
+}
+
+func (h *Handler) changeDependency(req *taskspb.DependencyRequest, change func(taskID, blockerID uint32) (*domain.Task, error)) (*taskspb.TaskResponse, error) {
+	if req.GetTaskId() == 0 || req.GetBlockerId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id and blocker id must be > 0")
+	}
+
+	dm, err := change(req.GetTaskId(), req.GetBlockerId())
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrTaskNotFound):
+			return nil, status.Error(codes.NotFound, "task not found")
+		case errors.Is(err, tasks.ErrDependencyCycle), errors.Is(err, tasks.ErrDependencyOwnerMismatch):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to change task dependency: %v", err)
+		}
+	}
+
+	return &taskspb.TaskResponse{Task: toPBTask(dm)}, nil
+}
+
+// GetPlan возвращает открытые задачи пользователя в порядке выполнения
+func (h *Handler) GetPlan(ctx context.Context, req *taskspb.GetPlanRequest) (*taskspb.TaskListResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	plan, err := h.svc.GetPlan(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build plan for user %d: %v", req.GetUserId(), err)
+	}
+
+	out := make([]*taskspb.Task, 0, len(plan))
+	for _, t := range plan {
+		out = append(out, toPBTask(t))
+	}
+
+	return &taskspb.TaskListResponse{Tasks: out}, nil
+}
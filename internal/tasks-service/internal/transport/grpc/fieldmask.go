@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"fmt"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+)
+
+// Пути update_mask, которые поддерживает UpdateTask
+const (
+	pathTitle    = "title"
+	pathIsDone   = "is_done"
+	pathDueAt    = "due_at"
+	pathRemindAt = "remind_at"
+	pathStatus   = "status"
+	pathPriority = "priority"
+	pathParentID = "parent_id"
+	pathRRule    = "rrule"
+)
+
+// taskUpdateFromRequest строит изменение задачи по update_mask.
+// Без маски запрос обновляет title и is_done, как до появления масок, а остальные
+// поля — только если они заданы: старый клиент их не отправляет и не должен стирать.
+func taskUpdateFromRequest(req *taskspb.TaskUpdateRequest) (domain.TaskUpdate, error) {
+	title, isDone := req.GetTitle(), req.GetIsDone()
+
+	dueAt, err := fromPBTime(req.GetDueAt())
+	if err != nil {
+		return domain.TaskUpdate{}, fmt.Errorf("invalid due_at: %w", err)
+	}
+	remindAt, err := fromPBTime(req.GetRemindAt())
+	if err != nil {
+		return domain.TaskUpdate{}, fmt.Errorf("invalid remind_at: %w", err)
+	}
+
+	taskStatus, err := fromPBStatus(req.GetStatus())
+	if err != nil {
+		return domain.TaskUpdate{}, err
+	}
+	priority, err := fromPBPriority(req.GetPriority())
+	if err != nil {
+		return domain.TaskUpdate{}, err
+	}
+
+	parentID := optionalID(req.GetParentId())
+	rrule := req.GetRrule()
+
+	scope, ok := editScopes[req.GetScope()]
+	if !ok {
+		return domain.TaskUpdate{}, fmt.Errorf("unknown edit scope %v", req.GetScope())
+	}
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if scope == domain.ScopeSeries {
+			return domain.TaskUpdate{}, fmt.Errorf("series scope requires update_mask")
+		}
+		upd := domain.TaskUpdate{Task: &title, IsDone: &isDone}
+		if req.GetDueAt() != nil {
+			upd.DueAt = &dueAt
+		}
+		if req.GetRemindAt() != nil {
+			upd.RemindAt = &remindAt
+		}
+		// старые клиенты не знают про status и priority, без маски меняем их только если заданы
+		if taskStatus != "" {
+			upd.Status = &taskStatus
+		}
+		if priority != 0 {
+			upd.Priority = &priority
+		}
+		if parentID != nil {
+			upd.ParentID = &parentID
+		}
+		return upd, nil
+	}
+
+	upd := domain.TaskUpdate{Scope: scope}
+	for _, p := range paths {
+		switch p {
+		case pathTitle:
+			upd.Task = &title
+		case pathIsDone:
+			upd.IsDone = &isDone
+		case pathDueAt:
+			upd.DueAt = &dueAt
+		case pathRemindAt:
+			upd.RemindAt = &remindAt
+		case pathStatus:
+			if taskStatus == "" {
+				return domain.TaskUpdate{}, fmt.Errorf("status is in update_mask but not set")
+			}
+			upd.Status = &taskStatus
+		case pathPriority:
+			if priority == 0 {
+				return domain.TaskUpdate{}, fmt.Errorf("priority is in update_mask but not set")
+			}
+			upd.Priority = &priority
+		case pathParentID:
+			upd.ParentID = &parentID
+		case pathRRule:
+			upd.RRule = &rrule
+		default:
+			return domain.TaskUpdate{}, fmt.Errorf("unknown update_mask path %q", p)
+		}
+	}
+
+	return upd, nil
+}
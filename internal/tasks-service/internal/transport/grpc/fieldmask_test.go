@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTaskUpdateFromRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		paths      []string
+		wantTitle  bool
+		wantIsDone bool
+		wantErr    bool
+	}{
+		{name: "no mask", wantTitle: true, wantIsDone: true},
+		{name: "title", paths: []string{"title"}, wantTitle: true},
+		{name: "is_done", paths: []string{"is_done"}, wantIsDone: true},
+		{name: "both", paths: []string{"is_done", "title"}, wantTitle: true, wantIsDone: true},
+		{name: "unknown path", paths: []string{"title", "owner"}, wantErr: true},
+		{name: "wrong case", paths: []string{"Title"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &taskspb.TaskUpdateRequest{Id: 1, Title: "купить хлеб", IsDone: true}
+			if tt.paths != nil {
+				req.UpdateMask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+
+			upd, err := taskUpdateFromRequest(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("taskUpdateFromRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (upd.Task != nil) != tt.wantTitle || (upd.IsDone != nil) != tt.wantIsDone {
+				t.Fatalf("Task = %v, IsDone = %v; want set %v, %v", upd.Task, upd.IsDone, tt.wantTitle, tt.wantIsDone)
+			}
+			if upd.Task != nil && *upd.Task != req.Title {
+				t.Errorf("Task = %q, want %q", *upd.Task, req.Title)
+			}
+			if upd.IsDone != nil && !*upd.IsDone {
+				t.Error("IsDone = false, want true")
+			}
+		})
+	}
+}
+
+func TestTaskUpdateFromRequestDueDates(t *testing.T) {
+	due := timestamppb.New(time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name  string
+		req   *taskspb.TaskUpdateRequest
+		paths []string
+		// "" — поле не меняется, "clear" — очищается, "set" — получает значение из запроса
+		wantDue, wantRemind string
+		wantErr             bool
+	}{
+		{name: "no mask, not set", req: &taskspb.TaskUpdateRequest{}},
+		{name: "no mask, due set", req: &taskspb.TaskUpdateRequest{DueAt: due}, wantDue: "set"},
+		{name: "mask clears", req: &taskspb.TaskUpdateRequest{}, paths: []string{"due_at", "remind_at"},
+			wantDue: "clear", wantRemind: "clear"},
+		{name: "mask sets", req: &taskspb.TaskUpdateRequest{RemindAt: due, DueAt: due}, paths: []string{"remind_at"},
+			wantRemind: "set"},
+		{name: "invalid timestamp", req: &taskspb.TaskUpdateRequest{DueAt: &timestamppb.Timestamp{Nanos: -1}},
+			wantErr: true},
+	}
+	state := func(v **time.Time) string {
+		switch {
+		case v == nil:
+			return ""
+		case *v == nil:
+			return "clear"
+		case !(*v).Equal(due.AsTime()):
+			return "wrong value"
+		}
+		return "set"
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.paths != nil {
+				tt.req.UpdateMask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+			upd, err := taskUpdateFromRequest(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("taskUpdateFromRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := state(upd.DueAt); got != tt.wantDue {
+				t.Errorf("DueAt = %q, want %q", got, tt.wantDue)
+			}
+			if got := state(upd.RemindAt); got != tt.wantRemind {
+				t.Errorf("RemindAt = %q, want %q", got, tt.wantRemind)
+			}
+		})
+	}
+}
+
+func TestTaskUpdateFromRequestStatus(t *testing.T) {
+	tests := []struct {
+		name                     string
+		req                      *taskspb.TaskUpdateRequest
+		paths                    []string
+		wantStatus, wantPriority bool
+		wantErr                  bool
+	}{
+		{name: "no mask, not set", req: &taskspb.TaskUpdateRequest{}},
+		{name: "no mask, set", req: &taskspb.TaskUpdateRequest{Status: taskspb.TaskStatus_TASK_STATUS_DONE,
+			Priority: taskspb.TaskPriority_TASK_PRIORITY_HIGH}, wantStatus: true, wantPriority: true},
+		{name: "mask, set", req: &taskspb.TaskUpdateRequest{Status: taskspb.TaskStatus_TASK_STATUS_BLOCKED},
+			paths: []string{"status"}, wantStatus: true},
+		{name: "status in mask, not set", req: &taskspb.TaskUpdateRequest{}, paths: []string{"status"}, wantErr: true},
+		{name: "priority in mask, not set", req: &taskspb.TaskUpdateRequest{}, paths: []string{"priority"}, wantErr: true},
+		{name: "unknown status", req: &taskspb.TaskUpdateRequest{Status: 42}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.paths != nil {
+				tt.req.UpdateMask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+			upd, err := taskUpdateFromRequest(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("taskUpdateFromRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (upd.Status != nil) != tt.wantStatus || (upd.Priority != nil) != tt.wantPriority {
+				t.Errorf("Status = %v, Priority = %v; want set %v, %v", upd.Status, upd.Priority, tt.wantStatus, tt.wantPriority)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExportUserData стримит все данные пользователя для архива, который собирает users-service
+func (h *Handler) ExportUserData(req *taskspb.ExportUserDataRequest, stream taskspb.TasksService_ExportUserDataServer) error {
+	if req.GetUserId() == 0 {
+		return status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	data, err := h.svc.ExportUserData(req.GetUserId())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to collect user data: %v", err)
+	}
+
+	items := make([]*taskspb.UserDataItem, 0, len(data.Tasks)+len(data.Trash)+len(data.Projects)+
+		len(data.Tags)+len(data.Comments)+len(data.Attachments))
+	for _, p := range data.Projects {
+		items = append(items, &taskspb.UserDataItem{Item: &taskspb.UserDataItem_Project{Project: toPBProject(p)}})
+	}
+	for _, t := range data.Tags {
+		items = append(items, &taskspb.UserDataItem{Item: &taskspb.UserDataItem_Tag{Tag: toPBTag(t)}})
+	}
+	for _, t := range data.Tasks {
+		items = append(items, &taskspb.UserDataItem{Item: &taskspb.UserDataItem_Task{Task: toPBTask(t)}})
+	}
+	for _, d := range data.Trash {
+		items = append(items, &taskspb.UserDataItem{Item: &taskspb.UserDataItem_DeletedTask{
+			DeletedTask: &taskspb.DeletedTask{Task: toPBTask(d.Task), DeletedAt: timestamppb.New(d.DeletedAt)},
+		}})
+	}
+	for _, c := range data.Comments {
+		items = append(items, &taskspb.UserDataItem{Item: &taskspb.UserDataItem_Comment{Comment: toPBComment(c)}})
+	}
+	for _, a := range data.Attachments {
+		items = append(items, &taskspb.UserDataItem{Item: &taskspb.UserDataItem_Attachment{Attachment: toPBAttachment(a)}})
+	}
+
+	for _, item := range items {
+		if err := stream.Send(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EraseUserData окончательно удаляет данные пользователя по запросу users-service
+func (h *Handler) EraseUserData(ctx context.Context, req *taskspb.EraseUserDataRequest) (*taskspb.EraseUserDataResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	erased, err := h.svc.EraseUserData(ctx, req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to erase data of user %d: %v", req.GetUserId(), err)
+	}
+
+	return &taskspb.EraseUserDataResponse{
+		Tasks:       uint32(erased.Tasks),
+		Comments:    uint32(erased.Comments),
+		Projects:    uint32(erased.Projects),
+		Tags:        uint32(erased.Tags),
+		Attachments: uint32(erased.Attachments),
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
 	"github.com/your-org/tasks-service/internal/tasks"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -13,36 +14,103 @@ import (
 
 type Handler struct {
 	taskspb.UnimplementedTasksServiceServer
-	svc    tasks.TasksService
-	client Client
+	svc         tasks.TasksService
+	attachments tasks.AttachmentsService
+	client      Client
 }
 
-func NewHandler(svc tasks.TasksService, client Client) *Handler {
-	return &Handler{svc: svc, client: client}
+func NewHandler(svc tasks.TasksService, attachments tasks.AttachmentsService, client Client) *Handler {
+	return &Handler{svc: svc, attachments: attachments, client: client}
+}
+
+// tasksFor возвращает сервис задач, который записывает изменения в историю от имени автора запроса
+func (h *Handler) tasksFor(ctx context.Context) tasks.TasksService {
+	return h.svc.WithActor(actorFromContext(ctx))
 }
 
 func (h *Handler) CreateTask(ctx context.Context, req *taskspb.TaskCreateRequest) (*taskspb.TaskResponse, error) {
-	if req.GetUserId() == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "user id must be > 0")
+	t, err := taskFromCreateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.checkUser(ctx, req.GetUserId()); err != nil {
+		return nil, err
 	}
 
-	if _, err := h.client.GetUser(ctx, req.GetUserId()); err != nil {
+	dm, err := h.tasksFor(ctx).CreateTask(t)
+	if err != nil {
+		return nil, createTaskError(err)
+	}
+
+	response := &taskspb.TaskResponse{Task: toPBTask(dm)}
+	return response, nil
+}
+
+// checkUser проверяет, что пользователь userID существует в user-service
+func (h *Handler) checkUser(ctx context.Context, userID uint32) error {
+	if _, err := h.client.GetUser(ctx, userID); err != nil {
 		if errors.Is(err, ErrUserNotFound) {
-			return nil, status.Errorf(codes.NotFound, "user with id %d not found", req.GetUserId())
+			return status.Errorf(codes.NotFound, "user with id %d not found", userID)
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+		return status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	return nil
+}
+
+// taskFromCreateRequest проверяет запрос создания и возвращает задачу для сервиса
+func taskFromCreateRequest(req *taskspb.TaskCreateRequest) (*domain.Task, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "user id must be > 0")
 	}
 
-	dm, err := h.svc.CreateTask(req.GetTitle(), req.GetIsDone(), req.GetUserId())
+	dueAt, err := fromPBTime(req.GetDueAt())
 	if err != nil {
-		if errors.Is(err, tasks.ErrInvalidInput) {
-			return nil, status.Error(codes.InvalidArgument, "title must not be empty")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to create task: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid due_at: %v", err)
+	}
+	remindAt, err := fromPBTime(req.GetRemindAt())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid remind_at: %v", err)
 	}
 
-	response := &taskspb.TaskResponse{Task: &taskspb.Task{Id: dm.ID, Title: dm.Task, IsDone: dm.IsDone, UserId: dm.UserID}}
-	return response, nil
+	taskStatus, err := fromPBStatus(req.GetStatus())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	priority, err := fromPBPriority(req.GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &domain.Task{
+		Task:      req.GetTitle(),
+		IsDone:    req.GetIsDone(),
+		Status:    taskStatus,
+		Priority:  priority,
+		UserID:    req.GetUserId(),
+		DueAt:     dueAt,
+		RemindAt:  remindAt,
+		ParentID:  optionalID(req.GetParentId()),
+		ProjectID: optionalID(req.GetProjectId()),
+		RRule:     req.GetRrule(),
+	}, nil
+}
+
+func createTaskError(err error) error {
+	if errors.Is(err, tasks.ErrInvalidInput) {
+		return status.Error(codes.InvalidArgument, "title must not be empty")
+	}
+	if isHierarchyError(err) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, tasks.ErrProjectNotFound) || errors.Is(err, tasks.ErrProjectArchived) ||
+		errors.Is(err, tasks.ErrProjectOwnerMismatch) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, tasks.ErrInvalidReminder) || errors.Is(err, tasks.ErrInvalidStatus) ||
+		errors.Is(err, tasks.ErrInvalidPriority) || isRecurrenceError(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to create task: %v", err)
 }
 
 func (h *Handler) GetTaskList(ctx context.Context, req *emptypb.Empty) (*taskspb.TaskListResponse, error) {
@@ -53,7 +121,7 @@ func (h *Handler) GetTaskList(ctx context.Context, req *emptypb.Empty) (*taskspb
 
 	out := make([]*taskspb.Task, 0, len(tasksList))
 	for _, t := range tasksList {
-		out = append(out, &taskspb.Task{Id: t.ID, Title: t.Task, IsDone: t.IsDone, UserId: t.UserID})
+		out = append(out, toPBTask(t))
 	}
 
 	response := &taskspb.TaskListResponse{Tasks: out}
@@ -65,47 +133,97 @@ func (h *Handler) UpdateTask(ctx context.Context, req *taskspb.TaskUpdateRequest
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
 
+	id, version, upd, err := updateArgs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	dm, err := h.tasksFor(ctx).UpdateTask(id, version, upd)
+	if err != nil {
+		return nil, updateTaskError(err)
+	}
+
+	return &taskspb.TaskResponse{Task: toPBTask(dm)}, nil
+}
+
+// updateArgs проверяет запрос изменения и возвращает id, ожидаемую версию и изменение задачи
+func updateArgs(req *taskspb.TaskUpdateRequest) (uint32, uint32, domain.TaskUpdate, error) {
 	id := req.GetId()
 	if id == 0 {
-		return nil, status.Error(codes.InvalidArgument, "task id must be > 0")
+		return 0, 0, domain.TaskUpdate{}, status.Error(codes.InvalidArgument, "task id must be > 0")
+	}
+
+	if req.GetEtag() == "" {
+		return 0, 0, domain.TaskUpdate{}, status.Error(codes.FailedPrecondition, "etag is required, read the task first")
+	}
+	version, err := parseETag(req.GetEtag())
+	if err != nil {
+		return 0, 0, domain.TaskUpdate{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	dm, err := h.svc.UpdateTask(req.GetTitle(), req.GetIsDone(), id)
+	upd, err := taskUpdateFromRequest(req)
 	if err != nil {
-		switch {
-		case errors.Is(err, tasks.ErrInvalidInput):
-			return nil, status.Error(codes.InvalidArgument, "title must not be empty")
-		case errors.Is(err, tasks.ErrTaskNotFound):
-			return nil, status.Error(codes.NotFound, "task not found")
-		default:
-			return nil, status.Errorf(codes.Internal, "failed to update task: %v", err)
-		}
+		return 0, 0, domain.TaskUpdate{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	return &taskspb.TaskResponse{
-		Task: &taskspb.Task{
-			Id:     dm.ID,
-			Title:  dm.Task,
-			IsDone: dm.IsDone,
-			UserId: dm.UserID,
-		},
-	}, nil
+	return id, version, upd, nil
+}
+
+func updateTaskError(err error) error {
+	switch {
+	case errors.Is(err, tasks.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, "title must not be empty")
+	case errors.Is(err, tasks.ErrInvalidReminder), errors.Is(err, tasks.ErrInvalidStatus),
+		errors.Is(err, tasks.ErrInvalidPriority), isRecurrenceError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tasks.ErrInvalidTransition), errors.Is(err, tasks.ErrOpenBlockers), isHierarchyError(err),
+		errors.Is(err, tasks.ErrNotRecurring), errors.Is(err, tasks.ErrSeriesEnded):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, tasks.ErrTaskNotFound):
+		return status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, tasks.ErrVersionConflict):
+		return status.Error(codes.Aborted, "task was modified concurrently, re-read it and retry")
+	default:
+		return status.Errorf(codes.Internal, "failed to update task: %v", err)
+	}
 }
 
 func (h *Handler) DeleteTask(ctx context.Context, req *taskspb.TaskDeleteRequest) (*emptypb.Empty, error) {
+	id, children, err := deleteArgs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.tasksFor(ctx).DeleteTask(id, children); err != nil {
+		return nil, deleteTaskError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// deleteArgs проверяет запрос удаления и возвращает id и правило для подзадач
+func deleteArgs(req *taskspb.TaskDeleteRequest) (uint32, domain.ChildrenPolicy, error) {
 	id := req.GetId()
 	if id == 0 {
-		return nil, status.Error(codes.InvalidArgument, "id must be > 0")
+		return 0, 0, status.Error(codes.InvalidArgument, "id must be > 0")
 	}
 
-	if err := h.svc.DeleteTask(id); err != nil {
-		if errors.Is(err, tasks.ErrTaskNotFound) {
-			return nil, status.Errorf(codes.NotFound, "task not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to delete task: %v", err)
+	children, ok := childrenPolicies[req.GetChildren()]
+	if !ok {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "unknown children policy %v", req.GetChildren())
 	}
 
-	return &emptypb.Empty{}, nil
+	return id, children, nil
+}
+
+func deleteTaskError(err error) error {
+	if errors.Is(err, tasks.ErrTaskNotFound) {
+		return status.Errorf(codes.NotFound, "task not found")
+	}
+	if errors.Is(err, tasks.ErrTaskHasChildren) {
+		return status.Error(codes.FailedPrecondition, "task has subtasks, choose a children policy")
+	}
+	return status.Errorf(codes.Internal, "failed to delete task: %v", err)
 }
 
 func (h *Handler) ListTasksByUser(ctx context.Context, req *taskspb.ListTasksByUserRequest) (*taskspb.TaskListResponse, error) {
@@ -113,7 +231,27 @@ func (h *Handler) ListTasksByUser(ctx context.Context, req *taskspb.ListTasksByU
 		return nil, status.Error(codes.InvalidArgument, "id must be > 0")
 	}
 
-	tasks, err := h.svc.ListTasksByUser(req.UserId)
+	due, ok := dueFilters[req.GetDue()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown due filter %v", req.GetDue())
+	}
+	if req.GetDueWithin() != nil {
+		if err := req.GetDueWithin().CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid due_within: %v", err)
+		}
+	}
+	tagMatch, ok := tagMatches[req.GetTagMatch()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown tag match %v", req.GetTagMatch())
+	}
+	filter := domain.TaskFilter{
+		Due:       due,
+		DueWithin: req.GetDueWithin().AsDuration(),
+		TagIDs:    req.GetTagIds(),
+		TagMatch:  tagMatch,
+	}
+
+	tasks, err := h.svc.ListTasksByUser(req.UserId, filter)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get list of tasks by user_id: %d", req.UserId)
 	}
@@ -121,9 +259,72 @@ func (h *Handler) ListTasksByUser(ctx context.Context, req *taskspb.ListTasksByU
 	out := make([]*taskspb.Task, 0, len(tasks))
 
 	for _, t := range tasks {
-		pbTask := &taskspb.Task{Id: t.ID, IsDone: t.IsDone, UserId: t.UserID, Title: t.Task}
-		out = append(out, pbTask)
+		out = append(out, toPBTask(t))
 	}
 
 	return &taskspb.TaskListResponse{Tasks: out}, nil
 }
+
+// OnUserDeleted принимает событие удаления пользователя от users-service.
+// Повторная доставка безопасна: одно и то же событие применяется один раз.
+func (h *Handler) OnUserDeleted(ctx context.Context, req *taskspb.UserDeletedEvent) (*taskspb.UserDeletedResponse, error) {
+	deleted, err := h.svc.HandleUserDeleted(req.GetEventId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, tasks.ErrInvalidEvent) {
+			return nil, status.Error(codes.InvalidArgument, "event id and user id are required")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete tasks of user %d: %v", req.GetUserId(), err)
+	}
+
+	return &taskspb.UserDeletedResponse{DeletedTasks: uint32(deleted)}, nil
+}
+
+// OnUserRestored принимает событие восстановления пользователя от users-service.
+// Повторная доставка безопасна: одно и то же событие применяется один раз.
+func (h *Handler) OnUserRestored(ctx context.Context, req *taskspb.UserRestoredEvent) (*taskspb.UserRestoredResponse, error) {
+	restored, err := h.svc.HandleUserRestored(req.GetEventId(), req.GetUserId())
+	if err != nil {
+		if errors.Is(err, tasks.ErrInvalidEvent) {
+			return nil, status.Error(codes.InvalidArgument, "event id and user id are required")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to restore tasks of user %d: %v", req.GetUserId(), err)
+	}
+
+	return &taskspb.UserRestoredResponse{RestoredTasks: uint32(restored)}, nil
+}
+
+// WatchTasks стримит изменения задач пользователя. После переподключения клиент
+// передает from_revision последнего полученного события и продолжает без пропусков.
+func (h *Handler) WatchTasks(req *taskspb.WatchTasksRequest, stream taskspb.TasksService_WatchTasksServer) error {
+	if req.GetUserId() == 0 {
+		return status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	ctx := stream.Context()
+	if _, err := h.client.GetUser(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return status.Errorf(codes.NotFound, "user with id %d not found", req.GetUserId())
+		}
+		return status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	err := h.svc.WatchTasks(ctx, req.GetUserId(), req.GetFromRevision(), func(ev *domain.TaskEvent) error {
+		return stream.Send(toPBTaskEvent(ev))
+	})
+	switch {
+	case err == nil, errors.Is(err, context.Canceled):
+		return nil
+	case errors.Is(err, tasks.ErrWatchLagging):
+		return status.Error(codes.Aborted, err.Error())
+	case status.Code(err) != codes.Unknown:
+		return err
+	default:
+		return status.Errorf(codes.Internal, "failed to watch tasks: %v", err)
+	}
+}
+
+// isRecurrenceError сообщает, что правило повторения или изменение серии недопустимы
+func isRecurrenceError(err error) bool {
+	return errors.Is(err, tasks.ErrInvalidRecurrence) || errors.Is(err, tasks.ErrRecurrenceOptions) ||
+		errors.Is(err, tasks.ErrInvalidEditScope)
+}
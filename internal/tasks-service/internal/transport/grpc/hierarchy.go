@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetSubtree возвращает задачу со всеми подзадачами и прогрессом по каждому узлу
+func (h *Handler) GetSubtree(ctx context.Context, req *taskspb.GetSubtreeRequest) (*taskspb.SubtreeResponse, error) {
+	if req.GetTaskId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id must be > 0")
+	}
+
+	nodes, err := h.svc.GetSubtree(req.GetTaskId())
+	if err != nil {
+		if errors.Is(err, tasks.ErrTaskNotFound) {
+			return nil, status.Errorf(codes.NotFound, "task with id %d not found", req.GetTaskId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get subtree: %v", err)
+	}
+
+	out := make([]*taskspb.TaskNode, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, &taskspb.TaskNode{
+			Task:          toPBTask(n.Task),
+			Depth:         uint32(n.Depth),
+			ChildrenTotal: uint32(n.ChildrenTotal),
+			ChildrenDone:  uint32(n.ChildrenDone),
+			Progress:      uint32(n.Progress()),
+		})
+	}
+
+	return &taskspb.SubtreeResponse{Nodes: out}, nil
+}
+
+// isHierarchyError сообщает, что родитель задачи недопустим
+func isHierarchyError(err error) bool {
+	return errors.Is(err, tasks.ErrParentNotFound) || errors.Is(err, tasks.ErrParentOwnerMismatch) ||
+		errors.Is(err, tasks.ErrHierarchyCycle)
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetTaskHistory возвращает историю изменений задачи постранично, от старых к новым
+func (h *Handler) GetTaskHistory(ctx context.Context, req *taskspb.GetTaskHistoryRequest) (*taskspb.GetTaskHistoryResponse, error) {
+	if req.GetTaskId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id must be > 0")
+	}
+	after, err := parseHistoryPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	entries, next, err := h.svc.GetTaskHistory(req.GetTaskId(), after, int(req.GetPageSize()))
+	if err != nil {
+		if errors.Is(err, tasks.ErrTaskNotFound) {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get task history: %v", err)
+	}
+
+	out := make([]*taskspb.TaskHistoryEntry, 0, len(entries))
+	for _, e := range entries {
+		changes := make([]*taskspb.FieldChange, 0, len(e.Changes))
+		for _, c := range e.Changes {
+			changes = append(changes, &taskspb.FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+		}
+		out = append(out, &taskspb.TaskHistoryEntry{
+			Id:        e.ID,
+			TaskId:    e.TaskID,
+			Type:      eventTypes[e.Type],
+			ActorId:   e.ActorID,
+			CreatedAt: timestamppb.New(e.CreatedAt),
+			Changes:   changes,
+		})
+	}
+
+	resp := &taskspb.GetTaskHistoryResponse{Entries: out}
+	if next != 0 {
+		resp.NextPageToken = strconv.FormatUint(next, 10)
+	}
+	return resp, nil
+}
+
+// parseHistoryPageToken возвращает id записи истории, после которой начинается страница
+func parseHistoryPageToken(token string) (uint64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(token, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed page token %q", token)
+	}
+	return v, nil
+}
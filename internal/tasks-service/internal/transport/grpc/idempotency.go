@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/servicekit/idempotency"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// idempotentMethods — методы, которые учитывают idempotency-key. Ключ действует в
+// пределах пользователей, чьи задачи или комментарии создает запрос.
+var idempotentMethods = map[string]idempotency.Method{
+	taskspb.TasksService_CreateTask_FullMethodName: {
+		NewResponse: func() proto.Message { return &taskspb.TaskResponse{} },
+		Scope:       func(req proto.Message) string { return userScope(req.(*taskspb.TaskCreateRequest).GetUserId()) },
+		Owners:      func(resp proto.Message) []uint32 { return []uint32{resp.(*taskspb.TaskResponse).GetTask().GetUserId()} },
+	},
+	taskspb.TasksService_CreateComment_FullMethodName: {
+		NewResponse: func() proto.Message { return &taskspb.Comment{} },
+		Scope:       func(req proto.Message) string { return userScope(req.(*taskspb.CreateCommentRequest).GetAuthorId()) },
+		Owners:      func(resp proto.Message) []uint32 { return []uint32{resp.(*taskspb.Comment).GetAuthorId()} },
+	},
+	taskspb.TasksService_BatchCreateTasks_FullMethodName: {
+		NewResponse: func() proto.Message { return &taskspb.BatchTasksResponse{} },
+		Scope:       batchScope,
+		Owners:      batchOwners,
+	},
+}
+
+// NewIdempotencyInterceptor возвращает interceptor, который для запросов с заголовком
+// idempotency-key возвращает сохраненный ответ при повторе и FailedPrecondition,
+// если тот же ключ пришел с другим телом запроса.
+func NewIdempotencyInterceptor(store idempotency.Store) grpc.UnaryServerInterceptor {
+	return idempotency.NewInterceptor(store, idempotentMethods)
+}
+
+// userScope возвращает область ключа для пользователей ids: их id по возрастанию через запятую
+func userScope(ids ...uint32) string {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// batchScope — пользователи всех задач пакета
+func batchScope(req proto.Message) string {
+	items := req.(*taskspb.BatchCreateTasksRequest).GetTasks()
+	ids := make([]uint32, len(items))
+	for i, item := range items {
+		ids[i] = item.GetUserId()
+	}
+	return userScope(ids...)
+}
+
+// batchOwners возвращает владельцев всех созданных задач пакета
+func batchOwners(resp proto.Message) []uint32 {
+	var owners []uint32
+	for _, r := range resp.(*taskspb.BatchTasksResponse).GetResults() {
+		if id := r.GetTask().GetUserId(); id != 0 && !slices.Contains(owners, id) {
+			owners = append(owners, id)
+		}
+	}
+	return owners
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"slices"
+	"testing"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+)
+
+func TestUserScope(t *testing.T) {
+	tests := []struct {
+		ids  []uint32
+		want string
+	}{
+		{ids: nil, want: ""},
+		{ids: []uint32{7}, want: "7"},
+		{ids: []uint32{12, 3}, want: "3,12"},
+		{ids: []uint32{5, 1, 5, 1}, want: "1,5"},
+	}
+	for _, tt := range tests {
+		if got := userScope(tt.ids...); got != tt.want {
+			t.Errorf("userScope(%v) = %q, want %q", tt.ids, got, tt.want)
+		}
+	}
+
+	// ключ одного пользователя не совпадает с ключом другого
+	if userScope(1) == userScope(2) {
+		t.Error("different users share a scope")
+	}
+}
+
+func TestBatchScopeAndOwners(t *testing.T) {
+	req := &taskspb.BatchCreateTasksRequest{Tasks: []*taskspb.TaskCreateRequest{
+		{UserId: 9}, {UserId: 2}, {UserId: 9},
+	}}
+	if got := batchScope(req); got != "2,9" {
+		t.Errorf("batchScope() = %q, want %q", got, "2,9")
+	}
+
+	resp := &taskspb.BatchTasksResponse{Results: []*taskspb.BatchTaskResult{
+		{Task: &taskspb.Task{Id: 1, UserId: 9}},
+		{Code: 5, Message: "user not found"},
+		{Task: &taskspb.Task{Id: 2, UserId: 2}},
+		{Task: &taskspb.Task{Id: 3, UserId: 9}},
+	}}
+	if got := batchOwners(resp); !slices.Equal(got, []uint32{9, 2}) {
+		t.Errorf("batchOwners() = %v, want [9 2]", got)
+	}
+}
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MoveTask меняет место задачи в её списке
+func (h *Handler) MoveTask(ctx context.Context, req *taskspb.MoveTaskRequest) (*taskspb.TaskResponse, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id must be > 0")
+	}
+	if req.GetBeforeId() == 0 && req.GetAfterId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "before_id or after_id is required")
+	}
+
+	dm, err := h.tasksFor(ctx).MoveTask(req.GetId(), req.GetBeforeId(), req.GetAfterId())
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrTaskNotFound):
+			return nil, status.Errorf(codes.NotFound, "task with id %d not found", req.GetId())
+		case errors.Is(err, tasks.ErrInvalidMove):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to move task: %v", err)
+		}
+	}
+
+	return &taskspb.TaskResponse{Task: toPBTask(dm)}, nil
+}
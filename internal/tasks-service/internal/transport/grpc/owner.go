@@ -0,0 +1,312 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serviceTokenHeader — заголовок с общим токеном сервисов; запрос без x-actor-id
+// принимается только с ним
+const serviceTokenHeader = "x-service-token"
+
+// serviceMethods вызывают только другие сервисы, сущностей пользователя у них нет.
+// Без x-actor-id доступны только они.
+var serviceMethods = map[string]bool{
+	taskspb.TasksService_GetTaskList_FullMethodName:    true,
+	taskspb.TasksService_OnUserDeleted_FullMethodName:  true,
+	taskspb.TasksService_OnUserRestored_FullMethodName: true,
+	taskspb.TasksService_ExportUserData_FullMethodName: true,
+	taskspb.TasksService_EraseUserData_FullMethodName:  true,
+}
+
+// Owners находит владельцев сущностей
+type Owners interface {
+	OwnerOf(kind domain.EntityKind, id uint32) (uint32, error)
+}
+
+// entityRef — сущность, к которой обращается запрос; id 0 — не задана
+type entityRef struct {
+	kind domain.EntityKind
+	id   uint32
+}
+
+func userRef(id uint32) entityRef       { return entityRef{domain.EntityUser, id} }
+func taskRef(id uint32) entityRef       { return entityRef{domain.EntityTask, id} }
+func projectRef(id uint32) entityRef    { return entityRef{domain.EntityProject, id} }
+func tagRef(id uint32) entityRef        { return entityRef{domain.EntityTag, id} }
+func commentRef(id uint32) entityRef    { return entityRef{domain.EntityComment, id} }
+func attachmentRef(id uint32) entityRef { return entityRef{domain.EntityAttachment, id} }
+
+func createRefs(r *taskspb.TaskCreateRequest) []entityRef {
+	return []entityRef{userRef(r.GetUserId()), taskRef(r.GetParentId()), projectRef(r.GetProjectId())}
+}
+
+func updateRefs(r *taskspb.TaskUpdateRequest) []entityRef {
+	return []entityRef{taskRef(r.GetId()), taskRef(r.GetParentId())}
+}
+
+// ownedEntities — сущности, к которым обращается каждый метод. Для клиентских потоков
+// функция вызывается на каждое сообщение: сущности есть только в первом.
+var ownedEntities = map[string]func(req any) []entityRef{
+	taskspb.TasksService_CreateTask_FullMethodName: func(req any) []entityRef {
+		return createRefs(req.(*taskspb.TaskCreateRequest))
+	},
+	taskspb.TasksService_UpdateTask_FullMethodName: func(req any) []entityRef {
+		return updateRefs(req.(*taskspb.TaskUpdateRequest))
+	},
+	taskspb.TasksService_DeleteTask_FullMethodName: func(req any) []entityRef {
+		return []entityRef{taskRef(req.(*taskspb.TaskDeleteRequest).GetId())}
+	},
+	taskspb.TasksService_ListTasksByUser_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.ListTasksByUserRequest).GetUserId())}
+	},
+	taskspb.TasksService_WatchTasks_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.WatchTasksRequest).GetUserId())}
+	},
+	taskspb.TasksService_GetSubtree_FullMethodName: func(req any) []entityRef {
+		return []entityRef{taskRef(req.(*taskspb.GetSubtreeRequest).GetTaskId())}
+	},
+	taskspb.TasksService_AddDependency_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.DependencyRequest)
+		return []entityRef{taskRef(r.GetTaskId()), taskRef(r.GetBlockerId())}
+	},
+	taskspb.TasksService_RemoveDependency_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.DependencyRequest)
+		return []entityRef{taskRef(r.GetTaskId()), taskRef(r.GetBlockerId())}
+	},
+	taskspb.TasksService_GetPlan_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.GetPlanRequest).GetUserId())}
+	},
+	taskspb.TasksService_CreateProject_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.CreateProjectRequest).GetUserId())}
+	},
+	taskspb.TasksService_UpdateProject_FullMethodName: func(req any) []entityRef {
+		return []entityRef{projectRef(req.(*taskspb.UpdateProjectRequest).GetId())}
+	},
+	taskspb.TasksService_DeleteProject_FullMethodName: func(req any) []entityRef {
+		return []entityRef{projectRef(req.(*taskspb.DeleteProjectRequest).GetId())}
+	},
+	taskspb.TasksService_ListProjects_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.ListProjectsRequest).GetUserId())}
+	},
+	taskspb.TasksService_ReorderProjects_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.ReorderProjectsRequest).GetUserId())}
+	},
+	taskspb.TasksService_ListTasksByProject_FullMethodName: func(req any) []entityRef {
+		return []entityRef{projectRef(req.(*taskspb.ListTasksByProjectRequest).GetProjectId())}
+	},
+	taskspb.TasksService_MoveTaskToProject_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.MoveTaskToProjectRequest)
+		return []entityRef{taskRef(r.GetTaskId()), projectRef(r.GetProjectId())}
+	},
+	taskspb.TasksService_MoveTask_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.MoveTaskRequest)
+		return []entityRef{taskRef(r.GetId()), taskRef(r.GetBeforeId()), taskRef(r.GetAfterId())}
+	},
+	taskspb.TasksService_GetTaskHistory_FullMethodName: func(req any) []entityRef {
+		return []entityRef{taskRef(req.(*taskspb.GetTaskHistoryRequest).GetTaskId())}
+	},
+	taskspb.TasksService_ListDeletedTasks_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.ListDeletedTasksRequest).GetUserId())}
+	},
+	taskspb.TasksService_RestoreTask_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.RestoreTaskRequest).GetUserId())}
+	},
+	taskspb.TasksService_PurgeTask_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.PurgeTaskRequest).GetUserId())}
+	},
+	taskspb.TasksService_BatchCreateTasks_FullMethodName: func(req any) []entityRef {
+		var refs []entityRef
+		for _, t := range req.(*taskspb.BatchCreateTasksRequest).GetTasks() {
+			refs = append(refs, createRefs(t)...)
+		}
+		return refs
+	},
+	taskspb.TasksService_BatchUpdateTasks_FullMethodName: func(req any) []entityRef {
+		var refs []entityRef
+		for _, t := range req.(*taskspb.BatchUpdateTasksRequest).GetTasks() {
+			refs = append(refs, updateRefs(t)...)
+		}
+		return refs
+	},
+	taskspb.TasksService_BatchDeleteTasks_FullMethodName: func(req any) []entityRef {
+		var refs []entityRef
+		for _, t := range req.(*taskspb.BatchDeleteTasksRequest).GetTasks() {
+			refs = append(refs, taskRef(t.GetId()))
+		}
+		return refs
+	},
+	taskspb.TasksService_ExportTasks_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.ExportTasksRequest).GetUserId())}
+	},
+	taskspb.TasksService_ImportTasks_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.ImportTasksRequest).GetOptions().GetUserId())}
+	},
+	taskspb.TasksService_CreateComment_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.CreateCommentRequest)
+		return []entityRef{taskRef(r.GetTaskId()), userRef(r.GetAuthorId())}
+	},
+	taskspb.TasksService_EditComment_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.EditCommentRequest)
+		return []entityRef{commentRef(r.GetId()), userRef(r.GetAuthorId())}
+	},
+	taskspb.TasksService_DeleteComment_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.DeleteCommentRequest)
+		return []entityRef{commentRef(r.GetId()), userRef(r.GetAuthorId())}
+	},
+	taskspb.TasksService_ListComments_FullMethodName: func(req any) []entityRef {
+		return []entityRef{taskRef(req.(*taskspb.ListCommentsRequest).GetTaskId())}
+	},
+	taskspb.TasksService_GetCommentHistory_FullMethodName: func(req any) []entityRef {
+		return []entityRef{commentRef(req.(*taskspb.GetCommentHistoryRequest).GetId())}
+	},
+	taskspb.TasksService_UploadAttachment_FullMethodName: func(req any) []entityRef {
+		return []entityRef{taskRef(req.(*taskspb.UploadAttachmentRequest).GetInfo().GetTaskId())}
+	},
+	taskspb.TasksService_DownloadAttachment_FullMethodName: func(req any) []entityRef {
+		return []entityRef{attachmentRef(req.(*taskspb.DownloadAttachmentRequest).GetId())}
+	},
+	taskspb.TasksService_ListAttachments_FullMethodName: func(req any) []entityRef {
+		return []entityRef{taskRef(req.(*taskspb.ListAttachmentsRequest).GetTaskId())}
+	},
+	taskspb.TasksService_DeleteAttachment_FullMethodName: func(req any) []entityRef {
+		return []entityRef{attachmentRef(req.(*taskspb.DeleteAttachmentRequest).GetId())}
+	},
+	taskspb.TasksService_CreateTag_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.CreateTagRequest).GetUserId())}
+	},
+	taskspb.TasksService_RenameTag_FullMethodName: func(req any) []entityRef {
+		return []entityRef{tagRef(req.(*taskspb.RenameTagRequest).GetId())}
+	},
+	taskspb.TasksService_DeleteTag_FullMethodName: func(req any) []entityRef {
+		return []entityRef{tagRef(req.(*taskspb.DeleteTagRequest).GetId())}
+	},
+	taskspb.TasksService_ListTags_FullMethodName: func(req any) []entityRef {
+		return []entityRef{userRef(req.(*taskspb.ListTagsRequest).GetUserId())}
+	},
+	taskspb.TasksService_AttachTag_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.TaskTagRequest)
+		return []entityRef{taskRef(r.GetTaskId()), tagRef(r.GetTagId())}
+	},
+	taskspb.TasksService_DetachTag_FullMethodName: func(req any) []entityRef {
+		r := req.(*taskspb.TaskTagRequest)
+		return []entityRef{taskRef(r.GetTaskId()), tagRef(r.GetTagId())}
+	},
+}
+
+// NewOwnerInterceptor возвращает interceptor, который для запросов с x-actor-id
+// допускает обращение только к сущностям этого пользователя: чужие задачи, проекты,
+// теги, комментарии и вложения не находятся. Запрос без заголовка — вызов другого
+// сервиса: он должен предъявить serviceToken и вызывать метод из serviceMethods.
+func NewOwnerInterceptor(owners Owners, serviceToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkOwners(ctx, owners, serviceToken, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewOwnerStreamInterceptor — то же для потоковых методов: вызов сервиса проверяется
+// до обработчика, владельцы — в каждом полученном сообщении
+func NewOwnerStreamInterceptor(owners Owners, serviceToken string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		actor, err := actorFromMetadata(ss.Context())
+		if err != nil {
+			return err
+		}
+		if actor == 0 {
+			if err := checkService(ss.Context(), serviceToken, info.FullMethod); err != nil {
+				return err
+			}
+		}
+		return handler(srv, &ownerStream{ServerStream: ss, owners: owners, serviceToken: serviceToken, method: info.FullMethod})
+	}
+}
+
+type ownerStream struct {
+	grpc.ServerStream
+	owners       Owners
+	serviceToken string
+	method       string
+}
+
+func (s *ownerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkOwners(s.Context(), s.owners, s.serviceToken, s.method, m)
+}
+
+// checkOwners сверяет владельцев сущностей запроса с автором запроса,
+// а у запроса без автора — токен сервиса
+func checkOwners(ctx context.Context, owners Owners, serviceToken, method string, req any) error {
+	actor, err := actorFromMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	if actor == 0 {
+		return checkService(ctx, serviceToken, method)
+	}
+	refsOf, ok := ownedEntities[method]
+	if !ok {
+		// методы, которые вызывают только другие сервисы
+		return status.Error(codes.PermissionDenied, "method is not available on behalf of a user")
+	}
+
+	for _, ref := range refsOf(req) {
+		if ref.id == 0 {
+			continue
+		}
+		owner, err := owners.OwnerOf(ref.kind, ref.id)
+		switch {
+		case isNotFound(err):
+			// ошибку об отсутствии вернет сам обработчик
+			continue
+		case err != nil:
+			return status.Error(codes.Internal, "failed to check owner")
+		case owner == actor:
+			continue
+		case ref.kind == domain.EntityUser:
+			return status.Error(codes.PermissionDenied, "access to another user's data is denied")
+		default:
+			return status.Errorf(codes.NotFound, "%s with id %d not found", ref.kind, ref.id)
+		}
+	}
+	return nil
+}
+
+// checkService пропускает вызов сервиса с верным токеном и только к методам из
+// serviceMethods. Без настроенного токена вызовы без x-actor-id не принимаются.
+func checkService(ctx context.Context, serviceToken, method string) error {
+	values := metadata.ValueFromIncomingContext(ctx, serviceTokenHeader)
+	if serviceToken == "" || len(values) == 0 ||
+		subtle.ConstantTimeCompare([]byte(values[0]), []byte(serviceToken)) != 1 {
+		return status.Errorf(codes.Unauthenticated, "%s or a valid %s is required", actorHeader, serviceTokenHeader)
+	}
+	if !serviceMethods[method] {
+		return status.Error(codes.PermissionDenied, "method is available on behalf of a user only")
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	for _, target := range []error{
+		tasks.ErrTaskNotFound, tasks.ErrProjectNotFound, tasks.ErrTagNotFound,
+		tasks.ErrCommentNotFound, tasks.ErrAttachmentNotFound,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
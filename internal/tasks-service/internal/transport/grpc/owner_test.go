@@ -0,0 +1,154 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeOwners — владельцы сущностей в памяти
+type fakeOwners map[entityRef]uint32
+
+func (f fakeOwners) OwnerOf(kind domain.EntityKind, id uint32) (uint32, error) {
+	if kind == domain.EntityUser {
+		return id, nil
+	}
+	owner, ok := f[entityRef{kind, id}]
+	if !ok {
+		return 0, tasks.ErrTaskNotFound
+	}
+	return owner, nil
+}
+
+// testOwners: у пользователя 1 задача 10, у пользователя 2 — задача 20,
+// комментарий 21 и вложение 22
+var testOwners = fakeOwners{
+	taskRef(10):       1,
+	taskRef(20):       2,
+	commentRef(21):    2,
+	attachmentRef(22): 2,
+}
+
+// testServiceToken — токен сервисов в тестах
+const testServiceToken = "service-secret"
+
+func actorContext(id string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(actorHeader, id))
+}
+
+func serviceContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(serviceTokenHeader, token))
+}
+
+func TestEveryMethodIsClassified(t *testing.T) {
+	service := "/" + taskspb.TasksService_ServiceDesc.ServiceName + "/"
+	var names []string
+	for _, m := range taskspb.TasksService_ServiceDesc.Methods {
+		names = append(names, m.MethodName)
+	}
+	for _, s := range taskspb.TasksService_ServiceDesc.Streams {
+		names = append(names, s.StreamName)
+	}
+	for _, name := range names {
+		method := service + name
+		if _, ok := ownedEntities[method]; ok == serviceMethods[method] {
+			t.Errorf("%s must be either in ownedEntities or in serviceMethods", method)
+		}
+	}
+}
+
+func TestCheckOwners(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    any
+		want   codes.Code
+	}{
+		{
+			"own task", actorContext("1"), taskspb.TasksService_UpdateTask_FullMethodName,
+			&taskspb.TaskUpdateRequest{Id: 10}, codes.OK,
+		},
+		{
+			"another user's task", actorContext("1"), taskspb.TasksService_DeleteTask_FullMethodName,
+			&taskspb.TaskDeleteRequest{Id: 20}, codes.NotFound,
+		},
+		{
+			"another user's parent", actorContext("1"), taskspb.TasksService_UpdateTask_FullMethodName,
+			&taskspb.TaskUpdateRequest{Id: 10, ParentId: 20}, codes.NotFound,
+		},
+		{
+			"another user's comment", actorContext("1"), taskspb.TasksService_EditComment_FullMethodName,
+			&taskspb.EditCommentRequest{Id: 21, AuthorId: 1}, codes.NotFound,
+		},
+		{
+			"another author", actorContext("2"), taskspb.TasksService_DeleteComment_FullMethodName,
+			&taskspb.DeleteCommentRequest{Id: 21, AuthorId: 1}, codes.PermissionDenied,
+		},
+		{
+			"another user's batch item", actorContext("1"), taskspb.TasksService_BatchDeleteTasks_FullMethodName,
+			&taskspb.BatchDeleteTasksRequest{Tasks: []*taskspb.TaskDeleteRequest{{Id: 10}, {Id: 20}}}, codes.NotFound,
+		},
+		{
+			"another user's id", actorContext("1"), taskspb.TasksService_ListTasksByUser_FullMethodName,
+			&taskspb.ListTasksByUserRequest{UserId: 2}, codes.PermissionDenied,
+		},
+		{
+			// ошибку об отсутствии возвращает обработчик
+			"missing task", actorContext("1"), taskspb.TasksService_GetSubtree_FullMethodName,
+			&taskspb.GetSubtreeRequest{TaskId: 99}, codes.OK,
+		},
+		{
+			"attachment chunk", actorContext("1"), taskspb.TasksService_UploadAttachment_FullMethodName,
+			&taskspb.UploadAttachmentRequest{Data: &taskspb.UploadAttachmentRequest_Chunk{Chunk: []byte("x")}}, codes.OK,
+		},
+		{
+			"another user's attachment", actorContext("1"), taskspb.TasksService_DownloadAttachment_FullMethodName,
+			&taskspb.DownloadAttachmentRequest{Id: 22}, codes.NotFound,
+		},
+		{
+			"service method", actorContext("1"), taskspb.TasksService_GetTaskList_FullMethodName,
+			nil, codes.PermissionDenied,
+		},
+		{
+			"service call", serviceContext(testServiceToken), taskspb.TasksService_OnUserDeleted_FullMethodName,
+			&taskspb.UserDeletedEvent{UserId: 2}, codes.OK,
+		},
+		{
+			"service call to a user method", serviceContext(testServiceToken), taskspb.TasksService_DeleteTask_FullMethodName,
+			&taskspb.TaskDeleteRequest{Id: 20}, codes.PermissionDenied,
+		},
+		{
+			"no actor and no token", context.Background(), taskspb.TasksService_DeleteTask_FullMethodName,
+			&taskspb.TaskDeleteRequest{Id: 20}, codes.Unauthenticated,
+		},
+		{
+			"wrong service token", serviceContext("guess"), taskspb.TasksService_EraseUserData_FullMethodName,
+			&taskspb.EraseUserDataRequest{UserId: 2}, codes.Unauthenticated,
+		},
+		{
+			"bad actor", actorContext("x"), taskspb.TasksService_DeleteTask_FullMethodName,
+			&taskspb.TaskDeleteRequest{Id: 10}, codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		err := checkOwners(tt.ctx, testOwners, testServiceToken, tt.method, tt.req)
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s: code = %v, want %v (%v)", tt.name, got, tt.want, err)
+		}
+	}
+}
+
+func TestCheckServiceWithoutToken(t *testing.T) {
+	// без настроенного токена вызовы без x-actor-id не принимаются, даже с пустым заголовком
+	err := checkService(serviceContext(""), "", taskspb.TasksService_OnUserDeleted_FullMethodName)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("checkService() = %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,191 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Пути update_mask, которые поддерживает UpdateProject
+const (
+	pathProjectName     = "name"
+	pathProjectArchived = "archived"
+)
+
+func toPBProject(p *domain.Project) *taskspb.Project {
+	return &taskspb.Project{
+		Id:       p.ID,
+		UserId:   p.UserID,
+		Name:     p.Name,
+		Archived: p.Archived,
+		Position: uint32(p.Position),
+	}
+}
+
+func toPBProjects(projects []*domain.Project) *taskspb.ListProjectsResponse {
+	out := make([]*taskspb.Project, 0, len(projects))
+	for _, p := range projects {
+		out = append(out, toPBProject(p))
+	}
+	return &taskspb.ListProjectsResponse{Projects: out}
+}
+
+// CreateProject добавляет проект в конец списка проектов пользователя
+func (h *Handler) CreateProject(ctx context.Context, req *taskspb.CreateProjectRequest) (*taskspb.Project, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	if _, err := h.client.GetUser(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user with id %d not found", req.GetUserId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	project, err := h.svc.CreateProject(req.GetUserId(), req.GetName())
+	if err != nil {
+		return nil, projectError(err)
+	}
+
+	return toPBProject(project), nil
+}
+
+// UpdateProject переименовывает проект или меняет флаг архива
+func (h *Handler) UpdateProject(ctx context.Context, req *taskspb.UpdateProjectRequest) (*taskspb.Project, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be > 0")
+	}
+
+	upd, err := projectUpdateFromRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	project, err := h.svc.UpdateProject(req.GetId(), upd)
+	if err != nil {
+		return nil, projectError(err)
+	}
+
+	return toPBProject(project), nil
+}
+
+// projectUpdateFromRequest строит изменение проекта по update_mask
+func projectUpdateFromRequest(req *taskspb.UpdateProjectRequest) (domain.ProjectUpdate, error) {
+	name, archived := req.GetName(), req.GetArchived()
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return domain.ProjectUpdate{Name: &name, Archived: &archived}, nil
+	}
+
+	var upd domain.ProjectUpdate
+	for _, p := range paths {
+		switch p {
+		case pathProjectName:
+			upd.Name = &name
+		case pathProjectArchived:
+			upd.Archived = &archived
+		default:
+			return domain.ProjectUpdate{}, fmt.Errorf("unknown update_mask path %q", p)
+		}
+	}
+
+	return upd, nil
+}
+
+// DeleteProject удаляет проект, его задачи остаются без проекта
+func (h *Handler) DeleteProject(ctx context.Context, req *taskspb.DeleteProjectRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be > 0")
+	}
+
+	if err := h.tasksFor(ctx).DeleteProject(req.GetId()); err != nil {
+		return nil, projectError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListProjects(ctx context.Context, req *taskspb.ListProjectsRequest) (*taskspb.ListProjectsResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	projects, err := h.svc.ListProjects(req.GetUserId(), req.GetIncludeArchived())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get projects of user %d: %v", req.GetUserId(), err)
+	}
+
+	return toPBProjects(projects), nil
+}
+
+// ReorderProjects задает новый порядок всех проектов пользователя
+func (h *Handler) ReorderProjects(ctx context.Context, req *taskspb.ReorderProjectsRequest) (*taskspb.ListProjectsResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	projects, err := h.svc.ReorderProjects(req.GetUserId(), req.GetProjectIds())
+	if err != nil {
+		return nil, projectError(err)
+	}
+
+	return toPBProjects(projects), nil
+}
+
+func (h *Handler) ListTasksByProject(ctx context.Context, req *taskspb.ListTasksByProjectRequest) (*taskspb.TaskListResponse, error) {
+	if req.GetProjectId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "project id must be > 0")
+	}
+
+	list, err := h.svc.ListTasksByProject(req.GetProjectId())
+	if err != nil {
+		return nil, projectError(err)
+	}
+
+	out := make([]*taskspb.Task, 0, len(list))
+	for _, t := range list {
+		out = append(out, toPBTask(t))
+	}
+
+	return &taskspb.TaskListResponse{Tasks: out}, nil
+}
+
+// MoveTaskToProject переносит задачу с подзадачами в другой проект
+func (h *Handler) MoveTaskToProject(ctx context.Context, req *taskspb.MoveTaskToProjectRequest) (*taskspb.TaskResponse, error) {
+	if req.GetTaskId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id must be > 0")
+	}
+
+	dm, err := h.tasksFor(ctx).MoveTaskToProject(req.GetTaskId(), optionalID(req.GetProjectId()))
+	if err != nil {
+		if errors.Is(err, tasks.ErrTaskNotFound) {
+			return nil, status.Errorf(codes.NotFound, "task with id %d not found", req.GetTaskId())
+		}
+		return nil, projectError(err)
+	}
+
+	return &taskspb.TaskResponse{Task: toPBTask(dm)}, nil
+}
+
+// projectError переводит ошибку работы с проектами в gRPC статус
+func projectError(err error) error {
+	switch {
+	case errors.Is(err, tasks.ErrProjectNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, tasks.ErrInvalidProjectName), errors.Is(err, tasks.ErrInvalidProjectOrder):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tasks.ErrProjectArchived), errors.Is(err, tasks.ErrProjectOwnerMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "project operation failed: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"testing"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestProjectUpdateFromRequest(t *testing.T) {
+	tests := []struct {
+		name                   string
+		paths                  []string
+		wantName, wantArchived bool
+		wantErr                bool
+	}{
+		{name: "no mask", wantName: true, wantArchived: true},
+		{name: "name", paths: []string{"name"}, wantName: true},
+		{name: "archived", paths: []string{"archived"}, wantArchived: true},
+		{name: "unknown path", paths: []string{"position"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &taskspb.UpdateProjectRequest{Id: 1, Name: "Работа", Archived: true}
+			if tt.paths != nil {
+				req.UpdateMask = &fieldmaskpb.FieldMask{Paths: tt.paths}
+			}
+
+			upd, err := projectUpdateFromRequest(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("projectUpdateFromRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (upd.Name != nil) != tt.wantName || (upd.Archived != nil) != tt.wantArchived {
+				t.Errorf("Name = %v, Archived = %v; want set %v, %v", upd.Name, upd.Archived, tt.wantName, tt.wantArchived)
+			}
+		})
+	}
+}
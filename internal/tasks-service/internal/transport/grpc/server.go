@@ -12,23 +12,32 @@ import (
 
 type Server struct {
 	server *grpc.Server
+	Host   string
 	Port   int
 }
 
-func NewServer(port int) *Server {
+// NewServer создает сервер задач на адресе host:port. Первыми выполняются проверки
+// владельцев: запрос от имени пользователя обращается только к его сущностям, а
+// запрос без него принимается только от сервисов с токеном serviceToken.
+func NewServer(host string, port int, owners Owners, serviceToken string, interceptors ...grpc.UnaryServerInterceptor) *Server {
+	unary := append([]grpc.UnaryServerInterceptor{NewOwnerInterceptor(owners, serviceToken)}, interceptors...)
 	return &Server{
-		server: grpc.NewServer(),
-		Port:   port,
+		server: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(unary...),
+			grpc.StreamInterceptor(NewOwnerStreamInterceptor(owners, serviceToken)),
+		),
+		Host: host,
+		Port: port,
 	}
 }
 
-func (s *Server) RegisterServices(svc tasks.TasksService, cl Client) {
-	tasksHandler := NewHandler(svc, cl)
+func (s *Server) RegisterServices(svc tasks.TasksService, attachments tasks.AttachmentsService, cl Client) {
+	tasksHandler := NewHandler(svc, attachments, cl)
 	taskspb.RegisterTasksServiceServer(s.server, tasksHandler)
 }
 
 func (s *Server) Start() error {
-	addr := net.JoinHostPort("", strconv.Itoa(s.Port)) // ":50051"
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
 	ls, err := net.Listen("tcp", addr)
 
 	fmt.Println("gRPC tasks server is running on port", s.Port)
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateTag добавляет тег в каталог пользователя
+func (h *Handler) CreateTag(ctx context.Context, req *taskspb.CreateTagRequest) (*taskspb.Tag, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	if _, err := h.client.GetUser(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user with id %d not found", req.GetUserId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	tag, err := h.svc.CreateTag(req.GetUserId(), req.GetName())
+	if err != nil {
+		return nil, tagError(err)
+	}
+
+	return toPBTag(tag), nil
+}
+
+func (h *Handler) RenameTag(ctx context.Context, req *taskspb.RenameTagRequest) (*taskspb.Tag, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be > 0")
+	}
+
+	tag, err := h.svc.RenameTag(req.GetId(), req.GetName())
+	if err != nil {
+		return nil, tagError(err)
+	}
+
+	return toPBTag(tag), nil
+}
+
+// DeleteTag удаляет тег и снимает его со всех задач
+func (h *Handler) DeleteTag(ctx context.Context, req *taskspb.DeleteTagRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be > 0")
+	}
+
+	if err := h.svc.DeleteTag(req.GetId()); err != nil {
+		return nil, tagError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) ListTags(ctx context.Context, req *taskspb.ListTagsRequest) (*taskspb.ListTagsResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+
+	tags, err := h.svc.ListTags(req.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get tags of user %d: %v", req.GetUserId(), err)
+	}
+
+	out := make([]*taskspb.Tag, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, toPBTag(t))
+	}
+
+	return &taskspb.ListTagsResponse{Tags: out}, nil
+}
+
+// AttachTag помечает задачу тегом её владельца
+func (h *Handler) AttachTag(ctx context.Context, req *taskspb.TaskTagRequest) (*taskspb.TaskResponse, error) {
+	return h.changeTaskTag(req, h.tasksFor(ctx).AttachTag)
+}
+
+// DetachTag снимает тег с задачи
+func (h *Handler) DetachTag(ctx context.Context, req *taskspb.TaskTagRequest) (*taskspb.TaskResponse, error) {
+	return h.changeTaskTag(req, h.tasksFor(ctx).DetachTag)
+}
+
+func (h *Handler) changeTaskTag(req *taskspb.TaskTagRequest, change func(taskID, tagID uint32) (*domain.Task, error)) (*taskspb.TaskResponse, error) {
+	if req.GetTaskId() == 0 || req.GetTagId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "task id and tag id must be > 0")
+	}
+
+	dm, err := change(req.GetTaskId(), req.GetTagId())
+	if err != nil {
+		if errors.Is(err, tasks.ErrTaskNotFound) {
+			return nil, status.Errorf(codes.NotFound, "task with id %d not found", req.GetTaskId())
+		}
+		return nil, tagError(err)
+	}
+
+	return &taskspb.TaskResponse{Task: toPBTask(dm)}, nil
+}
+
+// tagError переводит ошибку работы с тегами в gRPC статус
+func tagError(err error) error {
+	switch {
+	case errors.Is(err, tasks.ErrTagNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, tasks.ErrTagExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, tasks.ErrInvalidTagName):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tasks.ErrTagOwnerMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "tag operation failed: %v", err)
+	}
+}
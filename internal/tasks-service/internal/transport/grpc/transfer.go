@@ -0,0 +1,145 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/domain"
+	"github.com/your-org/tasks-service/internal/taskfile"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errUnexpectedOptions = fmt.Errorf("options are allowed only in the first message")
+
+var fileFormats = map[taskspb.FileFormat]taskfile.Format{
+	taskspb.FileFormat_FILE_FORMAT_CSV:    taskfile.CSV,
+	taskspb.FileFormat_FILE_FORMAT_JSONL:  taskfile.JSONLines,
+	taskspb.FileFormat_FILE_FORMAT_NDJSON: taskfile.JSONLines,
+}
+
+var importRowStatuses = map[domain.ImportRowStatus]taskspb.ImportRowStatus{
+	domain.ImportRowImported:  taskspb.ImportRowStatus_IMPORT_ROW_STATUS_IMPORTED,
+	domain.ImportRowDuplicate: taskspb.ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE,
+	domain.ImportRowInvalid:   taskspb.ImportRowStatus_IMPORT_ROW_STATUS_INVALID,
+}
+
+// exportWriter отправляет записанные байты сообщениями ExportTasks
+type exportWriter struct {
+	stream taskspb.TasksService_ExportTasksServer
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	// сообщение сериализуется при отправке, копия p не нужна
+	if err := w.stream.Send(&taskspb.ExportTasksResponse{Chunk: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// importReader читает файл из сообщений chunk клиентского потока
+type importReader struct {
+	stream taskspb.TasksService_ImportTasksServer
+	buf    []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetOptions() != nil {
+			return 0, errUnexpectedOptions
+		}
+		r.buf = msg.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// ExportTasks отдает задачи пользователя файлом кусками
+func (h *Handler) ExportTasks(req *taskspb.ExportTasksRequest, stream taskspb.TasksService_ExportTasksServer) error {
+	if req.GetUserId() == 0 {
+		return status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+	format, ok := fileFormats[req.GetFormat()]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown file format %v", req.GetFormat())
+	}
+
+	w := bufio.NewWriterSize(&exportWriter{stream: stream}, downloadChunkSize)
+	if _, err := h.svc.ExportTasks(req.GetUserId(), w, format); err != nil {
+		return status.Errorf(codes.Internal, "failed to export tasks: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ImportTasks принимает файл задач потоком: сначала options, затем файл кусками
+func (h *Handler) ImportTasks(stream taskspb.TasksService_ImportTasksServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "empty import stream")
+	}
+	if err != nil {
+		return err
+	}
+
+	opts := first.GetOptions()
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "first message must carry import options")
+	}
+	if opts.GetUserId() == 0 {
+		return status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+	format, ok := fileFormats[opts.GetFormat()]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown file format %v", opts.GetFormat())
+	}
+	if err := h.checkUser(stream.Context(), opts.GetUserId()); err != nil {
+		return err
+	}
+
+	report, err := h.tasksFor(stream.Context()).ImportTasks(opts.GetUserId(), &importReader{stream: stream}, format, opts.GetDryRun())
+	if err != nil {
+		switch {
+		case errors.Is(err, tasks.ErrInvalidImport), errors.Is(err, errUnexpectedOptions):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, tasks.ErrImportTooLarge):
+			return status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return status.FromContextError(err).Err()
+		default:
+			return status.Errorf(codes.Internal, "failed to import tasks: %v", err)
+		}
+	}
+
+	rows := make([]*taskspb.ImportRowResult, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = &taskspb.ImportRowResult{
+			Line:   uint32(row.Line),
+			Status: importRowStatuses[row.Status],
+			TaskId: row.TaskID,
+			Error:  row.Error,
+		}
+	}
+
+	return stream.SendAndClose(&taskspb.ImportTasksResponse{
+		Imported:   uint32(report.Imported),
+		Duplicates: uint32(report.Duplicates),
+		Invalid:    uint32(report.Invalid),
+		DryRun:     report.DryRun,
+		Rows:       rows,
+	})
+}
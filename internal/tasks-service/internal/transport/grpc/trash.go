@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/tasks-service/internal/tasks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListDeletedTasks возвращает корзину пользователя постранично
+func (h *Handler) ListDeletedTasks(ctx context.Context, req *taskspb.ListDeletedTasksRequest) (*taskspb.ListDeletedTasksResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id must be > 0")
+	}
+	after, err := parsePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	deleted, next, err := h.svc.ListDeletedTasks(req.GetUserId(), after, int(req.GetPageSize()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list deleted tasks: %v", err)
+	}
+
+	out := make([]*taskspb.DeletedTask, 0, len(deleted))
+	for _, d := range deleted {
+		out = append(out, &taskspb.DeletedTask{Task: toPBTask(d.Task), DeletedAt: timestamppb.New(d.DeletedAt)})
+	}
+	return &taskspb.ListDeletedTasksResponse{Tasks: out, NextPageToken: formatPageToken(next)}, nil
+}
+
+// RestoreTask возвращает задачу из корзины; владелец задачи должен существовать
+func (h *Handler) RestoreTask(ctx context.Context, req *taskspb.RestoreTaskRequest) (*taskspb.TaskResponse, error) {
+	if req.GetId() == 0 || req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id and user id must be > 0")
+	}
+
+	if _, err := h.client.GetUser(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, status.Errorf(codes.FailedPrecondition, "user with id %d is deleted, restore the user first", req.GetUserId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+
+	dm, err := h.tasksFor(ctx).RestoreTask(req.GetUserId(), req.GetId())
+	if err != nil {
+		return nil, trashError(err, "failed to restore task")
+	}
+
+	return &taskspb.TaskResponse{Task: toPBTask(dm)}, nil
+}
+
+// PurgeTask окончательно удаляет задачу из корзины
+func (h *Handler) PurgeTask(ctx context.Context, req *taskspb.PurgeTaskRequest) (*emptypb.Empty, error) {
+	if req.GetId() == 0 || req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id and user id must be > 0")
+	}
+
+	if err := h.svc.PurgeTask(ctx, req.GetUserId(), req.GetId()); err != nil {
+		return nil, trashError(err, "failed to purge task")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func trashError(err error, msg string) error {
+	switch {
+	case errors.Is(err, tasks.ErrTaskNotFound):
+		return status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, tasks.ErrTaskNotDeleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
@@ -0,0 +1,6 @@
+-- Идемпотентность потребителя событий: каждое событие обрабатывается один раз
+CREATE TABLE IF NOT EXISTS processed_events
+(
+    event_id     VARCHAR(64) PRIMARY KEY,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,13 @@
+-- Журнал изменений задач для подписок WatchTasks. revision задаёт порядок событий
+-- и считается отдельно для каждого пользователя: записи событий разных
+-- пользователей не ждут друг друга
+CREATE TABLE IF NOT EXISTS task_events
+(
+    user_id    INTEGER     NOT NULL,
+    revision   BIGINT      NOT NULL,
+    type       VARCHAR(16) NOT NULL,
+    task_id    INTEGER     NOT NULL,
+    task       JSONB       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, revision)
+);
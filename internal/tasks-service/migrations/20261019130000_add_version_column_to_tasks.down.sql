@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS tasks DROP COLUMN IF EXISTS version;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_pending_reminders;
+DROP INDEX IF EXISTS idx_tasks_user_id_due_at;
+
+ALTER TABLE IF EXISTS tasks
+    DROP COLUMN IF EXISTS reminded_at,
+    DROP COLUMN IF EXISTS remind_at,
+    DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,12 @@
+-- Срок выполнения и напоминание; reminded_at проставляется, когда напоминание отправлено
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS due_at      TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS remind_at   TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id_due_at ON tasks (user_id, due_at)
+    WHERE due_at IS NOT NULL AND deleted_at IS NULL;
+
+-- планировщик выбирает только неотправленные напоминания
+CREATE INDEX IF NOT EXISTS idx_tasks_pending_reminders ON tasks (remind_at)
+    WHERE remind_at IS NOT NULL AND reminded_at IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_tasks_user_id_status;
+
+ALTER TABLE IF EXISTS tasks DROP CONSTRAINT IF EXISTS tasks_priority_valid;
+ALTER TABLE IF EXISTS tasks DROP CONSTRAINT IF EXISTS tasks_status_valid;
+
+ALTER TABLE IF EXISTS tasks
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,19 @@
+-- Статус задачи вместо булева is_done; is_done остаётся производным полем (status = 'done')
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS status       VARCHAR(16) NOT NULL DEFAULT 'todo',
+    ADD COLUMN IF NOT EXISTS priority     SMALLINT    NOT NULL DEFAULT 2,
+    ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ NULL;
+
+UPDATE tasks
+SET status       = 'done',
+    completed_at = updated_at
+WHERE is_done = true;
+
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_status_valid CHECK (status IN ('todo', 'in_progress', 'blocked', 'done', 'canceled'));
+
+-- 1 low, 2 medium, 3 high, 4 urgent
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_priority_valid CHECK (priority BETWEEN 1 AND 4);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id_status ON tasks (user_id, status);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_task_tags_tag_id_task_id;
+DROP TABLE IF EXISTS task_tags;
+
+DROP INDEX IF EXISTS idx_tags_user_id_name;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,22 @@
+-- Каталог тегов пользователя; имя уникально в пределах пользователя без учёта регистра
+CREATE TABLE IF NOT EXISTS tags
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER     NOT NULL CHECK (user_id > 0),
+    name       VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_id_name ON tags (user_id, lower(name));
+
+-- Связь задач и тегов; при удалении тега или задачи связь удаляется
+CREATE TABLE IF NOT EXISTS task_tags
+(
+    task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    tag_id  INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+-- фильтр задач по тегам идёт от tag_id к task_id
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag_id_task_id ON task_tags (tag_id, task_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+
+ALTER TABLE IF EXISTS tasks
+    DROP CONSTRAINT IF EXISTS tasks_parent_id_not_self,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,7 @@
+-- Иерархия задач: parent_id ссылается на родительскую задачу того же пользователя
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS parent_id INTEGER NULL REFERENCES tasks (id) ON DELETE SET NULL,
+    ADD CONSTRAINT tasks_parent_id_not_self CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id)
+    WHERE parent_id IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,12 @@
+-- Зависимости задач: task_id заблокирована задачей blocker_id
+CREATE TABLE IF NOT EXISTS task_dependencies
+(
+    task_id    INTEGER     NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    blocker_id INTEGER     NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (task_id, blocker_id),
+    CHECK (task_id <> blocker_id)
+);
+
+-- обход графа от блокирующей задачи к заблокированным
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocker_id ON task_dependencies (blocker_id);
@@ -0,0 +1,20 @@
+-- Проекты — именованные списки задач пользователя; position задает порядок в списке
+CREATE TABLE IF NOT EXISTS projects
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER      NOT NULL CHECK (user_id > 0),
+    name       VARCHAR(128) NOT NULL,
+    archived   BOOLEAN      NOT NULL DEFAULT false,
+    position   INTEGER      NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_projects_user_id_position ON projects (user_id, position);
+
+-- задачи удаленного проекта остаются без проекта
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS project_id INTEGER NULL REFERENCES projects (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id)
+    WHERE project_id IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_list_rank;
+
+ALTER TABLE IF EXISTS tasks
+    DROP COLUMN IF EXISTS rank;
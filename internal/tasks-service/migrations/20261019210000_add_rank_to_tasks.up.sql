@@ -0,0 +1,13 @@
+-- Ранг задачи для ручной сортировки внутри списка (пользователь + проект).
+-- Ранги сравниваются побайтово (COLLATE "C") и не заканчиваются на '0'.
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS rank VARCHAR(64) COLLATE "C" NOT NULL DEFAULT '';
+
+-- существующие задачи сохраняют порядок создания
+UPDATE tasks t
+SET rank = lpad(r.n::text, 10, '0') || '1'
+FROM (SELECT id, row_number() OVER (PARTITION BY user_id, project_id ORDER BY id) AS n FROM tasks) r
+WHERE t.id = r.id;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_list_rank ON tasks (user_id, project_id, rank)
+    WHERE deleted_at IS NULL;
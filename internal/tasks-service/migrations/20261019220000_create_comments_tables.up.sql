@@ -0,0 +1,27 @@
+-- Комментарии к задачам; удаляются мягко, как и задачи
+CREATE TABLE IF NOT EXISTS comments
+(
+    id         SERIAL PRIMARY KEY,
+    task_id    INTEGER   NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    author_id  INTEGER   NOT NULL CHECK (author_id > 0),
+    body       TEXT      NOT NULL,
+    edited_at  TIMESTAMPTZ NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMP NULL
+);
+
+-- постраничный список комментариев задачи
+CREATE INDEX IF NOT EXISTS idx_comments_task_id_id ON comments (task_id, id)
+    WHERE deleted_at IS NULL;
+
+-- История правок: каждая строка хранит текст комментария до правки
+CREATE TABLE IF NOT EXISTS comment_edits
+(
+    id         SERIAL PRIMARY KEY,
+    comment_id INTEGER     NOT NULL REFERENCES comments (id) ON DELETE CASCADE,
+    body       TEXT        NOT NULL,
+    edited_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_edits_comment_id ON comment_edits (comment_id, id);
@@ -0,0 +1,14 @@
+-- Метаданные вложений задач; содержимое лежит в хранилище файлов под storage_key
+CREATE TABLE IF NOT EXISTS attachments
+(
+    id           SERIAL PRIMARY KEY,
+    task_id      INTEGER      NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size         BIGINT       NOT NULL CHECK (size >= 0),
+    sha256       CHAR(64)     NOT NULL,
+    storage_key  VARCHAR(512) NOT NULL UNIQUE,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_task_id ON attachments (task_id);
@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS tasks
+    DROP CONSTRAINT IF EXISTS tasks_series_occurrence_unique,
+    DROP COLUMN IF EXISTS occurrence_at,
+    DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS task_series;
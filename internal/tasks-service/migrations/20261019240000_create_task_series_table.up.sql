@@ -0,0 +1,29 @@
+-- Серии повторяющихся задач: правило повторения (RRULE) и шаблон повторений.
+-- generated_until — до какого момента повторения уже созданы генератором
+CREATE TABLE IF NOT EXISTS task_series
+(
+    id              SERIAL PRIMARY KEY,
+    user_id         INTEGER      NOT NULL CHECK (user_id > 0),
+    rrule           VARCHAR(255) NOT NULL,
+    dtstart         TIMESTAMPTZ  NOT NULL,
+    task            VARCHAR(255) NOT NULL,
+    priority        SMALLINT     NOT NULL DEFAULT 2,
+    project_id      INTEGER      NULL REFERENCES projects (id) ON DELETE SET NULL,
+    active          BOOLEAN      NOT NULL DEFAULT true,
+    generated_until TIMESTAMPTZ  NOT NULL,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_series_generated_until ON task_series (generated_until)
+    WHERE active;
+
+-- повторение серии создается не больше одного раза, в том числе после удаления
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS series_id     INTEGER     NULL REFERENCES task_series (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS occurrence_at TIMESTAMPTZ NULL;
+
+-- ADD CONSTRAINT не поддерживает IF NOT EXISTS: повторный запуск пересоздает ограничение
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS tasks_series_occurrence_unique,
+    ADD CONSTRAINT tasks_series_occurrence_unique UNIQUE (series_id, occurrence_at);
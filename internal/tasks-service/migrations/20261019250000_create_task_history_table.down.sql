@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_task_events_task_id_revision;
+
+DROP TRIGGER IF EXISTS task_history_no_update ON task_history;
+DROP FUNCTION IF EXISTS task_history_immutable();
+
+DROP TABLE IF EXISTS task_history;
@@ -0,0 +1,30 @@
+-- История изменений задач: кто, когда и какие поля изменил. task хранит состояние
+-- задачи после изменения, из него считается разница со следующей записью
+CREATE TABLE IF NOT EXISTS task_history
+(
+    id         BIGSERIAL PRIMARY KEY,
+    task_id    INTEGER     NOT NULL,
+    user_id    INTEGER     NOT NULL,
+    type       VARCHAR(16) NOT NULL,
+    actor_id   INTEGER     NOT NULL DEFAULT 0,
+    changes    JSONB       NOT NULL DEFAULT '[]',
+    task       JSONB       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_history_task_id_id ON task_history (task_id, id);
+
+-- записи истории не меняются
+CREATE OR REPLACE FUNCTION task_history_immutable() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'task_history rows are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_history_no_update
+    BEFORE UPDATE ON task_history
+    FOR EACH ROW EXECUTE FUNCTION task_history_immutable();
+
+-- прежнее состояние задач без истории берется из журнала событий
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id_revision ON task_events (task_id, revision);
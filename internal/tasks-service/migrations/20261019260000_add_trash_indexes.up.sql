@@ -0,0 +1,4 @@
+-- корзина: удаленные задачи и комментарии ищутся по времени удаления
+CREATE INDEX IF NOT EXISTS idx_tasks_trash ON tasks (user_id, id) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_deleted_with_user;
+
+ALTER TABLE task_series DROP COLUMN IF EXISTS paused_with_user;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_with_user;
@@ -0,0 +1,7 @@
+-- Задачи, удаленные вместе с пользователем, и серии, остановленные тогда же:
+-- при восстановлении пользователя возвращаются только они
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_with_user BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE task_series ADD COLUMN IF NOT EXISTS paused_with_user BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_with_user ON tasks (user_id)
+    WHERE deleted_with_user;
@@ -0,0 +1,5971 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: task/task.proto
+
+package taskpb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNSPECIFIED TaskStatus = 0
+	TaskStatus_TASK_STATUS_TODO        TaskStatus = 1
+	TaskStatus_TASK_STATUS_IN_PROGRESS TaskStatus = 2
+	TaskStatus_TASK_STATUS_BLOCKED     TaskStatus = 3
+	TaskStatus_TASK_STATUS_DONE        TaskStatus = 4
+	TaskStatus_TASK_STATUS_CANCELED    TaskStatus = 5
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNSPECIFIED",
+		1: "TASK_STATUS_TODO",
+		2: "TASK_STATUS_IN_PROGRESS",
+		3: "TASK_STATUS_BLOCKED",
+		4: "TASK_STATUS_DONE",
+		5: "TASK_STATUS_CANCELED",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNSPECIFIED": 0,
+		"TASK_STATUS_TODO":        1,
+		"TASK_STATUS_IN_PROGRESS": 2,
+		"TASK_STATUS_BLOCKED":     3,
+		"TASK_STATUS_DONE":        4,
+		"TASK_STATUS_CANCELED":    5,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[0]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{0}
+}
+
+type TaskPriority int32
+
+const (
+	TaskPriority_TASK_PRIORITY_UNSPECIFIED TaskPriority = 0
+	TaskPriority_TASK_PRIORITY_LOW         TaskPriority = 1
+	TaskPriority_TASK_PRIORITY_MEDIUM      TaskPriority = 2
+	TaskPriority_TASK_PRIORITY_HIGH        TaskPriority = 3
+	TaskPriority_TASK_PRIORITY_URGENT      TaskPriority = 4
+)
+
+// Enum value maps for TaskPriority.
+var (
+	TaskPriority_name = map[int32]string{
+		0: "TASK_PRIORITY_UNSPECIFIED",
+		1: "TASK_PRIORITY_LOW",
+		2: "TASK_PRIORITY_MEDIUM",
+		3: "TASK_PRIORITY_HIGH",
+		4: "TASK_PRIORITY_URGENT",
+	}
+	TaskPriority_value = map[string]int32{
+		"TASK_PRIORITY_UNSPECIFIED": 0,
+		"TASK_PRIORITY_LOW":         1,
+		"TASK_PRIORITY_MEDIUM":      2,
+		"TASK_PRIORITY_HIGH":        3,
+		"TASK_PRIORITY_URGENT":      4,
+	}
+)
+
+func (x TaskPriority) Enum() *TaskPriority {
+	p := new(TaskPriority)
+	*p = x
+	return p
+}
+
+func (x TaskPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskPriority) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[1]
+}
+
+func (x TaskPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskPriority.Descriptor instead.
+func (TaskPriority) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{1}
+}
+
+// EditScope — к чему применить изменение повторяющейся задачи
+type EditScope int32
+
+const (
+	// только к этому повторению
+	EditScope_EDIT_SCOPE_OCCURRENCE EditScope = 0
+	// к серии: этому и следующим открытым повторениям; допустимы только title, priority и rrule
+	EditScope_EDIT_SCOPE_SERIES EditScope = 1
+)
+
+// Enum value maps for EditScope.
+var (
+	EditScope_name = map[int32]string{
+		0: "EDIT_SCOPE_OCCURRENCE",
+		1: "EDIT_SCOPE_SERIES",
+	}
+	EditScope_value = map[string]int32{
+		"EDIT_SCOPE_OCCURRENCE": 0,
+		"EDIT_SCOPE_SERIES":     1,
+	}
+)
+
+func (x EditScope) Enum() *EditScope {
+	p := new(EditScope)
+	*p = x
+	return p
+}
+
+func (x EditScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EditScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[2].Descriptor()
+}
+
+func (EditScope) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[2]
+}
+
+func (x EditScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EditScope.Descriptor instead.
+func (EditScope) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{2}
+}
+
+// ChildrenPolicy — что сделать с подзадачами удаляемой задачи
+type ChildrenPolicy int32
+
+const (
+	// то же, что REJECT
+	ChildrenPolicy_CHILDREN_POLICY_UNSPECIFIED ChildrenPolicy = 0
+	// не удалять задачу, у которой есть подзадачи
+	ChildrenPolicy_CHILDREN_POLICY_REJECT ChildrenPolicy = 1
+	// удалить задачу вместе со всем поддеревом
+	ChildrenPolicy_CHILDREN_POLICY_CASCADE ChildrenPolicy = 2
+	// переподчинить подзадачи родителю удаляемой задачи
+	ChildrenPolicy_CHILDREN_POLICY_PROMOTE ChildrenPolicy = 3
+)
+
+// Enum value maps for ChildrenPolicy.
+var (
+	ChildrenPolicy_name = map[int32]string{
+		0: "CHILDREN_POLICY_UNSPECIFIED",
+		1: "CHILDREN_POLICY_REJECT",
+		2: "CHILDREN_POLICY_CASCADE",
+		3: "CHILDREN_POLICY_PROMOTE",
+	}
+	ChildrenPolicy_value = map[string]int32{
+		"CHILDREN_POLICY_UNSPECIFIED": 0,
+		"CHILDREN_POLICY_REJECT":      1,
+		"CHILDREN_POLICY_CASCADE":     2,
+		"CHILDREN_POLICY_PROMOTE":     3,
+	}
+)
+
+func (x ChildrenPolicy) Enum() *ChildrenPolicy {
+	p := new(ChildrenPolicy)
+	*p = x
+	return p
+}
+
+func (x ChildrenPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChildrenPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[3].Descriptor()
+}
+
+func (ChildrenPolicy) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[3]
+}
+
+func (x ChildrenPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChildrenPolicy.Descriptor instead.
+func (ChildrenPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{3}
+}
+
+type DueFilter int32
+
+const (
+	DueFilter_DUE_FILTER_UNSPECIFIED DueFilter = 0
+	// срок прошел, задача не выполнена
+	DueFilter_DUE_FILTER_OVERDUE DueFilter = 1
+	// срок наступит в пределах due_within, задача не выполнена
+	DueFilter_DUE_FILTER_DUE_SOON DueFilter = 2
+)
+
+// Enum value maps for DueFilter.
+var (
+	DueFilter_name = map[int32]string{
+		0: "DUE_FILTER_UNSPECIFIED",
+		1: "DUE_FILTER_OVERDUE",
+		2: "DUE_FILTER_DUE_SOON",
+	}
+	DueFilter_value = map[string]int32{
+		"DUE_FILTER_UNSPECIFIED": 0,
+		"DUE_FILTER_OVERDUE":     1,
+		"DUE_FILTER_DUE_SOON":    2,
+	}
+)
+
+func (x DueFilter) Enum() *DueFilter {
+	p := new(DueFilter)
+	*p = x
+	return p
+}
+
+func (x DueFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DueFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[4].Descriptor()
+}
+
+func (DueFilter) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[4]
+}
+
+func (x DueFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DueFilter.Descriptor instead.
+func (DueFilter) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{4}
+}
+
+type TagMatch int32
+
+const (
+	// то же, что ANY
+	TagMatch_TAG_MATCH_UNSPECIFIED TagMatch = 0
+	// задача помечена хотя бы одним из тегов
+	TagMatch_TAG_MATCH_ANY TagMatch = 1
+	// задача помечена всеми тегами
+	TagMatch_TAG_MATCH_ALL TagMatch = 2
+)
+
+// Enum value maps for TagMatch.
+var (
+	TagMatch_name = map[int32]string{
+		0: "TAG_MATCH_UNSPECIFIED",
+		1: "TAG_MATCH_ANY",
+		2: "TAG_MATCH_ALL",
+	}
+	TagMatch_value = map[string]int32{
+		"TAG_MATCH_UNSPECIFIED": 0,
+		"TAG_MATCH_ANY":         1,
+		"TAG_MATCH_ALL":         2,
+	}
+)
+
+func (x TagMatch) Enum() *TagMatch {
+	p := new(TagMatch)
+	*p = x
+	return p
+}
+
+func (x TagMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TagMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[5].Descriptor()
+}
+
+func (TagMatch) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[5]
+}
+
+func (x TagMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TagMatch.Descriptor instead.
+func (TagMatch) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{5}
+}
+
+type TaskEventType int32
+
+const (
+	TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED TaskEventType = 0
+	TaskEventType_TASK_EVENT_TYPE_CREATED     TaskEventType = 1
+	TaskEventType_TASK_EVENT_TYPE_UPDATED     TaskEventType = 2
+	TaskEventType_TASK_EVENT_TYPE_DELETED     TaskEventType = 3
+	// наступило время remind_at задачи
+	TaskEventType_TASK_EVENT_TYPE_REMINDER TaskEventType = 4
+	// задача восстановлена из корзины
+	TaskEventType_TASK_EVENT_TYPE_RESTORED TaskEventType = 5
+)
+
+// Enum value maps for TaskEventType.
+var (
+	TaskEventType_name = map[int32]string{
+		0: "TASK_EVENT_TYPE_UNSPECIFIED",
+		1: "TASK_EVENT_TYPE_CREATED",
+		2: "TASK_EVENT_TYPE_UPDATED",
+		3: "TASK_EVENT_TYPE_DELETED",
+		4: "TASK_EVENT_TYPE_REMINDER",
+		5: "TASK_EVENT_TYPE_RESTORED",
+	}
+	TaskEventType_value = map[string]int32{
+		"TASK_EVENT_TYPE_UNSPECIFIED": 0,
+		"TASK_EVENT_TYPE_CREATED":     1,
+		"TASK_EVENT_TYPE_UPDATED":     2,
+		"TASK_EVENT_TYPE_DELETED":     3,
+		"TASK_EVENT_TYPE_REMINDER":    4,
+		"TASK_EVENT_TYPE_RESTORED":    5,
+	}
+)
+
+func (x TaskEventType) Enum() *TaskEventType {
+	p := new(TaskEventType)
+	*p = x
+	return p
+}
+
+func (x TaskEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[6].Descriptor()
+}
+
+func (TaskEventType) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[6]
+}
+
+func (x TaskEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskEventType.Descriptor instead.
+func (TaskEventType) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{6}
+}
+
+// BatchMode — как выполняется пакет операций
+type BatchMode int32
+
+const (
+	// все элементы в одной транзакции: ошибка любого элемента откатывает пакет
+	// и возвращается с его номером
+	BatchMode_BATCH_MODE_ATOMIC BatchMode = 0
+	// элементы выполняются независимо, у каждого свой результат
+	BatchMode_BATCH_MODE_BEST_EFFORT BatchMode = 1
+)
+
+// Enum value maps for BatchMode.
+var (
+	BatchMode_name = map[int32]string{
+		0: "BATCH_MODE_ATOMIC",
+		1: "BATCH_MODE_BEST_EFFORT",
+	}
+	BatchMode_value = map[string]int32{
+		"BATCH_MODE_ATOMIC":      0,
+		"BATCH_MODE_BEST_EFFORT": 1,
+	}
+)
+
+func (x BatchMode) Enum() *BatchMode {
+	p := new(BatchMode)
+	*p = x
+	return p
+}
+
+func (x BatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[7].Descriptor()
+}
+
+func (BatchMode) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[7]
+}
+
+func (x BatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchMode.Descriptor instead.
+func (BatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{7}
+}
+
+// FileFormat — формат файла задач для импорта и экспорта
+type FileFormat int32
+
+const (
+	// заголовок с именами колонок: id, title, status, priority, due_at, remind_at,
+	// completed_at, project, tags (через ";"); даты в RFC 3339
+	FileFormat_FILE_FORMAT_CSV FileFormat = 0
+	// по JSON-объекту задачи с теми же полями в строке, tags — массив
+	FileFormat_FILE_FORMAT_JSONL FileFormat = 1
+	// то же, что JSONL
+	FileFormat_FILE_FORMAT_NDJSON FileFormat = 2
+)
+
+// Enum value maps for FileFormat.
+var (
+	FileFormat_name = map[int32]string{
+		0: "FILE_FORMAT_CSV",
+		1: "FILE_FORMAT_JSONL",
+		2: "FILE_FORMAT_NDJSON",
+	}
+	FileFormat_value = map[string]int32{
+		"FILE_FORMAT_CSV":    0,
+		"FILE_FORMAT_JSONL":  1,
+		"FILE_FORMAT_NDJSON": 2,
+	}
+)
+
+func (x FileFormat) Enum() *FileFormat {
+	p := new(FileFormat)
+	*p = x
+	return p
+}
+
+func (x FileFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[8].Descriptor()
+}
+
+func (FileFormat) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[8]
+}
+
+func (x FileFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileFormat.Descriptor instead.
+func (FileFormat) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{8}
+}
+
+type ImportRowStatus int32
+
+const (
+	ImportRowStatus_IMPORT_ROW_STATUS_IMPORTED ImportRowStatus = 0
+	// у пользователя уже есть задача с тем же названием и сроком
+	ImportRowStatus_IMPORT_ROW_STATUS_DUPLICATE ImportRowStatus = 1
+	ImportRowStatus_IMPORT_ROW_STATUS_INVALID   ImportRowStatus = 2
+)
+
+// Enum value maps for ImportRowStatus.
+var (
+	ImportRowStatus_name = map[int32]string{
+		0: "IMPORT_ROW_STATUS_IMPORTED",
+		1: "IMPORT_ROW_STATUS_DUPLICATE",
+		2: "IMPORT_ROW_STATUS_INVALID",
+	}
+	ImportRowStatus_value = map[string]int32{
+		"IMPORT_ROW_STATUS_IMPORTED":  0,
+		"IMPORT_ROW_STATUS_DUPLICATE": 1,
+		"IMPORT_ROW_STATUS_INVALID":   2,
+	}
+)
+
+func (x ImportRowStatus) Enum() *ImportRowStatus {
+	p := new(ImportRowStatus)
+	*p = x
+	return p
+}
+
+func (x ImportRowStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportRowStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_task_proto_enumTypes[9].Descriptor()
+}
+
+func (ImportRowStatus) Type() protoreflect.EnumType {
+	return &file_task_task_proto_enumTypes[9]
+}
+
+func (x ImportRowStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportRowStatus.Descriptor instead.
+func (ImportRowStatus) EnumDescriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{9}
+}
+
+type Task struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title  string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	IsDone bool                   `protobuf:"varint,3,opt,name=is_done,json=isDone,proto3" json:"is_done,omitempty"`
+	UserId uint32                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// версия задачи, передается в TaskUpdateRequest.etag
+	Etag        string                 `protobuf:"bytes,5,opt,name=etag,proto3" json:"etag,omitempty"`
+	DueAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	RemindAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	Status      TaskStatus             `protobuf:"varint,8,opt,name=status,proto3,enum=task.TaskStatus" json:"status,omitempty"`
+	Priority    TaskPriority           `protobuf:"varint,9,opt,name=priority,proto3,enum=task.TaskPriority" json:"priority,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	TagIds      []uint32               `protobuf:"varint,11,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	// 0 — задача верхнего уровня
+	ParentId uint32 `protobuf:"varint,12,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// задачи, которые должны быть выполнены или отменены раньше этой
+	BlockerIds []uint32 `protobuf:"varint,13,rep,packed,name=blocker_ids,json=blockerIds,proto3" json:"blocker_ids,omitempty"`
+	// 0 — задача вне проектов
+	ProjectId uint32 `protobuf:"varint,14,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// серия повторений, 0 — обычная задача
+	SeriesId uint32 `protobuf:"varint,15,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	// плановое время повторения, у обычной задачи не задано
+	OccurrenceAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=occurrence_at,json=occurrenceAt,proto3" json:"occurrence_at,omitempty"`
+	// правило повторения серии (RRULE), пусто у обычной задачи
+	Rrule         string `protobuf:"bytes,17,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_task_task_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetIsDone() bool {
+	if x != nil {
+		return x.IsDone
+	}
+	return false
+}
+
+func (x *Task) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Task) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *Task) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *Task) GetRemindAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemindAt
+	}
+	return nil
+}
+
+func (x *Task) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *Task) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Task) GetTagIds() []uint32 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *Task) GetParentId() uint32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Task) GetBlockerIds() []uint32 {
+	if x != nil {
+		return x.BlockerIds
+	}
+	return nil
+}
+
+func (x *Task) GetProjectId() uint32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Task) GetSeriesId() uint32 {
+	if x != nil {
+		return x.SeriesId
+	}
+	return 0
+}
+
+func (x *Task) GetOccurrenceAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurrenceAt
+	}
+	return nil
+}
+
+func (x *Task) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+type TaskCreateRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Title    string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	IsDone   bool                   `protobuf:"varint,2,opt,name=is_done,json=isDone,proto3" json:"is_done,omitempty"`
+	UserId   uint32                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DueAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	RemindAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	// по умолчанию TODO (или DONE при is_done = true)
+	Status TaskStatus `protobuf:"varint,6,opt,name=status,proto3,enum=task.TaskStatus" json:"status,omitempty"`
+	// по умолчанию MEDIUM
+	Priority TaskPriority `protobuf:"varint,7,opt,name=priority,proto3,enum=task.TaskPriority" json:"priority,omitempty"`
+	// 0 — задача верхнего уровня
+	ParentId uint32 `protobuf:"varint,8,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// 0 — задача вне проектов
+	ProjectId uint32 `protobuf:"varint,9,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// правило повторения (подмножество RRULE из RFC 5545), например "FREQ=WEEKLY;BYDAY=MO,WE";
+	// первое повторение — due_at или текущее время
+	Rrule         string `protobuf:"bytes,10,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskCreateRequest) Reset() {
+	*x = TaskCreateRequest{}
+	mi := &file_task_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskCreateRequest) ProtoMessage() {}
+
+func (x *TaskCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskCreateRequest.ProtoReflect.Descriptor instead.
+func (*TaskCreateRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TaskCreateRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskCreateRequest) GetIsDone() bool {
+	if x != nil {
+		return x.IsDone
+	}
+	return false
+}
+
+func (x *TaskCreateRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TaskCreateRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *TaskCreateRequest) GetRemindAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemindAt
+	}
+	return nil
+}
+
+func (x *TaskCreateRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *TaskCreateRequest) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *TaskCreateRequest) GetParentId() uint32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *TaskCreateRequest) GetProjectId() uint32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *TaskCreateRequest) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+type TaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskResponse) Reset() {
+	*x = TaskResponse{}
+	mi := &file_task_task_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskResponse) ProtoMessage() {}
+
+func (x *TaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskResponse.ProtoReflect.Descriptor instead.
+func (*TaskResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type TaskListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskListResponse) Reset() {
+	*x = TaskListResponse{}
+	mi := &file_task_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskListResponse) ProtoMessage() {}
+
+func (x *TaskListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskListResponse.ProtoReflect.Descriptor instead.
+func (*TaskListResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TaskListResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type TaskUpdateRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title  string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	IsDone bool                   `protobuf:"varint,3,opt,name=is_done,json=isDone,proto3" json:"is_done,omitempty"`
+	// пути: "title", "is_done", "due_at", "remind_at", "status", "priority", "parent_id", "rrule";
+	// пустая маска — обновить title и is_done, остальные поля — только если заданы
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// etag из последнего чтения задачи, обязателен
+	Etag string `protobuf:"bytes,5,opt,name=etag,proto3" json:"etag,omitempty"`
+	// не заданное значение при пути в маске очищает поле
+	DueAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	RemindAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=remind_at,json=remindAt,proto3" json:"remind_at,omitempty"`
+	Status   TaskStatus             `protobuf:"varint,8,opt,name=status,proto3,enum=task.TaskStatus" json:"status,omitempty"`
+	Priority TaskPriority           `protobuf:"varint,9,opt,name=priority,proto3,enum=task.TaskPriority" json:"priority,omitempty"`
+	// 0 при пути "parent_id" в маске делает задачу верхнего уровня
+	ParentId uint32 `protobuf:"varint,10,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// новое правило повторения, только с EDIT_SCOPE_SERIES; пустое завершает серию
+	Rrule         string    `protobuf:"bytes,11,opt,name=rrule,proto3" json:"rrule,omitempty"`
+	Scope         EditScope `protobuf:"varint,12,opt,name=scope,proto3,enum=task.EditScope" json:"scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskUpdateRequest) Reset() {
+	*x = TaskUpdateRequest{}
+	mi := &file_task_task_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskUpdateRequest) ProtoMessage() {}
+
+func (x *TaskUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskUpdateRequest.ProtoReflect.Descriptor instead.
+func (*TaskUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TaskUpdateRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskUpdateRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskUpdateRequest) GetIsDone() bool {
+	if x != nil {
+		return x.IsDone
+	}
+	return false
+}
+
+func (x *TaskUpdateRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *TaskUpdateRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *TaskUpdateRequest) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *TaskUpdateRequest) GetRemindAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemindAt
+	}
+	return nil
+}
+
+func (x *TaskUpdateRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *TaskUpdateRequest) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *TaskUpdateRequest) GetParentId() uint32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *TaskUpdateRequest) GetRrule() string {
+	if x != nil {
+		return x.Rrule
+	}
+	return ""
+}
+
+func (x *TaskUpdateRequest) GetScope() EditScope {
+	if x != nil {
+		return x.Scope
+	}
+	return EditScope_EDIT_SCOPE_OCCURRENCE
+}
+
+type TaskDeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Children      ChildrenPolicy         `protobuf:"varint,2,opt,name=children,proto3,enum=task.ChildrenPolicy" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskDeleteRequest) Reset() {
+	*x = TaskDeleteRequest{}
+	mi := &file_task_task_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDeleteRequest) ProtoMessage() {}
+
+func (x *TaskDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDeleteRequest.ProtoReflect.Descriptor instead.
+func (*TaskDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TaskDeleteRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskDeleteRequest) GetChildren() ChildrenPolicy {
+	if x != nil {
+		return x.Children
+	}
+	return ChildrenPolicy_CHILDREN_POLICY_UNSPECIFIED
+}
+
+type GetSubtreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubtreeRequest) Reset() {
+	*x = GetSubtreeRequest{}
+	mi := &file_task_task_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubtreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubtreeRequest) ProtoMessage() {}
+
+func (x *GetSubtreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubtreeRequest.ProtoReflect.Descriptor instead.
+func (*GetSubtreeRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetSubtreeRequest) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type TaskNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// глубина относительно корня поддерева, у корня 0
+	Depth uint32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// прямые подзадачи, отмененные не учитываются
+	ChildrenTotal uint32 `protobuf:"varint,3,opt,name=children_total,json=childrenTotal,proto3" json:"children_total,omitempty"`
+	ChildrenDone  uint32 `protobuf:"varint,4,opt,name=children_done,json=childrenDone,proto3" json:"children_done,omitempty"`
+	// процент выполненных прямых подзадач, 0 если подзадач нет
+	Progress      uint32 `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskNode) Reset() {
+	*x = TaskNode{}
+	mi := &file_task_task_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskNode) ProtoMessage() {}
+
+func (x *TaskNode) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskNode.ProtoReflect.Descriptor instead.
+func (*TaskNode) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TaskNode) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskNode) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *TaskNode) GetChildrenTotal() uint32 {
+	if x != nil {
+		return x.ChildrenTotal
+	}
+	return 0
+}
+
+func (x *TaskNode) GetChildrenDone() uint32 {
+	if x != nil {
+		return x.ChildrenDone
+	}
+	return 0
+}
+
+func (x *TaskNode) GetProgress() uint32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+type SubtreeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// узлы в порядке обхода в ширину, корень первый
+	Nodes         []*TaskNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubtreeResponse) Reset() {
+	*x = SubtreeResponse{}
+	mi := &file_task_task_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubtreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubtreeResponse) ProtoMessage() {}
+
+func (x *SubtreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubtreeResponse.ProtoReflect.Descriptor instead.
+func (*SubtreeResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SubtreeResponse) GetNodes() []*TaskNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type ListTasksByUserRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Due    DueFilter              `protobuf:"varint,2,opt,name=due,proto3,enum=task.DueFilter" json:"due,omitempty"`
+	// окно для DUE_FILTER_DUE_SOON, по умолчанию 24 часа
+	DueWithin     *durationpb.Duration `protobuf:"bytes,3,opt,name=due_within,json=dueWithin,proto3" json:"due_within,omitempty"`
+	TagIds        []uint32             `protobuf:"varint,4,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	TagMatch      TagMatch             `protobuf:"varint,5,opt,name=tag_match,json=tagMatch,proto3,enum=task.TagMatch" json:"tag_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksByUserRequest) Reset() {
+	*x = ListTasksByUserRequest{}
+	mi := &file_task_task_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksByUserRequest) ProtoMessage() {}
+
+func (x *ListTasksByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksByUserRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksByUserRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTasksByUserRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListTasksByUserRequest) GetDue() DueFilter {
+	if x != nil {
+		return x.Due
+	}
+	return DueFilter_DUE_FILTER_UNSPECIFIED
+}
+
+func (x *ListTasksByUserRequest) GetDueWithin() *durationpb.Duration {
+	if x != nil {
+		return x.DueWithin
+	}
+	return nil
+}
+
+func (x *ListTasksByUserRequest) GetTagIds() []uint32 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *ListTasksByUserRequest) GetTagMatch() TagMatch {
+	if x != nil {
+		return x.TagMatch
+	}
+	return TagMatch_TAG_MATCH_UNSPECIFIED
+}
+
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_task_task_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Tag) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tag) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTagRequest) Reset() {
+	*x = CreateTagRequest{}
+	mi := &file_task_task_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTagRequest) ProtoMessage() {}
+
+func (x *CreateTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTagRequest.ProtoReflect.Descriptor instead.
+func (*CreateTagRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateTagRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RenameTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagRequest) Reset() {
+	*x = RenameTagRequest{}
+	mi := &file_task_task_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagRequest) ProtoMessage() {}
+
+func (x *RenameTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagRequest.ProtoReflect.Descriptor instead.
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RenameTagRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RenameTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTagRequest) Reset() {
+	*x = DeleteTagRequest{}
+	mi := &file_task_task_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTagRequest) ProtoMessage() {}
+
+func (x *DeleteTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteTagRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_task_task_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTagsRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_task_task_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type TaskTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	TagId         uint32                 `protobuf:"varint,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskTagRequest) Reset() {
+	*x = TaskTagRequest{}
+	mi := &file_task_task_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskTagRequest) ProtoMessage() {}
+
+func (x *TaskTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskTagRequest.ProtoReflect.Descriptor instead.
+func (*TaskTagRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TaskTagRequest) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskTagRequest) GetTagId() uint32 {
+	if x != nil {
+		return x.TagId
+	}
+	return 0
+}
+
+type DependencyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// задача, которая блокирует task_id
+	BlockerId     uint32 `protobuf:"varint,2,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyRequest) Reset() {
+	*x = DependencyRequest{}
+	mi := &file_task_task_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyRequest) ProtoMessage() {}
+
+func (x *DependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyRequest.ProtoReflect.Descriptor instead.
+func (*DependencyRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DependencyRequest) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *DependencyRequest) GetBlockerId() uint32 {
+	if x != nil {
+		return x.BlockerId
+	}
+	return 0
+}
+
+type GetPlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlanRequest) Reset() {
+	*x = GetPlanRequest{}
+	mi := &file_task_task_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlanRequest) ProtoMessage() {}
+
+func (x *GetPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlanRequest.ProtoReflect.Descriptor instead.
+func (*GetPlanRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetPlanRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type Project struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId   uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Archived bool                   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	// место в списке проектов пользователя, от 0
+	Position      uint32 `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_task_task_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Project) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Project) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Project) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type CreateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_task_task_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateProjectRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateProjectRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Archived bool                   `protobuf:"varint,3,opt,name=archived,proto3" json:"archived,omitempty"`
+	// пути: "name", "archived"; пустая маска — обновить оба поля
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_task_task_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateProjectRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *UpdateProjectRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_task_task_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteProjectRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListProjectsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,2,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_task_task_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListProjectsRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListProjectsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_task_task_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type ReorderProjectsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// все проекты пользователя в новом порядке
+	ProjectIds    []uint32 `protobuf:"varint,2,rep,packed,name=project_ids,json=projectIds,proto3" json:"project_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderProjectsRequest) Reset() {
+	*x = ReorderProjectsRequest{}
+	mi := &file_task_task_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderProjectsRequest) ProtoMessage() {}
+
+func (x *ReorderProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ReorderProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReorderProjectsRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReorderProjectsRequest) GetProjectIds() []uint32 {
+	if x != nil {
+		return x.ProjectIds
+	}
+	return nil
+}
+
+type ListTasksByProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     uint32                 `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksByProjectRequest) Reset() {
+	*x = ListTasksByProjectRequest{}
+	mi := &file_task_task_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksByProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksByProjectRequest) ProtoMessage() {}
+
+func (x *ListTasksByProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksByProjectRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksByProjectRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTasksByProjectRequest) GetProjectId() uint32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+type MoveTaskToProjectRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// 0 — убрать задачу из проекта
+	ProjectId     uint32 `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTaskToProjectRequest) Reset() {
+	*x = MoveTaskToProjectRequest{}
+	mi := &file_task_task_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTaskToProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTaskToProjectRequest) ProtoMessage() {}
+
+func (x *MoveTaskToProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTaskToProjectRequest.ProtoReflect.Descriptor instead.
+func (*MoveTaskToProjectRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MoveTaskToProjectRequest) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *MoveTaskToProjectRequest) GetProjectId() uint32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+// MoveTaskRequest ставит задачу между двумя соседями в её списке
+// (задачи пользователя в том же проекте). Нужен хотя бы один сосед.
+type MoveTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// задача, после которой встанет id; 0 — id встанет сразу перед after_id
+	BeforeId uint32 `protobuf:"varint,2,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"`
+	// задача, перед которой встанет id; 0 — id встанет сразу после before_id
+	AfterId       uint32 `protobuf:"varint,3,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTaskRequest) Reset() {
+	*x = MoveTaskRequest{}
+	mi := &file_task_task_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTaskRequest) ProtoMessage() {}
+
+func (x *MoveTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTaskRequest.ProtoReflect.Descriptor instead.
+func (*MoveTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MoveTaskRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetBeforeId() uint32 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetAfterId() uint32 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+type Comment struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId    uint32                 `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	AuthorId  uint32                 `protobuf:"varint,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Body      string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// не задано, если комментарий не редактировался
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_task_task_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Comment) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Comment) GetAuthorId() uint32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *Comment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+type CreateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	AuthorId      uint32                 `protobuf:"varint,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentRequest) Reset() {
+	*x = CreateCommentRequest{}
+	mi := &file_task_task_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentRequest) ProtoMessage() {}
+
+func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateCommentRequest) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *CreateCommentRequest) GetAuthorId() uint32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *CreateCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type EditCommentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// редактировать может только автор
+	AuthorId      uint32 `protobuf:"varint,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Body          string `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditCommentRequest) Reset() {
+	*x = EditCommentRequest{}
+	mi := &file_task_task_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditCommentRequest) ProtoMessage() {}
+
+func (x *EditCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditCommentRequest.ProtoReflect.Descriptor instead.
+func (*EditCommentRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *EditCommentRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EditCommentRequest) GetAuthorId() uint32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *EditCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type DeleteCommentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// удалить может только автор
+	AuthorId      uint32 `protobuf:"varint,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_task_task_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteCommentRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteCommentRequest) GetAuthorId() uint32 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+type ListCommentsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// по умолчанию 50, не больше 200
+	PageSize uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token предыдущей страницы, пусто — с начала
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_task_task_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListCommentsRequest) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// от старых к новым
+	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	// пусто на последней странице
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_task_task_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetCommentHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentHistoryRequest) Reset() {
+	*x = GetCommentHistoryRequest{}
+	mi := &file_task_task_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentHistoryRequest) ProtoMessage() {}
+
+func (x *GetCommentHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetCommentHistoryRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CommentEdit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// текст комментария до правки
+	Body          string                 `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentEdit) Reset() {
+	*x = CommentEdit{}
+	mi := &file_task_task_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentEdit) ProtoMessage() {}
+
+func (x *CommentEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentEdit.ProtoReflect.Descriptor instead.
+func (*CommentEdit) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CommentEdit) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *CommentEdit) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+type CommentHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// от старых правок к новым
+	Edits         []*CommentEdit `protobuf:"bytes,1,rep,name=edits,proto3" json:"edits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentHistoryResponse) Reset() {
+	*x = CommentHistoryResponse{}
+	mi := &file_task_task_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentHistoryResponse) ProtoMessage() {}
+
+func (x *CommentHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentHistoryResponse.ProtoReflect.Descriptor instead.
+func (*CommentHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CommentHistoryResponse) GetEdits() []*CommentEdit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+type Attachment struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId      uint32                 `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	FileName    string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        uint64                 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// SHA-256 содержимого в hex
+	Sha256        string                 `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_task_task_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Attachment) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Attachment) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Attachment) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AttachmentInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TaskId      uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	FileName    string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// ожидаемый размер, 0 — не проверять
+	Size uint64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// ожидаемый SHA-256 в hex, пусто — не проверять
+	Sha256        string `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentInfo) Reset() {
+	*x = AttachmentInfo{}
+	mi := &file_task_task_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentInfo) ProtoMessage() {}
+
+func (x *AttachmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentInfo.ProtoReflect.Descriptor instead.
+func (*AttachmentInfo) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AttachmentInfo) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+// UploadAttachmentRequest: первое сообщение потока — info, затем chunk
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadAttachmentRequest_Info
+	//	*UploadAttachmentRequest_Chunk
+	Data          isUploadAttachmentRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_task_task_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UploadAttachmentRequest) GetData() isUploadAttachmentRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetInfo() *AttachmentInfo {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Data interface {
+	isUploadAttachmentRequest_Data()
+}
+
+type UploadAttachmentRequest_Info struct {
+	Info *AttachmentInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Info) isUploadAttachmentRequest_Data() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Data() {}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_task_task_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DownloadAttachmentRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// DownloadAttachmentResponse: первое сообщение потока — attachment, затем chunk
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadAttachmentResponse_Attachment
+	//	*DownloadAttachmentResponse_Chunk
+	Data          isDownloadAttachmentResponse_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_task_task_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DownloadAttachmentResponse) GetData() isDownloadAttachmentResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Data interface {
+	isDownloadAttachmentResponse_Data()
+}
+
+type DownloadAttachmentResponse_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Attachment) isDownloadAttachmentResponse_Data() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Data() {}
+
+type ListAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_task_task_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListAttachmentsRequest) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_task_task_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_task_task_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteAttachmentRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UserDeletedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId        uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDeletedEvent) Reset() {
+	*x = UserDeletedEvent{}
+	mi := &file_task_task_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDeletedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeletedEvent) ProtoMessage() {}
+
+func (x *UserDeletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeletedEvent.ProtoReflect.Descriptor instead.
+func (*UserDeletedEvent) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UserDeletedEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *UserDeletedEvent) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UserDeletedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedTasks  uint32                 `protobuf:"varint,1,opt,name=deleted_tasks,json=deletedTasks,proto3" json:"deleted_tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDeletedResponse) Reset() {
+	*x = UserDeletedResponse{}
+	mi := &file_task_task_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDeletedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeletedResponse) ProtoMessage() {}
+
+func (x *UserDeletedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeletedResponse.ProtoReflect.Descriptor instead.
+func (*UserDeletedResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UserDeletedResponse) GetDeletedTasks() uint32 {
+	if x != nil {
+		return x.DeletedTasks
+	}
+	return 0
+}
+
+// пользователь восстановлен из корзины users-service
+type UserRestoredEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId        uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserRestoredEvent) Reset() {
+	*x = UserRestoredEvent{}
+	mi := &file_task_task_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserRestoredEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRestoredEvent) ProtoMessage() {}
+
+func (x *UserRestoredEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRestoredEvent.ProtoReflect.Descriptor instead.
+func (*UserRestoredEvent) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *UserRestoredEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *UserRestoredEvent) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UserRestoredResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// задачи, удаленные вместе с пользователем и возвращенные из корзины
+	RestoredTasks uint32 `protobuf:"varint,1,opt,name=restored_tasks,json=restoredTasks,proto3" json:"restored_tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserRestoredResponse) Reset() {
+	*x = UserRestoredResponse{}
+	mi := &file_task_task_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserRestoredResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRestoredResponse) ProtoMessage() {}
+
+func (x *UserRestoredResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRestoredResponse.ProtoReflect.Descriptor instead.
+func (*UserRestoredResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UserRestoredResponse) GetRestoredTasks() uint32 {
+	if x != nil {
+		return x.RestoredTasks
+	}
+	return 0
+}
+
+// FieldChange — изменение поля задачи; значения в текстовом виде, пустая строка — не задано
+type FieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// имя поля как в Task: "title", "status", "due_at", "tag_ids" и т.д.
+	Field         string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Before        string `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         string `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_task_task_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *FieldChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// TaskHistoryEntry — неизменяемая запись истории задачи
+type TaskHistoryEntry struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId uint32                 `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Type   TaskEventType          `protobuf:"varint,3,opt,name=type,proto3,enum=task.TaskEventType" json:"type,omitempty"`
+	// пользователь из заголовка x-actor-id, 0 — система
+	ActorId       uint32                 `protobuf:"varint,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskHistoryEntry) Reset() {
+	*x = TaskHistoryEntry{}
+	mi := &file_task_task_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskHistoryEntry) ProtoMessage() {}
+
+func (x *TaskHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskHistoryEntry.ProtoReflect.Descriptor instead.
+func (*TaskHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TaskHistoryEntry) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskHistoryEntry) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskHistoryEntry) GetType() TaskEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *TaskHistoryEntry) GetActorId() uint32 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *TaskHistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TaskHistoryEntry) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetTaskHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId uint32                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// по умолчанию 50, не больше 200
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryRequest) Reset() {
+	*x = GetTaskHistoryRequest{}
+	mi := &file_task_task_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryRequest) ProtoMessage() {}
+
+func (x *GetTaskHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetTaskHistoryRequest) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetTaskHistoryResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*TaskHistoryEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// пусто, если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryResponse) Reset() {
+	*x = GetTaskHistoryResponse{}
+	mi := &file_task_task_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryResponse) ProtoMessage() {}
+
+func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetTaskHistoryResponse) GetEntries() []*TaskHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetTaskHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// DeletedTask — задача в корзине
+type DeletedTask struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletedTask) Reset() {
+	*x = DeletedTask{}
+	mi := &file_task_task_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletedTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedTask) ProtoMessage() {}
+
+func (x *DeletedTask) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedTask.ProtoReflect.Descriptor instead.
+func (*DeletedTask) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeletedTask) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *DeletedTask) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type ListDeletedTasksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// по умолчанию 50, не больше 200
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedTasksRequest) Reset() {
+	*x = ListDeletedTasksRequest{}
+	mi := &file_task_task_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedTasksRequest) ProtoMessage() {}
+
+func (x *ListDeletedTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListDeletedTasksRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListDeletedTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeletedTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeletedTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*DeletedTask         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// пусто, если страниц больше нет
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedTasksResponse) Reset() {
+	*x = ListDeletedTasksResponse{}
+	mi := &file_task_task_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedTasksResponse) ProtoMessage() {}
+
+func (x *ListDeletedTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedTasksResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListDeletedTasksResponse) GetTasks() []*DeletedTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListDeletedTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RestoreTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// владелец задачи; задача другого пользователя не найдется
+	UserId        uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskRequest) Reset() {
+	*x = RestoreTaskRequest{}
+	mi := &file_task_task_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskRequest) ProtoMessage() {}
+
+func (x *RestoreTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RestoreTaskRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RestoreTaskRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type PurgeTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// владелец задачи; задача другого пользователя не найдется
+	UserId        uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeTaskRequest) Reset() {
+	*x = PurgeTaskRequest{}
+	mi := &file_task_task_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeTaskRequest) ProtoMessage() {}
+
+func (x *PurgeTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeTaskRequest.ProtoReflect.Descriptor instead.
+func (*PurgeTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *PurgeTaskRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PurgeTaskRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// BatchTaskResult — результат элемента пакета, в порядке элементов запроса
+type BatchTaskResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// задача после операции; у удаления и неудачного элемента не задана
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// код gRPC (google.rpc.Code) и сообщение ошибки элемента, 0 — успешно
+	Code          int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTaskResult) Reset() {
+	*x = BatchTaskResult{}
+	mi := &file_task_task_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTaskResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTaskResult) ProtoMessage() {}
+
+func (x *BatchTaskResult) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTaskResult.ProtoReflect.Descriptor instead.
+func (*BatchTaskResult) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *BatchTaskResult) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *BatchTaskResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchTaskResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// пакеты ограничены 100 элементами
+type BatchCreateTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskCreateRequest   `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Mode          BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=task.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTasksRequest) Reset() {
+	*x = BatchCreateTasksRequest{}
+	mi := &file_task_task_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTasksRequest) ProtoMessage() {}
+
+func (x *BatchCreateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *BatchCreateTasksRequest) GetTasks() []*TaskCreateRequest {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *BatchCreateTasksRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_ATOMIC
+}
+
+type BatchUpdateTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskUpdateRequest   `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Mode          BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=task.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateTasksRequest) Reset() {
+	*x = BatchUpdateTasksRequest{}
+	mi := &file_task_task_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateTasksRequest) ProtoMessage() {}
+
+func (x *BatchUpdateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *BatchUpdateTasksRequest) GetTasks() []*TaskUpdateRequest {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *BatchUpdateTasksRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_ATOMIC
+}
+
+type BatchDeleteTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskDeleteRequest   `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Mode          BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=task.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteTasksRequest) Reset() {
+	*x = BatchDeleteTasksRequest{}
+	mi := &file_task_task_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteTasksRequest) ProtoMessage() {}
+
+func (x *BatchDeleteTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *BatchDeleteTasksRequest) GetTasks() []*TaskDeleteRequest {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *BatchDeleteTasksRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_ATOMIC
+}
+
+type BatchTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchTaskResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTasksResponse) Reset() {
+	*x = BatchTasksResponse{}
+	mi := &file_task_task_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTasksResponse) ProtoMessage() {}
+
+func (x *BatchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTasksResponse.ProtoReflect.Descriptor instead.
+func (*BatchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *BatchTasksResponse) GetResults() []*BatchTaskResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ExportTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format        FileFormat             `protobuf:"varint,2,opt,name=format,proto3,enum=task.FileFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTasksRequest) Reset() {
+	*x = ExportTasksRequest{}
+	mi := &file_task_task_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTasksRequest) ProtoMessage() {}
+
+func (x *ExportTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTasksRequest.ProtoReflect.Descriptor instead.
+func (*ExportTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ExportTasksRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ExportTasksRequest) GetFormat() FileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FileFormat_FILE_FORMAT_CSV
+}
+
+// ExportTasksResponse — очередной кусок файла
+type ExportTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTasksResponse) Reset() {
+	*x = ExportTasksResponse{}
+	mi := &file_task_task_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTasksResponse) ProtoMessage() {}
+
+func (x *ExportTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTasksResponse.ProtoReflect.Descriptor instead.
+func (*ExportTasksResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ExportTasksResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type ImportOptions struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format FileFormat             `protobuf:"varint,2,opt,name=format,proto3,enum=task.FileFormat" json:"format,omitempty"`
+	// только проверить файл, задачи не создаются
+	DryRun        bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_task_task_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ImportOptions) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImportOptions) GetFormat() FileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FileFormat_FILE_FORMAT_CSV
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// ImportTasksRequest: первое сообщение потока — options, затем файл кусками.
+// Не больше 10000 строк
+type ImportTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ImportTasksRequest_Options
+	//	*ImportTasksRequest_Chunk
+	Data          isImportTasksRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTasksRequest) Reset() {
+	*x = ImportTasksRequest{}
+	mi := &file_task_task_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTasksRequest) ProtoMessage() {}
+
+func (x *ImportTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTasksRequest.ProtoReflect.Descriptor instead.
+func (*ImportTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ImportTasksRequest) GetData() isImportTasksRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportTasksRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Data.(*ImportTasksRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportTasksRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*ImportTasksRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportTasksRequest_Data interface {
+	isImportTasksRequest_Data()
+}
+
+type ImportTasksRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportTasksRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportTasksRequest_Options) isImportTasksRequest_Data() {}
+
+func (*ImportTasksRequest_Chunk) isImportTasksRequest_Data() {}
+
+type ImportRowResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// номер строки файла, с 1
+	Line   uint32          `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Status ImportRowStatus `protobuf:"varint,2,opt,name=status,proto3,enum=task.ImportRowStatus" json:"status,omitempty"`
+	// созданная задача, 0 при dry_run
+	TaskId        uint32 `protobuf:"varint,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowResult) Reset() {
+	*x = ImportRowResult{}
+	mi := &file_task_task_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowResult) ProtoMessage() {}
+
+func (x *ImportRowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowResult.ProtoReflect.Descriptor instead.
+func (*ImportRowResult) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ImportRowResult) GetLine() uint32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowResult) GetStatus() ImportRowStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ImportRowStatus_IMPORT_ROW_STATUS_IMPORTED
+}
+
+func (x *ImportRowResult) GetTaskId() uint32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ImportRowResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      uint32                 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Duplicates    uint32                 `protobuf:"varint,2,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Invalid       uint32                 `protobuf:"varint,3,opt,name=invalid,proto3" json:"invalid,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Rows          []*ImportRowResult     `protobuf:"bytes,5,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTasksResponse) Reset() {
+	*x = ImportTasksResponse{}
+	mi := &file_task_task_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTasksResponse) ProtoMessage() {}
+
+func (x *ImportTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTasksResponse.ProtoReflect.Descriptor instead.
+func (*ImportTasksResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ImportTasksResponse) GetImported() uint32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetDuplicates() uint32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetInvalid() uint32 {
+	if x != nil {
+		return x.Invalid
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportTasksResponse) GetRows() []*ImportRowResult {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_task_task_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ExportUserDataRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// UserDataItem — одна запись из данных пользователя
+type UserDataItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Item:
+	//
+	//	*UserDataItem_Task
+	//	*UserDataItem_DeletedTask
+	//	*UserDataItem_Project
+	//	*UserDataItem_Tag
+	//	*UserDataItem_Comment
+	//	*UserDataItem_Attachment
+	Item          isUserDataItem_Item `protobuf_oneof:"item"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDataItem) Reset() {
+	*x = UserDataItem{}
+	mi := &file_task_task_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDataItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDataItem) ProtoMessage() {}
+
+func (x *UserDataItem) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDataItem.ProtoReflect.Descriptor instead.
+func (*UserDataItem) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *UserDataItem) GetItem() isUserDataItem_Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *UserDataItem) GetTask() *Task {
+	if x != nil {
+		if x, ok := x.Item.(*UserDataItem_Task); ok {
+			return x.Task
+		}
+	}
+	return nil
+}
+
+func (x *UserDataItem) GetDeletedTask() *DeletedTask {
+	if x != nil {
+		if x, ok := x.Item.(*UserDataItem_DeletedTask); ok {
+			return x.DeletedTask
+		}
+	}
+	return nil
+}
+
+func (x *UserDataItem) GetProject() *Project {
+	if x != nil {
+		if x, ok := x.Item.(*UserDataItem_Project); ok {
+			return x.Project
+		}
+	}
+	return nil
+}
+
+func (x *UserDataItem) GetTag() *Tag {
+	if x != nil {
+		if x, ok := x.Item.(*UserDataItem_Tag); ok {
+			return x.Tag
+		}
+	}
+	return nil
+}
+
+func (x *UserDataItem) GetComment() *Comment {
+	if x != nil {
+		if x, ok := x.Item.(*UserDataItem_Comment); ok {
+			return x.Comment
+		}
+	}
+	return nil
+}
+
+func (x *UserDataItem) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Item.(*UserDataItem_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+type isUserDataItem_Item interface {
+	isUserDataItem_Item()
+}
+
+type UserDataItem_Task struct {
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3,oneof"`
+}
+
+type UserDataItem_DeletedTask struct {
+	DeletedTask *DeletedTask `protobuf:"bytes,2,opt,name=deleted_task,json=deletedTask,proto3,oneof"`
+}
+
+type UserDataItem_Project struct {
+	Project *Project `protobuf:"bytes,3,opt,name=project,proto3,oneof"`
+}
+
+type UserDataItem_Tag struct {
+	Tag *Tag `protobuf:"bytes,4,opt,name=tag,proto3,oneof"`
+}
+
+type UserDataItem_Comment struct {
+	// комментарий пользователя, в том числе к чужой задаче
+	Comment *Comment `protobuf:"bytes,5,opt,name=comment,proto3,oneof"`
+}
+
+type UserDataItem_Attachment struct {
+	// метаданные вложения задачи пользователя, без содержимого
+	Attachment *Attachment `protobuf:"bytes,6,opt,name=attachment,proto3,oneof"`
+}
+
+func (*UserDataItem_Task) isUserDataItem_Item() {}
+
+func (*UserDataItem_DeletedTask) isUserDataItem_Item() {}
+
+func (*UserDataItem_Project) isUserDataItem_Item() {}
+
+func (*UserDataItem_Tag) isUserDataItem_Item() {}
+
+func (*UserDataItem_Comment) isUserDataItem_Item() {}
+
+func (*UserDataItem_Attachment) isUserDataItem_Item() {}
+
+type EraseUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_task_task_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *EraseUserDataRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// EraseUserDataResponse — сколько записей удалено окончательно
+type EraseUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         uint32                 `protobuf:"varint,1,opt,name=tasks,proto3" json:"tasks,omitempty"`
+	Comments      uint32                 `protobuf:"varint,2,opt,name=comments,proto3" json:"comments,omitempty"`
+	Projects      uint32                 `protobuf:"varint,3,opt,name=projects,proto3" json:"projects,omitempty"`
+	Tags          uint32                 `protobuf:"varint,4,opt,name=tags,proto3" json:"tags,omitempty"`
+	Attachments   uint32                 `protobuf:"varint,5,opt,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_task_task_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *EraseUserDataResponse) GetTasks() uint32 {
+	if x != nil {
+		return x.Tasks
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetComments() uint32 {
+	if x != nil {
+		return x.Comments
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetProjects() uint32 {
+	if x != nil {
+		return x.Projects
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetTags() uint32 {
+	if x != nil {
+		return x.Tags
+	}
+	return 0
+}
+
+func (x *EraseUserDataResponse) GetAttachments() uint32 {
+	if x != nil {
+		return x.Attachments
+	}
+	return 0
+}
+
+type WatchTasksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 0 — только новые события, иначе сначала события после этой ревизии
+	FromRevision  uint64 `protobuf:"varint,2,opt,name=from_revision,json=fromRevision,proto3" json:"from_revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_task_task_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *WatchTasksRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WatchTasksRequest) GetFromRevision() uint64 {
+	if x != nil {
+		return x.FromRevision
+	}
+	return 0
+}
+
+type TaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// растет в пределах пользователя; передается в WatchTasksRequest.from_revision
+	Revision      uint64        `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Type          TaskEventType `protobuf:"varint,2,opt,name=type,proto3,enum=task.TaskEventType" json:"type,omitempty"`
+	Task          *Task         `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_task_task_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_task_task_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_task_task_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *TaskEvent) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetType() TaskEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *TaskEvent) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+var File_task_task_proto protoreflect.FileDescriptor
+
+const file_task_task_proto_rawDesc = "" +
+	"\n" +
+	"\x0ftask/task.proto\x12\x04task\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe1\x04\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x17\n" +
+	"\ais_done\x18\x03 \x01(\bR\x06isDone\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\rR\x06userId\x12\x12\n" +
+	"\x04etag\x18\x05 \x01(\tR\x04etag\x121\n" +
+	"\x06due_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x127\n" +
+	"\tremind_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bremindAt\x12(\n" +
+	"\x06status\x18\b \x01(\x0e2\x10.task.TaskStatusR\x06status\x12.\n" +
+	"\bpriority\x18\t \x01(\x0e2\x12.task.TaskPriorityR\bpriority\x12=\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x17\n" +
+	"\atag_ids\x18\v \x03(\rR\x06tagIds\x12\x1b\n" +
+	"\tparent_id\x18\f \x01(\rR\bparentId\x12\x1f\n" +
+	"\vblocker_ids\x18\r \x03(\rR\n" +
+	"blockerIds\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x0e \x01(\rR\tprojectId\x12\x1b\n" +
+	"\tseries_id\x18\x0f \x01(\rR\bseriesId\x12?\n" +
+	"\roccurrence_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\foccurrenceAt\x12\x14\n" +
+	"\x05rrule\x18\x11 \x01(\tR\x05rrule\"\xf3\x02\n" +
+	"\x11TaskCreateRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x17\n" +
+	"\ais_done\x18\x02 \x01(\bR\x06isDone\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\rR\x06userId\x121\n" +
+	"\x06due_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x127\n" +
+	"\tremind_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bremindAt\x12(\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x10.task.TaskStatusR\x06status\x12.\n" +
+	"\bpriority\x18\a \x01(\x0e2\x12.task.TaskPriorityR\bpriority\x12\x1b\n" +
+	"\tparent_id\x18\b \x01(\rR\bparentId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\t \x01(\rR\tprojectId\x12\x14\n" +
+	"\x05rrule\x18\n" +
+	" \x01(\tR\x05rrule\".\n" +
+	"\fTaskResponse\x12\x1e\n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskR\x04task\"4\n" +
+	"\x10TaskListResponse\x12 \n" +
+	"\x05tasks\x18\x01 \x03(\v2\n" +
+	".task.TaskR\x05tasks\"\xc3\x03\n" +
+	"\x11TaskUpdateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x17\n" +
+	"\ais_done\x18\x03 \x01(\bR\x06isDone\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x12\n" +
+	"\x04etag\x18\x05 \x01(\tR\x04etag\x121\n" +
+	"\x06due_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x127\n" +
+	"\tremind_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bremindAt\x12(\n" +
+	"\x06status\x18\b \x01(\x0e2\x10.task.TaskStatusR\x06status\x12.\n" +
+	"\bpriority\x18\t \x01(\x0e2\x12.task.TaskPriorityR\bpriority\x12\x1b\n" +
+	"\tparent_id\x18\n" +
+	" \x01(\rR\bparentId\x12\x14\n" +
+	"\x05rrule\x18\v \x01(\tR\x05rrule\x12%\n" +
+	"\x05scope\x18\f \x01(\x0e2\x0f.task.EditScopeR\x05scope\"U\n" +
+	"\x11TaskDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x120\n" +
+	"\bchildren\x18\x02 \x01(\x0e2\x14.task.ChildrenPolicyR\bchildren\",\n" +
+	"\x11GetSubtreeRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\"\xa8\x01\n" +
+	"\bTaskNode\x12\x1e\n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskR\x04task\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\rR\x05depth\x12%\n" +
+	"\x0echildren_total\x18\x03 \x01(\rR\rchildrenTotal\x12#\n" +
+	"\rchildren_done\x18\x04 \x01(\rR\fchildrenDone\x12\x1a\n" +
+	"\bprogress\x18\x05 \x01(\rR\bprogress\"7\n" +
+	"\x0fSubtreeResponse\x12$\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x0e.task.TaskNodeR\x05nodes\"\xd4\x01\n" +
+	"\x16ListTasksByUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12!\n" +
+	"\x03due\x18\x02 \x01(\x0e2\x0f.task.DueFilterR\x03due\x128\n" +
+	"\n" +
+	"due_within\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\tdueWithin\x12\x17\n" +
+	"\atag_ids\x18\x04 \x03(\rR\x06tagIds\x12+\n" +
+	"\ttag_match\x18\x05 \x01(\x0e2\x0e.task.TagMatchR\btagMatch\"B\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"?\n" +
+	"\x10CreateTagRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"6\n" +
+	"\x10RenameTagRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\"\n" +
+	"\x10DeleteTagRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"*\n" +
+	"\x0fListTagsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\"1\n" +
+	"\x10ListTagsResponse\x12\x1d\n" +
+	"\x04tags\x18\x01 \x03(\v2\t.task.TagR\x04tags\"@\n" +
+	"\x0eTaskTagRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\x12\x15\n" +
+	"\x06tag_id\x18\x02 \x01(\rR\x05tagId\"K\n" +
+	"\x11DependencyRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x02 \x01(\rR\tblockerId\")\n" +
+	"\x0eGetPlanRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\"~\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\rR\bposition\"C\n" +
+	"\x14CreateProjectRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x93\x01\n" +
+	"\x14UpdateProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\barchived\x18\x03 \x01(\bR\barchived\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"&\n" +
+	"\x14DeleteProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"Y\n" +
+	"\x13ListProjectsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12)\n" +
+	"\x10include_archived\x18\x02 \x01(\bR\x0fincludeArchived\"A\n" +
+	"\x14ListProjectsResponse\x12)\n" +
+	"\bprojects\x18\x01 \x03(\v2\r.task.ProjectR\bprojects\"R\n" +
+	"\x16ReorderProjectsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x1f\n" +
+	"\vproject_ids\x18\x02 \x03(\rR\n" +
+	"projectIds\":\n" +
+	"\x19ListTasksByProjectRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\rR\tprojectId\"R\n" +
+	"\x18MoveTaskToProjectRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\rR\tprojectId\"Y\n" +
+	"\x0fMoveTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1b\n" +
+	"\tbefore_id\x18\x02 \x01(\rR\bbeforeId\x12\x19\n" +
+	"\bafter_id\x18\x03 \x01(\rR\aafterId\"\xd7\x01\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\rR\x06taskId\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\rR\bauthorId\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tedited_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\"`\n" +
+	"\x14CreateCommentRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\rR\bauthorId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"U\n" +
+	"\x12EditCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\rR\bauthorId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"C\n" +
+	"\x14DeleteCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\rR\bauthorId\"j\n" +
+	"\x13ListCommentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\rR\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"i\n" +
+	"\x14ListCommentsResponse\x12)\n" +
+	"\bcomments\x18\x01 \x03(\v2\r.task.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"*\n" +
+	"\x18GetCommentHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"Z\n" +
+	"\vCommentEdit\x12\x12\n" +
+	"\x04body\x18\x01 \x01(\tR\x04body\x127\n" +
+	"\tedited_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\"A\n" +
+	"\x16CommentHistoryResponse\x12'\n" +
+	"\x05edits\x18\x01 \x03(\v2\x11.task.CommentEditR\x05edits\"\xdc\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\rR\x06taskId\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x04R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x95\x01\n" +
+	"\x0eAttachmentInfo\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x04R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\"e\n" +
+	"\x17UploadAttachmentRequest\x12*\n" +
+	"\x04info\x18\x01 \x01(\v2\x14.task.AttachmentInfoH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"+\n" +
+	"\x19DownloadAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"p\n" +
+	"\x1aDownloadAttachmentResponse\x122\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x10.task.AttachmentH\x00R\n" +
+	"attachment\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"1\n" +
+	"\x16ListAttachmentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\"M\n" +
+	"\x17ListAttachmentsResponse\x122\n" +
+	"\vattachments\x18\x01 \x03(\v2\x10.task.AttachmentR\vattachments\")\n" +
+	"\x17DeleteAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"F\n" +
+	"\x10UserDeletedEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\":\n" +
+	"\x13UserDeletedResponse\x12#\n" +
+	"\rdeleted_tasks\x18\x01 \x01(\rR\fdeletedTasks\"G\n" +
+	"\x11UserRestoredEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\"=\n" +
+	"\x14UserRestoredResponse\x12%\n" +
+	"\x0erestored_tasks\x18\x01 \x01(\rR\rrestoredTasks\"Q\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"\xe7\x01\n" +
+	"\x10TaskHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\rR\x06taskId\x12'\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x13.task.TaskEventTypeR\x04type\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\rR\aactorId\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12+\n" +
+	"\achanges\x18\x06 \x03(\v2\x11.task.FieldChangeR\achanges\"l\n" +
+	"\x15GetTaskHistoryRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\rR\x06taskId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"r\n" +
+	"\x16GetTaskHistoryResponse\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.task.TaskHistoryEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"h\n" +
+	"\vDeletedTask\x12\x1e\n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskR\x04task\x129\n" +
+	"\n" +
+	"deleted_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"n\n" +
+	"\x17ListDeletedTasksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"k\n" +
+	"\x18ListDeletedTasksResponse\x12'\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x11.task.DeletedTaskR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"=\n" +
+	"\x12RestoreTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\";\n" +
+	"\x10PurgeTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\rR\x06userId\"_\n" +
+	"\x0fBatchTaskResult\x12\x1e\n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskR\x04task\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"m\n" +
+	"\x17BatchCreateTasksRequest\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.task.TaskCreateRequestR\x05tasks\x12#\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x0f.task.BatchModeR\x04mode\"m\n" +
+	"\x17BatchUpdateTasksRequest\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.task.TaskUpdateRequestR\x05tasks\x12#\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x0f.task.BatchModeR\x04mode\"m\n" +
+	"\x17BatchDeleteTasksRequest\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.task.TaskDeleteRequestR\x05tasks\x12#\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x0f.task.BatchModeR\x04mode\"E\n" +
+	"\x12BatchTasksResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.task.BatchTaskResultR\aresults\"W\n" +
+	"\x12ExportTasksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12(\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x10.task.FileFormatR\x06format\"+\n" +
+	"\x13ExportTasksResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"k\n" +
+	"\rImportOptions\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12(\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x10.task.FileFormatR\x06format\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"e\n" +
+	"\x12ImportTasksRequest\x12/\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.task.ImportOptionsH\x00R\aoptions\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"\x83\x01\n" +
+	"\x0fImportRowResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\rR\x04line\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.task.ImportRowStatusR\x06status\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\rR\x06taskId\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xaf\x01\n" +
+	"\x13ImportTasksResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\rR\bimported\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x02 \x01(\rR\n" +
+	"duplicates\x12\x18\n" +
+	"\ainvalid\x18\x03 \x01(\rR\ainvalid\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\x12)\n" +
+	"\x04rows\x18\x05 \x03(\v2\x15.task.ImportRowResultR\x04rows\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\"\x99\x02\n" +
+	"\fUserDataItem\x12 \n" +
+	"\x04task\x18\x01 \x01(\v2\n" +
+	".task.TaskH\x00R\x04task\x126\n" +
+	"\fdeleted_task\x18\x02 \x01(\v2\x11.task.DeletedTaskH\x00R\vdeletedTask\x12)\n" +
+	"\aproject\x18\x03 \x01(\v2\r.task.ProjectH\x00R\aproject\x12\x1d\n" +
+	"\x03tag\x18\x04 \x01(\v2\t.task.TagH\x00R\x03tag\x12)\n" +
+	"\acomment\x18\x05 \x01(\v2\r.task.CommentH\x00R\acomment\x122\n" +
+	"\n" +
+	"attachment\x18\x06 \x01(\v2\x10.task.AttachmentH\x00R\n" +
+	"attachmentB\x06\n" +
+	"\x04item\"/\n" +
+	"\x14EraseUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\"\x9b\x01\n" +
+	"\x15EraseUserDataResponse\x12\x14\n" +
+	"\x05tasks\x18\x01 \x01(\rR\x05tasks\x12\x1a\n" +
+	"\bcomments\x18\x02 \x01(\rR\bcomments\x12\x1a\n" +
+	"\bprojects\x18\x03 \x01(\rR\bprojects\x12\x12\n" +
+	"\x04tags\x18\x04 \x01(\rR\x04tags\x12 \n" +
+	"\vattachments\x18\x05 \x01(\rR\vattachments\"Q\n" +
+	"\x11WatchTasksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12#\n" +
+	"\rfrom_revision\x18\x02 \x01(\x04R\ffromRevision\"p\n" +
+	"\tTaskEvent\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\x12'\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x13.task.TaskEventTypeR\x04type\x12\x1e\n" +
+	"\x04task\x18\x03 \x01(\v2\n" +
+	".task.TaskR\x04task*\xa5\x01\n" +
+	"\n" +
+	"TaskStatus\x12\x1b\n" +
+	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10TASK_STATUS_TODO\x10\x01\x12\x1b\n" +
+	"\x17TASK_STATUS_IN_PROGRESS\x10\x02\x12\x17\n" +
+	"\x13TASK_STATUS_BLOCKED\x10\x03\x12\x14\n" +
+	"\x10TASK_STATUS_DONE\x10\x04\x12\x18\n" +
+	"\x14TASK_STATUS_CANCELED\x10\x05*\x90\x01\n" +
+	"\fTaskPriority\x12\x1d\n" +
+	"\x19TASK_PRIORITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TASK_PRIORITY_LOW\x10\x01\x12\x18\n" +
+	"\x14TASK_PRIORITY_MEDIUM\x10\x02\x12\x16\n" +
+	"\x12TASK_PRIORITY_HIGH\x10\x03\x12\x18\n" +
+	"\x14TASK_PRIORITY_URGENT\x10\x04*=\n" +
+	"\tEditScope\x12\x19\n" +
+	"\x15EDIT_SCOPE_OCCURRENCE\x10\x00\x12\x15\n" +
+	"\x11EDIT_SCOPE_SERIES\x10\x01*\x87\x01\n" +
+	"\x0eChildrenPolicy\x12\x1f\n" +
+	"\x1bCHILDREN_POLICY_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16CHILDREN_POLICY_REJECT\x10\x01\x12\x1b\n" +
+	"\x17CHILDREN_POLICY_CASCADE\x10\x02\x12\x1b\n" +
+	"\x17CHILDREN_POLICY_PROMOTE\x10\x03*X\n" +
+	"\tDueFilter\x12\x1a\n" +
+	"\x16DUE_FILTER_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12DUE_FILTER_OVERDUE\x10\x01\x12\x17\n" +
+	"\x13DUE_FILTER_DUE_SOON\x10\x02*K\n" +
+	"\bTagMatch\x12\x19\n" +
+	"\x15TAG_MATCH_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rTAG_MATCH_ANY\x10\x01\x12\x11\n" +
+	"\rTAG_MATCH_ALL\x10\x02*\xc3\x01\n" +
+	"\rTaskEventType\x12\x1f\n" +
+	"\x1bTASK_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_CREATED\x10\x01\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_UPDATED\x10\x02\x12\x1b\n" +
+	"\x17TASK_EVENT_TYPE_DELETED\x10\x03\x12\x1c\n" +
+	"\x18TASK_EVENT_TYPE_REMINDER\x10\x04\x12\x1c\n" +
+	"\x18TASK_EVENT_TYPE_RESTORED\x10\x05*>\n" +
+	"\tBatchMode\x12\x15\n" +
+	"\x11BATCH_MODE_ATOMIC\x10\x00\x12\x1a\n" +
+	"\x16BATCH_MODE_BEST_EFFORT\x10\x01*P\n" +
+	"\n" +
+	"FileFormat\x12\x13\n" +
+	"\x0fFILE_FORMAT_CSV\x10\x00\x12\x15\n" +
+	"\x11FILE_FORMAT_JSONL\x10\x01\x12\x16\n" +
+	"\x12FILE_FORMAT_NDJSON\x10\x02*q\n" +
+	"\x0fImportRowStatus\x12\x1e\n" +
+	"\x1aIMPORT_ROW_STATUS_IMPORTED\x10\x00\x12\x1f\n" +
+	"\x1bIMPORT_ROW_STATUS_DUPLICATE\x10\x01\x12\x1d\n" +
+	"\x19IMPORT_ROW_STATUS_INVALID\x10\x022\xee\"\n" +
+	"\fTasksService\x12_\n" +
+	"\n" +
+	"CreateTask\x12\x17.task.TaskCreateRequest\x1a\x12.task.TaskResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/users/{user_id}/tasks\x12P\n" +
+	"\vGetTaskList\x12\x16.google.protobuf.Empty\x1a\x16.task.TaskListResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/tasks\x12T\n" +
+	"\n" +
+	"UpdateTask\x12\x17.task.TaskUpdateRequest\x1a\x12.task.TaskResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*2\x0e/v1/tasks/{id}\x12U\n" +
+	"\n" +
+	"DeleteTask\x12\x17.task.TaskDeleteRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/tasks/{id}\x12j\n" +
+	"\x0fListTasksByUser\x12\x1c.task.ListTasksByUserRequest\x1a\x16.task.TaskListResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/users/{user_id}/tasks\x12B\n" +
+	"\rOnUserDeleted\x12\x16.task.UserDeletedEvent\x1a\x19.task.UserDeletedResponse\x12E\n" +
+	"\x0eOnUserRestored\x12\x17.task.UserRestoredEvent\x1a\x1a.task.UserRestoredResponse\x12a\n" +
+	"\n" +
+	"WatchTasks\x12\x17.task.WatchTasksRequest\x1a\x0f.task.TaskEvent\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/users/{user_id}/tasks/watch0\x01\x12a\n" +
+	"\n" +
+	"GetSubtree\x12\x17.task.GetSubtreeRequest\x1a\x15.task.SubtreeResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/tasks/{task_id}/subtree\x12i\n" +
+	"\rAddDependency\x12\x17.task.DependencyRequest\x1a\x12.task.TaskResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/tasks/{task_id}/dependencies\x12v\n" +
+	"\x10RemoveDependency\x12\x17.task.DependencyRequest\x1a\x12.task.TaskResponse\"5\x82\xd3\xe4\x93\x02/*-/v1/tasks/{task_id}/dependencies/{blocker_id}\x12Y\n" +
+	"\aGetPlan\x12\x14.task.GetPlanRequest\x1a\x16.task.TaskListResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/users/{user_id}/plan\x12c\n" +
+	"\rCreateProject\x12\x1a.task.CreateProjectRequest\x1a\r.task.Project\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/users/{user_id}/projects\x12X\n" +
+	"\rUpdateProject\x12\x1a.task.UpdateProjectRequest\x1a\r.task.Project\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*2\x11/v1/projects/{id}\x12^\n" +
+	"\rDeleteProject\x12\x1a.task.DeleteProjectRequest\x1a\x16.google.protobuf.Empty\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/projects/{id}\x12k\n" +
+	"\fListProjects\x12\x19.task.ListProjectsRequest\x1a\x1a.task.ListProjectsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/users/{user_id}/projects\x12|\n" +
+	"\x0fReorderProjects\x12\x1c.task.ReorderProjectsRequest\x1a\x1a.task.ListProjectsResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/v1/users/{user_id}/projects/reorder\x12v\n" +
+	"\x12ListTasksByProject\x12\x1f.task.ListTasksByProjectRequest\x1a\x16.task.TaskListResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/projects/{project_id}/tasks\x12o\n" +
+	"\x11MoveTaskToProject\x12\x1e.task.MoveTaskToProjectRequest\x1a\x12.task.TaskResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/tasks/{task_id}/project\x12U\n" +
+	"\bMoveTask\x12\x15.task.MoveTaskRequest\x1a\x12.task.TaskResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/tasks/{id}/move\x12p\n" +
+	"\x0eGetTaskHistory\x12\x1b.task.GetTaskHistoryRequest\x1a\x1c.task.GetTaskHistoryResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/tasks/{task_id}/history\x12t\n" +
+	"\x10ListDeletedTasks\x12\x1d.task.ListDeletedTasksRequest\x1a\x1e.task.ListDeletedTasksResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/users/{user_id}/trash\x12k\n" +
+	"\vRestoreTask\x12\x18.task.RestoreTaskRequest\x1a\x12.task.TaskResponse\".\x82\xd3\xe4\x93\x02(\"&/v1/users/{user_id}/trash/{id}/restore\x12c\n" +
+	"\tPurgeTask\x12\x16.task.PurgeTaskRequest\x1a\x16.google.protobuf.Empty\"&\x82\xd3\xe4\x93\x02 *\x1e/v1/users/{user_id}/trash/{id}\x12n\n" +
+	"\x10BatchCreateTasks\x12\x1d.task.BatchCreateTasksRequest\x1a\x18.task.BatchTasksResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/tasks/batch/create\x12n\n" +
+	"\x10BatchUpdateTasks\x12\x1d.task.BatchUpdateTasksRequest\x1a\x18.task.BatchTasksResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/tasks/batch/update\x12n\n" +
+	"\x10BatchDeleteTasks\x12\x1d.task.BatchDeleteTasksRequest\x1a\x18.task.BatchTasksResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/tasks/batch/delete\x12D\n" +
+	"\vExportTasks\x12\x18.task.ExportTasksRequest\x1a\x19.task.ExportTasksResponse0\x01\x12D\n" +
+	"\vImportTasks\x12\x18.task.ImportTasksRequest\x1a\x19.task.ImportTasksResponse(\x01\x12C\n" +
+	"\x0eExportUserData\x12\x1b.task.ExportUserDataRequest\x1a\x12.task.UserDataItem0\x01\x12H\n" +
+	"\rEraseUserData\x12\x1a.task.EraseUserDataRequest\x1a\x1b.task.EraseUserDataResponse\x12c\n" +
+	"\rCreateComment\x12\x1a.task.CreateCommentRequest\x1a\r.task.Comment\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/tasks/{task_id}/comments\x12T\n" +
+	"\vEditComment\x12\x18.task.EditCommentRequest\x1a\r.task.Comment\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*2\x11/v1/comments/{id}\x12^\n" +
+	"\rDeleteComment\x12\x1a.task.DeleteCommentRequest\x1a\x16.google.protobuf.Empty\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/comments/{id}\x12k\n" +
+	"\fListComments\x12\x19.task.ListCommentsRequest\x1a\x1a.task.ListCommentsResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/tasks/{task_id}/comments\x12t\n" +
+	"\x11GetCommentHistory\x12\x1e.task.GetCommentHistoryRequest\x1a\x1c.task.CommentHistoryResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/comments/{id}/history\x12E\n" +
+	"\x10UploadAttachment\x12\x1d.task.UploadAttachmentRequest\x1a\x10.task.Attachment(\x01\x12Y\n" +
+	"\x12DownloadAttachment\x12\x1f.task.DownloadAttachmentRequest\x1a .task.DownloadAttachmentResponse0\x01\x12w\n" +
+	"\x0fListAttachments\x12\x1c.task.ListAttachmentsRequest\x1a\x1d.task.ListAttachmentsResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/tasks/{task_id}/attachments\x12g\n" +
+	"\x10DeleteAttachment\x12\x1d.task.DeleteAttachmentRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/attachments/{id}\x12S\n" +
+	"\tCreateTag\x12\x16.task.CreateTagRequest\x1a\t.task.Tag\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/users/{user_id}/tags\x12H\n" +
+	"\tRenameTag\x12\x16.task.RenameTagRequest\x1a\t.task.Tag\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*2\r/v1/tags/{id}\x12R\n" +
+	"\tDeleteTag\x12\x16.task.DeleteTagRequest\x1a\x16.google.protobuf.Empty\"\x15\x82\xd3\xe4\x93\x02\x0f*\r/v1/tags/{id}\x12[\n" +
+	"\bListTags\x12\x15.task.ListTagsRequest\x1a\x16.task.ListTagsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/users/{user_id}/tags\x12`\n" +
+	"\tAttachTag\x12\x14.task.TaskTagRequest\x1a\x12.task.TaskResponse\")\x82\xd3\xe4\x93\x02#\x1a!/v1/tasks/{task_id}/tags/{tag_id}\x12`\n" +
+	"\tDetachTag\x12\x14.task.TaskTagRequest\x1a\x12.task.TaskResponse\")\x82\xd3\xe4\x93\x02#*!/v1/tasks/{task_id}/tags/{tag_id}B8Z6github.com/blastuha/test-service-proto/gen/task;taskpbb\x06proto3"
+
+var (
+	file_task_task_proto_rawDescOnce sync.Once
+	file_task_task_proto_rawDescData []byte
+)
+
+func file_task_task_proto_rawDescGZIP() []byte {
+	file_task_task_proto_rawDescOnce.Do(func() {
+		file_task_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_task_task_proto_rawDesc), len(file_task_task_proto_rawDesc)))
+	})
+	return file_task_task_proto_rawDescData
+}
+
+var file_task_task_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_task_task_proto_msgTypes = make([]protoimpl.MessageInfo, 76)
+var file_task_task_proto_goTypes = []any{
+	(TaskStatus)(0),                    // 0: task.TaskStatus
+	(TaskPriority)(0),                  // 1: task.TaskPriority
+	(EditScope)(0),                     // 2: task.EditScope
+	(ChildrenPolicy)(0),                // 3: task.ChildrenPolicy
+	(DueFilter)(0),                     // 4: task.DueFilter
+	(TagMatch)(0),                      // 5: task.TagMatch
+	(TaskEventType)(0),                 // 6: task.TaskEventType
+	(BatchMode)(0),                     // 7: task.BatchMode
+	(FileFormat)(0),                    // 8: task.FileFormat
+	(ImportRowStatus)(0),               // 9: task.ImportRowStatus
+	(*Task)(nil),                       // 10: task.Task
+	(*TaskCreateRequest)(nil),          // 11: task.TaskCreateRequest
+	(*TaskResponse)(nil),               // 12: task.TaskResponse
+	(*TaskListResponse)(nil),           // 13: task.TaskListResponse
+	(*TaskUpdateRequest)(nil),          // 14: task.TaskUpdateRequest
+	(*TaskDeleteRequest)(nil),          // 15: task.TaskDeleteRequest
+	(*GetSubtreeRequest)(nil),          // 16: task.GetSubtreeRequest
+	(*TaskNode)(nil),                   // 17: task.TaskNode
+	(*SubtreeResponse)(nil),            // 18: task.SubtreeResponse
+	(*ListTasksByUserRequest)(nil),     // 19: task.ListTasksByUserRequest
+	(*Tag)(nil),                        // 20: task.Tag
+	(*CreateTagRequest)(nil),           // 21: task.CreateTagRequest
+	(*RenameTagRequest)(nil),           // 22: task.RenameTagRequest
+	(*DeleteTagRequest)(nil),           // 23: task.DeleteTagRequest
+	(*ListTagsRequest)(nil),            // 24: task.ListTagsRequest
+	(*ListTagsResponse)(nil),           // 25: task.ListTagsResponse
+	(*TaskTagRequest)(nil),             // 26: task.TaskTagRequest
+	(*DependencyRequest)(nil),          // 27: task.DependencyRequest
+	(*GetPlanRequest)(nil),             // 28: task.GetPlanRequest
+	(*Project)(nil),                    // 29: task.Project
+	(*CreateProjectRequest)(nil),       // 30: task.CreateProjectRequest
+	(*UpdateProjectRequest)(nil),       // 31: task.UpdateProjectRequest
+	(*DeleteProjectRequest)(nil),       // 32: task.DeleteProjectRequest
+	(*ListProjectsRequest)(nil),        // 33: task.ListProjectsRequest
+	(*ListProjectsResponse)(nil),       // 34: task.ListProjectsResponse
+	(*ReorderProjectsRequest)(nil),     // 35: task.ReorderProjectsRequest
+	(*ListTasksByProjectRequest)(nil),  // 36: task.ListTasksByProjectRequest
+	(*MoveTaskToProjectRequest)(nil),   // 37: task.MoveTaskToProjectRequest
+	(*MoveTaskRequest)(nil),            // 38: task.MoveTaskRequest
+	(*Comment)(nil),                    // 39: task.Comment
+	(*CreateCommentRequest)(nil),       // 40: task.CreateCommentRequest
+	(*EditCommentRequest)(nil),         // 41: task.EditCommentRequest
+	(*DeleteCommentRequest)(nil),       // 42: task.DeleteCommentRequest
+	(*ListCommentsRequest)(nil),        // 43: task.ListCommentsRequest
+	(*ListCommentsResponse)(nil),       // 44: task.ListCommentsResponse
+	(*GetCommentHistoryRequest)(nil),   // 45: task.GetCommentHistoryRequest
+	(*CommentEdit)(nil),                // 46: task.CommentEdit
+	(*CommentHistoryResponse)(nil),     // 47: task.CommentHistoryResponse
+	(*Attachment)(nil),                 // 48: task.Attachment
+	(*AttachmentInfo)(nil),             // 49: task.AttachmentInfo
+	(*UploadAttachmentRequest)(nil),    // 50: task.UploadAttachmentRequest
+	(*DownloadAttachmentRequest)(nil),  // 51: task.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil), // 52: task.DownloadAttachmentResponse
+	(*ListAttachmentsRequest)(nil),     // 53: task.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),    // 54: task.ListAttachmentsResponse
+	(*DeleteAttachmentRequest)(nil),    // 55: task.DeleteAttachmentRequest
+	(*UserDeletedEvent)(nil),           // 56: task.UserDeletedEvent
+	(*UserDeletedResponse)(nil),        // 57: task.UserDeletedResponse
+	(*UserRestoredEvent)(nil),          // 58: task.UserRestoredEvent
+	(*UserRestoredResponse)(nil),       // 59: task.UserRestoredResponse
+	(*FieldChange)(nil),                // 60: task.FieldChange
+	(*TaskHistoryEntry)(nil),           // 61: task.TaskHistoryEntry
+	(*GetTaskHistoryRequest)(nil),      // 62: task.GetTaskHistoryRequest
+	(*GetTaskHistoryResponse)(nil),     // 63: task.GetTaskHistoryResponse
+	(*DeletedTask)(nil),                // 64: task.DeletedTask
+	(*ListDeletedTasksRequest)(nil),    // 65: task.ListDeletedTasksRequest
+	(*ListDeletedTasksResponse)(nil),   // 66: task.ListDeletedTasksResponse
+	(*RestoreTaskRequest)(nil),         // 67: task.RestoreTaskRequest
+	(*PurgeTaskRequest)(nil),           // 68: task.PurgeTaskRequest
+	(*BatchTaskResult)(nil),            // 69: task.BatchTaskResult
+	(*BatchCreateTasksRequest)(nil),    // 70: task.BatchCreateTasksRequest
+	(*BatchUpdateTasksRequest)(nil),    // 71: task.BatchUpdateTasksRequest
+	(*BatchDeleteTasksRequest)(nil),    // 72: task.BatchDeleteTasksRequest
+	(*BatchTasksResponse)(nil),         // 73: task.BatchTasksResponse
+	(*ExportTasksRequest)(nil),         // 74: task.ExportTasksRequest
+	(*ExportTasksResponse)(nil),        // 75: task.ExportTasksResponse
+	(*ImportOptions)(nil),              // 76: task.ImportOptions
+	(*ImportTasksRequest)(nil),         // 77: task.ImportTasksRequest
+	(*ImportRowResult)(nil),            // 78: task.ImportRowResult
+	(*ImportTasksResponse)(nil),        // 79: task.ImportTasksResponse
+	(*ExportUserDataRequest)(nil),      // 80: task.ExportUserDataRequest
+	(*UserDataItem)(nil),               // 81: task.UserDataItem
+	(*EraseUserDataRequest)(nil),       // 82: task.EraseUserDataRequest
+	(*EraseUserDataResponse)(nil),      // 83: task.EraseUserDataResponse
+	(*WatchTasksRequest)(nil),          // 84: task.WatchTasksRequest
+	(*TaskEvent)(nil),                  // 85: task.TaskEvent
+	(*timestamppb.Timestamp)(nil),      // 86: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 87: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),        // 88: google.protobuf.Duration
+	(*emptypb.Empty)(nil),              // 89: google.protobuf.Empty
+}
+var file_task_task_proto_depIdxs = []int32{
+	86,  // 0: task.Task.due_at:type_name -> google.protobuf.Timestamp
+	86,  // 1: task.Task.remind_at:type_name -> google.protobuf.Timestamp
+	0,   // 2: task.Task.status:type_name -> task.TaskStatus
+	1,   // 3: task.Task.priority:type_name -> task.TaskPriority
+	86,  // 4: task.Task.completed_at:type_name -> google.protobuf.Timestamp
+	86,  // 5: task.Task.occurrence_at:type_name -> google.protobuf.Timestamp
+	86,  // 6: task.TaskCreateRequest.due_at:type_name -> google.protobuf.Timestamp
+	86,  // 7: task.TaskCreateRequest.remind_at:type_name -> google.protobuf.Timestamp
+	0,   // 8: task.TaskCreateRequest.status:type_name -> task.TaskStatus
+	1,   // 9: task.TaskCreateRequest.priority:type_name -> task.TaskPriority
+	10,  // 10: task.TaskResponse.task:type_name -> task.Task
+	10,  // 11: task.TaskListResponse.tasks:type_name -> task.Task
+	87,  // 12: task.TaskUpdateRequest.update_mask:type_name -> google.protobuf.FieldMask
+	86,  // 13: task.TaskUpdateRequest.due_at:type_name -> google.protobuf.Timestamp
+	86,  // 14: task.TaskUpdateRequest.remind_at:type_name -> google.protobuf.Timestamp
+	0,   // 15: task.TaskUpdateRequest.status:type_name -> task.TaskStatus
+	1,   // 16: task.TaskUpdateRequest.priority:type_name -> task.TaskPriority
+	2,   // 17: task.TaskUpdateRequest.scope:type_name -> task.EditScope
+	3,   // 18: task.TaskDeleteRequest.children:type_name -> task.ChildrenPolicy
+	10,  // 19: task.TaskNode.task:type_name -> task.Task
+	17,  // 20: task.SubtreeResponse.nodes:type_name -> task.TaskNode
+	4,   // 21: task.ListTasksByUserRequest.due:type_name -> task.DueFilter
+	88,  // 22: task.ListTasksByUserRequest.due_within:type_name -> google.protobuf.Duration
+	5,   // 23: task.ListTasksByUserRequest.tag_match:type_name -> task.TagMatch
+	20,  // 24: task.ListTagsResponse.tags:type_name -> task.Tag
+	87,  // 25: task.UpdateProjectRequest.update_mask:type_name -> google.protobuf.FieldMask
+	29,  // 26: task.ListProjectsResponse.projects:type_name -> task.Project
+	86,  // 27: task.Comment.created_at:type_name -> google.protobuf.Timestamp
+	86,  // 28: task.Comment.edited_at:type_name -> google.protobuf.Timestamp
+	39,  // 29: task.ListCommentsResponse.comments:type_name -> task.Comment
+	86,  // 30: task.CommentEdit.edited_at:type_name -> google.protobuf.Timestamp
+	46,  // 31: task.CommentHistoryResponse.edits:type_name -> task.CommentEdit
+	86,  // 32: task.Attachment.created_at:type_name -> google.protobuf.Timestamp
+	49,  // 33: task.UploadAttachmentRequest.info:type_name -> task.AttachmentInfo
+	48,  // 34: task.DownloadAttachmentResponse.attachment:type_name -> task.Attachment
+	48,  // 35: task.ListAttachmentsResponse.attachments:type_name -> task.Attachment
+	6,   // 36: task.TaskHistoryEntry.type:type_name -> task.TaskEventType
+	86,  // 37: task.TaskHistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	60,  // 38: task.TaskHistoryEntry.changes:type_name -> task.FieldChange
+	61,  // 39: task.GetTaskHistoryResponse.entries:type_name -> task.TaskHistoryEntry
+	10,  // 40: task.DeletedTask.task:type_name -> task.Task
+	86,  // 41: task.DeletedTask.deleted_at:type_name -> google.protobuf.Timestamp
+	64,  // 42: task.ListDeletedTasksResponse.tasks:type_name -> task.DeletedTask
+	10,  // 43: task.BatchTaskResult.task:type_name -> task.Task
+	11,  // 44: task.BatchCreateTasksRequest.tasks:type_name -> task.TaskCreateRequest
+	7,   // 45: task.BatchCreateTasksRequest.mode:type_name -> task.BatchMode
+	14,  // 46: task.BatchUpdateTasksRequest.tasks:type_name -> task.TaskUpdateRequest
+	7,   // 47: task.BatchUpdateTasksRequest.mode:type_name -> task.BatchMode
+	15,  // 48: task.BatchDeleteTasksRequest.tasks:type_name -> task.TaskDeleteRequest
+	7,   // 49: task.BatchDeleteTasksRequest.mode:type_name -> task.BatchMode
+	69,  // 50: task.BatchTasksResponse.results:type_name -> task.BatchTaskResult
+	8,   // 51: task.ExportTasksRequest.format:type_name -> task.FileFormat
+	8,   // 52: task.ImportOptions.format:type_name -> task.FileFormat
+	76,  // 53: task.ImportTasksRequest.options:type_name -> task.ImportOptions
+	9,   // 54: task.ImportRowResult.status:type_name -> task.ImportRowStatus
+	78,  // 55: task.ImportTasksResponse.rows:type_name -> task.ImportRowResult
+	10,  // 56: task.UserDataItem.task:type_name -> task.Task
+	64,  // 57: task.UserDataItem.deleted_task:type_name -> task.DeletedTask
+	29,  // 58: task.UserDataItem.project:type_name -> task.Project
+	20,  // 59: task.UserDataItem.tag:type_name -> task.Tag
+	39,  // 60: task.UserDataItem.comment:type_name -> task.Comment
+	48,  // 61: task.UserDataItem.attachment:type_name -> task.Attachment
+	6,   // 62: task.TaskEvent.type:type_name -> task.TaskEventType
+	10,  // 63: task.TaskEvent.task:type_name -> task.Task
+	11,  // 64: task.TasksService.CreateTask:input_type -> task.TaskCreateRequest
+	89,  // 65: task.TasksService.GetTaskList:input_type -> google.protobuf.Empty
+	14,  // 66: task.TasksService.UpdateTask:input_type -> task.TaskUpdateRequest
+	15,  // 67: task.TasksService.DeleteTask:input_type -> task.TaskDeleteRequest
+	19,  // 68: task.TasksService.ListTasksByUser:input_type -> task.ListTasksByUserRequest
+	56,  // 69: task.TasksService.OnUserDeleted:input_type -> task.UserDeletedEvent
+	58,  // 70: task.TasksService.OnUserRestored:input_type -> task.UserRestoredEvent
+	84,  // 71: task.TasksService.WatchTasks:input_type -> task.WatchTasksRequest
+	16,  // 72: task.TasksService.GetSubtree:input_type -> task.GetSubtreeRequest
+	27,  // 73: task.TasksService.AddDependency:input_type -> task.DependencyRequest
+	27,  // 74: task.TasksService.RemoveDependency:input_type -> task.DependencyRequest
+	28,  // 75: task.TasksService.GetPlan:input_type -> task.GetPlanRequest
+	30,  // 76: task.TasksService.CreateProject:input_type -> task.CreateProjectRequest
+	31,  // 77: task.TasksService.UpdateProject:input_type -> task.UpdateProjectRequest
+	32,  // 78: task.TasksService.DeleteProject:input_type -> task.DeleteProjectRequest
+	33,  // 79: task.TasksService.ListProjects:input_type -> task.ListProjectsRequest
+	35,  // 80: task.TasksService.ReorderProjects:input_type -> task.ReorderProjectsRequest
+	36,  // 81: task.TasksService.ListTasksByProject:input_type -> task.ListTasksByProjectRequest
+	37,  // 82: task.TasksService.MoveTaskToProject:input_type -> task.MoveTaskToProjectRequest
+	38,  // 83: task.TasksService.MoveTask:input_type -> task.MoveTaskRequest
+	62,  // 84: task.TasksService.GetTaskHistory:input_type -> task.GetTaskHistoryRequest
+	65,  // 85: task.TasksService.ListDeletedTasks:input_type -> task.ListDeletedTasksRequest
+	67,  // 86: task.TasksService.RestoreTask:input_type -> task.RestoreTaskRequest
+	68,  // 87: task.TasksService.PurgeTask:input_type -> task.PurgeTaskRequest
+	70,  // 88: task.TasksService.BatchCreateTasks:input_type -> task.BatchCreateTasksRequest
+	71,  // 89: task.TasksService.BatchUpdateTasks:input_type -> task.BatchUpdateTasksRequest
+	72,  // 90: task.TasksService.BatchDeleteTasks:input_type -> task.BatchDeleteTasksRequest
+	74,  // 91: task.TasksService.ExportTasks:input_type -> task.ExportTasksRequest
+	77,  // 92: task.TasksService.ImportTasks:input_type -> task.ImportTasksRequest
+	80,  // 93: task.TasksService.ExportUserData:input_type -> task.ExportUserDataRequest
+	82,  // 94: task.TasksService.EraseUserData:input_type -> task.EraseUserDataRequest
+	40,  // 95: task.TasksService.CreateComment:input_type -> task.CreateCommentRequest
+	41,  // 96: task.TasksService.EditComment:input_type -> task.EditCommentRequest
+	42,  // 97: task.TasksService.DeleteComment:input_type -> task.DeleteCommentRequest
+	43,  // 98: task.TasksService.ListComments:input_type -> task.ListCommentsRequest
+	45,  // 99: task.TasksService.GetCommentHistory:input_type -> task.GetCommentHistoryRequest
+	50,  // 100: task.TasksService.UploadAttachment:input_type -> task.UploadAttachmentRequest
+	51,  // 101: task.TasksService.DownloadAttachment:input_type -> task.DownloadAttachmentRequest
+	53,  // 102: task.TasksService.ListAttachments:input_type -> task.ListAttachmentsRequest
+	55,  // 103: task.TasksService.DeleteAttachment:input_type -> task.DeleteAttachmentRequest
+	21,  // 104: task.TasksService.CreateTag:input_type -> task.CreateTagRequest
+	22,  // 105: task.TasksService.RenameTag:input_type -> task.RenameTagRequest
+	23,  // 106: task.TasksService.DeleteTag:input_type -> task.DeleteTagRequest
+	24,  // 107: task.TasksService.ListTags:input_type -> task.ListTagsRequest
+	26,  // 108: task.TasksService.AttachTag:input_type -> task.TaskTagRequest
+	26,  // 109: task.TasksService.DetachTag:input_type -> task.TaskTagRequest
+	12,  // 110: task.TasksService.CreateTask:output_type -> task.TaskResponse
+	13,  // 111: task.TasksService.GetTaskList:output_type -> task.TaskListResponse
+	12,  // 112: task.TasksService.UpdateTask:output_type -> task.TaskResponse
+	89,  // 113: task.TasksService.DeleteTask:output_type -> google.protobuf.Empty
+	13,  // 114: task.TasksService.ListTasksByUser:output_type -> task.TaskListResponse
+	57,  // 115: task.TasksService.OnUserDeleted:output_type -> task.UserDeletedResponse
+	59,  // 116: task.TasksService.OnUserRestored:output_type -> task.UserRestoredResponse
+	85,  // 117: task.TasksService.WatchTasks:output_type -> task.TaskEvent
+	18,  // 118: task.TasksService.GetSubtree:output_type -> task.SubtreeResponse
+	12,  // 119: task.TasksService.AddDependency:output_type -> task.TaskResponse
+	12,  // 120: task.TasksService.RemoveDependency:output_type -> task.TaskResponse
+	13,  // 121: task.TasksService.GetPlan:output_type -> task.TaskListResponse
+	29,  // 122: task.TasksService.CreateProject:output_type -> task.Project
+	29,  // 123: task.TasksService.UpdateProject:output_type -> task.Project
+	89,  // 124: task.TasksService.DeleteProject:output_type -> google.protobuf.Empty
+	34,  // 125: task.TasksService.ListProjects:output_type -> task.ListProjectsResponse
+	34,  // 126: task.TasksService.ReorderProjects:output_type -> task.ListProjectsResponse
+	13,  // 127: task.TasksService.ListTasksByProject:output_type -> task.TaskListResponse
+	12,  // 128: task.TasksService.MoveTaskToProject:output_type -> task.TaskResponse
+	12,  // 129: task.TasksService.MoveTask:output_type -> task.TaskResponse
+	63,  // 130: task.TasksService.GetTaskHistory:output_type -> task.GetTaskHistoryResponse
+	66,  // 131: task.TasksService.ListDeletedTasks:output_type -> task.ListDeletedTasksResponse
+	12,  // 132: task.TasksService.RestoreTask:output_type -> task.TaskResponse
+	89,  // 133: task.TasksService.PurgeTask:output_type -> google.protobuf.Empty
+	73,  // 134: task.TasksService.BatchCreateTasks:output_type -> task.BatchTasksResponse
+	73,  // 135: task.TasksService.BatchUpdateTasks:output_type -> task.BatchTasksResponse
+	73,  // 136: task.TasksService.BatchDeleteTasks:output_type -> task.BatchTasksResponse
+	75,  // 137: task.TasksService.ExportTasks:output_type -> task.ExportTasksResponse
+	79,  // 138: task.TasksService.ImportTasks:output_type -> task.ImportTasksResponse
+	81,  // 139: task.TasksService.ExportUserData:output_type -> task.UserDataItem
+	83,  // 140: task.TasksService.EraseUserData:output_type -> task.EraseUserDataResponse
+	39,  // 141: task.TasksService.CreateComment:output_type -> task.Comment
+	39,  // 142: task.TasksService.EditComment:output_type -> task.Comment
+	89,  // 143: task.TasksService.DeleteComment:output_type -> google.protobuf.Empty
+	44,  // 144: task.TasksService.ListComments:output_type -> task.ListCommentsResponse
+	47,  // 145: task.TasksService.GetCommentHistory:output_type -> task.CommentHistoryResponse
+	48,  // 146: task.TasksService.UploadAttachment:output_type -> task.Attachment
+	52,  // 147: task.TasksService.DownloadAttachment:output_type -> task.DownloadAttachmentResponse
+	54,  // 148: task.TasksService.ListAttachments:output_type -> task.ListAttachmentsResponse
+	89,  // 149: task.TasksService.DeleteAttachment:output_type -> google.protobuf.Empty
+	20,  // 150: task.TasksService.CreateTag:output_type -> task.Tag
+	20,  // 151: task.TasksService.RenameTag:output_type -> task.Tag
+	89,  // 152: task.TasksService.DeleteTag:output_type -> google.protobuf.Empty
+	25,  // 153: task.TasksService.ListTags:output_type -> task.ListTagsResponse
+	12,  // 154: task.TasksService.AttachTag:output_type -> task.TaskResponse
+	12,  // 155: task.TasksService.DetachTag:output_type -> task.TaskResponse
+	110, // [110:156] is the sub-list for method output_type
+	64,  // [64:110] is the sub-list for method input_type
+	64,  // [64:64] is the sub-list for extension type_name
+	64,  // [64:64] is the sub-list for extension extendee
+	0,   // [0:64] is the sub-list for field type_name
+}
+
+func init() { file_task_task_proto_init() }
+func file_task_task_proto_init() {
+	if File_task_task_proto != nil {
+		return
+	}
+	file_task_task_proto_msgTypes[40].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Info)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_task_task_proto_msgTypes[42].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Attachment)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
+	file_task_task_proto_msgTypes[67].OneofWrappers = []any{
+		(*ImportTasksRequest_Options)(nil),
+		(*ImportTasksRequest_Chunk)(nil),
+	}
+	file_task_task_proto_msgTypes[71].OneofWrappers = []any{
+		(*UserDataItem_Task)(nil),
+		(*UserDataItem_DeletedTask)(nil),
+		(*UserDataItem_Project)(nil),
+		(*UserDataItem_Tag)(nil),
+		(*UserDataItem_Comment)(nil),
+		(*UserDataItem_Attachment)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_task_task_proto_rawDesc), len(file_task_task_proto_rawDesc)),
+			NumEnums:      10,
+			NumMessages:   76,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_task_task_proto_goTypes,
+		DependencyIndexes: file_task_task_proto_depIdxs,
+		EnumInfos:         file_task_task_proto_enumTypes,
+		MessageInfos:      file_task_task_proto_msgTypes,
+	}.Build()
+	File_task_task_proto = out.File
+	file_task_task_proto_goTypes = nil
+	file_task_task_proto_depIdxs = nil
+}
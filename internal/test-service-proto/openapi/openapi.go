@@ -0,0 +1,15 @@
+// Package openapi — описания REST API сервисов в формате OpenAPI 2.0,
+// сгенерированные protoc-gen-openapiv2 по аннотациям google.api.http
+package openapi
+
+import _ "embed"
+
+// UserService — описание REST-маршрутов UserService
+//
+//go:embed user/user.swagger.json
+var UserService []byte
+
+// TasksService — описание REST-маршрутов TasksService
+//
+//go:embed task/task.swagger.json
+var TasksService []byte
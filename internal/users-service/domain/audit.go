@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Действия журнала аудита
+const (
+	AuditUserCreated         = "user.created"
+	AuditUserEmailChanged    = "user.email_changed"
+	AuditUserPasswordChanged = "user.password_changed"
+	AuditUserDeleted         = "user.deleted"
+	AuditUserRestored        = "user.restored"
+	AuditUserPurged          = "user.purged"
+	AuditLoginSucceeded      = "auth.login_succeeded"
+	AuditLoginFailed         = "auth.login_failed"
+
+	// AuditUserErasureRequested и AuditUserErased — удаление данных по запросу пользователя
+	AuditUserErasureRequested = "user.erasure_requested"
+	AuditUserErased           = "user.erased"
+)
+
+// Actor — кто выполняет действие: пользователь из заголовка запроса и адрес клиента
+type Actor struct {
+	// UserID — 0, если автор не передан (сам пользователь или система)
+	UserID uint32
+	Source string
+}
+
+// AuditEntry — запись журнала аудита. Hash — SHA-256 от PrevHash и полей записи,
+// поэтому изменение или удаление записи ломает цепочку.
+type AuditEntry struct {
+	ID uint64
+	// UserID — пользователь, которого касается запись
+	UserID  uint32
+	Actor   Actor
+	Action  string
+	Details string
+	// CreatedAt с точностью до микросекунды, как хранит Postgres
+	CreatedAt time.Time
+	PrevHash  string
+	Hash      string
+}
+
+// AuditFilter — условия выборки журнала аудита, нулевые поля не ограничивают
+type AuditFilter struct {
+	UserID uint32
+	Action string
+	// полуинтервал [From, To)
+	From *time.Time
+	To   *time.Time
+}
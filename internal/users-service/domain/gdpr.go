@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// UserRecord — учетная запись для выгрузки данных пользователя, без пароля
+type UserRecord struct {
+	ID        uint32     `json:"id"`
+	Email     string     `json:"email"`
+	Version   uint32     `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ErasureStatus — состояние удаления данных пользователя
+type ErasureStatus string
+
+const (
+	// ErasurePending — пользователь удален мягко, данные в tasks-service еще не удалены
+	ErasurePending   ErasureStatus = "pending"
+	ErasureCompleted ErasureStatus = "completed"
+)
+
+// ErasedTasksData — сколько записей пользователя удалено в tasks-service
+type ErasedTasksData struct {
+	Tasks       int `json:"tasks"`
+	Comments    int `json:"comments"`
+	Projects    int `json:"projects"`
+	Tags        int `json:"tags"`
+	Attachments int `json:"attachments"`
+}
+
+// Erasure — запись об удалении данных пользователя по его запросу. Остается после
+// удаления пользователя и подтверждает, что удаление выполнено; персональных данных не хранит.
+type Erasure struct {
+	ID          uint64
+	UserID      uint32
+	Status      ErasureStatus
+	RequestedAt time.Time
+	CompletedAt *time.Time
+	// Attempts и LastError — попытки удалить данные в tasks-service
+	Attempts  int
+	LastError string
+	Erased    ErasedTasksData
+}
@@ -1,7 +1,23 @@
 package domain
 
+import "time"
+
 type User struct {
 	ID       uint32
 	Email    string
 	Password string
+	// Version увеличивается при каждом обновлении, используется для оптимистичной блокировки
+	Version uint32
+}
+
+// UserUpdate описывает частичное изменение пользователя: nil-поля не меняются
+type UserUpdate struct {
+	Email    *string
+	Password *string
+}
+
+// DeletedUser — мягко удаленный пользователь в корзине
+type DeletedUser struct {
+	User      *User
+	DeletedAt time.Time
 }
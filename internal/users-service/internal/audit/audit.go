@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/users-service/domain"
+	"gorm.io/gorm"
+)
+
+// lockKey — ключ advisory lock записи в журнал: записи добавляются по одной,
+// поэтому порядок id совпадает с порядком цепочки
+const lockKey = 29_001
+
+// actorSetting — ключ настройки gorm с автором изменений
+const actorSetting = "audit:actor"
+
+// genesisHash — prev_hash первой записи журнала
+var genesisHash = strings.Repeat("0", sha256.Size*2)
+
+// WithActor возвращает db, записи журнала из которой получают автора actor
+func WithActor(db *gorm.DB, actor domain.Actor) *gorm.DB {
+	return db.Set(actorSetting, actor).Session(&gorm.Session{})
+}
+
+// ActorOf возвращает автора, заданного через WithActor
+func ActorOf(db *gorm.DB) domain.Actor {
+	v, _ := db.Get(actorSetting)
+	actor, _ := v.(domain.Actor)
+	return actor
+}
+
+// Append добавляет запись о действии action над пользователем userID в рамках транзакции tx.
+// details сериализуется в JSON; автор берется из tx (см. WithActor).
+func Append(tx *gorm.DB, action string, userID uint32, details any) error {
+	r, err := newRecord(tx, action, userID, details)
+	if err != nil {
+		return fmt.Errorf("audit.Append %s: %w", action, err)
+	}
+	if err := appendRecords(tx, r); err != nil {
+		return fmt.Errorf("audit.Append %s: %w", action, err)
+	}
+
+	return nil
+}
+
+// newRecord собирает запись без prev_hash и hash
+func newRecord(db *gorm.DB, action string, userID uint32, details any) (*Record, error) {
+	raw := []byte("{}")
+	if details != nil {
+		var err error
+		if raw, err = json.Marshal(details); err != nil {
+			return nil, err
+		}
+	}
+
+	actor := ActorOf(db)
+	return &Record{
+		UserID:  userID,
+		ActorID: actor.UserID,
+		Source:  actor.Source,
+		Action:  action,
+		Details: string(raw),
+		// Postgres хранит микросекунды, hash считается от того же значения
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}, nil
+}
+
+// appendRecords дописывает записи в конец цепочки под блокировкой журнала
+func appendRecords(tx *gorm.DB, records ...*Record) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+		return fmt.Errorf("failed to lock: %w", err)
+	}
+
+	var last []string
+	if err := tx.Model(&Record{}).Order("id DESC").Limit(1).Pluck("hash", &last).Error; err != nil {
+		return fmt.Errorf("failed to get last hash: %w", err)
+	}
+	prev := genesisHash
+	if len(last) > 0 {
+		prev = last[0]
+	}
+
+	link(prev, records)
+	if err := tx.Create(records).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// link продолжает цепочку записями records после записи с hash prev
+// и возвращает hash последней из них
+func link(prev string, records []*Record) string {
+	for _, r := range records {
+		r.PrevHash = prev
+		r.Hash = hashRecord(r)
+		prev = r.Hash
+	}
+	return prev
+}
+
+// hashRecord считает hash записи от prev_hash и её полей
+func hashRecord(r *Record) string {
+	h := sha256.New()
+	for _, field := range []string{
+		r.PrevHash,
+		strconv.FormatUint(uint64(r.UserID), 10),
+		strconv.FormatUint(uint64(r.ActorID), 10),
+		r.Source,
+		r.Action,
+		r.Details,
+		r.CreatedAt.UTC().Format(time.RFC3339Nano),
+	} {
+		// длина перед полем, чтобы границы полей нельзя было сдвинуть
+		h.Write([]byte(strconv.Itoa(len(field)) + ":" + field))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"testing"
+	"time"
+)
+
+// chain возвращает n связанных записей с id 1..n
+func chain(n int) []Record {
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	records := make([]*Record, n)
+	for i := range records {
+		records[i] = &Record{
+			ID:        uint64(i + 1),
+			UserID:    7,
+			ActorID:   3,
+			Source:    "users-service",
+			Action:    "user.updated",
+			Details:   `{"name":"x"}`,
+			CreatedAt: at.Add(time.Duration(i) * time.Second),
+		}
+	}
+	link(genesisHash, records)
+
+	out := make([]Record, n)
+	for i, r := range records {
+		out[i] = *r
+	}
+	return out
+}
+
+func TestLink(t *testing.T) {
+	rows := chain(3)
+	if rows[0].PrevHash != genesisHash {
+		t.Errorf("first PrevHash = %q, want genesis", rows[0].PrevHash)
+	}
+	for i := 1; i < len(rows); i++ {
+		if rows[i].PrevHash != rows[i-1].Hash {
+			t.Errorf("rows[%d].PrevHash = %q, want %q", i, rows[i].PrevHash, rows[i-1].Hash)
+		}
+	}
+
+	// продолжение цепочки по частям дает те же хеши, что и целиком
+	rest := []*Record{{UserID: 1, Action: "user.created", Details: "{}", CreatedAt: rows[0].CreatedAt}}
+	last := link(rows[2].Hash, rest)
+	if rest[0].PrevHash != rows[2].Hash || last != rest[0].Hash {
+		t.Errorf("link continued from %q: prev %q, last %q", rows[2].Hash, rest[0].PrevHash, last)
+	}
+}
+
+func TestHashRecordFieldBoundaries(t *testing.T) {
+	a := Record{PrevHash: genesisHash, Source: "ab", Action: "c", Details: "{}"}
+	b := Record{PrevHash: genesisHash, Source: "a", Action: "bc", Details: "{}"}
+	if hashRecord(&a) == hashRecord(&b) {
+		t.Error("hashRecord does not separate fields")
+	}
+}
+
+func TestCheckChain(t *testing.T) {
+	tests := []struct {
+		name       string
+		tamper     func(rows []Record) []Record
+		wantOK     int
+		wantBroken uint64
+	}{
+		{"intact", func(rows []Record) []Record { return rows }, 4, 0},
+		{"user id", func(rows []Record) []Record { rows[1].UserID = 8; return rows }, 1, 2},
+		{"actor id", func(rows []Record) []Record { rows[2].ActorID = 0; return rows }, 2, 3},
+		{"source", func(rows []Record) []Record { rows[0].Source = "admin"; return rows }, 0, 1},
+		{"action", func(rows []Record) []Record { rows[3].Action = "user.deleted"; return rows }, 3, 4},
+		{"details", func(rows []Record) []Record { rows[1].Details = `{"name":"y"}`; return rows }, 1, 2},
+		{"created at", func(rows []Record) []Record {
+			rows[2].CreatedAt = rows[2].CreatedAt.Add(time.Microsecond)
+			return rows
+		}, 2, 3},
+		{"prev hash", func(rows []Record) []Record { rows[2].PrevHash = genesisHash; return rows }, 2, 3},
+		{"rehashed", func(rows []Record) []Record {
+			// запись пересчитана, но следующая ссылается на старый hash
+			rows[1].Details = "{}"
+			rows[1].Hash = hashRecord(&rows[1])
+			return rows
+		}, 2, 3},
+		{"deleted", func(rows []Record) []Record { return append(rows[:1], rows[2:]...) }, 1, 3},
+		{"reordered", func(rows []Record) []Record {
+			rows[1], rows[2] = rows[2], rows[1]
+			return rows
+		}, 1, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := tt.tamper(chain(4))
+			_, ok, broken := checkChain(genesisHash, rows)
+			if ok != tt.wantOK || broken != tt.wantBroken {
+				t.Errorf("checkChain = %d, %d; want %d, %d", ok, broken, tt.wantOK, tt.wantBroken)
+			}
+		})
+	}
+}
+
+func TestCheckChainBatches(t *testing.T) {
+	rows := chain(5)
+	prev, ok, broken := checkChain(genesisHash, rows[:2])
+	if ok != 2 || broken != 0 {
+		t.Fatalf("first batch = %d, %d", ok, broken)
+	}
+	if _, ok, broken = checkChain(prev, rows[2:]); ok != 3 || broken != 0 {
+		t.Errorf("second batch = %d, %d", ok, broken)
+	}
+	// следующая пачка с чужим началом цепочки
+	if _, _, broken = checkChain(genesisHash, rows[2:]); broken != 3 {
+		t.Errorf("batch after wrong prev broken at %d, want 3", broken)
+	}
+}
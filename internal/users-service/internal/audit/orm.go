@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/your-org/users-service/domain"
+)
+
+// Record — строка таблицы audit_log
+type Record struct {
+	ID        uint64 `gorm:"primaryKey"`
+	UserID    uint32 `gorm:"not null;default:0"`
+	ActorID   uint32 `gorm:"not null;default:0"`
+	Source    string `gorm:"type:varchar(64);not null;default:''"`
+	Action    string `gorm:"type:varchar(64);not null"`
+	Details   string `gorm:"type:text;not null"`
+	CreatedAt time.Time
+	PrevHash  string `gorm:"type:char(64);not null"`
+	Hash      string `gorm:"type:char(64);not null"`
+}
+
+func (Record) TableName() string {
+	return "audit_log"
+}
+
+func (r *Record) toDomain() *domain.AuditEntry {
+	return &domain.AuditEntry{
+		ID:        r.ID,
+		UserID:    r.UserID,
+		Actor:     domain.Actor{UserID: r.ActorID, Source: r.Source},
+		Action:    r.Action,
+		Details:   r.Details,
+		CreatedAt: r.CreatedAt,
+		PrevHash:  r.PrevHash,
+		Hash:      r.Hash,
+	}
+}
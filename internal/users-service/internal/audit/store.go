@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/your-org/users-service/domain"
+	"gorm.io/gorm"
+)
+
+// verifyBatchSize — сколько записей читается за раз при проверке цепочки
+const verifyBatchSize = 1000
+
+// Store читает и проверяет журнал аудита
+type Store struct {
+	db *gorm.DB
+}
+
+func NewStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// List возвращает до limit записей, подходящих под filter, с id больше afterID, от старых к новым
+func (s *Store) List(filter domain.AuditFilter, afterID uint64, limit int) ([]*domain.AuditEntry, error) {
+	q := s.db.Where("id > ?", afterID)
+	if filter.UserID != 0 {
+		q = q.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Action != "" {
+		q = q.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		q = q.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		q = q.Where("created_at < ?", *filter.To)
+	}
+
+	var rows []Record
+	if err := q.Order("id").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("audit.List: %w", err)
+	}
+
+	out := make([]*domain.AuditEntry, len(rows))
+	for i := range rows {
+		out[i] = rows[i].toDomain()
+	}
+
+	return out, nil
+}
+
+// Verify проходит журнал от первой записи и пересчитывает цепочку хешей.
+// Возвращает число проверенных записей и id первой записи, на которой цепочка
+// нарушена (0 — нарушений нет).
+func (s *Store) Verify() (uint64, uint64, error) {
+	var checked, after uint64
+	prev := genesisHash
+
+	for {
+		var rows []Record
+		if err := s.db.Where("id > ?", after).Order("id").Limit(verifyBatchSize).Find(&rows).Error; err != nil {
+			return checked, 0, fmt.Errorf("audit.Verify: %w", err)
+		}
+
+		var broken uint64
+		var n int
+		prev, n, broken = checkChain(prev, rows)
+		checked += uint64(n)
+		if broken != 0 {
+			return checked, broken, nil
+		}
+
+		if len(rows) < verifyBatchSize {
+			return checked, 0, nil
+		}
+		after = rows[len(rows)-1].ID
+	}
+}
+
+// checkChain проверяет, что rows продолжают цепочку после записи с hash prev.
+// Возвращает hash последней верной записи, число верных записей и id первой
+// записи, на которой цепочка нарушена (0 — нарушений нет).
+func checkChain(prev string, rows []Record) (string, int, uint64) {
+	for i := range rows {
+		r := &rows[i]
+		if r.PrevHash != prev || hashRecord(r) != r.Hash {
+			return prev, i, r.ID
+		}
+		prev = r.Hash
+	}
+	return prev, len(rows), 0
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/your-org/users-service/domain"
+)
+
+// Типы доменных событий сервиса пользователей
+const (
+	TypeUserCreated = "user.created"
+	TypeUserUpdated = "user.updated"
+	TypeUserDeleted = "user.deleted"
+	// TypeUserRestored — пользователь восстановлен из корзины; tasks-service возвращает
+	// из своей корзины задачи, удаленные вместе с ним
+	TypeUserRestored = "user.restored"
+)
+
+// Event описывает доменное событие, произошедшее с пользователем
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	UserID     uint32          `json:"user_id"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// userPayload — данные пользователя в событии; пароль в события не попадает
+type userPayload struct {
+	Email string `json:"email"`
+}
+
+// NewUserCreated создает событие создания пользователя
+func NewUserCreated(u *domain.User) Event {
+	return newEvent(TypeUserCreated, u.ID, userPayload{Email: u.Email})
+}
+
+// NewUserUpdated создает событие изменения пользователя
+func NewUserUpdated(u *domain.User) Event {
+	return newEvent(TypeUserUpdated, u.ID, userPayload{Email: u.Email})
+}
+
+// NewUserDeleted создает событие удаления пользователя
+func NewUserDeleted(userID uint32) Event {
+	return newEvent(TypeUserDeleted, userID, nil)
+}
+
+// NewUserRestored создает событие восстановления пользователя
+func NewUserRestored(u *domain.User) Event {
+	return newEvent(TypeUserRestored, u.ID, userPayload{Email: u.Email})
+}
+
+func newEvent(eventType string, userID uint32, payload any) Event {
+	e := Event{
+		ID:         newID(),
+		Type:       eventType,
+		UserID:     userID,
+		OccurredAt: time.Now().UTC(),
+	}
+	if payload != nil {
+		// payload — простые структуры, ошибки маршалинга быть не может
+		e.Payload, _ = json.Marshal(payload)
+	}
+
+	return e
+}
+
+// newID генерирует случайный идентификатор события, по нему потребители отсекают дубли
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// DefaultChannel — канал Postgres NOTIFY для событий пользователей
+const DefaultChannel = "user_events"
+
+// PgNotify публикует события через pg_notify. Доставка гарантируется только
+// подключенным в этот момент слушателям, гарантии at-least-once дает outbox.
+type PgNotify struct {
+	db      *gorm.DB
+	channel string
+}
+
+func NewPgNotify(db *gorm.DB, channel string) *PgNotify {
+	return &PgNotify{db: db, channel: channel}
+}
+
+func (p *PgNotify) Publish(ctx context.Context, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("PgNotify.Publish: %w", err)
+	}
+
+	if err := p.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", p.channel, string(payload)).Error; err != nil {
+		return fmt.Errorf("PgNotify.Publish: %w", err)
+	}
+
+	return nil
+}
+
+// Listener слушает канал через LISTEN и передает события в handler.
+// При обрыве соединения переподключается.
+type Listener struct {
+	dsn     string
+	channel string
+	handler Handler
+}
+
+func NewListener(dsn string, channel string, handler Handler) *Listener {
+	return &Listener{dsn: dsn, channel: channel, handler: handler}
+}
+
+// Run слушает канал до отмены ctx
+func (l *Listener) Run(ctx context.Context) {
+	for {
+		if err := l.listen(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("events listener on %q: %v", l.channel, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+
+		var e Event
+		if err := json.Unmarshal([]byte(n.Payload), &e); err != nil {
+			log.Printf("events listener on %q: skip malformed payload: %v", l.channel, err)
+			continue
+		}
+
+		if err := l.handler(ctx, e); err != nil {
+			log.Printf("events listener on %q: handle %s: %v", l.channel, e.ID, err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Publisher доставляет доменные события потребителям
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// Handler обрабатывает доставленное событие
+type Handler func(ctx context.Context, e Event) error
+
+// InProcess доставляет события подписчикам внутри процесса
+type InProcess struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewInProcess() *InProcess {
+	return &InProcess{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe подписывает h на события типа eventType
+func (b *InProcess) Subscribe(eventType string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], h)
+}
+
+// Publish синхронно вызывает всех подписчиков. Если хотя бы один вернул ошибку,
+// событие будет доставлено повторно всем, поэтому обработчики должны быть идемпотентны.
+func (b *InProcess) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[e.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("InProcess.Publish %s: %w", e.ID, err)
+	}
+
+	return nil
+}
+
+// Fanout публикует событие во все publishers по очереди
+type Fanout []Publisher
+
+func (f Fanout) Publish(ctx context.Context, e Event) error {
+	for _, p := range f {
+		if err := p.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
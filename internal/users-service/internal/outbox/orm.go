@@ -0,0 +1,54 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/your-org/users-service/internal/events"
+)
+
+// Event — строка таблицы outbox_events
+type Event struct {
+	ID          uint64 `gorm:"primaryKey"`
+	EventID     string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	AggregateID uint32 `gorm:"not null"`
+	Type        string `gorm:"type:varchar(64);not null"`
+	Payload     []byte `gorm:"type:jsonb;not null;default:'{}'"`
+	OccurredAt  time.Time
+	PublishedAt *time.Time
+	// LeaseID и LeasedUntil — аренда relay, который сейчас публикует событие
+	LeaseID     *string `gorm:"type:varchar(32)"`
+	LeasedUntil *time.Time
+}
+
+func (Event) TableName() string {
+	return "outbox_events"
+}
+
+func (r *Event) toEvent() events.Event {
+	e := events.Event{
+		ID:         r.EventID,
+		Type:       r.Type,
+		UserID:     r.AggregateID,
+		OccurredAt: r.OccurredAt,
+	}
+	if len(r.Payload) > 0 && string(r.Payload) != "{}" {
+		e.Payload = r.Payload
+	}
+
+	return e
+}
+
+func fromEvent(e events.Event) *Event {
+	payload := []byte(e.Payload)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+
+	return &Event{
+		EventID:     e.ID,
+		AggregateID: e.UserID,
+		Type:        e.Type,
+		Payload:     payload,
+		OccurredAt:  e.OccurredAt,
+	}
+}
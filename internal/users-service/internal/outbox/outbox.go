@@ -0,0 +1,18 @@
+package outbox
+
+import (
+	"fmt"
+
+	"github.com/your-org/users-service/internal/events"
+	"gorm.io/gorm"
+)
+
+// Append записывает событие в outbox в рамках транзакции tx.
+// Событие будет опубликовано только если транзакция зафиксируется.
+func Append(tx *gorm.DB, e events.Event) error {
+	if err := tx.Create(fromEvent(e)).Error; err != nil {
+		return fmt.Errorf("outbox.Append %s: %w", e.Type, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/your-org/users-service/internal/events"
+	"gorm.io/gorm"
+)
+
+// relayLockKey — ключ advisory lock: события забирает в аренду один relay за раз,
+// иначе две реплики могли бы взять события одного пользователя и нарушить их порядок
+const relayLockKey = 27_001
+
+// Relay периодически публикует неопубликованные события из outbox.
+// Доставка at-least-once: событие помечается опубликованным только после
+// успешного Publish, при ошибке оно и все следующие события того же
+// пользователя откладываются до следующего прохода.
+//
+// События забираются в аренду короткой транзакцией и публикуются вне её,
+// поэтому медленный подписчик не держит транзакцию и блокировки строк.
+// Если relay упал, не успев отпустить аренду, события вернутся после её истечения.
+type Relay struct {
+	db        *gorm.DB
+	publisher events.Publisher
+	interval  time.Duration
+	lease     time.Duration
+	batchSize int
+}
+
+// NewRelay создает relay. lease — срок аренды пачки: что не опубликовано за это время,
+// откладывается до следующего прохода.
+func NewRelay(db *gorm.DB, publisher events.Publisher, interval, lease time.Duration, batchSize int) *Relay {
+	return &Relay{db: db, publisher: publisher, interval: interval, lease: lease, batchSize: batchSize}
+}
+
+// Run публикует события до отмены ctx
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.publishBatch(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("outbox relay: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) publishBatch(ctx context.Context) error {
+	leaseID := newLeaseID()
+	until := time.Now().Add(r.lease)
+
+	pending, err := r.claim(ctx, leaseID, until)
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+
+	// отметки пишутся и при остановке сервиса: опубликованное не должно уйти повторно
+	// из-за отмены ctx
+	defer func() {
+		if err := r.release(context.WithoutCancel(ctx), leaseID); err != nil {
+			log.Printf("outbox relay: %v", err)
+		}
+	}()
+
+	// после истечения аренды события может забрать другая реплика
+	publishCtx, cancel := context.WithDeadline(ctx, until)
+	defer cancel()
+
+	published := publishInOrder(publishCtx, r.publisher, pending)
+	if len(published) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(context.WithoutCancel(ctx)).Model(&Event{}).
+		Where("id IN ?", published).
+		Updates(map[string]any{"published_at": time.Now(), "lease_id": nil, "leased_until": nil}).Error; err != nil {
+		return fmt.Errorf("failed to mark events published: %w", err)
+	}
+
+	return nil
+}
+
+// publishInOrder публикует события pending по порядку и возвращает id опубликованных.
+// После ошибки следующие события того же пользователя пропускаются, чтобы подписчики
+// не получили их раньше неудавшегося.
+func publishInOrder(ctx context.Context, publisher events.Publisher, pending []Event) []uint64 {
+	blocked := make(map[uint32]bool)
+	published := make([]uint64, 0, len(pending))
+	for i := range pending {
+		row := &pending[i]
+		if ctx.Err() != nil {
+			break
+		}
+		if blocked[row.AggregateID] {
+			continue
+		}
+
+		if err := publisher.Publish(ctx, row.toEvent()); err != nil {
+			log.Printf("outbox relay: publish %s (%s): %v", row.EventID, row.Type, err)
+			blocked[row.AggregateID] = true
+			continue
+		}
+		published = append(published, row.ID)
+	}
+
+	return published
+}
+
+// claim забирает в аренду leaseID до until до batchSize неопубликованных событий по порядку.
+// События пользователей, у которых есть события в чужой действующей аренде, пропускаются.
+func (r *Relay) claim(ctx context.Context, leaseID string, until time.Time) ([]Event, error) {
+	var pending []Event
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", relayLockKey).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to take relay lock: %w", err)
+		}
+		if !locked {
+			return nil
+		}
+
+		now := time.Now()
+		leased := tx.Model(&Event{}).
+			Select("aggregate_id").
+			Where("published_at IS NULL AND leased_until > ?", now)
+		if err := tx.Where("published_at IS NULL").
+			Where("aggregate_id NOT IN (?)", leased).
+			Order("id").
+			Limit(r.batchSize).
+			Find(&pending).Error; err != nil {
+			return fmt.Errorf("failed to load pending events: %w", err)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(pending))
+		for i := range pending {
+			ids[i] = pending[i].ID
+		}
+		if err := tx.Model(&Event{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{"lease_id": leaseID, "leased_until": until}).Error; err != nil {
+			return fmt.Errorf("failed to lease events: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// release отпускает аренду leaseID: неопубликованные события достанутся следующему проходу
+func (r *Relay) release(ctx context.Context, leaseID string) error {
+	if err := r.db.WithContext(ctx).Model(&Event{}).
+		Where("lease_id = ? AND published_at IS NULL", leaseID).
+		Updates(map[string]any{"lease_id": nil, "leased_until": nil}).Error; err != nil {
+		return fmt.Errorf("failed to release leased events: %w", err)
+	}
+	return nil
+}
+
+func newLeaseID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/your-org/users-service/internal/events"
+)
+
+// fakePublisher запоминает опубликованные события и отказывает на событиях из fail
+type fakePublisher struct {
+	fail      map[string]bool
+	cancel    func()
+	cancelOn  string
+	published []string
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, e events.Event) error {
+	if p.fail[e.ID] {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, e.ID)
+	if e.ID == p.cancelOn {
+		p.cancel()
+	}
+	return nil
+}
+
+func TestPublishInOrder(t *testing.T) {
+	// id события совпадает с порядком в outbox, AggregateID — пользователь
+	pending := []Event{
+		{ID: 1, EventID: "a1", AggregateID: 1},
+		{ID: 2, EventID: "b1", AggregateID: 2},
+		{ID: 3, EventID: "a2", AggregateID: 1},
+		{ID: 4, EventID: "c1", AggregateID: 3},
+		{ID: 5, EventID: "b2", AggregateID: 2},
+		{ID: 6, EventID: "a3", AggregateID: 1},
+	}
+
+	tests := []struct {
+		name          string
+		fail          []string
+		cancelOn      string
+		wantPublished []string
+		wantIDs       []uint64
+	}{
+		{
+			name:          "all published",
+			wantPublished: []string{"a1", "b1", "a2", "c1", "b2", "a3"},
+			wantIDs:       []uint64{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:          "failure blocks the rest of the user",
+			fail:          []string{"a2"},
+			wantPublished: []string{"a1", "b1", "c1", "b2"},
+			wantIDs:       []uint64{1, 2, 4, 5},
+		},
+		{
+			name:          "first event of a user fails",
+			fail:          []string{"b1"},
+			wantPublished: []string{"a1", "a2", "c1", "a3"},
+			wantIDs:       []uint64{1, 3, 4, 6},
+		},
+		{
+			name:          "lease expired",
+			cancelOn:      "a2",
+			wantPublished: []string{"a1", "b1", "a2"},
+			wantIDs:       []uint64{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			p := &fakePublisher{fail: make(map[string]bool), cancel: cancel, cancelOn: tt.cancelOn}
+			for _, id := range tt.fail {
+				p.fail[id] = true
+			}
+
+			ids := publishInOrder(ctx, p, pending)
+			if !slices.Equal(p.published, tt.wantPublished) {
+				t.Errorf("published %v, want %v", p.published, tt.wantPublished)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("publishInOrder() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
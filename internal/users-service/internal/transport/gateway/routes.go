@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"net/netip"
+
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/blastuha/test-service-proto/openapi"
+	"github.com/your-org/servicekit/restgateway"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewServer создает REST-шлюз на адресе host:port к UserService по адресу grpcAddr.
+// Маршруты заданы аннотациями google.api.http в user.proto.
+// X-Forwarded-For принимается только от прокси из сетей trustedProxies.
+func NewServer(host string, port int, grpcAddr string, trustedProxies []netip.Prefix) (*restgateway.Server, error) {
+	return restgateway.NewServer(restgateway.Config{
+		Host:           host,
+		Port:           port,
+		GRPCAddr:       grpcAddr,
+		Service:        protoreflect.FullName(userpb.UserService_ServiceDesc.ServiceName),
+		Register:       userpb.RegisterUserServiceHandler,
+		Streams:        streams,
+		OpenAPI:        openapi.UserService,
+		Headers:        forwardedHeaders,
+		TrustedProxies: trustedProxies,
+	})
+}
+
+// forwardedHeaders — заголовки HTTP, которые передаются сервису метаданными gRPC
+var forwardedHeaders = []string{"x-actor-id", "x-admin-token", "idempotency-key"}
+
+// streams — маршруты методов, которые отдают файл потоком байт
+var streams = []restgateway.StreamRoute{
+	{Method: "GET", Pattern: "/v1/admin/users/{id}/export", RPC: "ExportUserData", Download: "application/zip"},
+}
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/your-org/users-service/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// actorHeader — заголовок с id пользователя, от имени которого выполняется запрос
+	actorHeader = "x-actor-id"
+	// forwardedForHeader — адрес клиента HTTP-шлюза; учитывается только от локального шлюза.
+	// Шлюзы передают один адрес, уже проверенный по своим доверенным прокси; если адресов
+	// несколько, берется последний — его добавил ближайший к сервису шлюз
+	forwardedForHeader = "x-forwarded-for"
+	// maxSourceLen — длина колонки audit_log.source
+	maxSourceLen = 64
+)
+
+type actorKey struct{}
+
+// NewActorInterceptor возвращает interceptor, который кладет в контекст автора запроса:
+// id из заголовка x-actor-id и адрес клиента. Они попадают в журнал аудита.
+func NewActorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var actor domain.Actor
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			actor.Source = p.Addr.String()
+			if forwarded := metadata.ValueFromIncomingContext(ctx, forwardedForHeader); len(forwarded) > 0 && isLoopback(p.Addr) {
+				hops := strings.Split(forwarded[len(forwarded)-1], ",")
+				actor.Source = strings.TrimSpace(hops[len(hops)-1])
+			}
+			if len(actor.Source) > maxSourceLen {
+				actor.Source = actor.Source[:maxSourceLen]
+			}
+		}
+
+		if values := metadata.ValueFromIncomingContext(ctx, actorHeader); len(values) > 0 && values[0] != "" {
+			id, err := strconv.ParseUint(values[0], 10, 32)
+			if err != nil || id == 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "%s must be a positive user id", actorHeader)
+			}
+			actor.UserID = uint32(id)
+		}
+
+		return handler(context.WithValue(ctx, actorKey{}, actor), req)
+	}
+}
+
+// isLoopback сообщает, что запрос пришел с того же хоста, то есть от HTTP-шлюза
+func isLoopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
+
+// actorFromContext возвращает автора запроса, нулевой — не задан
+func actorFromContext(ctx context.Context) domain.Actor {
+	actor, _ := ctx.Value(actorKey{}).(domain.Actor)
+	return actor
+}
@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+	"time"
+
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/users-service/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// adminTokenHeader — заголовок с токеном администратора для методов аудита и корзины
+	adminTokenHeader = "x-admin-token"
+
+	defaultAuditPage = 100
+	maxAuditPage     = 1000
+)
+
+// requireAdmin пропускает запрос только с верным токеном администратора.
+// Без настроенного токена методы аудита и корзины недоступны.
+func (h *Handler) requireAdmin(ctx context.Context) error {
+	values := metadata.ValueFromIncomingContext(ctx, adminTokenHeader)
+	if h.adminToken == "" || len(values) == 0 ||
+		subtle.ConstantTimeCompare([]byte(values[0]), []byte(h.adminToken)) != 1 {
+		return status.Error(codes.PermissionDenied, "method is available to administrators only")
+	}
+	return nil
+}
+
+// ListAuditLog возвращает журнал аудита постранично, от старых записей к новым
+func (h *Handler) ListAuditLog(ctx context.Context, req *userpb.ListAuditLogRequest) (*userpb.ListAuditLogResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filter := domain.AuditFilter{UserID: req.GetUserId(), Action: req.GetAction()}
+	if req.GetFrom() != nil {
+		from, err := auditTime(req.GetFrom(), "from")
+		if err != nil {
+			return nil, handleValidationError(err)
+		}
+		filter.From = &from
+	}
+	if req.GetTo() != nil {
+		to, err := auditTime(req.GetTo(), "to")
+		if err != nil {
+			return nil, handleValidationError(err)
+		}
+		filter.To = &to
+	}
+
+	var after uint64
+	if token := req.GetPageToken(); token != "" {
+		v, err := strconv.ParseUint(token, 10, 64)
+		if err != nil {
+			return nil, handleValidationError(ValidationError{Field: "page_token", Message: "malformed page token"})
+		}
+		after = v
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultAuditPage
+	}
+	if pageSize > maxAuditPage {
+		pageSize = maxAuditPage
+	}
+
+	// берем на одну больше, чтобы знать, есть ли следующая страница
+	entries, err := h.audit.List(filter, after, pageSize+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get audit log: %v", err)
+	}
+
+	response := &userpb.ListAuditLogResponse{}
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		response.NextPageToken = strconv.FormatUint(entries[pageSize-1].ID, 10)
+	}
+
+	response.Entries = make([]*userpb.AuditEntry, len(entries))
+	for i, e := range entries {
+		response.Entries[i] = &userpb.AuditEntry{
+			Id:        e.ID,
+			UserId:    e.UserID,
+			ActorId:   e.Actor.UserID,
+			Source:    e.Actor.Source,
+			Action:    e.Action,
+			Details:   e.Details,
+			CreatedAt: timestamppb.New(e.CreatedAt),
+			PrevHash:  e.PrevHash,
+			Hash:      e.Hash,
+		}
+	}
+
+	return response, nil
+}
+
+// VerifyAuditLog пересчитывает цепочку хешей журнала аудита
+func (h *Handler) VerifyAuditLog(ctx context.Context, req *userpb.VerifyAuditLogRequest) (*userpb.VerifyAuditLogResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	checked, brokenID, err := h.audit.Verify()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify audit log: %v", err)
+	}
+
+	return &userpb.VerifyAuditLogResponse{Valid: brokenID == 0, BrokenId: brokenID, Checked: checked}, nil
+}
+
+// auditTime проверяет границу интервала выборки журнала
+func auditTime(ts *timestamppb.Timestamp, field string) (time.Time, error) {
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, ValidationError{Field: field, Message: fmt.Sprintf("invalid timestamp: %v", err)}
+	}
+	return ts.AsTime(), nil
+}
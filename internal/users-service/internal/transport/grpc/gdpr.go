@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/users-service/domain"
+	"github.com/your-org/users-service/internal/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// exportChunkSize — размер куска архива в одном сообщении ExportUserData
+const exportChunkSize = 64 << 10
+
+var erasureStatuses = map[domain.ErasureStatus]userpb.ErasureStatus{
+	domain.ErasurePending:   userpb.ErasureStatus_ERASURE_STATUS_PENDING,
+	domain.ErasureCompleted: userpb.ErasureStatus_ERASURE_STATUS_COMPLETED,
+}
+
+// archiveWriter отправляет записанные байты сообщениями ExportUserData
+type archiveWriter struct {
+	stream userpb.UserService_ExportUserDataServer
+}
+
+func (w *archiveWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&userpb.ExportUserDataResponse{Chunk: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ExportUserData отдает zip-архив с данными пользователя кусками
+func (h *Handler) ExportUserData(req *userpb.ExportUserDataRequest, stream userpb.UserService_ExportUserDataServer) error {
+	if err := h.requireAdmin(stream.Context()); err != nil {
+		return err
+	}
+	if err := validateUserID(req.Id); err != nil {
+		return handleValidationError(err)
+	}
+
+	w := bufio.NewWriterSize(&archiveWriter{stream: stream}, exportChunkSize)
+	if err := h.gdpr.ExportUserData(stream.Context(), req.Id, w); err != nil {
+		if errors.Is(err, user.ErrUserNoFound) {
+			return status.Error(codes.NotFound, "user not found")
+		}
+		return status.Errorf(codes.Internal, "failed to export user data: %v", err)
+	}
+
+	return w.Flush()
+}
+
+// EraseUser удаляет пользователя и его данные в обоих сервисах
+func (h *Handler) EraseUser(ctx context.Context, req *userpb.EraseUserRequest) (*userpb.UserErasure, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateUserID(req.Id); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	erasure, err := h.gdpr.WithActor(actorFromContext(ctx)).EraseUser(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNoFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to erase user: %v", err)
+	}
+
+	return toPBErasure(erasure), nil
+}
+
+// GetUserErasure возвращает запись об удалении данных пользователя
+func (h *Handler) GetUserErasure(ctx context.Context, req *userpb.GetUserErasureRequest) (*userpb.UserErasure, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateUserID(req.UserId); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	erasure, err := h.gdpr.GetErasure(req.UserId)
+	if err != nil {
+		if errors.Is(err, user.ErrErasureNotFound) {
+			return nil, status.Error(codes.NotFound, "user erasure not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user erasure: %v", err)
+	}
+
+	return toPBErasure(erasure), nil
+}
+
+func toPBErasure(e *domain.Erasure) *userpb.UserErasure {
+	pb := &userpb.UserErasure{
+		Id:          e.ID,
+		UserId:      e.UserID,
+		Status:      erasureStatuses[e.Status],
+		RequestedAt: timestamppb.New(e.RequestedAt),
+		Attempts:    uint32(e.Attempts),
+		LastError:   e.LastError,
+		Tasks:       uint32(e.Erased.Tasks),
+		Comments:    uint32(e.Erased.Comments),
+		Projects:    uint32(e.Erased.Projects),
+		Tags:        uint32(e.Erased.Tags),
+		Attachments: uint32(e.Erased.Attachments),
+	}
+	if e.CompletedAt != nil {
+		pb.CompletedAt = timestamppb.New(*e.CompletedAt)
+	}
+	return pb
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/users-service/internal/audit"
 	"github.com/your-org/users-service/internal/user"
 
 	"google.golang.org/grpc/codes"
@@ -11,12 +12,21 @@ import (
 )
 
 type Handler struct {
-	svc user.UsersService
+	svc   user.UsersService
+	gdpr  user.GDPRService
+	audit *audit.Store
+	// adminToken открывает методы журнала аудита, корзины и GDPR, пустой — методы недоступны
+	adminToken string
 	userpb.UnimplementedUserServiceServer
 }
 
-func NewHandler(svc user.UsersService) *Handler {
-	return &Handler{svc: svc}
+func NewHandler(svc user.UsersService, gdpr user.GDPRService, auditStore *audit.Store, adminToken string) *Handler {
+	return &Handler{svc: svc, gdpr: gdpr, audit: auditStore, adminToken: adminToken}
+}
+
+// usersFor возвращает сервис, который пишет журнал аудита от имени автора запроса
+func (h *Handler) usersFor(ctx context.Context) user.UsersService {
+	return h.svc.WithActor(actorFromContext(ctx))
 }
 
 // CreateUser создает нового пользователя
@@ -27,7 +37,7 @@ func (h *Handler) CreateUser(ctx context.Context, req *userpb.CreateUserRequest)
 	}
 
 	// Создаем пользователя через сервис
-	createdUser, err := h.svc.CreateUser(req.Email, req.Password)
+	createdUser, err := h.usersFor(ctx).CreateUser(req.Email, req.Password)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
 	}
@@ -35,9 +45,9 @@ func (h *Handler) CreateUser(ctx context.Context, req *userpb.CreateUserRequest)
 	// Конвертируем результат в gRPC ответ
 	response := &userpb.CreateUserResponse{
 		User: &userpb.User{
-			Id:       uint32(createdUser.ID),
-			Email:    createdUser.Email,
-			Password: createdUser.Password,
+			Id:    uint32(createdUser.ID),
+			Email: createdUser.Email,
+			Etag:  formatETag(createdUser.Version),
 		},
 	}
 
@@ -61,9 +71,9 @@ func (h *Handler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*use
 
 	// Конвертируем результат в gRPC ответ
 	response := &userpb.User{
-		Id:       uint32(userObj.ID),
-		Email:    userObj.Email,
-		Password: userObj.Password,
+		Id:    uint32(userObj.ID),
+		Email: userObj.Email,
+		Etag:  formatETag(userObj.Version),
 	}
 
 	return response, nil
@@ -71,24 +81,37 @@ func (h *Handler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*use
 
 // UpdateUser обновляет пользователя
 func (h *Handler) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest) (*userpb.User, error) {
-	if err := validateUpdateUserRequest(req.Id, req.Email, req.Password); err != nil {
+	upd, err := validateUpdateUserRequest(req)
+	if err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	// etag обязателен: без него нельзя понять, не изменил ли пользователя кто-то другой
+	if req.GetEtag() == "" {
+		return nil, status.Error(codes.FailedPrecondition, "etag is required, read the user first")
+	}
+	version, err := parseETag(req.GetEtag())
+	if err != nil {
 		return nil, handleValidationError(err)
 	}
 
 	// Обновляем пользователя через сервис
-	updatedUser, err := h.svc.UpdateUser(req.Id, req.Email, req.Password)
+	updatedUser, err := h.usersFor(ctx).UpdateUser(req.Id, version, upd)
 	if err != nil {
 		if err == user.ErrUserNoFound {
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
+		if err == user.ErrVersionConflict {
+			return nil, status.Errorf(codes.Aborted, "user was modified concurrently, re-read it and retry")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
 	}
 
 	// Конвертируем результат в gRPC ответ
 	response := &userpb.User{
-		Id:       uint32(updatedUser.ID),
-		Email:    updatedUser.Email,
-		Password: updatedUser.Password,
+		Id:    uint32(updatedUser.ID),
+		Email: updatedUser.Email,
+		Etag:  formatETag(updatedUser.Version),
 	}
 
 	return response, nil
@@ -109,9 +132,9 @@ func (h *Handler) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (
 
 	for i, u := range users {
 		response.Users[i] = &userpb.User{
-			Id:       uint32(u.ID),
-			Email:    u.Email,
-			Password: u.Password,
+			Id:    uint32(u.ID),
+			Email: u.Email,
+			Etag:  formatETag(u.Version),
 		}
 	}
 
@@ -125,7 +148,7 @@ func (h *Handler) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest)
 	}
 
 	// Удаляем пользователя через сервис
-	err := h.svc.DeleteUser(req.Id)
+	err := h.usersFor(ctx).DeleteUser(req.Id)
 	if err != nil {
 		if err == user.ErrUserNoFound {
 			return nil, status.Errorf(codes.NotFound, "user not found")
@@ -140,3 +163,24 @@ func (h *Handler) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest)
 
 	return response, nil
 }
+
+// Authenticate проверяет email и пароль пользователя
+func (h *Handler) Authenticate(ctx context.Context, req *userpb.AuthenticateRequest) (*userpb.User, error) {
+	if req.GetEmail() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and password are required")
+	}
+
+	authenticated, err := h.usersFor(ctx).Authenticate(req.GetEmail(), req.GetPassword())
+	if err != nil {
+		if err == user.ErrInvalidCredentials {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to authenticate: %v", err)
+	}
+
+	return &userpb.User{
+		Id:    authenticated.ID,
+		Email: authenticated.Email,
+		Etag:  formatETag(authenticated.Version),
+	}, nil
+}
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/servicekit/idempotency"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// idempotentMethods — методы, которые учитывают idempotency-key. CreateUser вызывается
+// анонимно, поэтому его ключи общие: сохраненный ответ получит только запрос с теми же
+// email и паролем.
+var idempotentMethods = map[string]idempotency.Method{
+	userpb.UserService_CreateUser_FullMethodName: {
+		NewResponse: func() proto.Message { return &userpb.CreateUserResponse{} },
+		Owners: func(resp proto.Message) []uint32 {
+			return []uint32{resp.(*userpb.CreateUserResponse).GetUser().GetId()}
+		},
+	},
+}
+
+// NewIdempotencyInterceptor возвращает interceptor, который для запросов с заголовком
+// idempotency-key возвращает сохраненный ответ при повторе и FailedPrecondition,
+// если тот же ключ пришел с другим телом запроса.
+func NewIdempotencyInterceptor(store idempotency.Store) grpc.UnaryServerInterceptor {
+	return idempotency.NewInterceptor(store, idempotentMethods)
+}
@@ -3,32 +3,37 @@ package grpc
 import (
 	"fmt"
 	"net"
+	"strconv"
 
 	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/users-service/internal/audit"
 	"github.com/your-org/users-service/internal/user"
 	"google.golang.org/grpc"
 )
 
 type Server struct {
 	server *grpc.Server
+	host   string
 	port   int
 }
 
-func NewServer(port int) *Server {
+// NewServer создает сервер пользователей на адресе host:port
+func NewServer(host string, port int, interceptors ...grpc.UnaryServerInterceptor) *Server {
 	return &Server{
-		server: grpc.NewServer(),
+		server: grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...)),
+		host:   host,
 		port:   port,
 	}
 }
 
-func (s *Server) RegisterServices(userService user.UsersService) {
+func (s *Server) RegisterServices(userService user.UsersService, gdprService user.GDPRService, auditStore *audit.Store, adminToken string) {
 	// Регистрируем gRPC обработчики
-	userHandler := NewHandler(userService)
+	userHandler := NewHandler(userService, gdprService, auditStore, adminToken)
 	userpb.RegisterUserServiceServer(s.server, userHandler)
 }
 
 func (s *Server) Start() error {
-	ls, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	ls, err := net.Listen("tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)))
 	fmt.Println("gRPC users server is running on port", s.port)
 
 	if err != nil {
@@ -0,0 +1,163 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	taskspb "github.com/blastuha/test-service-proto/gen/task"
+	"github.com/your-org/users-service/domain"
+	"github.com/your-org/users-service/internal/events"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var defaultTimeout = 5 * time.Second
+
+// выгрузка и удаление данных пользователя затрагивают все его записи и файлы
+var (
+	exportTimeout = time.Minute
+	eraseTimeout  = 30 * time.Second
+)
+
+// TasksClient определяет интерфейс клиента сервиса задач.
+type TasksClient interface {
+	OnUserDeleted(ctx context.Context, eventID string, userID uint32) error
+	OnUserRestored(ctx context.Context, eventID string, userID uint32) error
+	// ExportUserData пишет данные пользователя в w, по одному объекту JSON в строке
+	ExportUserData(ctx context.Context, userID uint32, w io.Writer) error
+	EraseUserData(ctx context.Context, userID uint32) (domain.ErasedTasksData, error)
+}
+
+// serviceTokenHeader — заголовок с общим токеном сервисов
+const serviceTokenHeader = "x-service-token"
+
+// serviceCredentials передает токен сервисов в метаданных каждого вызова
+type serviceCredentials string
+
+func (c serviceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{serviceTokenHeader: string(c)}, nil
+}
+
+// RequireTransportSecurity — сервисы общаются внутри частной сети без TLS
+func (c serviceCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+type tasksClient struct {
+	raw taskspb.TasksServiceClient
+}
+
+// NewTasksClient создает клиент сервиса задач. Каждый вызов предъявляет общий
+// токен сервисов serviceToken: без x-actor-id tasks-service принимает только его.
+// Возвращает клиент, функцию для закрытия соединения и ошибку.
+func NewTasksClient(ctx context.Context, addr, serviceToken string) (TasksClient, func(), error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(serviceCredentials(serviceToken)),
+	}
+
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to tasks service: %w", err)
+	}
+
+	cleanup := func() {
+		conn.Close()
+	}
+
+	return &tasksClient{raw: taskspb.NewTasksServiceClient(conn)}, cleanup, nil
+}
+
+// OnUserDeleted сообщает сервису задач об удалении пользователя.
+func (c *tasksClient) OnUserDeleted(ctx context.Context, eventID string, userID uint32) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	_, err := c.raw.OnUserDeleted(ctx, &taskspb.UserDeletedEvent{EventId: eventID, UserId: userID})
+	if err != nil {
+		return fmt.Errorf("OnUserDeleted: %w", err)
+	}
+
+	return nil
+}
+
+// OnUserRestored сообщает сервису задач о восстановлении пользователя из корзины.
+func (c *tasksClient) OnUserRestored(ctx context.Context, eventID string, userID uint32) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	_, err := c.raw.OnUserRestored(ctx, &taskspb.UserRestoredEvent{EventId: eventID, UserId: userID})
+	if err != nil {
+		return fmt.Errorf("OnUserRestored: %w", err)
+	}
+
+	return nil
+}
+
+// ExportUserData читает данные пользователя из tasks-service и пишет их в w строками JSON
+func (c *tasksClient) ExportUserData(ctx context.Context, userID uint32, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	stream, err := c.raw.ExportUserData(ctx, &taskspb.ExportUserDataRequest{UserId: userID})
+	if err != nil {
+		return fmt.Errorf("ExportUserData: %w", err)
+	}
+
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("ExportUserData: %w", err)
+		}
+
+		line, err := protojson.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("ExportUserData: %w", err)
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("ExportUserData: %w", err)
+		}
+	}
+}
+
+// EraseUserData окончательно удаляет данные пользователя в tasks-service
+func (c *tasksClient) EraseUserData(ctx context.Context, userID uint32) (domain.ErasedTasksData, error) {
+	ctx, cancel := context.WithTimeout(ctx, eraseTimeout)
+	defer cancel()
+
+	resp, err := c.raw.EraseUserData(ctx, &taskspb.EraseUserDataRequest{UserId: userID})
+	if err != nil {
+		return domain.ErasedTasksData{}, fmt.Errorf("EraseUserData: %w", err)
+	}
+
+	return domain.ErasedTasksData{
+		Tasks:       int(resp.GetTasks()),
+		Comments:    int(resp.GetComments()),
+		Projects:    int(resp.GetProjects()),
+		Tags:        int(resp.GetTags()),
+		Attachments: int(resp.GetAttachments()),
+	}, nil
+}
+
+// NewUserDeletedHandler возвращает обработчик user.deleted, который передает событие
+// сервису задач. Сервис задач отсекает повторы по id события.
+func NewUserDeletedHandler(client TasksClient) events.Handler {
+	return func(ctx context.Context, e events.Event) error {
+		return client.OnUserDeleted(ctx, e.ID, e.UserID)
+	}
+}
+
+// NewUserRestoredHandler возвращает обработчик user.restored, который передает событие
+// сервису задач. Сервис задач отсекает повторы по id события.
+func NewUserRestoredHandler(client TasksClient) events.Handler {
+	return func(ctx context.Context, e events.Event) error {
+		return client.OnUserRestored(ctx, e.ID, e.UserID)
+	}
+}
@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/users-service/internal/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListDeletedUsers возвращает корзину пользователей постранично
+func (h *Handler) ListDeletedUsers(ctx context.Context, req *userpb.ListDeletedUsersRequest) (*userpb.ListDeletedUsersResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var after uint32
+	if token := req.GetPageToken(); token != "" {
+		v, err := strconv.ParseUint(token, 10, 32)
+		if err != nil {
+			return nil, handleValidationError(ValidationError{Field: "page_token", Message: "malformed page token"})
+		}
+		after = uint32(v)
+	}
+
+	deleted, next, err := h.svc.ListDeletedUsers(after, int(req.GetPageSize()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list deleted users: %v", err)
+	}
+
+	response := &userpb.ListDeletedUsersResponse{Users: make([]*userpb.DeletedUser, len(deleted))}
+	for i, d := range deleted {
+		response.Users[i] = &userpb.DeletedUser{
+			User: &userpb.User{
+				Id:    d.User.ID,
+				Email: d.User.Email,
+				Etag:  formatETag(d.User.Version),
+			},
+			DeletedAt: timestamppb.New(d.DeletedAt),
+		}
+	}
+	if next != 0 {
+		response.NextPageToken = strconv.FormatUint(uint64(next), 10)
+	}
+
+	return response, nil
+}
+
+// RestoreUser возвращает пользователя из корзины, при необходимости с новым email
+func (h *Handler) RestoreUser(ctx context.Context, req *userpb.RestoreUserRequest) (*userpb.User, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateUserID(req.Id); err != nil {
+		return nil, handleValidationError(err)
+	}
+	if req.Email != nil {
+		if err := validateEmail(req.GetEmail()); err != nil {
+			return nil, handleValidationError(err)
+		}
+	}
+
+	restored, err := h.usersFor(ctx).RestoreUser(req.Id, req.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrEmailTaken):
+			return nil, status.Error(codes.AlreadyExists, "email is already used by another user, restore with a new email")
+		default:
+			return nil, trashError(err, "failed to restore user")
+		}
+	}
+
+	return &userpb.User{
+		Id:    restored.ID,
+		Email: restored.Email,
+		Etag:  formatETag(restored.Version),
+	}, nil
+}
+
+// PurgeUser окончательно удаляет пользователя из корзины
+func (h *Handler) PurgeUser(ctx context.Context, req *userpb.PurgeUserRequest) (*userpb.PurgeUserResponse, error) {
+	if err := h.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateUserID(req.Id); err != nil {
+		return nil, handleValidationError(err)
+	}
+
+	if err := h.usersFor(ctx).PurgeUser(req.Id); err != nil {
+		return nil, trashError(err, "failed to purge user")
+	}
+
+	return &userpb.PurgeUserResponse{Success: true}, nil
+}
+
+func trashError(err error, msg string) error {
+	switch {
+	case errors.Is(err, user.ErrUserNoFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, user.ErrUserNotDeleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/users-service/domain"
+	"github.com/your-org/users-service/internal/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// restoreService восстанавливает пользователя 1, если его email свободен
+type restoreService struct {
+	user.UsersService
+	taken string
+}
+
+func (s *restoreService) WithActor(actor domain.Actor) user.UsersService { return s }
+
+func (s *restoreService) RestoreUser(id uint32, email *string) (*domain.User, error) {
+	switch {
+	case id != 1:
+		return nil, user.ErrUserNoFound
+	case email == nil || *email == s.taken:
+		return nil, fmt.Errorf("RestoreUser: %w", user.ErrEmailTaken)
+	}
+	return &domain.User{ID: id, Email: *email, Version: 2}, nil
+}
+
+func TestRestoreUser(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name      string
+		token     string
+		req       *userpb.RestoreUserRequest
+		wantCode  codes.Code
+		wantEmail string
+	}{
+		{name: "new email", token: "secret", req: &userpb.RestoreUserRequest{Id: 1, Email: str("new@example.com")},
+			wantEmail: "new@example.com"},
+		{name: "email taken", token: "secret", req: &userpb.RestoreUserRequest{Id: 1}, wantCode: codes.AlreadyExists},
+		{name: "new email taken", token: "secret", req: &userpb.RestoreUserRequest{Id: 1, Email: str("taken@example.com")},
+			wantCode: codes.AlreadyExists},
+		{name: "invalid email", token: "secret", req: &userpb.RestoreUserRequest{Id: 1, Email: str("not an email")},
+			wantCode: codes.InvalidArgument},
+		{name: "not in trash", token: "secret", req: &userpb.RestoreUserRequest{Id: 2}, wantCode: codes.NotFound},
+		{name: "not an admin", token: "guess", req: &userpb.RestoreUserRequest{Id: 1, Email: str("new@example.com")},
+			wantCode: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &restoreService{taken: "taken@example.com"}
+			h := &Handler{svc: svc, adminToken: "secret"}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(adminTokenHeader, tt.token))
+
+			restored, err := h.RestoreUser(ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("RestoreUser() = %v, want %v", err, tt.wantCode)
+			}
+			if err == nil && restored.Email != tt.wantEmail {
+				t.Errorf("email = %q, want %q", restored.Email, tt.wantEmail)
+			}
+		})
+	}
+}
@@ -3,11 +3,18 @@ package grpc
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"github.com/your-org/users-service/domain"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// maxPasswordBytes — предел длины пароля, дальше bcrypt байты не различает
+const maxPasswordBytes = 72
+
 // ValidationError представляет ошибку валидации
 type ValidationError struct {
 	Field   string
@@ -43,6 +50,10 @@ func validatePassword(password string) error {
 		return ValidationError{Field: "password", Message: "password must be at least 6 characters"}
 	}
 
+	if len(password) > maxPasswordBytes {
+		return ValidationError{Field: "password", Message: "password must be at most 72 bytes"}
+	}
+
 	return nil
 }
 
@@ -68,38 +79,70 @@ func validateCreateUserRequest(email, password string) error {
 	return nil
 }
 
-// validateUpdateUserRequest валидирует запрос обновления пользователя
-func validateUpdateUserRequest(id uint32, email, password *string) error {
-	if err := validateUserID(id); err != nil {
-		return err
-	}
+// Пути update_mask, которые поддерживает UpdateUser
+const (
+	pathEmail    = "email"
+	pathPassword = "password"
+)
 
-	// 1. В PATCH методе email/pass может быть опциональным, то есть не передан, то есть nil
-	// 2. Если передан, то он не может быть пустым
-	// 3. Если передан, то он должен быть валидным (проверка формата email)
-	// 4. Если передан, то он должен быть не меньше 6 символов (проверка длины пароля)
+// validateUpdateUserRequest валидирует запрос обновления пользователя и возвращает изменение.
+// Поля из update_mask должны быть переданы и валидны, остальные поля запроса игнорируются.
+// Без маски обновляются все переданные поля (для клиентов, не знающих про маски).
+func validateUpdateUserRequest(req *userpb.UpdateUserRequest) (domain.UserUpdate, error) {
+	if err := validateUserID(req.GetId()); err != nil {
+		return domain.UserUpdate{}, err
+	}
 
-	// Email и password опциональны при обновлении
-	// Если поле передано, оно должно быть не пустым и валидным
-	if email != nil {
-		if *email == "" {
-			return ValidationError{Field: "email", Message: "email cannot be empty if provided"}
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if req.Email != nil {
+			paths = append(paths, pathEmail)
 		}
-		if err := validateEmail(*email); err != nil {
-			return err
+		if req.Password != nil {
+			paths = append(paths, pathPassword)
 		}
 	}
 
-	if password != nil {
-		if *password == "" {
-			return ValidationError{Field: "password", Message: "password cannot be empty if provided"}
-		}
-		if err := validatePassword(*password); err != nil {
-			return err
+	var upd domain.UserUpdate
+	for _, p := range paths {
+		switch p {
+		case pathEmail:
+			if req.Email == nil {
+				return domain.UserUpdate{}, ValidationError{Field: "email", Message: "email is in update_mask but not provided"}
+			}
+			if err := validateEmail(*req.Email); err != nil {
+				return domain.UserUpdate{}, err
+			}
+			upd.Email = req.Email
+		case pathPassword:
+			if req.Password == nil {
+				return domain.UserUpdate{}, ValidationError{Field: "password", Message: "password is in update_mask but not provided"}
+			}
+			if err := validatePassword(*req.Password); err != nil {
+				return domain.UserUpdate{}, err
+			}
+			upd.Password = req.Password
+		default:
+			return domain.UserUpdate{}, ValidationError{Field: "update_mask", Message: fmt.Sprintf("unknown path %q", p)}
 		}
 	}
 
-	return nil
+	return upd, nil
+}
+
+// formatETag возвращает etag для версии пользователя
+func formatETag(version uint32) string {
+	return strconv.FormatUint(uint64(version), 10)
+}
+
+// parseETag возвращает версию пользователя из etag
+func parseETag(etag string) (uint32, error) {
+	v, err := strconv.ParseUint(etag, 10, 32)
+	if err != nil || v == 0 {
+		return 0, ValidationError{Field: "etag", Message: "malformed etag"}
+	}
+
+	return uint32(v), nil
 }
 
 // handleValidationError конвертирует ошибку валидации в gRPC статус
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"errors"
+	"testing"
+
+	userpb "github.com/blastuha/test-service-proto/gen/user"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestValidateUpdateUserRequest(t *testing.T) {
+	email, badEmail, password := "new@example.com", "not-an-email", "secret42"
+
+	tests := []struct {
+		name         string
+		req          *userpb.UpdateUserRequest
+		wantEmail    bool
+		wantPassword bool
+		wantField    string
+	}{
+		{
+			name:      "no id",
+			req:       &userpb.UpdateUserRequest{Email: &email},
+			wantField: "id",
+		},
+		{
+			name:      "no mask, email only",
+			req:       &userpb.UpdateUserRequest{Id: 1, Email: &email},
+			wantEmail: true,
+		},
+		{
+			name:         "no mask, both",
+			req:          &userpb.UpdateUserRequest{Id: 1, Email: &email, Password: &password},
+			wantEmail:    true,
+			wantPassword: true,
+		},
+		{
+			name:      "no mask, invalid email",
+			req:       &userpb.UpdateUserRequest{Id: 1, Email: &badEmail},
+			wantField: "email",
+		},
+		{
+			name: "mask ignores other fields",
+			req: &userpb.UpdateUserRequest{Id: 1, Email: &badEmail, Password: &password,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password"}}},
+			wantPassword: true,
+		},
+		{
+			name:      "path without value",
+			req:       &userpb.UpdateUserRequest{Id: 1, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}}},
+			wantField: "email",
+		},
+		{
+			name: "unknown path",
+			req: &userpb.UpdateUserRequest{Id: 1, Email: &email,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email", "id"}}},
+			wantField: "update_mask",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upd, err := validateUpdateUserRequest(tt.req)
+			if tt.wantField != "" {
+				var verr ValidationError
+				if !errors.As(err, &verr) || verr.Field != tt.wantField {
+					t.Fatalf("validateUpdateUserRequest() error = %v, want field %q", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateUpdateUserRequest() error = %v", err)
+			}
+			if (upd.Email != nil) != tt.wantEmail || (upd.Password != nil) != tt.wantPassword {
+				t.Errorf("Email = %v, Password = %v; want set %v, %v", upd.Email, upd.Password, tt.wantEmail, tt.wantPassword)
+			}
+		})
+	}
+}
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		etag    string
+		want    uint32
+		wantErr bool
+	}{
+		{etag: "1", want: 1},
+		{etag: formatETag(4294967295), want: 4294967295},
+		{etag: "0", wantErr: true},
+		{etag: "-1", wantErr: true},
+		{etag: "4294967296", wantErr: true},
+		{etag: `"3"`, wantErr: true},
+		{etag: "3a", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseETag(tt.etag)
+		if tt.wantErr {
+			var verr ValidationError
+			if !errors.As(err, &verr) || verr.Field != "etag" {
+				t.Errorf("parseETag(%q) error = %v, want etag validation error", tt.etag, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseETag(%q) = %d, %v; want %d", tt.etag, got, err, tt.want)
+		}
+	}
+}
@@ -3,3 +3,8 @@ package user
 import "fmt"
 
 var ErrUserNoFound = fmt.Errorf("user not found")
+var ErrVersionConflict = fmt.Errorf("user was modified concurrently")
+var ErrInvalidCredentials = fmt.Errorf("invalid email or password")
+var ErrUserNotDeleted = fmt.Errorf("user is not in the trash")
+var ErrEmailTaken = fmt.Errorf("email is already used by another user")
+var ErrErasureNotFound = fmt.Errorf("user erasure not found")
@@ -0,0 +1,402 @@
+package user
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/your-org/servicekit/idempotency"
+	"github.com/your-org/users-service/domain"
+	"github.com/your-org/users-service/internal/audit"
+	"github.com/your-org/users-service/internal/events"
+	"github.com/your-org/users-service/internal/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// auditExportBatch — сколько записей журнала аудита читается за раз при выгрузке
+const auditExportBatch = 1000
+
+// GetUserRecord возвращает учетную запись пользователя, в том числе из корзины
+func (repo *usersRepo) GetUserRecord(id uint32) (*domain.UserRecord, error) {
+	var orm User
+	if err := repo.db.Unscoped().First(&orm, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNoFound
+		}
+		return nil, fmt.Errorf("usersRepo.GetUserRecord: %w", err)
+	}
+
+	rec := &domain.UserRecord{
+		ID:        uint32(orm.ID),
+		Email:     orm.Email,
+		Version:   orm.Version,
+		CreatedAt: orm.CreatedAt,
+		UpdatedAt: orm.UpdatedAt,
+	}
+	if orm.DeletedAt.Valid {
+		rec.DeletedAt = &orm.DeletedAt.Time
+	}
+
+	return rec, nil
+}
+
+// BeginErasure заводит запись об удалении данных пользователя и мягко удаляет его,
+// чтобы до завершения удаления он не мог войти. Если запись уже есть, возвращает её.
+func (repo *usersRepo) BeginErasure(id uint32) (*domain.Erasure, error) {
+	var rec Erasure
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var orm User
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&orm, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// пользователя уже нет: повторный запрос получает прежнюю запись
+			if err := tx.Where("user_id = ?", id).First(&rec).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrUserNoFound
+				}
+				return err
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("user_id = ?", id).First(&rec).Error
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if !orm.DeletedAt.Valid {
+			if err := tx.Delete(&orm).Error; err != nil {
+				return err
+			}
+			if err := audit.Append(tx, domain.AuditUserDeleted, id, nil); err != nil {
+				return err
+			}
+			if err := outbox.Append(tx, events.NewUserDeleted(id)); err != nil {
+				return err
+			}
+		}
+
+		rec = Erasure{
+			UserID:      id,
+			Status:      string(domain.ErasurePending),
+			RequestedAt: time.Now(),
+		}
+		if err := tx.Create(&rec).Error; err != nil {
+			return err
+		}
+
+		return audit.Append(tx, domain.AuditUserErasureRequested, id, erasureDetails{ErasureID: rec.ID})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usersRepo.BeginErasure: %w", err)
+	}
+
+	return rec.toDomain(), nil
+}
+
+// CompleteErasure окончательно удаляет пользователя, его события из outbox и ключи
+// идемпотентности после того, как данные удалены в tasks-service, и закрывает запись
+// об удалении. Журнал аудита не меняется: он хранится как юридическая запись, защищен
+// цепочкой хешей и ссылается на пользователя только по id.
+func (repo *usersRepo) CompleteErasure(id uint64, erased domain.ErasedTasksData) (*domain.Erasure, error) {
+	var rec Erasure
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&rec, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrErasureNotFound
+			}
+			return err
+		}
+		if rec.Status == string(domain.ErasureCompleted) {
+			return nil
+		}
+
+		// пользователя может уже не быть, если корзина очистилась раньше
+		if err := tx.Unscoped().Where("id = ?", rec.UserID).Delete(&User{}).Error; err != nil {
+			return err
+		}
+		// в событиях есть email, неопубликованные тоже больше не нужны
+		if err := tx.Where("aggregate_id = ?", rec.UserID).Delete(&outbox.Event{}).Error; err != nil {
+			return err
+		}
+		// и в сохраненных ответах CreateUser
+		if err := idempotency.DeleteOwnedBy(tx, rec.UserID); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&rec).Clauses(clause.Returning{}).Updates(map[string]any{
+			"status":       string(domain.ErasureCompleted),
+			"completed_at": time.Now(),
+			"last_error":   "",
+			"tasks":        erased.Tasks,
+			"comments":     erased.Comments,
+			"projects":     erased.Projects,
+			"tags":         erased.Tags,
+			"attachments":  erased.Attachments,
+		}).Error; err != nil {
+			return err
+		}
+
+		return audit.Append(tx, domain.AuditUserErased, rec.UserID, erasureDetails{
+			ErasureID: rec.ID,
+			Erased:    &erased,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usersRepo.CompleteErasure: %w", err)
+	}
+
+	return rec.toDomain(), nil
+}
+
+// RecordErasureFailure запоминает неудачную попытку удалить данные в tasks-service
+func (repo *usersRepo) RecordErasureFailure(id uint64, cause error) error {
+	if err := repo.db.Model(&Erasure{}).
+		Where("id = ? AND status = ?", id, string(domain.ErasurePending)).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": cause.Error(),
+		}).Error; err != nil {
+		return fmt.Errorf("usersRepo.RecordErasureFailure: %w", err)
+	}
+
+	return nil
+}
+
+// GetErasure возвращает запись об удалении данных пользователя
+func (repo *usersRepo) GetErasure(userID uint32) (*domain.Erasure, error) {
+	var rec Erasure
+	if err := repo.db.Where("user_id = ?", userID).First(&rec).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrErasureNotFound
+		}
+		return nil, fmt.Errorf("usersRepo.GetErasure: %w", err)
+	}
+
+	return rec.toDomain(), nil
+}
+
+// ListPendingErasures возвращает до limit незавершенных удалений, начиная с самых старых
+func (repo *usersRepo) ListPendingErasures(limit int) ([]*domain.Erasure, error) {
+	var recs []Erasure
+	if err := repo.db.Where("status = ?", string(domain.ErasurePending)).
+		Order("id").
+		Limit(limit).
+		Find(&recs).Error; err != nil {
+		return nil, fmt.Errorf("usersRepo.ListPendingErasures: %w", err)
+	}
+
+	out := make([]*domain.Erasure, len(recs))
+	for i := range recs {
+		out[i] = recs[i].toDomain()
+	}
+
+	return out, nil
+}
+
+// erasureDetails — запись журнала аудита об удалении данных
+type erasureDetails struct {
+	ErasureID uint64                  `json:"erasure_id"`
+	Erased    *domain.ErasedTasksData `json:"erased,omitempty"`
+}
+
+// TasksData — данные пользователя в tasks-service
+type TasksData interface {
+	// ExportUserData пишет данные пользователя в w, по одному объекту JSON в строке
+	ExportUserData(ctx context.Context, userID uint32, w io.Writer) error
+	// EraseUserData окончательно удаляет данные пользователя; повторный вызов безопасен
+	EraseUserData(ctx context.Context, userID uint32) (domain.ErasedTasksData, error)
+}
+
+// GDPRService выгружает и удаляет данные пользователя по его запросу в обоих сервисах
+type GDPRService interface {
+	// ExportUserData пишет в w zip-архив: user.json, audit_log.jsonl и tasks.jsonl
+	ExportUserData(ctx context.Context, userID uint32, w io.Writer) error
+	// EraseUser удаляет пользователя и его данные. Если tasks-service недоступен,
+	// возвращает запись в статусе pending, удаление завершит ErasureWorker.
+	EraseUser(ctx context.Context, userID uint32) (*domain.Erasure, error)
+	GetErasure(userID uint32) (*domain.Erasure, error)
+	// WithActor возвращает сервис, который пишет журнал аудита от имени actor
+	WithActor(actor domain.Actor) GDPRService
+}
+
+type gdprService struct {
+	repo  UsersRepo
+	audit *audit.Store
+	tasks TasksData
+}
+
+func NewGDPRService(repo UsersRepo, auditStore *audit.Store, tasks TasksData) GDPRService {
+	return &gdprService{repo: repo, audit: auditStore, tasks: tasks}
+}
+
+func (s *gdprService) WithActor(actor domain.Actor) GDPRService {
+	return &gdprService{repo: s.repo.WithActor(actor), audit: s.audit, tasks: s.tasks}
+}
+
+// auditLine — запись журнала аудита в архиве
+type auditLine struct {
+	ID        uint64          `json:"id"`
+	Action    string          `json:"action"`
+	ActorID   uint32          `json:"actor_id,omitempty"`
+	Source    string          `json:"source,omitempty"`
+	Details   json.RawMessage `json:"details"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (s *gdprService) ExportUserData(ctx context.Context, userID uint32, w io.Writer) error {
+	// до начала архива: отсутствующий пользователь — ошибка, а не пустой архив
+	rec, err := s.repo.GetUserRecord(userID)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+
+	f, err := zw.Create("user.json")
+	if err != nil {
+		return fmt.Errorf("gdprService.ExportUserData: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(rec); err != nil {
+		return fmt.Errorf("gdprService.ExportUserData: failed to write user: %w", err)
+	}
+
+	if f, err = zw.Create("audit_log.jsonl"); err != nil {
+		return fmt.Errorf("gdprService.ExportUserData: %w", err)
+	}
+	if err := s.exportAudit(userID, json.NewEncoder(f)); err != nil {
+		return fmt.Errorf("gdprService.ExportUserData: %w", err)
+	}
+
+	if f, err = zw.Create("tasks.jsonl"); err != nil {
+		return fmt.Errorf("gdprService.ExportUserData: %w", err)
+	}
+	if err := s.tasks.ExportUserData(ctx, userID, f); err != nil {
+		return fmt.Errorf("gdprService.ExportUserData: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("gdprService.ExportUserData: %w", err)
+	}
+	return nil
+}
+
+// exportAudit пишет записи журнала аудита о пользователе от старых к новым
+func (s *gdprService) exportAudit(userID uint32, enc *json.Encoder) error {
+	var after uint64
+	for {
+		entries, err := s.audit.List(domain.AuditFilter{UserID: userID}, after, auditExportBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			if err := enc.Encode(auditLine{
+				ID:        e.ID,
+				Action:    e.Action,
+				ActorID:   e.Actor.UserID,
+				Source:    e.Actor.Source,
+				Details:   json.RawMessage(e.Details),
+				CreatedAt: e.CreatedAt,
+			}); err != nil {
+				return fmt.Errorf("failed to write audit entry: %w", err)
+			}
+			after = e.ID
+		}
+
+		if len(entries) < auditExportBatch {
+			return nil
+		}
+	}
+}
+
+func (s *gdprService) EraseUser(ctx context.Context, userID uint32) (*domain.Erasure, error) {
+	e, err := s.repo.BeginErasure(userID)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status == domain.ErasureCompleted {
+		return e, nil
+	}
+
+	return completeErasure(ctx, s.repo, s.tasks, e)
+}
+
+func (s *gdprService) GetErasure(userID uint32) (*domain.Erasure, error) {
+	return s.repo.GetErasure(userID)
+}
+
+// completeErasure удаляет данные в tasks-service и закрывает запись. Ошибка tasks-service
+// не возвращается: запись остается pending с текстом ошибки до следующей попытки.
+func completeErasure(ctx context.Context, repo UsersRepo, tasks TasksData, e *domain.Erasure) (*domain.Erasure, error) {
+	erased, err := tasks.EraseUserData(ctx, e.UserID)
+	if err != nil {
+		if recErr := repo.RecordErasureFailure(e.ID, err); recErr != nil {
+			return nil, recErr
+		}
+		e.Attempts++
+		e.LastError = err.Error()
+		return e, nil
+	}
+
+	return repo.CompleteErasure(e.ID, erased)
+}
+
+// ErasureWorker повторяет удаления, которые не завершились из-за недоступности tasks-service
+type ErasureWorker struct {
+	repo      UsersRepo
+	tasks     TasksData
+	interval  time.Duration
+	batchSize int
+}
+
+func NewErasureWorker(repo UsersRepo, tasks TasksData, interval time.Duration, batchSize int) *ErasureWorker {
+	return &ErasureWorker{repo: repo, tasks: tasks, interval: interval, batchSize: batchSize}
+}
+
+// Run повторяет незавершенные удаления до отмены ctx
+func (w *ErasureWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// за проход каждое удаление пробуется один раз, чтобы недоступный
+		// tasks-service не держал цикл
+		pending, err := w.repo.ListPendingErasures(w.batchSize)
+		if err != nil {
+			log.Printf("erasure worker: %v", err)
+			continue
+		}
+		for _, e := range pending {
+			done, err := completeErasure(ctx, w.repo, w.tasks, e)
+			if err != nil {
+				log.Printf("erasure worker: user %d: %v", e.UserID, err)
+				continue
+			}
+			if done.Status != domain.ErasureCompleted {
+				log.Printf("erasure worker: user %d: %s", e.UserID, done.LastError)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/your-org/users-service/domain"
+)
+
+// fakeErasureRepo — UsersRepo с одной записью об удалении; остальные методы не вызываются
+type fakeErasureRepo struct {
+	UsersRepo
+	erasure   *domain.Erasure
+	beginErr  error
+	failures  []string
+	completed *domain.ErasedTasksData
+}
+
+func (r *fakeErasureRepo) BeginErasure(id uint32) (*domain.Erasure, error) {
+	if r.beginErr != nil {
+		return nil, r.beginErr
+	}
+	e := *r.erasure
+	return &e, nil
+}
+
+func (r *fakeErasureRepo) RecordErasureFailure(id uint64, cause error) error {
+	r.failures = append(r.failures, cause.Error())
+	return nil
+}
+
+func (r *fakeErasureRepo) CompleteErasure(id uint64, erased domain.ErasedTasksData) (*domain.Erasure, error) {
+	r.completed = &erased
+	e := *r.erasure
+	e.Status = domain.ErasureCompleted
+	e.Erased = erased
+	return &e, nil
+}
+
+type fakeTasksData struct {
+	erased domain.ErasedTasksData
+	err    error
+	calls  int
+}
+
+func (t *fakeTasksData) ExportUserData(ctx context.Context, userID uint32, w io.Writer) error {
+	return nil
+}
+
+func (t *fakeTasksData) EraseUserData(ctx context.Context, userID uint32) (domain.ErasedTasksData, error) {
+	t.calls++
+	return t.erased, t.err
+}
+
+func TestEraseUser(t *testing.T) {
+	errBegin := errors.New("user not found")
+	erased := domain.ErasedTasksData{Tasks: 3, Comments: 2, Attachments: 1}
+
+	tests := []struct {
+		name         string
+		status       domain.ErasureStatus
+		beginErr     error
+		tasksErr     error
+		wantErr      error
+		wantStatus   domain.ErasureStatus
+		wantCalls    int
+		wantAttempts int
+	}{
+		{name: "completed", status: domain.ErasurePending, wantStatus: domain.ErasureCompleted, wantCalls: 1},
+		{name: "already completed", status: domain.ErasureCompleted, wantStatus: domain.ErasureCompleted},
+		{name: "tasks unavailable", status: domain.ErasurePending, tasksErr: errors.New("unavailable"),
+			wantStatus: domain.ErasurePending, wantCalls: 1, wantAttempts: 2},
+		{name: "begin failed", beginErr: errBegin, wantErr: errBegin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeErasureRepo{
+				erasure:  &domain.Erasure{ID: 10, UserID: 7, Status: tt.status, Attempts: 1},
+				beginErr: tt.beginErr,
+			}
+			tasks := &fakeTasksData{erased: erased, err: tt.tasksErr}
+			s := NewGDPRService(repo, nil, tasks)
+
+			e, err := s.EraseUser(context.Background(), 7)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EraseUser() error = %v, want %v", err, tt.wantErr)
+			}
+			if tasks.calls != tt.wantCalls {
+				t.Errorf("EraseUserData calls = %d, want %d", tasks.calls, tt.wantCalls)
+			}
+			if err != nil {
+				return
+			}
+			if e.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", e.Status, tt.wantStatus)
+			}
+
+			switch {
+			case tt.tasksErr != nil:
+				if e.Attempts != tt.wantAttempts || e.LastError != tt.tasksErr.Error() {
+					t.Errorf("Attempts, LastError = %d, %q", e.Attempts, e.LastError)
+				}
+				if len(repo.failures) != 1 || repo.completed != nil {
+					t.Errorf("failures = %v, completed = %v", repo.failures, repo.completed)
+				}
+			case tt.wantCalls > 0:
+				if repo.completed == nil || *repo.completed != erased {
+					t.Errorf("completed with %v, want %v", repo.completed, erased)
+				}
+			}
+		})
+	}
+}
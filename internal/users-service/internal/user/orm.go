@@ -1,14 +1,17 @@
 package user
 
 import (
+	"time"
+
 	"github.com/your-org/users-service/domain"
 	"gorm.io/gorm"
 )
 
 type User struct {
 	gorm.Model
-	Email    string `gorm:"uniqueIndex;not null"`
+	Email    string `gorm:"uniqueIndex:idx_users_email_active,where:deleted_at IS NULL;not null"`
 	Password string `gorm:"not null"`
+	Version  uint32 `gorm:"not null;default:1"`
 }
 
 // Методы конвертации между domain и DB моделями
@@ -17,6 +20,7 @@ func (db *User) toDomain() *domain.User {
 		ID:       uint32(db.ID),
 		Email:    db.Email,
 		Password: db.Password,
+		Version:  db.Version,
 	}
 }
 
@@ -27,5 +31,45 @@ func fromDomain(u *domain.User) *User {
 		},
 		Email:    u.Email,
 		Password: u.Password,
+		Version:  u.Version,
+	}
+}
+
+// Erasure — строка таблицы user_erasures
+type Erasure struct {
+	ID          uint64 `gorm:"primaryKey"`
+	UserID      uint32 `gorm:"uniqueIndex;not null"`
+	Status      string `gorm:"type:varchar(16);not null;default:pending"`
+	RequestedAt time.Time
+	CompletedAt *time.Time
+	Attempts    int    `gorm:"not null;default:0"`
+	LastError   string `gorm:"type:text;not null;default:''"`
+	Tasks       int    `gorm:"not null;default:0"`
+	Comments    int    `gorm:"not null;default:0"`
+	Projects    int    `gorm:"not null;default:0"`
+	Tags        int    `gorm:"not null;default:0"`
+	Attachments int    `gorm:"not null;default:0"`
+}
+
+func (Erasure) TableName() string {
+	return "user_erasures"
+}
+
+func (e *Erasure) toDomain() *domain.Erasure {
+	return &domain.Erasure{
+		ID:          e.ID,
+		UserID:      e.UserID,
+		Status:      domain.ErasureStatus(e.Status),
+		RequestedAt: e.RequestedAt,
+		CompletedAt: e.CompletedAt,
+		Attempts:    e.Attempts,
+		LastError:   e.LastError,
+		Erased: domain.ErasedTasksData{
+			Tasks:       e.Tasks,
+			Comments:    e.Comments,
+			Projects:    e.Projects,
+			Tags:        e.Tags,
+			Attachments: e.Attachments,
+		},
 	}
 }
@@ -3,9 +3,14 @@ package user
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/your-org/users-service/domain"
+	"github.com/your-org/users-service/internal/audit"
+	"github.com/your-org/users-service/internal/events"
+	"github.com/your-org/users-service/internal/outbox"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UsersRepo interface {
@@ -15,6 +20,22 @@ type UsersRepo interface {
 	UpdateUser(u *domain.User) (*domain.User, error)
 	DeleteUser(id uint32) error
 	GetUserByID(id uint32) (*domain.User, error)
+	GetUserByEmail(email string) (*domain.User, error)
+	// RecordLogin пишет в журнал аудита попытку входа
+	RecordLogin(userID uint32, succeeded bool) error
+	ListDeletedUsers(afterID uint32, limit int) ([]*domain.DeletedUser, error)
+	RestoreUser(id uint32, email *string) (*domain.User, error)
+	PurgeUser(id uint32) error
+	PurgeDeletedBefore(cutoff time.Time, limit int) (int, error)
+	// GetUserRecord возвращает учетную запись с датами, в том числе из корзины
+	GetUserRecord(id uint32) (*domain.UserRecord, error)
+	BeginErasure(id uint32) (*domain.Erasure, error)
+	CompleteErasure(id uint64, erased domain.ErasedTasksData) (*domain.Erasure, error)
+	RecordErasureFailure(id uint64, cause error) error
+	GetErasure(userID uint32) (*domain.Erasure, error)
+	ListPendingErasures(limit int) ([]*domain.Erasure, error)
+	// WithActor возвращает репозиторий, который пишет журнал аудита от имени actor
+	WithActor(actor domain.Actor) UsersRepo
 }
 
 type usersRepo struct {
@@ -25,6 +46,10 @@ func NewUsersRepo(db *gorm.DB) UsersRepo {
 	return &usersRepo{db: db}
 }
 
+func (repo *usersRepo) WithActor(actor domain.Actor) UsersRepo {
+	return &usersRepo{db: audit.WithActor(repo.db, actor)}
+}
+
 func (repo *usersRepo) GetUserByID(id uint32) (*domain.User, error) {
 	var u User
 	if err := repo.db.First(&u, id).Error; err != nil {
@@ -39,6 +64,37 @@ func (repo *usersRepo) GetUserByID(id uint32) (*domain.User, error) {
 	return dm, nil
 }
 
+func (repo *usersRepo) GetUserByEmail(email string) (*domain.User, error) {
+	var u User
+	if err := repo.db.Where("email = ?", email).First(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNoFound
+		}
+		return nil, err
+	}
+
+	return u.toDomain(), nil
+}
+
+// RecordLogin пишет в журнал аудита успешный или неудачный вход пользователя userID.
+// Неудачные входы пишутся сразу, как и остальные записи: порядок цепочки
+// совпадает с порядком событий.
+func (repo *usersRepo) RecordLogin(userID uint32, succeeded bool) error {
+	action := domain.AuditLoginFailed
+	if succeeded {
+		action = domain.AuditLoginSucceeded
+	}
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		return audit.Append(tx, action, userID, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("usersRepo.RecordLogin: %w", err)
+	}
+
+	return nil
+}
+
 // func (repo *usersRepo) GetTasksForUser(id uint) ([]tasksService.Task, error) {
 // 	var tasks []tasksService.Task
 // 	if err := repo.db.
@@ -65,10 +121,21 @@ func (repo *usersRepo) GetAllUsers() ([]*domain.User, error) {
 	return dmUsers, nil
 }
 
+// CreateUser создает пользователя и в той же транзакции пишет событие user.created в outbox
 func (repo *usersRepo) CreateUser(u *domain.User) (*domain.User, error) {
 	orm := fromDomain(u)
 
-	if err := repo.db.Create(orm).Error; err != nil {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(orm).Error; err != nil {
+			return err
+		}
+		if err := audit.Append(tx, domain.AuditUserCreated, uint32(orm.ID), nil); err != nil {
+			return err
+		}
+
+		return outbox.Append(tx, events.NewUserCreated(orm.toDomain()))
+	})
+	if err != nil {
 		return nil, fmt.Errorf("usersRepo.CreateUser: %w", err)
 	}
 
@@ -77,10 +144,60 @@ func (repo *usersRepo) CreateUser(u *domain.User) (*domain.User, error) {
 	return dm, nil
 }
 
+// UpdateUser обновляет пользователя, если его версия в БД всё ещё равна u.Version,
+// увеличивает версию и в той же транзакции пишет событие user.updated в outbox.
+// Иначе возвращает ErrVersionConflict.
 func (repo *usersRepo) UpdateUser(u *domain.User) (*domain.User, error) {
-	orm := fromDomain(u)
-	if err := repo.db.Save(orm).Error; err != nil {
-		return nil, err
+	orm := User{Model: gorm.Model{ID: uint(u.ID)}}
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		// прежние значения нужны журналу аудита
+		var before User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, u.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNoFound
+			}
+			return err
+		}
+
+		res := tx.Model(&orm).
+			Clauses(clause.Returning{}).
+			Where("version = ?", u.Version).
+			Updates(map[string]any{
+				"email":    u.Email,
+				"password": u.Password,
+				"version":  gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&User{}).Where("id = ?", u.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return ErrUserNoFound
+			}
+			return ErrVersionConflict
+		}
+
+		if before.Email != orm.Email {
+			if err := audit.Append(tx, domain.AuditUserEmailChanged, u.ID, nil); err != nil {
+				return err
+			}
+		}
+		// значения пароля в журнал не попадают
+		if before.Password != orm.Password {
+			if err := audit.Append(tx, domain.AuditUserPasswordChanged, u.ID, nil); err != nil {
+				return err
+			}
+		}
+
+		return outbox.Append(tx, events.NewUserUpdated(orm.toDomain()))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usersRepo.UpdateUser: %w", err)
 	}
 
 	dm := orm.toDomain()
@@ -88,6 +205,7 @@ func (repo *usersRepo) UpdateUser(u *domain.User) (*domain.User, error) {
 	return dm, nil
 }
 
+// DeleteUser мягко удаляет пользователя и в той же транзакции пишет событие user.deleted в outbox
 func (repo *usersRepo) DeleteUser(id uint32) error {
 	var user User
 
@@ -99,7 +217,17 @@ func (repo *usersRepo) DeleteUser(id uint32) error {
 		return fmt.Errorf("userRepo.DeleteUser: %w", err)
 	}
 
-	if err := repo.db.Delete(&user).Error; err != nil {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&user).Error; err != nil {
+			return err
+		}
+		if err := audit.Append(tx, domain.AuditUserDeleted, id, nil); err != nil {
+			return err
+		}
+
+		return outbox.Append(tx, events.NewUserDeleted(id))
+	})
+	if err != nil {
 		return fmt.Errorf("userRepo.DeleteUser: %w", err)
 	}
 
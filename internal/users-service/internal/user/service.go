@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/your-org/users-service/domain"
+	"golang.org/x/crypto/bcrypt"
 	// "github.com/your-org/users-service/internal/web/users"
 	// "task1/internal/tasksService"
 	// "task1/internal/web/users"
@@ -13,12 +14,33 @@ import (
 type UsersService interface {
 	GetAllUsers() ([]*domain.User, error)
 	CreateUser(email string, password string) (*domain.User, error)
-	UpdateUser(id uint32, email *string, password *string) (*domain.User, error)
+	UpdateUser(id uint32, version uint32, upd domain.UserUpdate) (*domain.User, error)
 	DeleteUser(id uint32) error
 	GetUserByID(id uint32) (*domain.User, error)
+	Authenticate(email, password string) (*domain.User, error)
+	ListDeletedUsers(afterID uint32, pageSize int) ([]*domain.DeletedUser, uint32, error)
+	RestoreUser(id uint32, email *string) (*domain.User, error)
+	PurgeUser(id uint32) error
+	// WithActor возвращает сервис, который пишет журнал аудита от имени actor
+	WithActor(actor domain.Actor) UsersService
 	// GetTasksForUser(id uint) ([]tasksService.Task, error)
 }
 
+// passwordCost — стоимость bcrypt для новых хешей паролей
+const passwordCost = bcrypt.DefaultCost
+
+// dummyPasswordHash — хеш, с которым сверяется пароль, если email не найден
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("no such user"), passwordCost)
+
+// hashPassword возвращает bcrypt-хеш пароля; в БД пароли хранятся только так
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
 type usersService struct {
 	repo UsersRepo
 }
@@ -49,9 +71,13 @@ func (u *usersService) GetAllUsers() ([]*domain.User, error) {
 
 func (u *usersService) CreateUser(email string, password string) (*domain.User, error) {
 	// Валидация уже выполнена в gRPC handler
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("usersService.CreateUser: %w", err)
+	}
 	userToCreate := domain.User{
 		Email:    email,
-		Password: password,
+		Password: hash,
 	}
 
 	createdUser, err := u.repo.CreateUser(&userToCreate)
@@ -62,7 +88,9 @@ func (u *usersService) CreateUser(email string, password string) (*domain.User,
 	return createdUser, nil
 }
 
-func (u *usersService) UpdateUser(id uint32, email *string, password *string) (*domain.User, error) {
+// UpdateUser меняет только переданные в upd поля, если текущая версия пользователя равна version.
+// Значения уже провалидированы в gRPC handler.
+func (u *usersService) UpdateUser(id uint32, version uint32, upd domain.UserUpdate) (*domain.User, error) {
 	existingUser, err := u.repo.GetUserByID(id)
 	if err != nil {
 		if errors.Is(err, ErrUserNoFound) {
@@ -71,16 +99,34 @@ func (u *usersService) UpdateUser(id uint32, email *string, password *string) (*
 		return nil, fmt.Errorf("usersService.UpdateUser: %w", err)
 	}
 
-	if email != nil && *email != "" {
-		existingUser.Email = *email
+	if existingUser.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	if upd.Email == nil && upd.Password == nil {
+		return existingUser, nil
 	}
 
-	if password != nil && *password != "" {
-		existingUser.Password = *password
+	if upd.Email != nil {
+		existingUser.Email = *upd.Email
+	}
+
+	if upd.Password != nil {
+		hash, err := hashPassword(*upd.Password)
+		if err != nil {
+			return nil, fmt.Errorf("usersService.UpdateUser: %w", err)
+		}
+		existingUser.Password = hash
 	}
 
 	updatedUser, err := u.repo.UpdateUser(existingUser)
 	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			return nil, ErrVersionConflict
+		}
+		if errors.Is(err, ErrUserNoFound) {
+			return nil, ErrUserNoFound
+		}
 		return nil, fmt.Errorf("usersService.UpdateUser: %w", err)
 	}
 
@@ -110,3 +156,40 @@ func (u *usersService) GetUserByID(id uint32) (*domain.User, error) {
 
 	return user, nil
 }
+
+func (u *usersService) WithActor(actor domain.Actor) UsersService {
+	return &usersService{repo: u.repo.WithActor(actor)}
+}
+
+// Authenticate проверяет email и пароль. Каждая попытка пишется в журнал аудита;
+// на неверный email и неверный пароль возвращается одна и та же ErrInvalidCredentials.
+func (u *usersService) Authenticate(email, password string) (*domain.User, error) {
+	found, err := u.repo.GetUserByEmail(email)
+	if err != nil && !errors.Is(err, ErrUserNoFound) {
+		return nil, fmt.Errorf("usersService.Authenticate: %w", err)
+	}
+
+	// для неизвестного email пароль сверяется с заглушкой, чтобы по времени ответа
+	// нельзя было узнать, зарегистрирован ли email
+	hash := dummyPasswordHash
+	if found != nil {
+		hash = []byte(found.Password)
+	}
+	succeeded := bcrypt.CompareHashAndPassword(hash, []byte(password)) == nil && found != nil
+
+	var userID uint32
+	if found != nil {
+		userID = found.ID
+	}
+	// в журнал попадает только id пользователя (0 для неизвестного email), сам email
+	// журнал не хранит
+	// попытка, не попавшая в журнал, не должна пройти, даже успешная
+	if err := u.repo.RecordLogin(userID, succeeded); err != nil {
+		return nil, fmt.Errorf("usersService.Authenticate: %w", err)
+	}
+
+	if !succeeded {
+		return nil, ErrInvalidCredentials
+	}
+	return found, nil
+}
@@ -0,0 +1,251 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/your-org/users-service/domain"
+	"github.com/your-org/users-service/internal/audit"
+	"github.com/your-org/users-service/internal/events"
+	"github.com/your-org/users-service/internal/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultTrashPage = 50
+	maxTrashPage     = 200
+)
+
+// isUniqueViolation сообщает, что запись нарушила уникальный индекс
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// ListDeletedUsers возвращает до limit удаленных пользователей с id больше afterID
+func (repo *usersRepo) ListDeletedUsers(afterID uint32, limit int) ([]*domain.DeletedUser, error) {
+	var ormUsers []User
+	if err := repo.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&ormUsers).Error; err != nil {
+		return nil, fmt.Errorf("usersRepo.ListDeletedUsers: %w", err)
+	}
+
+	out := make([]*domain.DeletedUser, len(ormUsers))
+	for i := range ormUsers {
+		out[i] = &domain.DeletedUser{User: ormUsers[i].toDomain(), DeletedAt: ormUsers[i].DeletedAt.Time}
+	}
+
+	return out, nil
+}
+
+// RestoreUser возвращает пользователя из корзины, при email != nil — с новым email.
+// Если email уже занят другим пользователем, возвращает ErrEmailTaken.
+func (repo *usersRepo) RestoreUser(id uint32, email *string) (*domain.User, error) {
+	var orm User
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockDeletedUser(tx, id, &orm); err != nil {
+			return err
+		}
+
+		previous := orm.Email
+		newEmail := orm.Email
+		if email != nil {
+			newEmail = *email
+		}
+
+		// уникальный индекс тоже не пропустит дубль, проверка дает понятную ошибку заранее
+		var taken int64
+		if err := tx.Model(&User{}).Where("email = ?", newEmail).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrEmailTaken
+		}
+
+		if err := tx.Unscoped().Model(&orm).
+			Clauses(clause.Returning{}).
+			Updates(map[string]any{
+				"deleted_at": nil,
+				"email":      newEmail,
+				"version":    gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			if isUniqueViolation(err) {
+				return ErrEmailTaken
+			}
+			return err
+		}
+
+		if err := audit.Append(tx, domain.AuditUserRestored, id, nil); err != nil {
+			return err
+		}
+		if previous != orm.Email {
+			if err := audit.Append(tx, domain.AuditUserEmailChanged, id, nil); err != nil {
+				return err
+			}
+		}
+
+		return outbox.Append(tx, events.NewUserRestored(orm.toDomain()))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usersRepo.RestoreUser: %w", err)
+	}
+
+	return orm.toDomain(), nil
+}
+
+// PurgeUser окончательно удаляет пользователя из корзины. Журнал аудита сохраняется.
+func (repo *usersRepo) PurgeUser(id uint32) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var orm User
+		if err := lockDeletedUser(tx, id, &orm); err != nil {
+			return err
+		}
+		return purgeUsers(tx, []User{orm})
+	})
+	if err != nil {
+		return fmt.Errorf("usersRepo.PurgeUser: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedBefore окончательно удаляет до limit пользователей, удаленных раньше cutoff,
+// и возвращает их число
+func (repo *usersRepo) PurgeDeletedBefore(cutoff time.Time, limit int) (int, error) {
+	var purged int
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var ormUsers []User
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("deleted_at < ?", cutoff).
+			Order("id").
+			Limit(limit).
+			Find(&ormUsers).Error; err != nil {
+			return err
+		}
+		if len(ormUsers) == 0 {
+			return nil
+		}
+
+		purged = len(ormUsers)
+		return purgeUsers(tx, ormUsers)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("usersRepo.PurgeDeletedBefore: %w", err)
+	}
+
+	return purged, nil
+}
+
+// lockDeletedUser читает пользователя из корзины в orm и блокирует его до конца транзакции tx
+func lockDeletedUser(tx *gorm.DB, id uint32, orm *User) error {
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(orm, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNoFound
+		}
+		return err
+	}
+	if !orm.DeletedAt.Valid {
+		return ErrUserNotDeleted
+	}
+	return nil
+}
+
+// purgeUsers удаляет строки пользователей и пишет удаление в журнал аудита
+func purgeUsers(tx *gorm.DB, ormUsers []User) error {
+	ids := make([]uint, len(ormUsers))
+	for i, u := range ormUsers {
+		ids[i] = u.ID
+	}
+	if err := tx.Unscoped().Where("id IN ?", ids).Delete(&User{}).Error; err != nil {
+		return err
+	}
+
+	for _, u := range ormUsers {
+		if err := audit.Append(tx, domain.AuditUserPurged, uint32(u.ID), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListDeletedUsers возвращает страницу корзины после пользователя afterID
+// и id, с которого начнется следующая страница (0 — страниц больше нет)
+func (u *usersService) ListDeletedUsers(afterID uint32, pageSize int) ([]*domain.DeletedUser, uint32, error) {
+	if pageSize <= 0 {
+		pageSize = defaultTrashPage
+	}
+	if pageSize > maxTrashPage {
+		pageSize = maxTrashPage
+	}
+
+	// берем на одного больше, чтобы знать, есть ли следующая страница
+	deleted, err := u.repo.ListDeletedUsers(afterID, pageSize+1)
+	if err != nil {
+		return nil, 0, fmt.Errorf("usersService.ListDeletedUsers: %w", err)
+	}
+	if len(deleted) <= pageSize {
+		return deleted, 0, nil
+	}
+
+	deleted = deleted[:pageSize]
+	return deleted, deleted[pageSize-1].User.ID, nil
+}
+
+// RestoreUser восстанавливает пользователя из корзины; email уже провалидирован в gRPC handler
+func (u *usersService) RestoreUser(id uint32, email *string) (*domain.User, error) {
+	return u.repo.RestoreUser(id, email)
+}
+
+// PurgeUser окончательно удаляет пользователя из корзины
+func (u *usersService) PurgeUser(id uint32) error {
+	return u.repo.PurgeUser(id)
+}
+
+// TrashPurger окончательно удаляет пользователей, пролежавших в корзине дольше retention
+type TrashPurger struct {
+	repo      UsersRepo
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+}
+
+func NewTrashPurger(repo UsersRepo, interval, retention time.Duration, batchSize int) *TrashPurger {
+	return &TrashPurger{repo: repo, interval: interval, retention: retention, batchSize: batchSize}
+}
+
+// Run очищает корзину до отмены ctx
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cutoff := time.Now().Add(-p.retention)
+		for {
+			n, err := p.repo.PurgeDeletedBefore(cutoff, p.batchSize)
+			if err != nil {
+				log.Printf("trash purger: %v", err)
+				break
+			}
+			if n < p.batchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+-- Outbox: события пишутся в одной транзакции с изменением пользователя,
+-- а фоновый relay публикует их и проставляет published_at
+CREATE TABLE IF NOT EXISTS outbox_events
+(
+    id           BIGSERIAL PRIMARY KEY,
+    event_id     VARCHAR(64) NOT NULL UNIQUE,
+    aggregate_id INTEGER     NOT NULL,
+    type         VARCHAR(64) NOT NULL,
+    payload      JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    occurred_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ NULL,
+    -- relay забирает события в аренду и публикует их вне транзакции: пока аренда
+    -- не истекла, другие реплики эти события и события тех же пользователей не берут
+    lease_id     VARCHAR(32) NULL,
+    leased_until TIMESTAMPTZ NULL
+);
+
+-- relay выбирает только неопубликованные события в порядке id
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (id) WHERE published_at IS NULL;
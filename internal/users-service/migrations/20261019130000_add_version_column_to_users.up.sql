@@ -0,0 +1,3 @@
+-- Версия строки для оптимистичной блокировки, увеличивается при каждом обновлении
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
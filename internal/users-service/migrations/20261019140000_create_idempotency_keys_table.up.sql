@@ -0,0 +1,19 @@
+-- Ключи идемпотентности CreateUser: хеш запроса и сериализованный ответ.
+-- scope — пользователи, от имени которых выполнен запрос: ключи разных
+-- пользователей не пересекаются. CreateUser вызывается анонимно, его scope пуст
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    scope        TEXT         NOT NULL DEFAULT '',
+    key          VARCHAR(128) NOT NULL,
+    method       VARCHAR(128) NOT NULL,
+    request_hash CHAR(64)     NOT NULL,
+    response     BYTEA        NULL,
+    -- резерв ключа действует до locked_until: если запрос упал, не сохранив ответ,
+    -- ключ можно занять заново, не дожидаясь истечения expires_at
+    locked_until TIMESTAMPTZ  NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (scope, key, method)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
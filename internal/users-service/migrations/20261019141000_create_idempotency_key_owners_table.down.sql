@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_idempotency_key_owners_owner_id;
+DROP TABLE IF EXISTS idempotency_key_owners;
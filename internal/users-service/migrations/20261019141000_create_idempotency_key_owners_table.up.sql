@@ -0,0 +1,13 @@
+-- Пользователи, чьи данные есть в сохраненных ответах на запросы с idempotency-key:
+-- при удалении данных пользователя такие ответы удаляются
+CREATE TABLE IF NOT EXISTS idempotency_key_owners
+(
+    scope    TEXT         NOT NULL,
+    key      VARCHAR(128) NOT NULL,
+    method   VARCHAR(128) NOT NULL,
+    owner_id INTEGER      NOT NULL,
+    PRIMARY KEY (scope, key, method, owner_id),
+    FOREIGN KEY (scope, key, method) REFERENCES idempotency_keys (scope, key, method) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_key_owners_owner_id ON idempotency_key_owners (owner_id);
@@ -0,0 +1,29 @@
+-- Журнал аудита пользователей: только добавление. hash каждой записи считается
+-- от hash предыдущей, поэтому изменение или удаление записи обнаруживается проверкой цепочки.
+-- details хранится текстом, чтобы байты, по которым считался hash, не менялись
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    INTEGER     NOT NULL DEFAULT 0,
+    actor_id   INTEGER     NOT NULL DEFAULT 0,
+    source     VARCHAR(64) NOT NULL DEFAULT '',
+    action     VARCHAR(64) NOT NULL,
+    details    TEXT        NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL,
+    prev_hash  CHAR(64)    NOT NULL,
+    hash       CHAR(64)    NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_user_id_id ON audit_log (user_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_change
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,2 @@
+-- хеши не обратить в пароли: откат оставляет их как есть
+SELECT 1;
@@ -0,0 +1,7 @@
+-- Пароли хранятся bcrypt-хешами. Хеши pgcrypto ($2a$) проверяет golang.org/x/crypto/bcrypt;
+-- строки, уже начинающиеся с $2, считаются хешами
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+UPDATE users
+SET password = crypt(password, gen_salt('bf', 10))
+WHERE password NOT LIKE '$2%';
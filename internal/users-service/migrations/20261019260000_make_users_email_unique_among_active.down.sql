@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_users_email_active;
+
+-- не применится, если email удаленного пользователя уже занят
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
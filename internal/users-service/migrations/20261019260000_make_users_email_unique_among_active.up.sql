@@ -0,0 +1,7 @@
+-- email уникален только среди неудаленных пользователей: удаленный остается в корзине,
+-- а его email можно занять. При восстановлении конфликт проверяется заново
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+DROP INDEX IF EXISTS idx_users_email;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_active ON users (email) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,21 @@
+-- Записи об удалении данных пользователей по их запросу (GDPR). Строка пользователя
+-- удаляется, а запись остается доказательством: в ней только id пользователя и число
+-- удаленных в tasks-service записей, самих данных нет
+CREATE TABLE IF NOT EXISTS user_erasures
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      INTEGER     NOT NULL UNIQUE,
+    status       VARCHAR(16) NOT NULL DEFAULT 'pending',
+    requested_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ NULL,
+    attempts     INTEGER     NOT NULL DEFAULT 0,
+    last_error   TEXT        NOT NULL DEFAULT '',
+    tasks        INTEGER     NOT NULL DEFAULT 0,
+    comments     INTEGER     NOT NULL DEFAULT 0,
+    projects     INTEGER     NOT NULL DEFAULT 0,
+    tags         INTEGER     NOT NULL DEFAULT 0,
+    attachments  INTEGER     NOT NULL DEFAULT 0
+);
+
+-- незавершенные удаления повторяет фоновая задача
+CREATE INDEX IF NOT EXISTS idx_user_erasures_pending ON user_erasures (id) WHERE status = 'pending';
@@ -13,14 +13,6 @@ fi
 
 echo "✅ Go найден: $(go version)"
 
-# Устанавливаем oapi-codegen если не установлен
-if ! command -v oapi-codegen &> /dev/null; then
-    echo "📦 Устанавливаем oapi-codegen..."
-    go install github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@latest
-else
-    echo "✅ oapi-codegen уже установлен"
-fi
-
 # Устанавливаем migrate если не установлен
 if ! command -v migrate &> /dev/null; then
     echo "📦 Устанавливаем migrate..."
@@ -29,10 +21,6 @@ else
     echo "✅ migrate уже установлен"
 fi
 
-# Генерируем код из OpenAPI
-echo "🔧 Генерируем код из OpenAPI спецификации..."
-make gen-users
-
 # Проверяем, что все зависимости установлены
 echo "🔍 Проверяем зависимости..."
 go mod verify
@@ -40,6 +28,7 @@ go mod verify
 echo "✅ Настройка завершена!"
 echo ""
 echo "📋 Следующие шаги:"
-echo "1. Настройте переменную окружения DB_DSN"
+echo "1. Настройте переменные окружения DB_DSN и SERVICE_TOKEN (тот же, что у tasks-service)"
 echo "2. Запустите миграции: make migrate"
-echo "3. Запустите сервис: make run" 
\ No newline at end of file
+echo "3. Запустите сервис: make run"
+echo "   gRPC — 127.0.0.1:50051, REST/JSON — 127.0.0.1:8081 (адрес задает BIND_HOST), описание API (OpenAPI) — http://localhost:8081/openapi.json" 
\ No newline at end of file